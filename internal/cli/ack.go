@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/cliutil"
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/message"
+	"github.com/i9wa4/tmux-a2a-postman/internal/projection"
+)
+
+// RunAck archives one or all inbox messages of a node to read/ without
+// printing their content. When a daemon owns the session the ack is submitted
+// to it, so its idle tracker records the node as having answered its mail.
+func RunAck(args []string) error {
+	return runAckWithContext(defaultCommandContext(), args)
+}
+
+type ackOutput struct {
+	Status    string   `json:"status"`
+	Node      string   `json:"node"`
+	Acked     []string `json:"acked"`
+	Remaining int      `json:"remaining"`
+}
+
+func runAckWithContext(ctx commandContext, args []string) error {
+	ctx = ctx.withDefaults()
+	fs := flag.NewFlagSet("ack", flag.ContinueOnError)
+	cliutil.SetUsageWithoutContextID(fs)
+	contextID := fs.String("context-id", "", "context ID")
+	configPath := fs.String("config", "", "path to config file (optional)")
+	node := fs.String("node", "", "node whose inbox messages to acknowledge (required)")
+	file := fs.String("file", "", "inbox message filename to acknowledge")
+	all := fs.Bool("all", false, "acknowledge every unread inbox message")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *node == "" {
+		return fmt.Errorf("--node is required")
+	}
+	if err := cliutil.ValidateOutboundNodeName("--node", *node); err != nil {
+		return err
+	}
+	if (*file == "") == !*all {
+		return fmt.Errorf("exactly one of --file or --all is required")
+	}
+	if *file != "" && (filepath.Base(*file) != *file || !strings.HasSuffix(*file, ".md")) {
+		return fmt.Errorf("--file %q: must be a bare inbox .md filename", *file)
+	}
+
+	inboxArgs := fs.Args()
+	if *contextID != "" {
+		inboxArgs = append([]string{"--context-id", *contextID}, inboxArgs...)
+	}
+	if *configPath != "" {
+		inboxArgs = append([]string{"--config", *configPath}, inboxArgs...)
+	}
+	selfInboxPath, err := ctx.resolveInboxPath(inboxArgs)
+	if err != nil {
+		return err
+	}
+	inboxPath := filepath.Join(filepath.Dir(selfInboxPath), *node)
+
+	cfg, err := ctx.loadConfig(*configPath)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	if _, ok := cfg.Nodes[*node]; !ok {
+		if info, statErr := os.Stat(inboxPath); statErr != nil || !info.IsDir() {
+			return fmt.Errorf("--node %q: unknown node", *node)
+		}
+	}
+
+	msgs := message.ScanInboxMessages(inboxPath)
+	sort.Slice(msgs, func(i, j int) bool {
+		return msgs[i].Filename < msgs[j].Filename
+	})
+	var targets []string
+	for _, msg := range msgs {
+		if *all || msg.Filename == *file {
+			targets = append(targets, msg.Filename)
+		}
+	}
+	if *file != "" && len(targets) == 0 {
+		return fmt.Errorf("--file %q: not found in %s inbox", *file, *node)
+	}
+
+	// With a daemon running, the ack goes through it so its idle tracker
+	// counts the node as having answered; otherwise archive directly.
+	sessionDir := filepath.Dir(filepath.Dir(inboxPath))
+	contextDir := filepath.Dir(sessionDir)
+	sessionName := filepath.Base(sessionDir)
+	baseDir := filepath.Dir(contextDir)
+	if cfg.HasSessionBaseDir(sessionName) {
+		baseDir = config.ResolveBaseDir(cfg.BaseDir)
+	}
+	viaDaemon := ctx.contextOwnsSession(baseDir, filepath.Base(contextDir), sessionName)
+
+	acked := make([]string, 0, len(targets))
+	for _, filename := range targets {
+		if viaDaemon {
+			response, err := ctx.roundTripDaemonSubmit(sessionDir, projection.DaemonSubmitRequest{
+				Command:  projection.DaemonSubmitAck,
+				Node:     *node,
+				Filename: filename,
+			}, daemonSubmitTimeout(cfg.TmuxTimeout))
+			if err != nil {
+				return fmt.Errorf("daemon submit ack %s: %w", filename, err)
+			}
+			if response.Empty {
+				if *all {
+					// Claimed concurrently by pop; nothing left to ack.
+					continue
+				}
+				return fmt.Errorf("acking %s: %w", filename, os.ErrNotExist)
+			}
+			acked = append(acked, filename)
+			continue
+		}
+		if _, err := message.ArchiveInboxMessage(filepath.Join(inboxPath, filename), filename); err != nil {
+			if errors.Is(err, os.ErrNotExist) && *all {
+				// Claimed concurrently by pop; nothing left to ack.
+				continue
+			}
+			return fmt.Errorf("acking %s: %w", filename, err)
+		}
+		acked = append(acked, filename)
+	}
+
+	return json.NewEncoder(ctx.stdout).Encode(ackOutput{
+		Status:    "acked",
+		Node:      *node,
+		Acked:     acked,
+		Remaining: len(message.ScanInboxMessages(inboxPath)),
+	})
+}
@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/projection"
+)
+
+func setupAckSession(t *testing.T, files ...string) (string, commandContext) {
+	t.Helper()
+	sessionDir := filepath.Join(t.TempDir(), "ctx-ack", "main")
+	inboxDir := filepath.Join(sessionDir, "inbox", "worker")
+	if err := os.MkdirAll(inboxDir, 0o700); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	for _, name := range files {
+		if err := os.WriteFile(filepath.Join(inboxDir, name), []byte(messageFixture("orchestrator", "worker", "body")), 0o600); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+	cfg := config.DefaultConfig()
+	cfg.Nodes = map[string]config.NodeConfig{"worker": {}}
+	ctx := commandContext{
+		resolveInboxPath: func(args []string) (string, error) {
+			return filepath.Join(sessionDir, "inbox", "orchestrator"), nil
+		},
+		loadConfig: func(path string) (*config.Config, error) {
+			return cfg, nil
+		},
+	}
+	return sessionDir, ctx
+}
+
+func TestRunAck_FileMovesSingleMessageToRead(t *testing.T) {
+	first := "20260415-010203-from-orchestrator-to-worker.md"
+	second := "20260415-010204-from-orchestrator-to-worker.md"
+	sessionDir, ctx := setupAckSession(t, first, second)
+	var stdout bytes.Buffer
+	ctx.stdout = &stdout
+
+	if err := runAckWithContext(ctx, []string{"--node", "worker", "--file", second}); err != nil {
+		t.Fatalf("runAckWithContext: %v", err)
+	}
+
+	var payload ackOutput
+	if err := json.Unmarshal(stdout.Bytes(), &payload); err != nil {
+		t.Fatalf("json.Unmarshal(%q): %v", stdout.String(), err)
+	}
+	if payload.Status != "acked" || len(payload.Acked) != 1 || payload.Acked[0] != second || payload.Remaining != 1 {
+		t.Fatalf("payload = %#v, want %s acked with 1 remaining", payload, second)
+	}
+	if _, err := os.Stat(filepath.Join(sessionDir, "read", second)); err != nil {
+		t.Fatalf("acked message missing from read/: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(sessionDir, "inbox", "worker", first)); err != nil {
+		t.Fatalf("unacked message left inbox: %v", err)
+	}
+}
+
+func TestRunAck_AllMovesEveryMessageToRead(t *testing.T) {
+	files := []string{
+		"20260415-010203-from-orchestrator-to-worker.md",
+		"20260415-010204-from-orchestrator-to-worker.md",
+	}
+	sessionDir, ctx := setupAckSession(t, files...)
+	var stdout bytes.Buffer
+	ctx.stdout = &stdout
+
+	if err := runAckWithContext(ctx, []string{"--node", "worker", "--all"}); err != nil {
+		t.Fatalf("runAckWithContext: %v", err)
+	}
+
+	var payload ackOutput
+	if err := json.Unmarshal(stdout.Bytes(), &payload); err != nil {
+		t.Fatalf("json.Unmarshal(%q): %v", stdout.String(), err)
+	}
+	if len(payload.Acked) != len(files) || payload.Remaining != 0 {
+		t.Fatalf("payload = %#v, want %d acked and none remaining", payload, len(files))
+	}
+	for _, name := range files {
+		if _, err := os.Stat(filepath.Join(sessionDir, "read", name)); err != nil {
+			t.Fatalf("%s missing from read/: %v", name, err)
+		}
+	}
+}
+
+func TestRunAck_SubmitsToOwningDaemon(t *testing.T) {
+	filename := "20260415-010203-from-orchestrator-to-worker.md"
+	sessionDir, ctx := setupAckSession(t, filename)
+	var stdout bytes.Buffer
+	ctx.stdout = &stdout
+	ctx.contextOwnsSession = func(baseDir, contextID, sessionName string) bool { return true }
+	var requests []projection.DaemonSubmitRequest
+	ctx.roundTripDaemonSubmit = func(gotSessionDir string, request projection.DaemonSubmitRequest, timeout time.Duration) (projection.DaemonSubmitResponse, error) {
+		if gotSessionDir != sessionDir {
+			t.Fatalf("sessionDir = %q, want %q", gotSessionDir, sessionDir)
+		}
+		requests = append(requests, request)
+		return projection.DaemonSubmitResponse{Filename: request.Filename}, nil
+	}
+
+	if err := runAckWithContext(ctx, []string{"--node", "worker", "--file", filename}); err != nil {
+		t.Fatalf("runAckWithContext: %v", err)
+	}
+
+	if len(requests) != 1 || requests[0].Command != projection.DaemonSubmitAck || requests[0].Node != "worker" || requests[0].Filename != filename {
+		t.Fatalf("requests = %#v, want one ack of %s for worker", requests, filename)
+	}
+	if _, err := os.Stat(filepath.Join(sessionDir, "inbox", "worker", filename)); err != nil {
+		t.Fatalf("ack archived locally instead of leaving it to the daemon: %v", err)
+	}
+}
+
+func TestRunAck_RejectsInvalidInvocations(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "missing node", args: []string{"--all"}, want: "--node is required"},
+		{name: "unknown node", args: []string{"--node", "ghost", "--all"}, want: "unknown node"},
+		{name: "neither file nor all", args: []string{"--node", "worker"}, want: "exactly one of --file or --all"},
+		{name: "both file and all", args: []string{"--node", "worker", "--all", "--file", "x.md"}, want: "exactly one of --file or --all"},
+		{name: "path traversal", args: []string{"--node", "worker", "--file", "../x.md"}, want: "bare inbox .md filename"},
+		{name: "missing file", args: []string{"--node", "worker", "--file", "20260415-010203-from-orchestrator-to-worker.md"}, want: "not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, ctx := setupAckSession(t)
+			ctx.stdout = &bytes.Buffer{}
+			err := runAckWithContext(ctx, tt.args)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("runAckWithContext(%v) error = %v, want containing %q", tt.args, err, tt.want)
+			}
+		})
+	}
+}
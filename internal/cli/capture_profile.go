@@ -56,7 +56,7 @@ func runCaptureProfileWithContext(ctx commandContext, args []string) error {
 	if err != nil {
 		return err
 	}
-	sessionDir := filepath.Join(target.baseDir, target.contextID, target.sessionName)
+	sessionDir := target.cfg.SessionDir(target.baseDir, target.contextID, target.sessionName)
 	destination := "file"
 	var outputPath string
 	displayOutputPath := *output
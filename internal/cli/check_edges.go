@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/cliutil"
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+)
+
+// RunCheckEdges reports self-loops, duplicate hops, and one-way hops in the
+// configured edges.
+func RunCheckEdges(args []string) error {
+	return runCheckEdgesWithContext(defaultCommandContext(), args)
+}
+
+func runCheckEdgesWithContext(ctx commandContext, args []string) error {
+	ctx = ctx.withDefaults()
+	fs := flag.NewFlagSet("check-edges", flag.ContinueOnError)
+	cliutil.SetUsageWithoutContextID(fs)
+	configPath := fs.String("config", "", "path to config file (optional)")
+	jsonOutput := fs.Bool("json", false, "print JSON instead of a table")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := ctx.loadConfig(*configPath)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	report, err := config.CheckEdges(cfg.Edges)
+	if err != nil {
+		return err
+	}
+
+	if *jsonOutput {
+		if err := json.NewEncoder(ctx.stdout).Encode(report); err != nil {
+			return err
+		}
+	} else if report.Count() == 0 {
+		_, _ = fmt.Fprintf(ctx.stdout, "edges OK: %d edge(s), no self-loops, duplicates, or one-way hops\n", len(cfg.Edges))
+	} else {
+		w := tabwriter.NewWriter(ctx.stdout, 0, 4, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "ISSUE\tEDGE\tHOP\tNOTE")
+		for _, f := range report.SelfLoops {
+			_, _ = fmt.Fprintf(w, "self-loop\tedges[%d]\t%s --- %s\t\n", f.Index, f.From, f.To)
+		}
+		for _, f := range report.Duplicates {
+			_, _ = fmt.Fprintf(w, "duplicate\tedges[%d]\t%s --- %s\tfirst defined at edges[%d]\n", f.Index, f.From, f.To, f.FirstIndex)
+		}
+		for _, f := range report.Asymmetric {
+			_, _ = fmt.Fprintf(w, "one-way\t-\t%s -> %s\tno %s -> %s\n", f.From, f.To, f.To, f.From)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+	if n := report.Count(); n > 0 {
+		return fmt.Errorf("%d edge issue(s) found", n)
+	}
+	return nil
+}
@@ -36,7 +36,7 @@ var discoverNodesForCommandApprovalDeliveryFn = discovery.DiscoverNodesWithColli
 // reply must preserve the given thread_id in its own frontmatter for the
 // decision to be recorded automatically.
 func deliverCommandApprovalRequest(cfg *config.Config, baseDir, contextID, requesterSessionName string, policy resolvedCommandApprovalPolicy, commandApproverNode, threadID, commandHash, reason string, storeCommandText bool, now time.Time) {
-	nodes, _, err := discoverNodesForCommandApprovalDeliveryFn(baseDir, contextID, requesterSessionName)
+	nodes, _, err := discoverNodesForCommandApprovalDeliveryFn(baseDir, contextID, requesterSessionName, cfg)
 	if err != nil {
 		log.Printf("postman: WARNING: command approval delivery: discovering nodes: %v\n", err)
 		return
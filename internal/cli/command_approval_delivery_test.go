@@ -26,7 +26,7 @@ func TestDeliverCommandApprovalRequest_WritesMessageIntoReviewerPostDir(t *testi
 	}
 
 	original := discoverNodesForCommandApprovalDeliveryFn
-	discoverNodesForCommandApprovalDeliveryFn = func(baseDir, contextID, selfSession string) (map[string]discovery.NodeInfo, []discovery.CollisionReport, error) {
+	discoverNodesForCommandApprovalDeliveryFn = func(baseDir, contextID, selfSession string, _ discovery.Settings) (map[string]discovery.NodeInfo, []discovery.CollisionReport, error) {
 		return map[string]discovery.NodeInfo{
 			"orchestrator": {PaneID: "%2", SessionName: "reviewer-session", SessionDir: reviewerSessionDir},
 		}, nil, nil
@@ -99,7 +99,7 @@ func TestDeliverCommandApprovalRequest_UnknownNodeIsBestEffort(t *testing.T) {
 	baseDir := t.TempDir()
 
 	original := discoverNodesForCommandApprovalDeliveryFn
-	discoverNodesForCommandApprovalDeliveryFn = func(baseDir, contextID, selfSession string) (map[string]discovery.NodeInfo, []discovery.CollisionReport, error) {
+	discoverNodesForCommandApprovalDeliveryFn = func(baseDir, contextID, selfSession string, _ discovery.Settings) (map[string]discovery.NodeInfo, []discovery.CollisionReport, error) {
 		return map[string]discovery.NodeInfo{}, nil, nil
 	}
 	t.Cleanup(func() { discoverNodesForCommandApprovalDeliveryFn = original })
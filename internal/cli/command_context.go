@@ -10,6 +10,7 @@ import (
 	"github.com/i9wa4/tmux-a2a-postman/internal/cliutil"
 	"github.com/i9wa4/tmux-a2a-postman/internal/config"
 	"github.com/i9wa4/tmux-a2a-postman/internal/discovery"
+	"github.com/i9wa4/tmux-a2a-postman/internal/ping"
 	"github.com/i9wa4/tmux-a2a-postman/internal/projection"
 )
 
@@ -25,15 +26,19 @@ type commandContext struct {
 	resolveContextSession func(baseDir, sessionName string) (string, error)
 	contextOwnsSession    func(baseDir, contextID, sessionName string) bool
 	contextHasLiveDaemon  func(baseDir, contextID string) bool
-	roundTripDaemonSubmit func(sessionDir string, request projection.DaemonSubmitRequest, timeout time.Duration) (projection.DaemonSubmitResponse, error)
-	getTmuxPaneName       func() string
-	getTmuxSessionName    func() string
-	getTmuxPaneID         func() string
-	discoverNodes         func(baseDir, contextID, selfSession string) (map[string]discovery.NodeInfo, error)
-	discoverAllSessions   func() ([]string, error)
-	collectSessionStatus  sessionStatusCollector
-	now                   func() time.Time
-	runBash               func(command string, stdout, stderr io.Writer) (int, error)
+	// Context named by a session's @a2a_session_on_ marker ("" = unset)
+	sessionEnabledMarkerContext func(sessionName string) string
+	roundTripDaemonSubmit       func(sessionDir string, request projection.DaemonSubmitRequest, timeout time.Duration) (projection.DaemonSubmitResponse, error)
+	getTmuxPaneName             func(format string) string
+	getTmuxSessionName          func() string
+	getTmuxPaneID               func() string
+	discoverNodes               func(baseDir, contextID, selfSession string, settings discovery.Settings) (map[string]discovery.NodeInfo, error)
+	discoverAllSessions         func() ([]string, error)
+	discoverAllContexts         func(baseDir string, settings discovery.Settings) (map[string]map[string]discovery.NodeInfo, error)
+	sendPing                    func(nodeInfo discovery.NodeInfo, contextID, nodeName, tmpl string, cfg *config.Config, activeNodes []string, livenessMap map[string]bool, adjacency map[string][]string, nodes map[string]discovery.NodeInfo) error
+	collectSessionStatus        sessionStatusCollector
+	now                         func() time.Time
+	runBash                     func(command string, stdout, stderr io.Writer) (int, error)
 }
 
 func defaultCommandContext() commandContext {
@@ -71,6 +76,9 @@ func (ctx commandContext) withDefaults() commandContext {
 	if ctx.contextHasLiveDaemon == nil {
 		ctx.contextHasLiveDaemon = config.ContextHasLiveDaemon
 	}
+	if ctx.sessionEnabledMarkerContext == nil {
+		ctx.sessionEnabledMarkerContext = config.SessionEnabledMarkerContext
+	}
 	if ctx.roundTripDaemonSubmit == nil {
 		ctx.roundTripDaemonSubmit = roundTripDaemonSubmit
 	}
@@ -89,6 +97,12 @@ func (ctx commandContext) withDefaults() commandContext {
 	if ctx.discoverAllSessions == nil {
 		ctx.discoverAllSessions = discovery.DiscoverAllSessions
 	}
+	if ctx.discoverAllContexts == nil {
+		ctx.discoverAllContexts = discovery.DiscoverNodesAcrossContexts
+	}
+	if ctx.sendPing == nil {
+		ctx.sendPing = ping.SendPingToNode
+	}
 	if ctx.collectSessionStatus == nil {
 		ctx.collectSessionStatus = collectSessionStatus
 	}
@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/BurntSushi/toml"
+	"github.com/i9wa4/tmux-a2a-postman/internal/cliutil"
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+)
+
+// RunConfig dispatches config subcommands.
+func RunConfig(stdout io.Writer, args []string) error {
+	fs := flag.NewFlagSet("config", flag.ContinueOnError)
+	cliutil.SetUsageWithoutContextID(fs)
+	configPath := fs.String("config", "", "path to config file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("config subcommand is required (available: dump, origins, migrate)")
+	}
+	switch sub := fs.Arg(0); sub {
+	case "dump":
+		return runConfigDump(stdout, *configPath, fs.Args()[1:])
+	case "origins":
+		return runConfigOrigins(stdout, *configPath, fs.Args()[1:])
+	case "migrate":
+		return runConfigMigrate(stdout, *configPath, fs.Args()[1:])
+	default:
+		return fmt.Errorf("unknown config subcommand %q (available: dump, origins, migrate)", sub)
+	}
+}
+
+// runConfigDump loads config the same way the daemon does and prints the
+// fully merged effective settings.
+func runConfigDump(stdout io.Writer, configPath string, args []string) error {
+	fs := flag.NewFlagSet("config dump", flag.ContinueOnError)
+	cliutil.SetUsageWithoutContextID(fs)
+	fs.StringVar(&configPath, "config", configPath, "path to config file")
+	format := fs.String("format", "toml", "output format: toml or json")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("config dump takes no positional arguments")
+	}
+	if *format != "toml" && *format != "json" {
+		return fmt.Errorf("--format %q: must be toml or json", *format)
+	}
+
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	sections, err := config.EffectiveSections(cfg)
+	if err != nil {
+		return err
+	}
+
+	if *format == "json" {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(sections)
+	}
+	return toml.NewEncoder(stdout).Encode(sections)
+}
+
+// runConfigOrigins prints every effective setting with the file that set it,
+// so overrides across embedded defaults, postman.toml, nodes/, and postman.md
+// can be traced.
+func runConfigOrigins(stdout io.Writer, configPath string, args []string) error {
+	fs := flag.NewFlagSet("config origins", flag.ContinueOnError)
+	cliutil.SetUsageWithoutContextID(fs)
+	fs.StringVar(&configPath, "config", configPath, "path to config file")
+	format := fs.String("format", "text", "output format: text or json")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("config origins takes no positional arguments")
+	}
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("--format %q: must be text or json", *format)
+	}
+
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	origins := cfg.SettingOrigins()
+
+	if *format == "json" {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(origins)
+	}
+	for _, origin := range origins {
+		if _, err := fmt.Fprintf(stdout, "%s = %s  # %s\n", origin.Key, origin.Value, origin.Source); err != nil {
+			return err
+		}
+	}
+	return nil
+}
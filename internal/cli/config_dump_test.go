@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeConfigDumpFixture(t *testing.T) {
+	t.Helper()
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	configDir := filepath.Join(configHome, "tmux-a2a-postman")
+	if err := os.MkdirAll(configDir, 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	toml := "[postman]\nscan_interval_seconds = 2.5\nreply_command = \"toml-reply <recipient>\"\nedges = [\"orchestrator --- worker\"]\n\n[worker]\nrole = \"implementer\"\n"
+	if err := os.WriteFile(filepath.Join(configDir, "postman.toml"), []byte(toml), 0o644); err != nil {
+		t.Fatalf("WriteFile postman.toml: %v", err)
+	}
+	markdown := "---\nreply_command: md-reply <recipient>\n---\n"
+	if err := os.WriteFile(filepath.Join(configDir, "postman.md"), []byte(markdown), 0o644); err != nil {
+		t.Fatalf("WriteFile postman.md: %v", err)
+	}
+}
+
+func TestRunConfigDump_JSONReflectsMergedLayers(t *testing.T) {
+	writeConfigDumpFixture(t)
+
+	var stdout bytes.Buffer
+	if err := RunConfig(&stdout, []string{"dump", "--format", "json"}); err != nil {
+		t.Fatalf("RunConfig: %v", err)
+	}
+
+	var sections map[string]map[string]interface{}
+	if err := json.Unmarshal(stdout.Bytes(), &sections); err != nil {
+		t.Fatalf("decode dump: %v\n%s", err, stdout.String())
+	}
+	postman := sections["postman"]
+	if got := postman["reply_command"]; got != "md-reply <recipient>" {
+		t.Fatalf("reply_command = %v, want postman.md overlay to win over postman.toml", got)
+	}
+	if got := postman["scan_interval_seconds"]; got != 2.5 {
+		t.Fatalf("scan_interval_seconds = %v, want postman.toml value to win over embedded default", got)
+	}
+	if got := postman["tmux_timeout_seconds"]; got != 30.0 {
+		t.Fatalf("tmux_timeout_seconds = %v, want embedded default 30", got)
+	}
+	if got := sections["worker"]["role"]; got != "implementer" {
+		t.Fatalf("worker.role = %v, want implementer", got)
+	}
+	if _, ok := sections["orchestrator"]; !ok {
+		t.Fatalf("edge-only node orchestrator missing from dump: %v", sections)
+	}
+}
+
+func TestRunConfigDump_DefaultFormatIsTOML(t *testing.T) {
+	writeConfigDumpFixture(t)
+
+	var stdout bytes.Buffer
+	if err := RunConfig(&stdout, []string{"dump"}); err != nil {
+		t.Fatalf("RunConfig: %v", err)
+	}
+	got := stdout.String()
+	for _, want := range []string{"[postman]", `reply_command = "md-reply <recipient>"`, "scan_interval_seconds = 2.5", "[worker]"} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("TOML dump missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestRunConfig_RejectsUnknownSubcommandAndFormat(t *testing.T) {
+	if err := RunConfig(&bytes.Buffer{}, []string{"show"}); err == nil || !strings.Contains(err.Error(), `unknown config subcommand "show"`) {
+		t.Fatalf("RunConfig(show) error = %v", err)
+	}
+	if err := RunConfig(&bytes.Buffer{}, []string{"dump", "--format", "yaml"}); err == nil || !strings.Contains(err.Error(), "must be toml or json") {
+		t.Fatalf("RunConfig(dump --format yaml) error = %v", err)
+	}
+}
+
+func TestRunConfigOrigins_ReportsOverridingFile(t *testing.T) {
+	writeConfigDumpFixture(t)
+	configDir := filepath.Join(os.Getenv("XDG_CONFIG_HOME"), "tmux-a2a-postman")
+
+	var stdout bytes.Buffer
+	if err := RunConfig(&stdout, []string{"origins", "--format", "json"}); err != nil {
+		t.Fatalf("RunConfig: %v", err)
+	}
+	var origins []struct {
+		Key    string `json:"key"`
+		Value  string `json:"value"`
+		Source string `json:"source"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &origins); err != nil {
+		t.Fatalf("decode origins: %v\n%s", err, stdout.String())
+	}
+	sources := make(map[string]string, len(origins))
+	for _, origin := range origins {
+		sources[origin.Key] = origin.Source
+	}
+	for key, want := range map[string]string{
+		"postman.scan_interval_seconds": filepath.Join(configDir, "postman.toml"),
+		"postman.reply_command":         filepath.Join(configDir, "postman.md"),
+		"worker.role":                   filepath.Join(configDir, "postman.toml"),
+		"postman.tmux_timeout_seconds":  "embedded defaults",
+	} {
+		if got := sources[key]; got != want {
+			t.Errorf("origin of %s = %q, want %q", key, got, want)
+		}
+	}
+
+	stdout.Reset()
+	if err := RunConfig(&stdout, []string{"origins"}); err != nil {
+		t.Fatalf("RunConfig text: %v", err)
+	}
+	if want := "postman.scan_interval_seconds = 2.5  # " + filepath.Join(configDir, "postman.toml"); !strings.Contains(stdout.String(), want) {
+		t.Fatalf("text output missing %q:\n%s", want, stdout.String())
+	}
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/cliutil"
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+)
+
+// runConfigMigrate converts a Python-postman style postman.toml to the
+// current format. Without --output it is a dry run that only prints the diff.
+func runConfigMigrate(stdout io.Writer, configPath string, args []string) error {
+	fs := flag.NewFlagSet("config migrate", flag.ContinueOnError)
+	cliutil.SetUsageWithoutContextID(fs)
+	from := fs.String("from", "", "source config format (required; supported: python)")
+	input := fs.String("input", configPath, "config file to migrate (defaults to --config)")
+	output := fs.String("output", "", "write the migrated config here (optional; omit for a dry run)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("config migrate takes no positional arguments")
+	}
+	if *from != "python" {
+		return fmt.Errorf("--from %q: must be python", *from)
+	}
+	if *input == "" {
+		return fmt.Errorf("--input is required")
+	}
+
+	raw, err := os.ReadFile(*input)
+	if err != nil {
+		return fmt.Errorf("reading config: %w", err)
+	}
+	migrated, changes, err := config.MigratePythonConfig(raw)
+	if err != nil {
+		return err
+	}
+
+	if *output != "" {
+		if err := os.WriteFile(*output, migrated, 0o644); err != nil {
+			return fmt.Errorf("writing migrated config: %w", err)
+		}
+	}
+	return writeMigrationDiff(stdout, *input, *output, changes)
+}
+
+func writeMigrationDiff(w io.Writer, input, output string, changes []config.MigrationChange) error {
+	if len(changes) == 0 {
+		_, err := fmt.Fprintf(w, "%s: already in the current format; no changes\n", input)
+		return err
+	}
+	target := output
+	if target == "" {
+		target = input + " (dry run)"
+	}
+	if _, err := fmt.Fprintf(w, "--- %s\n+++ %s\n", input, target); err != nil {
+		return err
+	}
+	for _, change := range changes {
+		if _, err := fmt.Fprintf(w, "@@ line %d: %s @@\n-%s\n+%s\n", change.Line, change.Reason, change.Before, change.After); err != nil {
+			return err
+		}
+	}
+	return nil
+}
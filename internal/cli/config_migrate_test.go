@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunConfigMigrate_DryRunPrintsDiffWithoutWriting(t *testing.T) {
+	input := filepath.Join(t.TempDir(), "old.toml")
+	raw := "[postman]\nedges = [\"orchestrator --> worker\"]\n"
+	if err := os.WriteFile(input, []byte(raw), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout bytes.Buffer
+	if err := RunConfig(&stdout, []string{"migrate", "--from", "python", "--input", input}); err != nil {
+		t.Fatalf("RunConfig migrate: %v", err)
+	}
+	wantDiff := "@@ line 2: normalized edge separator to --- @@\n" +
+		"-edges = [\"orchestrator --> worker\"]\n" +
+		"+edges = [\"orchestrator --- worker\"]\n"
+	if !strings.Contains(stdout.String(), wantDiff) {
+		t.Fatalf("stdout = %q, want diff %q", stdout.String(), wantDiff)
+	}
+	if got, _ := os.ReadFile(input); string(got) != raw {
+		t.Fatalf("dry run modified input: %q", got)
+	}
+}
+
+func TestRunConfigMigrate_WritesOutput(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "old.toml")
+	output := filepath.Join(dir, "postman.toml")
+	if err := os.WriteFile(input, []byte("[postman]\nauto_enable_new_agents = true\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout bytes.Buffer
+	if err := RunConfig(&stdout, []string{"migrate", "--from", "python", "--input", input, "--output", output}); err != nil {
+		t.Fatalf("RunConfig migrate: %v", err)
+	}
+	got, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("ReadFile output: %v", err)
+	}
+	if string(got) != "[postman]\nauto_enable_new_sessions = true\n" {
+		t.Fatalf("output = %q", got)
+	}
+}
+
+func TestRunConfigMigrate_RequiresPythonSource(t *testing.T) {
+	err := RunConfig(&bytes.Buffer{}, []string{"migrate", "--input", "x.toml"})
+	if err == nil || !strings.Contains(err.Error(), "--from") {
+		t.Fatalf("RunConfig migrate without --from error = %v, want --from error", err)
+	}
+}
@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/cliutil"
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+)
+
+// Context daemon states reported by the contexts command.
+const (
+	contextStatusRunning = "running" // a session holds a live postman.pid
+	contextStatusStale   = "stale"   // postman.pid left behind by a dead daemon
+	contextStatusStopped = "stopped" // no postman.pid at all
+)
+
+// RunContexts lists every context dir under the base dir with its daemon
+// state, for machines that run several meshes at once.
+func RunContexts(args []string) error {
+	return runContextsWithContext(defaultCommandContext(), args)
+}
+
+type contextsOutput struct {
+	Contexts []contextReport `json:"contexts"`
+}
+
+type contextReport struct {
+	ContextID string   `json:"context_id"`
+	Status    string   `json:"status"`
+	PID       int      `json:"pid"`
+	Session   string   `json:"session,omitempty"`
+	NodeCount int      `json:"node_count"`
+	Nodes     []string `json:"nodes,omitempty"`
+}
+
+func runContextsWithContext(ctx commandContext, args []string) error {
+	ctx = ctx.withDefaults()
+	fs := flag.NewFlagSet("contexts", flag.ContinueOnError)
+	cliutil.SetUsageWithoutContextID(fs)
+	configPath := fs.String("config", "", "path to config file (optional)")
+	jsonOutput := fs.Bool("json", false, "print JSON instead of a table")
+	showNodes := fs.Bool("nodes", false, "also list the live tmux nodes discovered for each context")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := ctx.loadConfig(*configPath)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	baseDir := config.ResolveBaseDir(cfg.BaseDir)
+	reports, err := listContexts(cfg, baseDir)
+	if err != nil {
+		return err
+	}
+	if *showNodes {
+		byContext, err := ctx.discoverAllContexts(baseDir, cfg)
+		if err != nil {
+			return fmt.Errorf("discovering nodes: %w", err)
+		}
+		for i := range reports {
+			reports[i].Nodes = []string{}
+			for nodeKey := range byContext[reports[i].ContextID] {
+				reports[i].Nodes = append(reports[i].Nodes, nodeKey)
+			}
+			sort.Strings(reports[i].Nodes)
+		}
+	}
+
+	if *jsonOutput {
+		return json.NewEncoder(ctx.stdout).Encode(contextsOutput{Contexts: reports})
+	}
+	w := tabwriter.NewWriter(ctx.stdout, 0, 4, 2, ' ', 0)
+	header := "CONTEXT\tSTATUS\tPID\tSESSION\tNODES"
+	if *showNodes {
+		header += "\tLIVE NODES"
+	}
+	_, _ = fmt.Fprintln(w, header)
+	for _, report := range reports {
+		pid, session := "-", "-"
+		if report.PID > 0 {
+			pid = fmt.Sprint(report.PID)
+		}
+		if report.Session != "" {
+			session = report.Session
+		}
+		row := fmt.Sprintf("%s\t%s\t%s\t%s\t%d", report.ContextID, report.Status, pid, session, report.NodeCount)
+		if *showNodes {
+			live := "-"
+			if len(report.Nodes) > 0 {
+				live = strings.Join(report.Nodes, ",")
+			}
+			row += "\t" + live
+		}
+		_, _ = fmt.Fprintln(w, row)
+	}
+	return w.Flush()
+}
+
+// listContexts reports each context dir under baseDir, sorted by id. A
+// context is running when any of its sessions has a live postman.pid; the
+// node count is the number of node inboxes across its sessions, wherever
+// cfg places their directories.
+func listContexts(cfg *config.Config, baseDir string) ([]contextReport, error) {
+	entries, err := os.ReadDir(baseDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []contextReport{}, nil
+		}
+		return nil, fmt.Errorf("reading base dir: %w", err)
+	}
+
+	reports := []contextReport{}
+	for _, entry := range entries {
+		if !entry.IsDir() || entry.Name() == "lock" {
+			continue
+		}
+		contextID := entry.Name()
+		report := contextReport{ContextID: contextID, Status: contextStatusStopped}
+		sessions, err := os.ReadDir(filepath.Join(baseDir, contextID))
+		if err != nil {
+			return nil, fmt.Errorf("reading context %s: %w", contextID, err)
+		}
+		for _, session := range sessions {
+			if !session.IsDir() {
+				continue
+			}
+			report.NodeCount += countNodeInboxes(cfg.SessionDir(baseDir, contextID, session.Name()))
+			if report.Status == contextStatusRunning {
+				continue
+			}
+			pid, err := config.ReadSessionPIDFile(filepath.Join(baseDir, contextID, session.Name(), "postman.pid"))
+			if err != nil {
+				continue
+			}
+			if config.IsSessionPIDAlive(baseDir, contextID, session.Name()) {
+				report.Status, report.PID, report.Session = contextStatusRunning, pid, session.Name()
+			} else if report.Status == contextStatusStopped {
+				report.Status, report.PID, report.Session = contextStatusStale, pid, session.Name()
+			}
+		}
+		reports = append(reports, report)
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].ContextID < reports[j].ContextID
+	})
+	return reports, nil
+}
+
+func countNodeInboxes(sessionDir string) int {
+	entries, err := os.ReadDir(filepath.Join(sessionDir, "inbox"))
+	if err != nil {
+		return 0
+	}
+	count := 0
+	for _, entry := range entries {
+		if entry.IsDir() {
+			count++
+		}
+	}
+	return count
+}
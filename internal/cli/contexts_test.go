@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/discovery"
+)
+
+func TestRunContexts_ReportsRunningAndStaleDaemons(t *testing.T) {
+	baseDir := t.TempDir()
+
+	liveSession := filepath.Join(baseDir, "ctx-live", "main")
+	if err := config.CreateSessionDirs(liveSession); err != nil {
+		t.Fatalf("CreateSessionDirs: %v", err)
+	}
+	for _, node := range []string{"orchestrator", "worker"} {
+		if err := os.MkdirAll(filepath.Join(liveSession, "inbox", node), 0o700); err != nil {
+			t.Fatalf("MkdirAll inbox: %v", err)
+		}
+	}
+	if err := config.WriteSessionPIDFile(filepath.Join(liveSession, "postman.pid"), os.Getpid()); err != nil {
+		t.Fatalf("WriteSessionPIDFile: %v", err)
+	}
+
+	staleSession := filepath.Join(baseDir, "ctx-stale", "review")
+	if err := config.CreateSessionDirs(staleSession); err != nil {
+		t.Fatalf("CreateSessionDirs: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(staleSession, "postman.pid"), []byte("999999999\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile(pid): %v", err)
+	}
+
+	ctx := commandContext{
+		loadConfig: func(string) (*config.Config, error) {
+			return &config.Config{BaseDir: baseDir}, nil
+		},
+	}
+
+	var stdout bytes.Buffer
+	ctx.stdout = &stdout
+	if err := runContextsWithContext(ctx, []string{"--json"}); err != nil {
+		t.Fatalf("runContextsWithContext(--json): %v", err)
+	}
+	var out contextsOutput
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		t.Fatalf("decode output %q: %v", stdout.String(), err)
+	}
+	want := []contextReport{
+		{ContextID: "ctx-live", Status: contextStatusRunning, PID: os.Getpid(), Session: "main", NodeCount: 2},
+		{ContextID: "ctx-stale", Status: contextStatusStale, PID: 999999999, Session: "review", NodeCount: 0},
+	}
+	if len(out.Contexts) != len(want) {
+		t.Fatalf("contexts = %+v, want %+v", out.Contexts, want)
+	}
+	for i := range want {
+		if !reflect.DeepEqual(out.Contexts[i], want[i]) {
+			t.Fatalf("contexts[%d] = %+v, want %+v", i, out.Contexts[i], want[i])
+		}
+	}
+
+	stdout.Reset()
+	if err := runContextsWithContext(ctx, nil); err != nil {
+		t.Fatalf("runContextsWithContext(table): %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "CONTEXT") ||
+		!strings.Contains(lines[1], "running") || !strings.Contains(lines[2], "stale") {
+		t.Fatalf("table output = %q", stdout.String())
+	}
+}
+
+func TestRunContexts_NodesListsLiveNodesPerContext(t *testing.T) {
+	baseDir := t.TempDir()
+	for _, sessionDir := range []string{
+		filepath.Join(baseDir, "ctx-a", "alpha"),
+		filepath.Join(baseDir, "ctx-b", "beta"),
+		filepath.Join(baseDir, "ctx-idle", "gamma"),
+	} {
+		if err := config.CreateSessionDirs(sessionDir); err != nil {
+			t.Fatalf("CreateSessionDirs: %v", err)
+		}
+	}
+
+	var stdout bytes.Buffer
+	ctx := commandContext{
+		stdout: &stdout,
+		loadConfig: func(string) (*config.Config, error) {
+			return &config.Config{BaseDir: baseDir}, nil
+		},
+		discoverAllContexts: func(gotBaseDir string, _ discovery.Settings) (map[string]map[string]discovery.NodeInfo, error) {
+			if gotBaseDir != baseDir {
+				t.Fatalf("discoverAllContexts baseDir = %q, want %q", gotBaseDir, baseDir)
+			}
+			return map[string]map[string]discovery.NodeInfo{
+				"ctx-a": {"alpha:worker": {PaneID: "%2"}, "alpha:boss": {PaneID: "%1"}},
+				"ctx-b": {"beta:critic": {PaneID: "%3"}},
+			}, nil
+		},
+	}
+	if err := runContextsWithContext(ctx, []string{"--json", "--nodes"}); err != nil {
+		t.Fatalf("runContextsWithContext(--json --nodes): %v", err)
+	}
+	var out contextsOutput
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		t.Fatalf("decode output %q: %v", stdout.String(), err)
+	}
+	got := map[string][]string{}
+	for _, report := range out.Contexts {
+		got[report.ContextID] = report.Nodes
+	}
+	want := map[string][]string{
+		"ctx-a":    {"alpha:boss", "alpha:worker"},
+		"ctx-b":    {"beta:critic"},
+		"ctx-idle": nil,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("nodes by context = %v, want %v", got, want)
+	}
+
+	stdout.Reset()
+	if err := runContextsWithContext(ctx, []string{"--nodes"}); err != nil {
+		t.Fatalf("runContextsWithContext(--nodes): %v", err)
+	}
+	if !strings.Contains(stdout.String(), "LIVE NODES") || !strings.Contains(stdout.String(), "alpha:boss,alpha:worker") {
+		t.Fatalf("table output = %q", stdout.String())
+	}
+}
@@ -0,0 +1,186 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/cliutil"
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/discovery"
+	"github.com/i9wa4/tmux-a2a-postman/internal/idle"
+	"github.com/i9wa4/tmux-a2a-postman/internal/message"
+	"github.com/i9wa4/tmux-a2a-postman/internal/store"
+)
+
+// RunDeliverOnce delivers everything waiting in post/ once, without a
+// daemon, and prints what happened to each message.
+func RunDeliverOnce(args []string) error {
+	return runDeliverOnceWithContext(defaultCommandContext(), args)
+}
+
+type deliverOnceOutput struct {
+	Status       string               `json:"status"`
+	ContextID    string               `json:"context_id"`
+	Delivered    int                  `json:"delivered"`
+	DeadLettered int                  `json:"dead_lettered"`
+	Pending      int                  `json:"pending"`
+	Messages     []deliverOnceMessage `json:"messages"`
+}
+
+type deliverOnceMessage struct {
+	File    string `json:"file"`
+	Session string `json:"session"`
+	Outcome string `json:"outcome"`
+}
+
+const (
+	deliverOnceDelivered    = "delivered"
+	deliverOnceDeadLettered = "dead_lettered"
+	deliverOncePending      = "pending"
+)
+
+func runDeliverOnceWithContext(ctx commandContext, args []string) error {
+	ctx = ctx.withDefaults()
+	fs := flag.NewFlagSet("deliver-once", flag.ContinueOnError)
+	cliutil.SetUsageWithoutContextID(fs)
+	contextID := fs.String("context-id", "", "context ID")
+	configPath := fs.String("config", "", "path to config file (optional)")
+	session := fs.String("session", "", "session acting as the daemon session (default: current tmux session)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := ctx.loadConfig(*configPath)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	baseDir := config.ResolveBaseDir(cfg.BaseDir)
+
+	sessionName := *session
+	if sessionName == "" {
+		sessionName = ctx.getTmuxSessionName()
+	}
+	if sessionName == "" {
+		return fmt.Errorf("--session required outside tmux")
+	}
+	if sessionName, err = config.ValidateSessionName(sessionName); err != nil {
+		return fmt.Errorf("invalid session name: %w", err)
+	}
+
+	var resolvedContextID string
+	if *contextID != "" {
+		resolvedContextID, err = ctx.resolveContextID(*contextID)
+	} else {
+		resolvedContextID, err = ctx.resolveContextSession(baseDir, sessionName)
+	}
+	if err != nil {
+		return err
+	}
+	// A live daemon is already delivering this context; two deliverers would
+	// race for the same post/ files.
+	if ctx.contextHasLiveDaemon(baseDir, resolvedContextID) {
+		return fmt.Errorf("a postman daemon is running for context %s; it delivers post/ itself", resolvedContextID)
+	}
+
+	adjacency, err := config.ParseEdges(cfg.Edges)
+	if err != nil {
+		return fmt.Errorf("parsing edges: %w", err)
+	}
+	discovered, err := ctx.discoverNodes(baseDir, resolvedContextID, sessionName, cfg)
+	if err != nil {
+		return fmt.Errorf("discovering nodes: %w", err)
+	}
+	nodes := filterDiscoveredActivationNodes(discovered, activationNodeNames(cfg))
+
+	// Sessions are enabled as the daemon left them: the daemon session, and
+	// every session whose @a2a_session_on_ marker names this context. Mail in
+	// other sessions stays in post/ for the daemon that owns them.
+	enabled := map[string]bool{sessionName: true}
+	sessionDirs := map[string]string{sessionName: cfg.SessionDir(baseDir, resolvedContextID, sessionName)}
+	for _, info := range nodes {
+		if info.SessionName == "" || info.SessionDir == "" {
+			continue
+		}
+		if _, seen := enabled[info.SessionName]; !seen {
+			enabled[info.SessionName] = ctx.sessionEnabledMarkerContext(info.SessionName) == resolvedContextID
+		}
+		if enabled[info.SessionName] {
+			sessionDirs[info.SessionName] = info.SessionDir
+		}
+	}
+	sessions := make([]string, 0, len(sessionDirs))
+	for name := range sessionDirs {
+		sessions = append(sessions, name)
+	}
+	sort.Strings(sessions)
+	isSessionEnabled := func(name string) bool {
+		return enabled[name]
+	}
+
+	out := deliverOnceOutput{
+		Status:    "done",
+		ContextID: resolvedContextID,
+		Messages:  []deliverOnceMessage{},
+	}
+	idleTracker := idle.NewIdleTracker()
+	opts := message.DeliverOptions{
+		DeliveredIDs: store.NewDeliveredMessageIDs(),
+		// There is no event loop to stall here, and the outcome printed for
+		// a confirm: true message must be final, so confirm inline.
+		ScheduleConfirm: func(delay time.Duration, check func()) {
+			time.Sleep(delay)
+			check()
+		},
+	}
+	for _, name := range sessions {
+		for _, postPath := range message.PendingPostPaths(sessionDirs[name]) {
+			outcome, err := deliverOncePost(postPath, resolvedContextID, nodes, adjacency, cfg, isSessionEnabled, idleTracker, sessionName, opts)
+			if err != nil {
+				return fmt.Errorf("delivering %s: %w", filepath.Base(postPath), err)
+			}
+			switch outcome {
+			case deliverOnceDelivered:
+				out.Delivered++
+			case deliverOnceDeadLettered:
+				out.DeadLettered++
+			default:
+				out.Pending++
+			}
+			out.Messages = append(out.Messages, deliverOnceMessage{
+				File:    filepath.Base(postPath),
+				Session: name,
+				Outcome: outcome,
+			})
+		}
+	}
+
+	return json.NewEncoder(ctx.stdout).Encode(out)
+}
+
+// deliverOncePost runs DeliverMessageWithOptions for one post and reports
+// where the message ended up. Mail held by serialize_per_node stays pending.
+func deliverOncePost(postPath, contextID string, nodes map[string]discovery.NodeInfo, adjacency map[string][]string, cfg *config.Config, isSessionEnabled func(string) bool, idleTracker *idle.IdleTracker, daemonSession string, opts message.DeliverOptions) (string, error) {
+	err := message.DeliverMessageWithOptions(postPath, contextID, nodes, adjacency, cfg, isSessionEnabled, nil, idleTracker, daemonSession, opts)
+	if errors.Is(err, message.ErrDeliveryHeld) {
+		return deliverOncePending, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if _, statErr := os.Stat(postPath); statErr == nil {
+		return deliverOncePending, nil
+	}
+	sessionDir := filepath.Dir(filepath.Dir(postPath))
+	deadLetters, _ := filepath.Glob(filepath.Join(sessionDir, "dead-letter", strings.TrimSuffix(filepath.Base(postPath), ".md")+"-dl-*.md"))
+	if len(deadLetters) > 0 {
+		return deliverOnceDeadLettered, nil
+	}
+	return deliverOnceDelivered, nil
+}
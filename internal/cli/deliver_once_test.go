@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/discovery"
+)
+
+func TestRunDeliverOnce_DeliversPendingPostsAndDeadLetters(t *testing.T) {
+	baseDir := t.TempDir()
+	sessionDir := filepath.Join(baseDir, "ctx-once", "main")
+	if err := config.CreateSessionDirs(sessionDir); err != nil {
+		t.Fatalf("CreateSessionDirs: %v", err)
+	}
+	posts := map[string]string{
+		"20260201-040000-from-orchestrator-to-worker.md": "orchestrator",
+		"20260201-040001-from-worker-to-orchestrator.md": "worker",
+		"20260201-040002-from-worker-to-critic.md":       "worker",
+	}
+	for filename, from := range posts {
+		to := strings.TrimSuffix(filename[strings.Index(filename, "-to-")+len("-to-"):], ".md")
+		content := "---\nparams:\n  contextId: ctx-once\n  from: " + from + "\n  to: " + to + "\n  timestamp: 2026-02-01T04:00:00Z\n---\n\nhello\n"
+		if err := os.WriteFile(filepath.Join(sessionDir, "post", filename), []byte(content), 0o600); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+
+	var stdout bytes.Buffer
+	ctx := commandContext{
+		stdout: &stdout,
+		loadConfig: func(string) (*config.Config, error) {
+			return &config.Config{
+				BaseDir:     baseDir,
+				Edges:       []string{"orchestrator --- worker"},
+				EnterDelay:  0.1,
+				TmuxTimeout: 1.0,
+			}, nil
+		},
+		getTmuxSessionName:   func() string { return "main" },
+		contextHasLiveDaemon: func(string, string) bool { return false },
+		discoverNodes: func(baseDir, contextID, selfSession string, _ discovery.Settings) (map[string]discovery.NodeInfo, error) {
+			return map[string]discovery.NodeInfo{
+				"main:orchestrator": {PaneID: "%1", SessionName: "main", SessionDir: sessionDir},
+				"main:worker":       {PaneID: "%2", SessionName: "main", SessionDir: sessionDir},
+			}, nil
+		},
+	}
+
+	if err := runDeliverOnceWithContext(ctx, []string{"--context-id", "ctx-once"}); err != nil {
+		t.Fatalf("runDeliverOnceWithContext: %v", err)
+	}
+
+	var out deliverOnceOutput
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		t.Fatalf("json.Unmarshal(%q): %v", stdout.String(), err)
+	}
+	if out.Status != "done" || out.ContextID != "ctx-once" || out.Delivered != 2 || out.DeadLettered != 1 || out.Pending != 0 {
+		t.Fatalf("output = %#v, want 2 delivered and 1 dead-lettered", out)
+	}
+	if len(out.Messages) != 3 || out.Messages[2].Outcome != deliverOnceDeadLettered {
+		t.Fatalf("messages = %#v, want the worker->critic post dead-lettered last", out.Messages)
+	}
+
+	if entries, _ := os.ReadDir(filepath.Join(sessionDir, "post")); len(entries) != 0 {
+		t.Fatalf("post/ still has %d entries after deliver-once", len(entries))
+	}
+	for _, path := range []string{
+		filepath.Join(sessionDir, "inbox", "worker", "20260201-040000-from-orchestrator-to-worker.md"),
+		filepath.Join(sessionDir, "inbox", "orchestrator", "20260201-040001-from-worker-to-orchestrator.md"),
+	} {
+		if _, err := os.Stat(path); err != nil {
+			t.Fatalf("delivered message missing: %v", err)
+		}
+	}
+	if deadLetters, _ := filepath.Glob(filepath.Join(sessionDir, "dead-letter", "20260201-040002-from-worker-to-critic-dl-*.md")); len(deadLetters) != 1 {
+		t.Fatalf("dead-letter files = %v, want one for worker->critic", deadLetters)
+	}
+}
+
+func TestRunDeliverOnce_OnlyDeliversSessionsMarkedEnabled(t *testing.T) {
+	baseDir := t.TempDir()
+	sessionDirs := map[string]string{}
+	nodes := map[string]discovery.NodeInfo{}
+	for i, session := range []string{"main", "review", "other"} {
+		sessionDir := filepath.Join(baseDir, "ctx-once", session)
+		if err := config.CreateSessionDirs(sessionDir); err != nil {
+			t.Fatalf("CreateSessionDirs: %v", err)
+		}
+		content := "---\nparams:\n  contextId: ctx-once\n  from: orchestrator\n  to: worker\n  timestamp: 2026-02-01T04:00:00Z\n---\n\nhello\n"
+		if err := os.WriteFile(filepath.Join(sessionDir, "post", "20260201-040000-from-orchestrator-to-worker.md"), []byte(content), 0o600); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+		sessionDirs[session] = sessionDir
+		nodes[session+":orchestrator"] = discovery.NodeInfo{PaneID: fmt.Sprintf("%%%d", 2*i+1), SessionName: session, SessionDir: sessionDir}
+		nodes[session+":worker"] = discovery.NodeInfo{PaneID: fmt.Sprintf("%%%d", 2*i+2), SessionName: session, SessionDir: sessionDir}
+	}
+	// review is enabled for this context; other belongs to another daemon.
+	markers := map[string]string{"review": "ctx-once", "other": "ctx-elsewhere"}
+
+	var stdout bytes.Buffer
+	ctx := commandContext{
+		stdout: &stdout,
+		loadConfig: func(string) (*config.Config, error) {
+			return &config.Config{
+				BaseDir:     baseDir,
+				Edges:       []string{"orchestrator --- worker"},
+				EnterDelay:  0.1,
+				TmuxTimeout: 1.0,
+			}, nil
+		},
+		getTmuxSessionName:          func() string { return "main" },
+		contextHasLiveDaemon:        func(string, string) bool { return false },
+		sessionEnabledMarkerContext: func(session string) string { return markers[session] },
+		discoverNodes: func(string, string, string, discovery.Settings) (map[string]discovery.NodeInfo, error) {
+			return nodes, nil
+		},
+	}
+
+	if err := runDeliverOnceWithContext(ctx, []string{"--context-id", "ctx-once"}); err != nil {
+		t.Fatalf("runDeliverOnceWithContext: %v", err)
+	}
+
+	var out deliverOnceOutput
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		t.Fatalf("json.Unmarshal(%q): %v", stdout.String(), err)
+	}
+	if out.Delivered != 2 || len(out.Messages) != 2 {
+		t.Fatalf("output = %#v, want main and review delivered only", out)
+	}
+	for _, session := range []string{"main", "review"} {
+		if _, err := os.Stat(filepath.Join(sessionDirs[session], "inbox", "worker", "20260201-040000-from-orchestrator-to-worker.md")); err != nil {
+			t.Fatalf("%s post not delivered: %v", session, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(sessionDirs["other"], "post", "20260201-040000-from-orchestrator-to-worker.md")); err != nil {
+		t.Fatalf("post in a session owned by another context left post/: %v", err)
+	}
+}
+
+func TestRunDeliverOnce_RefusesWhileDaemonRuns(t *testing.T) {
+	ctx := commandContext{
+		stdout: &bytes.Buffer{},
+		loadConfig: func(string) (*config.Config, error) {
+			return &config.Config{BaseDir: t.TempDir()}, nil
+		},
+		getTmuxSessionName:   func() string { return "main" },
+		contextHasLiveDaemon: func(string, string) bool { return true },
+		discoverNodes: func(string, string, string, discovery.Settings) (map[string]discovery.NodeInfo, error) {
+			t.Fatal("discovery ran although a daemon owns the context")
+			return nil, nil
+		},
+	}
+
+	err := runDeliverOnceWithContext(ctx, []string{"--context-id", "ctx-once"})
+	if err == nil || !strings.Contains(err.Error(), "daemon is running") {
+		t.Fatalf("error = %v, want daemon-running refusal", err)
+	}
+}
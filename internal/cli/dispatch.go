@@ -9,19 +9,36 @@ type Config struct {
 }
 
 type Handlers struct {
-	Start                   func(contextID, configPath, logFilePath string) error
+	Start                   func(contextID, configPath, logFilePath string, args []string) error
 	Pop                     func(args []string) error
+	Ack                     func(args []string) error
+	Recall                  func(args []string) error
+	Register                func(args []string) error
+	InitDirs                func(args []string) error
+	DeliverOnce             func(args []string) error
+	Focus                   func(args []string) error
+	Ping                    func(args []string) error
+	TestDelivery            func(args []string) error
+	PruneContexts           func(args []string) error
+	Contexts                func(args []string) error
+	Stats                   func(args []string) error
+	RebuildState            func(args []string) error
+	CheckEdges              func(args []string) error
+	TalksTo                 func(args []string) error
 	CaptureProfile          func(args []string) error
 	GetSessionStatus        func(args []string) error
 	GetSessionStatusOneline func(args []string) error
 	InspectInput            func(args []string) error
 	InspectMessage          func(args []string) error
+	Search                  func(args []string) error
 	InspectCommandApprovals func(args []string) error
 	InspectDaemonSubmit     func(args []string) error
 	BackfillVerdictEvents   func(args []string) error
+	Config                  func(args []string) error
 	ExecuteBash             func(args []string) error
 	SendMessage             func(args []string) error
 	SendHeredoc             func(args []string) error
+	Reindex                 func(args []string) error
 	Stop                    func(args []string) error
 	Version                 func(args []string) error
 	Help                    func(args []string)
@@ -49,7 +66,7 @@ func Dispatch(command string, args []string, cfg Config, handlers Handlers) Resu
 	case "start":
 		return Result{
 			Label: "postman start",
-			Err:   handlers.Start(cfg.ContextID, cfg.ConfigPath, cfg.LogFilePath),
+			Err:   handlers.Start(cfg.ContextID, cfg.ConfigPath, cfg.LogFilePath, args),
 		}
 	case "capture-profile":
 		return Result{
@@ -61,6 +78,76 @@ func Dispatch(command string, args []string, cfg Config, handlers Handlers) Resu
 			Label: "postman pop",
 			Err:   handlers.Pop(prependConfig(cfg.ConfigPath, prependContextID(cfg.ContextID, args))),
 		}
+	case "ack":
+		return Result{
+			Label: "postman ack",
+			Err:   handlers.Ack(prependConfig(cfg.ConfigPath, prependContextID(cfg.ContextID, args))),
+		}
+	case "recall":
+		return Result{
+			Label: "postman recall",
+			Err:   handlers.Recall(prependConfig(cfg.ConfigPath, prependContextID(cfg.ContextID, args))),
+		}
+	case "register":
+		return Result{
+			Label: "postman register",
+			Err:   handlers.Register(prependConfig(cfg.ConfigPath, prependContextID(cfg.ContextID, args))),
+		}
+	case "init-dirs":
+		return Result{
+			Label: "postman init-dirs",
+			Err:   handlers.InitDirs(prependConfig(cfg.ConfigPath, prependContextID(cfg.ContextID, args))),
+		}
+	case "deliver-once":
+		return Result{
+			Label: "postman deliver-once",
+			Err:   handlers.DeliverOnce(prependConfig(cfg.ConfigPath, prependContextID(cfg.ContextID, args))),
+		}
+	case "focus":
+		return Result{
+			Label: "postman focus",
+			Err:   handlers.Focus(prependConfig(cfg.ConfigPath, prependContextID(cfg.ContextID, args))),
+		}
+	case "ping":
+		return Result{
+			Label: "postman ping",
+			Err:   handlers.Ping(prependConfig(cfg.ConfigPath, prependContextID(cfg.ContextID, args))),
+		}
+	case "test-delivery":
+		return Result{
+			Label: "postman test-delivery",
+			Err:   handlers.TestDelivery(prependConfig(cfg.ConfigPath, prependContextID(cfg.ContextID, args))),
+		}
+	case "prune-contexts":
+		return Result{
+			Label: "postman prune-contexts",
+			Err:   handlers.PruneContexts(prependConfig(cfg.ConfigPath, prependContextID(cfg.ContextID, args))),
+		}
+	case "contexts":
+		return Result{
+			Label: "postman contexts",
+			Err:   handlers.Contexts(prependConfig(cfg.ConfigPath, args)),
+		}
+	case "stats":
+		return Result{
+			Label: "postman stats",
+			Err:   handlers.Stats(prependConfig(cfg.ConfigPath, prependContextID(cfg.ContextID, args))),
+		}
+	case "rebuild-state":
+		return Result{
+			Label: "postman rebuild-state",
+			Err:   handlers.RebuildState(prependConfig(cfg.ConfigPath, prependContextID(cfg.ContextID, args))),
+		}
+	case "check-edges":
+		return Result{
+			Label: "postman check-edges",
+			Err:   handlers.CheckEdges(prependConfig(cfg.ConfigPath, args)),
+		}
+	case "talks-to":
+		return Result{
+			Label: "postman talks-to",
+			Err:   handlers.TalksTo(prependConfig(cfg.ConfigPath, prependContextID(cfg.ContextID, args))),
+		}
 	case "get-status":
 		return Result{
 			Label: "postman get-status",
@@ -81,6 +168,11 @@ func Dispatch(command string, args []string, cfg Config, handlers Handlers) Resu
 			Label: "postman inspect-message",
 			Err:   handlers.InspectMessage(prependConfig(cfg.ConfigPath, prependContextID(cfg.ContextID, args))),
 		}
+	case "search":
+		return Result{
+			Label: "postman search",
+			Err:   handlers.Search(prependConfig(cfg.ConfigPath, prependContextID(cfg.ContextID, args))),
+		}
 	case "inspect-command-approvals":
 		return Result{
 			Label: "postman inspect-command-approvals",
@@ -96,6 +188,11 @@ func Dispatch(command string, args []string, cfg Config, handlers Handlers) Resu
 			Label: "postman backfill-verdict-events",
 			Err:   handlers.BackfillVerdictEvents(args),
 		}
+	case "config":
+		return Result{
+			Label: "postman config",
+			Err:   handlers.Config(prependConfig(cfg.ConfigPath, args)),
+		}
 	case "execute-bash":
 		return Result{
 			Label: "postman execute-bash",
@@ -111,6 +208,11 @@ func Dispatch(command string, args []string, cfg Config, handlers Handlers) Resu
 			Label: "postman send-heredoc",
 			Err:   handlers.SendHeredoc(prependConfig(cfg.ConfigPath, prependContextID(cfg.ContextID, args))),
 		}
+	case "reindex":
+		return Result{
+			Label: "postman reindex",
+			Err:   handlers.Reindex(prependConfig(cfg.ConfigPath, args)),
+		}
 	case "stop":
 		return Result{
 			Label: "postman stop",
@@ -18,7 +18,7 @@ func TestDispatch_StartCallsStartHandler(t *testing.T) {
 			LogFilePath: "/tmp/postman.log",
 		},
 		Handlers{
-			Start: func(contextID, configPath, logFilePath string) error {
+			Start: func(contextID, configPath, logFilePath string, args []string) error {
 				called = true
 				if contextID != "ctx-start" {
 					t.Fatalf("contextID = %q, want %q", contextID, "ctx-start")
@@ -96,6 +96,87 @@ func TestDispatch_PopPrependsContextAndConfig(t *testing.T) {
 	}
 }
 
+func TestDispatch_AckPrependsContextAndConfig(t *testing.T) {
+	var gotArgs []string
+
+	result := Dispatch(
+		"ack",
+		[]string{"--node", "worker", "--all"},
+		Config{ContextID: "ctx-123", ConfigPath: "/tmp/postman.toml"},
+		Handlers{
+			Ack: func(args []string) error {
+				gotArgs = append([]string(nil), args...)
+				return nil
+			},
+		},
+	)
+
+	if result.Err != nil {
+		t.Fatalf("Dispatch returned error: %v", result.Err)
+	}
+	if result.Label != "postman ack" {
+		t.Fatalf("label = %q, want %q", result.Label, "postman ack")
+	}
+	wantArgs := []string{"--config", "/tmp/postman.toml", "--context-id", "ctx-123", "--node", "worker", "--all"}
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("ack args = %#v, want %#v", gotArgs, wantArgs)
+	}
+}
+
+func TestDispatch_RecallPrependsContextAndConfig(t *testing.T) {
+	var gotArgs []string
+
+	result := Dispatch(
+		"recall",
+		[]string{"--file", "msg.md"},
+		Config{ContextID: "ctx-123", ConfigPath: "/tmp/postman.toml"},
+		Handlers{
+			Recall: func(args []string) error {
+				gotArgs = append([]string(nil), args...)
+				return nil
+			},
+		},
+	)
+
+	if result.Err != nil {
+		t.Fatalf("Dispatch returned error: %v", result.Err)
+	}
+	if result.Label != "postman recall" {
+		t.Fatalf("label = %q, want %q", result.Label, "postman recall")
+	}
+	wantArgs := []string{"--config", "/tmp/postman.toml", "--context-id", "ctx-123", "--file", "msg.md"}
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("recall args = %#v, want %#v", gotArgs, wantArgs)
+	}
+}
+
+func TestDispatch_RegisterPrependsContextAndConfig(t *testing.T) {
+	var gotArgs []string
+
+	result := Dispatch(
+		"register",
+		nil,
+		Config{ContextID: "ctx-123", ConfigPath: "/tmp/postman.toml"},
+		Handlers{
+			Register: func(args []string) error {
+				gotArgs = append([]string(nil), args...)
+				return nil
+			},
+		},
+	)
+
+	if result.Err != nil {
+		t.Fatalf("Dispatch returned error: %v", result.Err)
+	}
+	if result.Label != "postman register" {
+		t.Fatalf("label = %q, want %q", result.Label, "postman register")
+	}
+	wantArgs := []string{"--config", "/tmp/postman.toml", "--context-id", "ctx-123"}
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("register args = %#v, want %#v", gotArgs, wantArgs)
+	}
+}
+
 func TestDispatch_StatusCommandsArePublic(t *testing.T) {
 	t.Run("get-status", func(t *testing.T) {
 		var gotArgs []string
@@ -341,6 +422,60 @@ func TestDispatch_StopPrependsConfigOnly(t *testing.T) {
 	}
 }
 
+func TestDispatch_ReindexPrependsConfigOnly(t *testing.T) {
+	var gotArgs []string
+
+	result := Dispatch(
+		"reindex",
+		nil,
+		Config{ContextID: "ctx-123", ConfigPath: "/tmp/postman.toml"},
+		Handlers{
+			Reindex: func(args []string) error {
+				gotArgs = append([]string(nil), args...)
+				return nil
+			},
+		},
+	)
+
+	if result.Err != nil {
+		t.Fatalf("Dispatch returned error: %v", result.Err)
+	}
+	if result.Label != "postman reindex" {
+		t.Fatalf("label = %q, want %q", result.Label, "postman reindex")
+	}
+	wantArgs := []string{"--config", "/tmp/postman.toml"}
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("reindex args = %#v, want %#v", gotArgs, wantArgs)
+	}
+}
+
+func TestDispatch_ConfigPrependsConfigOnly(t *testing.T) {
+	var gotArgs []string
+
+	result := Dispatch(
+		"config",
+		[]string{"dump", "--format", "json"},
+		Config{ContextID: "ctx-123", ConfigPath: "/tmp/postman.toml"},
+		Handlers{
+			Config: func(args []string) error {
+				gotArgs = append([]string(nil), args...)
+				return nil
+			},
+		},
+	)
+
+	if result.Err != nil {
+		t.Fatalf("Dispatch returned error: %v", result.Err)
+	}
+	if result.Label != "postman config" {
+		t.Fatalf("label = %q, want %q", result.Label, "postman config")
+	}
+	wantArgs := []string{"--config", "/tmp/postman.toml", "dump", "--format", "json"}
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("config args = %#v, want %#v", gotArgs, wantArgs)
+	}
+}
+
 func TestDispatch_VersionCallsVersionHandler(t *testing.T) {
 	called := false
 
@@ -111,8 +111,8 @@ func runExecuteBashWithContext(ctx commandContext, args []string) error {
 	if err != nil {
 		return err
 	}
-	sessionDir := filepath.Join(baseDir, resolvedContextID, resolvedSessionName)
-	if err := config.CreateSessionDirs(sessionDir); err != nil {
+	sessionDir := cfg.SessionDir(baseDir, resolvedContextID, resolvedSessionName)
+	if err := cfg.CreateMultiSessionDirs(filepath.Join(baseDir, resolvedContextID), resolvedSessionName); err != nil {
 		return fmt.Errorf("creating session directories: %w", err)
 	}
 
@@ -126,10 +126,11 @@ func runExecuteBashWithContext(ctx commandContext, args []string) error {
 			reason:           *reason,
 			storeCommandText: *storeCommandText,
 			commandText:      *command,
+			paneNameFormat:   cfg.PaneNameFormat(),
 		})
 	}
 
-	resolvedRequester, err := resolveExecuteBashRequester(ctx, *requester)
+	resolvedRequester, err := resolveExecuteBashRequester(ctx, cfg, *requester)
 	if err != nil {
 		return err
 	}
@@ -253,6 +254,7 @@ type executeBashDecisionOptions struct {
 	reason           string
 	storeCommandText bool
 	commandText      string
+	paneNameFormat   string
 }
 
 func recordExecuteBashDecision(ctx commandContext, opts executeBashDecisionOptions) error {
@@ -280,7 +282,7 @@ func recordExecuteBashDecision(ctx commandContext, opts executeBashDecisionOptio
 	// B1) — never re-resolved from current config, so a decision can't be
 	// laundered through a config change between request and decision time
 	// either.
-	authenticatedCaller := strings.TrimSpace(ctx.getTmuxPaneName())
+	authenticatedCaller := strings.TrimSpace(ctx.getTmuxPaneName(opts.paneNameFormat))
 	if authenticatedCaller == "" {
 		return fmt.Errorf("--record-decision requires a resolvable tmux pane title identity; run inside tmux")
 	}
@@ -335,10 +337,10 @@ func resolveExecuteBashContextID(baseDir, sessionName, flagValue string) (string
 	return config.ResolveContextIDFromSession(baseDir, sessionName)
 }
 
-func resolveExecuteBashRequester(ctx commandContext, flagValue string) (string, error) {
+func resolveExecuteBashRequester(ctx commandContext, cfg *config.Config, flagValue string) (string, error) {
 	requester := strings.TrimSpace(flagValue)
 	if requester == "" {
-		requester = strings.TrimSpace(ctx.getTmuxPaneName())
+		requester = strings.TrimSpace(ctx.getTmuxPaneName(cfg.PaneNameFormat()))
 	}
 	if requester == "" {
 		return "", fmt.Errorf("requester node required: set tmux pane title or pass --requester")
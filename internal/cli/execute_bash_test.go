@@ -81,7 +81,7 @@ func (f *executeBashFixture) contextAsPane(paneName string) commandContext {
 				Nodes:               f.nodes,
 			}, nil
 		},
-		getTmuxPaneName:    func() string { return paneName },
+		getTmuxPaneName:    func(string) string { return paneName },
 		getTmuxSessionName: func() string { return f.sessionName },
 		now:                func() time.Time { return f.now },
 		runBash: func(command string, stdout, stderr io.Writer) (int, error) {
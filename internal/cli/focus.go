@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/cliutil"
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/nodeaddr"
+	"github.com/i9wa4/tmux-a2a-postman/internal/tmuxrunner"
+)
+
+// RunFocus brings a node's pane into view: it switches the client to the
+// node's session when needed, then selects its window and pane.
+func RunFocus(args []string) error {
+	return runFocusWithContext(defaultCommandContext(), args)
+}
+
+type focusOutput struct {
+	Status  string `json:"status"`
+	Node    string `json:"node"`
+	Session string `json:"session"`
+	PaneID  string `json:"pane_id"`
+}
+
+func runFocusWithContext(ctx commandContext, args []string) error {
+	ctx = ctx.withDefaults()
+	fs := flag.NewFlagSet("focus", flag.ContinueOnError)
+	cliutil.SetUsageWithoutContextID(fs)
+	contextID := fs.String("context-id", "", "context ID")
+	configPath := fs.String("config", "", "path to config file (optional)")
+	node := fs.String("node", "", "node to focus; session:node is accepted (required)")
+	session := fs.String("session", "", "session of the node (default: current tmux session)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *node == "" {
+		return fmt.Errorf("--node is required")
+	}
+	if err := cliutil.ValidateNodeAddress("--node", *node); err != nil {
+		return err
+	}
+
+	cfg, err := ctx.loadConfig(*configPath)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	baseDir := config.ResolveBaseDir(cfg.BaseDir)
+
+	currentSession := ctx.getTmuxSessionName()
+	targetSession, nodeName, hasSession := nodeaddr.Split(*node)
+	if !hasSession {
+		targetSession = *session
+	} else if *session != "" && *session != targetSession {
+		return fmt.Errorf("--node %q conflicts with --session %q", *node, *session)
+	}
+	if targetSession == "" {
+		targetSession = currentSession
+	}
+	if targetSession == "" {
+		return fmt.Errorf("--session required outside tmux")
+	}
+
+	var resolvedContextID string
+	if *contextID != "" {
+		resolvedContextID, err = ctx.resolveContextID(*contextID)
+	} else {
+		resolvedContextID, err = ctx.resolveContextSession(baseDir, targetSession)
+	}
+	if err != nil {
+		return err
+	}
+
+	nodes, err := ctx.discoverNodes(baseDir, resolvedContextID, targetSession, cfg)
+	if err != nil {
+		return fmt.Errorf("discovering nodes: %w", err)
+	}
+	nodeKey := targetSession + ":" + nodeName
+	info, ok := nodes[nodeKey]
+	if !ok || info.PaneID == "" {
+		var known []string
+		for key := range nodes {
+			if strings.HasPrefix(key, targetSession+":") {
+				known = append(known, nodeaddr.Simple(key))
+			}
+		}
+		sort.Strings(known)
+		if len(known) == 0 {
+			return fmt.Errorf("node %q has no pane in session %q (no nodes discovered there)", nodeName, targetSession)
+		}
+		return fmt.Errorf("node %q has no pane in session %q (known: %s)", nodeName, targetSession, strings.Join(known, ", "))
+	}
+
+	var commands [][]string
+	if currentSession != "" && currentSession != targetSession {
+		commands = append(commands, []string{"switch-client", "-t", info.PaneID})
+	}
+	commands = append(commands,
+		[]string{"select-window", "-t", info.PaneID},
+		[]string{"select-pane", "-t", info.PaneID},
+	)
+	for _, command := range commands {
+		if out, err := tmuxrunner.CombinedOutput(command...); err != nil {
+			return fmt.Errorf("tmux %s: %w: %s", command[0], err, strings.TrimSpace(string(out)))
+		}
+	}
+
+	return json.NewEncoder(ctx.stdout).Encode(focusOutput{
+		Status:  "focused",
+		Node:    nodeName,
+		Session: targetSession,
+		PaneID:  info.PaneID,
+	})
+}
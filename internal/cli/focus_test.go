@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/discovery"
+	"github.com/i9wa4/tmux-a2a-postman/internal/tmuxtest"
+)
+
+func focusTestContext(stdout *bytes.Buffer, currentSession string, nodes map[string]discovery.NodeInfo) commandContext {
+	return commandContext{
+		stdout: stdout,
+		loadConfig: func(string) (*config.Config, error) {
+			return &config.Config{}, nil
+		},
+		getTmuxSessionName: func() string { return currentSession },
+		resolveContextID:   func(id string) (string, error) { return id, nil },
+		discoverNodes: func(baseDir, contextID, selfSession string, _ discovery.Settings) (map[string]discovery.NodeInfo, error) {
+			return nodes, nil
+		},
+	}
+}
+
+func installFocusTmux(t *testing.T) *tmuxtest.FakeTmux {
+	t.Helper()
+	var opts []tmuxtest.Option
+	for _, verb := range []string{"switch-client", "select-window", "select-pane"} {
+		opts = append(opts, tmuxtest.WithCommand(tmuxtest.Command{Args: []string{verb, "-t", "%12"}}))
+	}
+	return tmuxtest.Install(t, opts...)
+}
+
+func TestRunFocus_SelectsResolvedPaneInOtherSession(t *testing.T) {
+	fake := installFocusTmux(t)
+	var stdout bytes.Buffer
+	ctx := focusTestContext(&stdout, "main", map[string]discovery.NodeInfo{
+		"review:worker": {PaneID: "%12", SessionName: "review"},
+	})
+
+	if err := runFocusWithContext(ctx, []string{"--context-id", "ctx-focus", "--node", "worker", "--session", "review"}); err != nil {
+		t.Fatalf("runFocusWithContext: %v", err)
+	}
+
+	want := []string{"switch-client -t %12", "select-window -t %12", "select-pane -t %12"}
+	if got := fake.Invocations(); !slices.Equal(got, want) {
+		t.Fatalf("tmux invocations = %q, want %q", got, want)
+	}
+	var out focusOutput
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		t.Fatalf("json.Unmarshal(%q): %v", stdout.String(), err)
+	}
+	if out != (focusOutput{Status: "focused", Node: "worker", Session: "review", PaneID: "%12"}) {
+		t.Fatalf("output = %#v", out)
+	}
+}
+
+func TestRunFocus_SameSessionSkipsSwitchClient(t *testing.T) {
+	fake := installFocusTmux(t)
+	ctx := focusTestContext(&bytes.Buffer{}, "review", map[string]discovery.NodeInfo{
+		"review:worker": {PaneID: "%12", SessionName: "review"},
+	})
+
+	if err := runFocusWithContext(ctx, []string{"--context-id", "ctx-focus", "--node", "review:worker"}); err != nil {
+		t.Fatalf("runFocusWithContext: %v", err)
+	}
+
+	want := []string{"select-window -t %12", "select-pane -t %12"}
+	if got := fake.Invocations(); !slices.Equal(got, want) {
+		t.Fatalf("tmux invocations = %q, want %q", got, want)
+	}
+}
+
+func TestRunFocus_MissingPaneListsKnownNodes(t *testing.T) {
+	fake := installFocusTmux(t)
+	ctx := focusTestContext(&bytes.Buffer{}, "review", map[string]discovery.NodeInfo{
+		"review:critic": {PaneID: "%3", SessionName: "review"},
+		"main:worker":   {PaneID: "%4", SessionName: "main"},
+	})
+
+	err := runFocusWithContext(ctx, []string{"--context-id", "ctx-focus", "--node", "worker"})
+	if err == nil {
+		t.Fatal("runFocusWithContext() error = nil, want missing pane error")
+	}
+	if !strings.Contains(err.Error(), `node "worker" has no pane in session "review" (known: critic)`) {
+		t.Fatalf("error = %v", err)
+	}
+	if got := fake.Invocations(); len(got) != 0 {
+		t.Fatalf("tmux invoked for missing pane: %q", got)
+	}
+}
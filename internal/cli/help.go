@@ -13,10 +13,15 @@ var helpTextFS embed.FS
 
 var helpTopicFiles = map[string]string{
 	"":                          "helptext/overview.txt",
+	"ack":                       "helptext/ack.txt",
 	"backfill-verdict-events":   "helptext/backfill-verdict-events.txt",
 	"capture-profile":           "helptext/capture-profile.txt",
 	"commands":                  "helptext/commands.txt",
 	"config":                    "helptext/config.txt",
+	"contexts":                  "helptext/contexts.txt",
+	"stats":                     "helptext/stats.txt",
+	"rebuild-state":             "helptext/rebuild-state.txt",
+	"check-edges":               "helptext/check-edges.txt",
 	"directories":               "helptext/directories.txt",
 	"get-status":                "helptext/get-status.txt",
 	"get-status-oneline":        "helptext/get-status-oneline.txt",
@@ -26,8 +31,19 @@ var helpTopicFiles = map[string]string{
 	"inspect-input":             "helptext/inspect-input.txt",
 	"inspect-daemon-submit":     "helptext/inspect-daemon-submit.txt",
 	"inspect-message":           "helptext/inspect-message.txt",
+	"search":                    "helptext/search.txt",
 	"messaging":                 "helptext/messaging.txt",
 	"pop":                       "helptext/pop.txt",
+	"recall":                    "helptext/recall.txt",
+	"focus":                     "helptext/focus.txt",
+	"ping":                      "helptext/ping.txt",
+	"test-delivery":             "helptext/test-delivery.txt",
+	"talks-to":                  "helptext/talks-to.txt",
+	"prune-contexts":            "helptext/prune-contexts.txt",
+	"register":                  "helptext/register.txt",
+	"init-dirs":                 "helptext/init-dirs.txt",
+	"deliver-once":              "helptext/deliver-once.txt",
+	"reindex":                   "helptext/reindex.txt",
 	"send":                      "helptext/send.txt",
 	"send-heredoc":              "helptext/send-heredoc.txt",
 	"start":                     "helptext/start.txt",
@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/cliutil"
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/nodeaddr"
+)
+
+// RunInitDirs creates the session dirs and inbox/<node>/ for every node
+// named in edges, so the mesh is ready before any message flows.
+func RunInitDirs(args []string) error {
+	return runInitDirsWithContext(defaultCommandContext(), args)
+}
+
+type initDirsOutput struct {
+	Status    string         `json:"status"`
+	ContextID string         `json:"context_id"`
+	Nodes     []initDirsNode `json:"nodes"`
+}
+
+type initDirsNode struct {
+	Node    string `json:"node"`
+	Session string `json:"session"`
+	Inbox   string `json:"inbox"`
+}
+
+func runInitDirsWithContext(ctx commandContext, args []string) error {
+	ctx = ctx.withDefaults()
+	fs := flag.NewFlagSet("init-dirs", flag.ContinueOnError)
+	cliutil.SetUsageWithoutContextID(fs)
+	contextID := fs.String("context-id", "", "context ID")
+	configPath := fs.String("config", "", "path to config file (optional)")
+	session := fs.String("session", "", "session for edge nodes without a session prefix (default: current tmux session)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := ctx.loadConfig(*configPath)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	baseDir := config.ResolveBaseDir(cfg.BaseDir)
+
+	sessionName := *session
+	if sessionName == "" {
+		sessionName = ctx.getTmuxSessionName()
+	}
+	if sessionName == "" {
+		return fmt.Errorf("--session required outside tmux")
+	}
+	if sessionName, err = config.ValidateSessionName(sessionName); err != nil {
+		return fmt.Errorf("invalid session name: %w", err)
+	}
+
+	var resolvedContextID string
+	if *contextID != "" {
+		resolvedContextID, err = ctx.resolveContextID(*contextID)
+		if err != nil {
+			return err
+		}
+	} else if resolvedContextID, err = ctx.resolveContextSession(baseDir, sessionName); err != nil {
+		// Same fallback as register: a session nothing has written to yet
+		// belongs to the current user's daemon.
+		daemonContextID, _, ok := config.FindCurrentUserDaemon(baseDir)
+		if !ok {
+			return err
+		}
+		resolvedContextID = daemonContextID
+	}
+
+	var names []string
+	for name := range config.GetEdgeNodeNames(cfg.Edges) {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := initDirsOutput{Status: "initialized", ContextID: resolvedContextID, Nodes: []initDirsNode{}}
+	for _, name := range names {
+		if err := cliutil.ValidateNodeAddress("edge node", name); err != nil {
+			return err
+		}
+		nodeSession, nodeName, hasSession := nodeaddr.Split(name)
+		if !hasSession {
+			nodeSession = sessionName
+		}
+		sessionDir := cfg.SessionDir(baseDir, resolvedContextID, nodeSession)
+		if err := cfg.CreateMultiSessionDirs(filepath.Join(baseDir, resolvedContextID), nodeSession); err != nil {
+			return fmt.Errorf("creating session dirs: %w", err)
+		}
+		inbox := filepath.Join(sessionDir, "inbox", nodeName)
+		if err := os.MkdirAll(inbox, 0o700); err != nil {
+			return fmt.Errorf("creating inbox: %w", err)
+		}
+		out.Nodes = append(out.Nodes, initDirsNode{Node: nodeName, Session: nodeSession, Inbox: inbox})
+	}
+
+	return json.NewEncoder(ctx.stdout).Encode(out)
+}
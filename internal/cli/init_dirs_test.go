@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+)
+
+func TestRunInitDirs_CreatesDirsForEveryEdgeNode(t *testing.T) {
+	baseDir := t.TempDir()
+	var stdout bytes.Buffer
+	ctx := commandContext{
+		stdout: &stdout,
+		loadConfig: func(string) (*config.Config, error) {
+			return &config.Config{
+				BaseDir: baseDir,
+				Edges:   []string{"orchestrator --- worker --- critic", "worker --- ops:deployer"},
+			}, nil
+		},
+		getTmuxSessionName: func() string { return "review" },
+	}
+
+	if err := runInitDirsWithContext(ctx, []string{"--context-id", "ctx-init"}); err != nil {
+		t.Fatalf("runInitDirsWithContext: %v", err)
+	}
+
+	var out initDirsOutput
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		t.Fatalf("json.Unmarshal(%q): %v", stdout.String(), err)
+	}
+	if out.Status != "initialized" || out.ContextID != "ctx-init" || len(out.Nodes) != 4 {
+		t.Fatalf("output = %#v, want 4 initialized nodes in ctx-init", out)
+	}
+
+	contextDir := filepath.Join(baseDir, "ctx-init")
+	for _, node := range []struct{ session, name string }{
+		{"review", "orchestrator"},
+		{"review", "worker"},
+		{"review", "critic"},
+		{"ops", "deployer"},
+	} {
+		sessionDir := filepath.Join(contextDir, node.session)
+		for _, dir := range []string{"inbox", "post", "draft", "read", "dead-letter", filepath.Join("inbox", node.name)} {
+			if info, err := os.Stat(filepath.Join(sessionDir, dir)); err != nil || !info.IsDir() {
+				t.Fatalf("%s/%s missing after init-dirs: %v", node.session, dir, err)
+			}
+		}
+	}
+
+	// Re-running is harmless.
+	stdout.Reset()
+	if err := runInitDirsWithContext(ctx, []string{"--context-id", "ctx-init"}); err != nil {
+		t.Fatalf("second runInitDirsWithContext: %v", err)
+	}
+}
@@ -126,7 +126,7 @@ func resolveInspectMessageSessionDir(contextID, sessionName, configPath string)
 		return "", "", "", err
 	}
 
-	return filepath.Join(baseDir, contextID, sessionName), contextID, sessionName, nil
+	return cfg.SessionDir(baseDir, contextID, sessionName), contextID, sessionName, nil
 }
 
 func findInspectMessageMatches(sessionDir, id string) ([]inspectMessageMatch, error) {
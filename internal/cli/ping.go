@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/cliutil"
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/nodeaddr"
+)
+
+// RunPing sends one PING to a single node, like the TUI 'p' key does for a
+// whole session.
+func RunPing(args []string) error {
+	return runPingWithContext(defaultCommandContext(), args)
+}
+
+type pingOutput struct {
+	Status  string `json:"status"`
+	Node    string `json:"node"`
+	Session string `json:"session"`
+	PaneID  string `json:"pane_id"`
+}
+
+func runPingWithContext(ctx commandContext, args []string) error {
+	ctx = ctx.withDefaults()
+	fs := flag.NewFlagSet("ping", flag.ContinueOnError)
+	cliutil.SetUsageWithoutContextID(fs)
+	contextID := fs.String("context-id", "", "context ID")
+	configPath := fs.String("config", "", "path to config file (optional)")
+	node := fs.String("node", "", "node to ping; session:node is accepted (required)")
+	session := fs.String("session", "", "session of the node (default: current tmux session)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *node == "" {
+		return fmt.Errorf("--node is required")
+	}
+	if err := cliutil.ValidateNodeAddress("--node", *node); err != nil {
+		return err
+	}
+
+	cfg, err := ctx.loadConfig(*configPath)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	baseDir := config.ResolveBaseDir(cfg.BaseDir)
+
+	targetSession, nodeName, hasSession := nodeaddr.Split(*node)
+	if !hasSession {
+		targetSession = *session
+	} else if *session != "" && *session != targetSession {
+		return fmt.Errorf("--node %q conflicts with --session %q", *node, *session)
+	}
+	if targetSession == "" {
+		targetSession = ctx.getTmuxSessionName()
+	}
+	if targetSession == "" {
+		return fmt.Errorf("--session required outside tmux")
+	}
+
+	var resolvedContextID string
+	if *contextID != "" {
+		resolvedContextID, err = ctx.resolveContextID(*contextID)
+	} else {
+		resolvedContextID, err = ctx.resolveContextSession(baseDir, targetSession)
+	}
+	if err != nil {
+		return err
+	}
+
+	discovered, err := ctx.discoverNodes(baseDir, resolvedContextID, targetSession, cfg)
+	if err != nil {
+		return fmt.Errorf("discovering nodes: %w", err)
+	}
+	// Same node set the TUI PING uses: discovered panes that the topology
+	// knows about.
+	nodes := filterDiscoveredActivationNodes(discovered, activationNodeNames(cfg))
+	nodeKey := targetSession + ":" + nodeName
+	info, ok := nodes[nodeKey]
+	if !ok || info.PaneID == "" {
+		var known []string
+		for key := range nodes {
+			if strings.HasPrefix(key, targetSession+":") {
+				known = append(known, nodeaddr.Simple(key))
+			}
+		}
+		sort.Strings(known)
+		if len(known) == 0 {
+			return fmt.Errorf("node %q has no pane in session %q (no nodes discovered there)", nodeName, targetSession)
+		}
+		return fmt.Errorf("node %q has no pane in session %q (known: %s)", nodeName, targetSession, strings.Join(known, ", "))
+	}
+
+	adjacency, _ := config.ParseEdges(cfg.Edges)
+	if adjacency == nil {
+		adjacency = map[string][]string{}
+	}
+	if err := ctx.sendPing(info, resolvedContextID, nodeKey, cfg.DaemonMessageTemplate, cfg, activePingNodeNames(nodes), nil, adjacency, nodes); err != nil {
+		return fmt.Errorf("sending PING to %s: %w", nodeKey, err)
+	}
+
+	return json.NewEncoder(ctx.stdout).Encode(pingOutput{
+		Status:  "sent",
+		Node:    nodeName,
+		Session: targetSession,
+		PaneID:  info.PaneID,
+	})
+}
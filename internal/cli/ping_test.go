@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/discovery"
+)
+
+func TestRunPing_TargetsNodePaneWithDiscoveredActiveNodes(t *testing.T) {
+	var stdout bytes.Buffer
+	var gotPane, gotContext, gotNode string
+	var gotActive []string
+	ctx := commandContext{
+		stdout: &stdout,
+		loadConfig: func(string) (*config.Config, error) {
+			return &config.Config{Edges: []string{"boss --- worker --- critic"}}, nil
+		},
+		getTmuxSessionName: func() string { return "main" },
+		resolveContextID:   func(id string) (string, error) { return id, nil },
+		discoverNodes: func(baseDir, contextID, selfSession string, _ discovery.Settings) (map[string]discovery.NodeInfo, error) {
+			return map[string]discovery.NodeInfo{
+				"review:boss":   {PaneID: "%10", SessionName: "review"},
+				"review:worker": {PaneID: "%12", SessionName: "review"},
+				"review:stray":  {PaneID: "%13", SessionName: "review"},
+			}, nil
+		},
+		sendPing: func(nodeInfo discovery.NodeInfo, contextID, nodeName, tmpl string, cfg *config.Config, activeNodes []string, livenessMap map[string]bool, adjacency map[string][]string, nodes map[string]discovery.NodeInfo) error {
+			gotPane, gotContext, gotNode, gotActive = nodeInfo.PaneID, contextID, nodeName, activeNodes
+			return nil
+		},
+	}
+
+	if err := runPingWithContext(ctx, []string{"--context-id", "ctx-ping", "--node", "review:worker"}); err != nil {
+		t.Fatalf("runPingWithContext: %v", err)
+	}
+
+	if gotPane != "%12" || gotNode != "review:worker" || gotContext != "ctx-ping" {
+		t.Fatalf("PING target = pane %q node %q context %q, want %%12 review:worker ctx-ping", gotPane, gotNode, gotContext)
+	}
+	if want := []string{"boss", "worker"}; !slices.Equal(gotActive, want) {
+		t.Fatalf("active nodes = %q, want %q (discovered topology nodes only)", gotActive, want)
+	}
+	var out pingOutput
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		t.Fatalf("json.Unmarshal(%q): %v", stdout.String(), err)
+	}
+	if out != (pingOutput{Status: "sent", Node: "worker", Session: "review", PaneID: "%12"}) {
+		t.Fatalf("output = %#v", out)
+	}
+}
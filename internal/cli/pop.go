@@ -52,16 +52,19 @@ func runPopWithContext(ctx commandContext, args []string) error {
 	if err != nil {
 		return err
 	}
+	cfg, err := ctx.loadConfig(*configPath)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
 	sessionDir := filepath.Dir(filepath.Dir(inboxPath))
 	contextDir := filepath.Dir(sessionDir)
 	resolvedContextID := filepath.Base(contextDir)
-	baseDir := filepath.Dir(contextDir)
 	sessionName := filepath.Base(sessionDir)
 	nodeName := filepath.Base(inboxPath)
-
-	cfg, err := ctx.loadConfig(*configPath)
-	if err != nil {
-		return fmt.Errorf("loading config: %w", err)
+	baseDir := filepath.Dir(contextDir)
+	if cfg.HasSessionBaseDir(sessionName) {
+		// The inbox lives under the override; the daemon PID file does not.
+		baseDir = config.ResolveBaseDir(cfg.BaseDir)
 	}
 	if ctx.contextOwnsSession(baseDir, resolvedContextID, sessionName) {
 		response, err := ctx.roundTripDaemonSubmit(sessionDir, projection.DaemonSubmitRequest{
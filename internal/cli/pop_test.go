@@ -114,6 +114,62 @@ func TestRunPopWithContextWritesJSONToConfiguredStdout(t *testing.T) {
 	}
 }
 
+func TestRunPopReadsOverriddenSessionBaseDir(t *testing.T) {
+	tmpDir := t.TempDir()
+	fastDir := filepath.Join(tmpDir, "fast")
+	contextID := "ctx-pop-override"
+	configPath := filepath.Join(tmpDir, "postman.toml")
+	content := `[postman]
+edges = ["orchestrator --- worker"]
+
+[worker]
+role = "worker"
+
+[session.test-session]
+base_dir = "` + fastDir + `"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile config: %v", err)
+	}
+	installFakeTmuxForCLI(t, tmpDir, "test-session", "worker")
+
+	// Mail the daemon delivered under the override; the base_dir inbox
+	// exists too but stays empty.
+	sessionDir := filepath.Join(fastDir, contextID, "test-session")
+	if err := config.CreateSessionDirs(filepath.Join(tmpDir, contextID, "test-session")); err != nil {
+		t.Fatalf("CreateSessionDirs(base): %v", err)
+	}
+	inboxDir := filepath.Join(sessionDir, "inbox", "worker")
+	if err := os.MkdirAll(inboxDir, 0o700); err != nil {
+		t.Fatalf("MkdirAll inbox: %v", err)
+	}
+	filename := "20260414-032800-from-orchestrator-to-worker.md"
+	if err := os.WriteFile(filepath.Join(inboxDir, filename), []byte(messageFixture("orchestrator", "worker", "override payload")), 0o600); err != nil {
+		t.Fatalf("WriteFile inbox: %v", err)
+	}
+
+	var stdout bytes.Buffer
+	err := runPopWithContext(commandContext{
+		stdout: &stdout,
+		contextOwnsSession: func(baseDir, resolvedContextID, sessionName string) bool {
+			if baseDir != tmpDir || resolvedContextID != contextID || sessionName != "test-session" {
+				t.Fatalf("ownership args = %q/%q/%q, want the base_dir PID location", baseDir, resolvedContextID, sessionName)
+			}
+			return false
+		},
+	}, []string{"--config", configPath, "--context-id", contextID})
+	if err != nil {
+		t.Fatalf("runPopWithContext: %v", err)
+	}
+	payload := decodePopMessageOutputForTest(t, stdout.String())
+	if payload.MarkdownPath != filepath.Join(sessionDir, "read", filename) {
+		t.Fatalf("MarkdownPath = %q, want the overridden read/ path", payload.MarkdownPath)
+	}
+	if !strings.Contains(readPopArchiveForTest(t, payload), "override payload") {
+		t.Fatalf("archived body missing expected payload")
+	}
+}
+
 func TestWritePopMessageOutputReturnsReceiptWriteError(t *testing.T) {
 	tmpDir := t.TempDir()
 	readDir := filepath.Join(tmpDir, "ctx", "review", "read")
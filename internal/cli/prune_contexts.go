@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/cliutil"
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+)
+
+// defaultPruneContextsAgeDays applies when neither --older-than-days nor
+// retention_period_days gives a positive age.
+const defaultPruneContextsAgeDays = 30
+
+// RunPruneContexts lists, and with --force removes, context directories under
+// the base dir that have no live daemon and no recent activity.
+func RunPruneContexts(args []string) error {
+	return runPruneContextsWithContext(defaultCommandContext(), args)
+}
+
+type pruneContextsOutput struct {
+	Status        string               `json:"status"`
+	OlderThanDays int                  `json:"older_than_days"`
+	Contexts      []pruneContextReport `json:"contexts"`
+}
+
+type pruneContextReport struct {
+	ContextID    string `json:"context_id"`
+	Path         string `json:"path"`
+	LastActivity string `json:"last_activity"`
+}
+
+func runPruneContextsWithContext(ctx commandContext, args []string) error {
+	ctx = ctx.withDefaults()
+	fs := flag.NewFlagSet("prune-contexts", flag.ContinueOnError)
+	cliutil.SetUsageWithoutContextID(fs)
+	contextID := fs.String("context-id", "", "active context ID to keep (default: the context owning this tmux session)")
+	configPath := fs.String("config", "", "path to config file (optional)")
+	olderThanDays := fs.Int("older-than-days", 0, "minimum days since last activity (default: retention_period_days, else 30)")
+	force := fs.Bool("force", false, "remove the listed contexts instead of only listing them")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *olderThanDays < 0 {
+		return fmt.Errorf("--older-than-days must be >= 0")
+	}
+
+	cfg, err := ctx.loadConfig(*configPath)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	baseDir := config.ResolveBaseDir(cfg.BaseDir)
+
+	days := *olderThanDays
+	if days == 0 {
+		days = cfg.RetentionPeriodDays
+	}
+	if days <= 0 {
+		days = defaultPruneContextsAgeDays
+	}
+
+	activeContextID := ""
+	if *contextID != "" {
+		if activeContextID, err = ctx.resolveContextID(*contextID); err != nil {
+			return err
+		}
+	} else if sessionName := ctx.getTmuxSessionName(); sessionName != "" {
+		// Outside an owned session there is no active context; live-daemon
+		// checks still protect every running context.
+		activeContextID, _ = ctx.resolveContextSession(baseDir, sessionName)
+	}
+
+	candidates, err := findPrunableContexts(baseDir, activeContextID, ctx.now().AddDate(0, 0, -days), ctx.contextHasLiveDaemon)
+	if err != nil {
+		return err
+	}
+
+	out := pruneContextsOutput{Status: "dry_run", OlderThanDays: days, Contexts: candidates}
+	if *force {
+		for _, candidate := range candidates {
+			if err := os.RemoveAll(candidate.Path); err != nil {
+				return fmt.Errorf("removing context %s: %w", candidate.ContextID, err)
+			}
+			// Sessions moved by [session.<name>] base_dir keep their
+			// directories outside the context dir.
+			for sessionName := range cfg.Sessions {
+				if !cfg.HasSessionBaseDir(sessionName) {
+					continue
+				}
+				if err := os.RemoveAll(cfg.SessionDir(baseDir, candidate.ContextID, sessionName)); err != nil {
+					return fmt.Errorf("removing context %s session %s: %w", candidate.ContextID, sessionName, err)
+				}
+			}
+		}
+		out.Status = "pruned"
+	}
+	return json.NewEncoder(ctx.stdout).Encode(out)
+}
+
+// findPrunableContexts returns contexts other than the active one that have
+// no live daemon and whose newest file is older than cutoff.
+func findPrunableContexts(baseDir, activeContextID string, cutoff time.Time, hasLiveDaemon func(baseDir, contextID string) bool) ([]pruneContextReport, error) {
+	entries, err := os.ReadDir(baseDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []pruneContextReport{}, nil
+		}
+		return nil, fmt.Errorf("reading base dir: %w", err)
+	}
+
+	candidates := []pruneContextReport{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		if name == "lock" || name == activeContextID || hasLiveDaemon(baseDir, name) {
+			continue
+		}
+		contextDir := filepath.Join(baseDir, name)
+		lastActivity, err := newestModTime(contextDir)
+		if err != nil {
+			return nil, fmt.Errorf("scanning context %s: %w", name, err)
+		}
+		if lastActivity.After(cutoff) {
+			continue
+		}
+		candidates = append(candidates, pruneContextReport{
+			ContextID:    name,
+			Path:         contextDir,
+			LastActivity: lastActivity.UTC().Format(time.RFC3339),
+		})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].ContextID < candidates[j].ContextID
+	})
+	return candidates, nil
+}
+
+// newestModTime returns the latest modification time of root or anything
+// beneath it.
+func newestModTime(root string) (time.Time, error) {
+	var newest time.Time
+	err := filepath.WalkDir(root, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if info.ModTime().After(newest) {
+			newest = info.ModTime()
+		}
+		return nil
+	})
+	return newest, err
+}
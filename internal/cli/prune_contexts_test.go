@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+)
+
+func writeAgedContext(t *testing.T, baseDir, contextID string, modTime time.Time) {
+	t.Helper()
+	sessionDir := filepath.Join(baseDir, contextID, "main")
+	if err := config.CreateSessionDirs(sessionDir); err != nil {
+		t.Fatalf("CreateSessionDirs: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(sessionDir, "postman.pid"), []byte("999999999\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile(pid): %v", err)
+	}
+	err := filepath.WalkDir(filepath.Join(baseDir, contextID), func(path string, _ fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		return os.Chtimes(path, modTime, modTime)
+	})
+	if err != nil {
+		t.Fatalf("Chtimes: %v", err)
+	}
+}
+
+func TestRunPruneContexts_ListsOnlyAbandonedContexts(t *testing.T) {
+	baseDir := t.TempDir()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	old := now.AddDate(0, 0, -40)
+	writeAgedContext(t, baseDir, "ctx-stale", old)
+	writeAgedContext(t, baseDir, "ctx-active", old)
+	writeAgedContext(t, baseDir, "ctx-live", old)
+	writeAgedContext(t, baseDir, "ctx-recent", now.AddDate(0, 0, -2))
+
+	var stdout bytes.Buffer
+	ctx := commandContext{
+		stdout: &stdout,
+		loadConfig: func(string) (*config.Config, error) {
+			return &config.Config{BaseDir: baseDir, RetentionPeriodDays: 30}, nil
+		},
+		getTmuxSessionName:    func() string { return "main" },
+		resolveContextSession: func(string, string) (string, error) { return "ctx-active", nil },
+		contextHasLiveDaemon:  func(_, contextID string) bool { return contextID == "ctx-live" },
+		now:                   func() time.Time { return now },
+	}
+
+	if err := runPruneContextsWithContext(ctx, nil); err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	var out pruneContextsOutput
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		t.Fatalf("json.Unmarshal(%q): %v", stdout.String(), err)
+	}
+	if out.Status != "dry_run" || out.OlderThanDays != 30 {
+		t.Fatalf("output = %#v, want dry_run over 30 days", out)
+	}
+	if len(out.Contexts) != 1 || out.Contexts[0].ContextID != "ctx-stale" {
+		t.Fatalf("contexts = %#v, want only ctx-stale", out.Contexts)
+	}
+	if _, err := os.Stat(filepath.Join(baseDir, "ctx-stale")); err != nil {
+		t.Fatalf("dry run removed ctx-stale: %v", err)
+	}
+
+	stdout.Reset()
+	if err := runPruneContextsWithContext(ctx, []string{"--force"}); err != nil {
+		t.Fatalf("--force: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(baseDir, "ctx-stale")); !os.IsNotExist(err) {
+		t.Fatalf("ctx-stale still present after --force: %v", err)
+	}
+	for _, kept := range []string{"ctx-active", "ctx-live", "ctx-recent"} {
+		if _, err := os.Stat(filepath.Join(baseDir, kept)); err != nil {
+			t.Fatalf("%s removed by --force: %v", kept, err)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/cliutil"
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/idle"
+	"github.com/i9wa4/tmux-a2a-postman/internal/journal"
+	"github.com/i9wa4/tmux-a2a-postman/internal/nodeaddr"
+	"github.com/i9wa4/tmux-a2a-postman/internal/projection"
+)
+
+// RunRebuildState rebuilds the daemon's in-memory activity state from the
+// session journal and prints it, for post-crash debugging.
+func RunRebuildState(args []string) error {
+	return runRebuildStateWithContext(defaultCommandContext(), args)
+}
+
+type rebuildStateOutput struct {
+	ContextID           string             `json:"context_id"`
+	Session             string             `json:"session"`
+	AsOf                string             `json:"as_of,omitempty"`
+	Events              int                `json:"events"`
+	ActiveWindowSeconds float64            `json:"active_window_seconds"`
+	Nodes               []rebuildStateNode `json:"nodes"`
+	ActiveEdges         []rebuildStateEdge `json:"active_edges"`
+	BallHolders         []string           `json:"ball_holders"`
+	PendingAutoPings    []string           `json:"pending_auto_pings"`
+}
+
+type rebuildStateNode struct {
+	Node              string `json:"node"`
+	LastSent          string `json:"last_sent,omitempty"`
+	LastReceived      string `json:"last_received,omitempty"`
+	LivenessConfirmed bool   `json:"liveness_confirmed"`
+	HoldsBall         bool   `json:"holds_ball"`
+}
+
+type rebuildStateEdge struct {
+	From         string `json:"from"`
+	To           string `json:"to"`
+	LastDelivery string `json:"last_delivery"`
+	at           time.Time
+}
+
+func runRebuildStateWithContext(ctx commandContext, args []string) error {
+	ctx = ctx.withDefaults()
+	fs := flag.NewFlagSet("rebuild-state", flag.ContinueOnError)
+	cliutil.SetUsageWithoutContextID(fs)
+	contextID := fs.String("context-id", "", "Context ID (optional, auto-resolved from tmux session)")
+	configPath := fs.String("config", "", "path to config file (optional)")
+	sessionName := fs.String("session", "", "tmux session name (optional, defaults to current tmux session)")
+	activeWindow := fs.Float64("active-window-seconds", 0, "an edge counts as active when it carried a delivery this long before the last event (default: activity_window_seconds)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *activeWindow < 0 {
+		return fmt.Errorf("--active-window-seconds must be >= 0")
+	}
+
+	cfg, err := ctx.loadConfig(*configPath)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	window := *activeWindow
+	if window == 0 {
+		window = cfg.ActivityWindowSeconds
+	}
+
+	baseDir := config.ResolveBaseDir(cfg.BaseDir)
+	session := *sessionName
+	if session == "" {
+		if session = ctx.getTmuxSessionName(); session == "" {
+			return fmt.Errorf("tmux session name required (run inside tmux or pass --session)")
+		}
+	}
+	if session, err = config.ValidateSessionName(session); err != nil {
+		return err
+	}
+	resolvedContextID := ""
+	if *contextID != "" {
+		resolvedContextID, err = ctx.resolveContextID(*contextID)
+	} else {
+		resolvedContextID, err = ctx.resolveContextSession(baseDir, session)
+	}
+	if err != nil {
+		return err
+	}
+
+	out, err := replaySessionJournal(cfg.SessionDir(baseDir, resolvedContextID, session), session, time.Duration(window*float64(time.Second)))
+	if err != nil {
+		return fmt.Errorf("reading journal: %w", err)
+	}
+	out.ContextID = resolvedContextID
+	out.Session = session
+	out.ActiveWindowSeconds = window
+	return json.NewEncoder(ctx.stdout).Encode(out)
+}
+
+// replaySessionJournal feeds the journal's mailbox events through an
+// IdleTracker the way live delivery does: a delivery is activity for both
+// ends, a read is the recipient's ack. The tracker's clock follows the
+// events, so the snapshot is the state as of the last recorded event.
+func replaySessionJournal(sessionDir, sessionName string, activeWindow time.Duration) (rebuildStateOutput, error) {
+	var at time.Time
+	tracker := idle.NewIdleTrackerWithClock(func() time.Time { return at })
+	out := rebuildStateOutput{}
+	edgeLast := make(map[string]rebuildStateEdge)
+	pendingPings := make(map[string]bool)
+
+	err := journal.ReplayEach(sessionDir, func(event journal.Event) error {
+		occurredAt, err := time.Parse(time.RFC3339, event.OccurredAt)
+		if err != nil {
+			return nil
+		}
+		if occurredAt.After(at) {
+			at = occurredAt
+		}
+		out.Events++
+
+		switch event.Type {
+		case projection.MailboxProjectionDeliveredEventType, projection.MailboxProjectionReadEventType:
+			var payload journal.MailboxEventPayload
+			if err := json.Unmarshal(event.Payload, &payload); err != nil || payload.To == "" {
+				return nil
+			}
+			to := nodeaddr.Full(payload.To, sessionName)
+			if event.Type == projection.MailboxProjectionReadEventType {
+				tracker.MarkNodeAlive(to)
+				return nil
+			}
+			// Daemon mail is not node activity, as in DeliverMessage.
+			if payload.From == "" || payload.From == "daemon" {
+				return nil
+			}
+			from := nodeaddr.Full(payload.From, sessionName)
+			tracker.UpdateSendActivity(from)
+			tracker.UpdateReceiveActivity(to)
+			edgeLast[from+"\x00"+to] = rebuildStateEdge{From: from, To: to, at: occurredAt}
+		case projection.NodeAckedEventType:
+			var payload projection.NodeAckedEventPayload
+			if err := json.Unmarshal(event.Payload, &payload); err != nil || payload.NodeKey == "" {
+				return nil
+			}
+			tracker.RecordAck(payload.NodeKey)
+		case projection.AutoPingPendingEventType, projection.AutoPingDeliveredEventType:
+			var payload projection.AutoPingEventPayload
+			if err := json.Unmarshal(event.Payload, &payload); err != nil || payload.NodeKey == "" {
+				return nil
+			}
+			pendingPings[payload.NodeKey] = event.Type == projection.AutoPingPendingEventType
+		}
+		return nil
+	})
+	if err != nil {
+		return rebuildStateOutput{}, err
+	}
+
+	if !at.IsZero() {
+		out.AsOf = at.Format(time.RFC3339)
+	}
+	out.Nodes = []rebuildStateNode{}
+	out.BallHolders = []string{}
+	for node, activity := range tracker.GetNodeStates() {
+		state := rebuildStateNode{
+			Node:              node,
+			LivenessConfirmed: activity.LivenessConfirmed,
+			HoldsBall:         tracker.HasUnackedReceipt(node),
+		}
+		if !activity.LastSent.IsZero() {
+			state.LastSent = activity.LastSent.Format(time.RFC3339)
+		}
+		if !activity.LastReceived.IsZero() {
+			state.LastReceived = activity.LastReceived.Format(time.RFC3339)
+		}
+		out.Nodes = append(out.Nodes, state)
+		if state.HoldsBall {
+			out.BallHolders = append(out.BallHolders, node)
+		}
+	}
+	sort.Slice(out.Nodes, func(i, j int) bool { return out.Nodes[i].Node < out.Nodes[j].Node })
+	sort.Strings(out.BallHolders)
+
+	out.ActiveEdges = []rebuildStateEdge{}
+	for _, edge := range edgeLast {
+		if at.Sub(edge.at) > activeWindow {
+			continue
+		}
+		edge.LastDelivery = edge.at.Format(time.RFC3339)
+		out.ActiveEdges = append(out.ActiveEdges, edge)
+	}
+	sort.Slice(out.ActiveEdges, func(i, j int) bool {
+		if out.ActiveEdges[i].From != out.ActiveEdges[j].From {
+			return out.ActiveEdges[i].From < out.ActiveEdges[j].From
+		}
+		return out.ActiveEdges[i].To < out.ActiveEdges[j].To
+	})
+
+	out.PendingAutoPings = []string{}
+	for node, pending := range pendingPings {
+		if pending {
+			out.PendingAutoPings = append(out.PendingAutoPings, node)
+		}
+	}
+	sort.Strings(out.PendingAutoPings)
+	return out, nil
+}
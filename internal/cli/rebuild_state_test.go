@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/journal"
+	"github.com/i9wa4/tmux-a2a-postman/internal/projection"
+)
+
+func TestRunRebuildState_RebuildsEdgesAndBallHolders(t *testing.T) {
+	baseDir := t.TempDir()
+	sessionDir := filepath.Join(baseDir, "ctx-replay", "main")
+	start := time.Date(2026, time.May, 2, 9, 0, 0, 0, time.UTC)
+
+	writer, err := journal.OpenShadowWriter(sessionDir, "ctx-replay", "main", 101, start)
+	if err != nil {
+		t.Fatalf("OpenShadowWriter: %v", err)
+	}
+	appendEvent := func(eventType, messageID, from, to string, at time.Time) {
+		t.Helper()
+		if _, err := writer.AppendEvent(eventType, journal.VisibilityMailboxProjection, journal.MailboxEventPayload{
+			MessageID: messageID,
+			From:      from,
+			To:        to,
+		}, at); err != nil {
+			t.Fatalf("AppendEvent(%s %s): %v", eventType, messageID, err)
+		}
+	}
+
+	// An old exchange that has gone quiet: outside the 10-minute window.
+	appendEvent(projection.MailboxProjectionDeliveredEventType, "m1.md", "boss", "critic", start)
+	appendEvent(projection.MailboxProjectionReadEventType, "m1.md", "boss", "critic", start.Add(time.Minute))
+	// worker reads boss's delivery (the pong) and replies; neither boss nor
+	// critic reads what worker sends them.
+	appendEvent(projection.MailboxProjectionDeliveredEventType, "m2.md", "boss", "worker", start.Add(25*time.Minute))
+	appendEvent(projection.MailboxProjectionReadEventType, "m2.md", "boss", "worker", start.Add(26*time.Minute))
+	appendEvent(projection.MailboxProjectionDeliveredEventType, "m3.md", "worker", "boss", start.Add(27*time.Minute))
+	appendEvent(projection.MailboxProjectionDeliveredEventType, "m4.md", "worker", "main:critic", start.Add(28*time.Minute))
+	// Daemon mail is not node activity.
+	appendEvent(projection.MailboxProjectionDeliveredEventType, "m5.md", "daemon", "boss", start.Add(30*time.Minute))
+
+	var stdout bytes.Buffer
+	ctx := commandContext{
+		stdout: &stdout,
+		loadConfig: func(string) (*config.Config, error) {
+			return &config.Config{BaseDir: baseDir, ActivityWindowSeconds: 300}, nil
+		},
+		getTmuxSessionName: func() string { return "main" },
+	}
+	if err := runRebuildStateWithContext(ctx, []string{"--context-id", "ctx-replay", "--active-window-seconds", "600"}); err != nil {
+		t.Fatalf("runRebuildStateWithContext: %v", err)
+	}
+
+	var out rebuildStateOutput
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		t.Fatalf("json.Unmarshal(%q): %v", stdout.String(), err)
+	}
+	if out.AsOf != start.Add(30*time.Minute).Format(time.RFC3339) || out.ActiveWindowSeconds != 600 {
+		t.Fatalf("as_of = %q window = %v, want the last event and 600s", out.AsOf, out.ActiveWindowSeconds)
+	}
+	var edges []string
+	for _, edge := range out.ActiveEdges {
+		edges = append(edges, edge.From+">"+edge.To)
+	}
+	if want := []string{"main:boss>main:worker", "main:worker>main:boss", "main:worker>main:critic"}; !slices.Equal(edges, want) {
+		t.Fatalf("active edges = %q, want %q", edges, want)
+	}
+	if want := []string{"main:boss", "main:critic"}; !slices.Equal(out.BallHolders, want) {
+		t.Fatalf("ball holders = %q, want %q", out.BallHolders, want)
+	}
+	alive := map[string]bool{}
+	for _, node := range out.Nodes {
+		alive[node.Node] = node.LivenessConfirmed
+	}
+	if !alive["main:worker"] || !alive["main:critic"] || alive["main:boss"] {
+		t.Fatalf("liveness = %v, want worker and critic confirmed by their reads, boss not", alive)
+	}
+}
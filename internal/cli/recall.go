@@ -0,0 +1,147 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/cliutil"
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/journal"
+	"github.com/i9wa4/tmux-a2a-postman/internal/message"
+	"github.com/i9wa4/tmux-a2a-postman/internal/store"
+)
+
+// RunRecall withdraws a message the caller sent before the recipient reads
+// it: from post/ if not yet delivered, otherwise from the recipient inbox
+// into dead-letter/.
+func RunRecall(args []string) error {
+	return runRecallWithContext(defaultCommandContext(), args)
+}
+
+type recallOutput struct {
+	Status         string `json:"status"`
+	File           string `json:"file"`
+	Stage          string `json:"stage"`
+	DeadLetterPath string `json:"dead_letter_path,omitempty"`
+}
+
+func runRecallWithContext(ctx commandContext, args []string) error {
+	ctx = ctx.withDefaults()
+	fs := flag.NewFlagSet("recall", flag.ContinueOnError)
+	cliutil.SetUsageWithoutContextID(fs)
+	contextID := fs.String("context-id", "", "context ID")
+	configPath := fs.String("config", "", "path to config file (optional)")
+	file := fs.String("file", "", "filename of a message you sent (required)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("--file is required")
+	}
+	if filepath.Base(*file) != *file || !strings.HasSuffix(*file, ".md") {
+		return fmt.Errorf("--file %q: must be a bare message .md filename", *file)
+	}
+	info, err := message.ParseMessageFilename(*file)
+	if err != nil {
+		return fmt.Errorf("--file %q: %w", *file, err)
+	}
+
+	inboxArgs := fs.Args()
+	if *contextID != "" {
+		inboxArgs = append([]string{"--context-id", *contextID}, inboxArgs...)
+	}
+	if *configPath != "" {
+		inboxArgs = append([]string{"--config", *configPath}, inboxArgs...)
+	}
+	selfInboxPath, err := ctx.resolveInboxPath(inboxArgs)
+	if err != nil {
+		return err
+	}
+	cfg, err := ctx.loadConfig(*configPath)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	nodeName := filepath.Base(selfInboxPath)
+	sessionDir := filepath.Dir(filepath.Dir(selfInboxPath))
+	contextDir := filepath.Dir(sessionDir)
+	if cfg.HasSessionBaseDir(filepath.Base(sessionDir)) {
+		// Other sessions are listed under base_dir, not under the override.
+		contextDir = filepath.Join(config.ResolveBaseDir(cfg.BaseDir), filepath.Base(contextDir))
+	}
+	if info.From != nodeName {
+		return fmt.Errorf("--file %q: sent by %q; only the sender can recall it", *file, info.From)
+	}
+
+	out := recallOutput{Status: "recalled", File: *file}
+	// Removing from post/ and renaming out of an inbox are both atomic, so a
+	// concurrent delivery or pop either wins cleanly or leaves the file for us.
+	if err := store.ConsumePost(filepath.Join(sessionDir, "post", *file)); err == nil {
+		out.Stage = "post"
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("recalling from post/: %w", err)
+	} else {
+		dst, err := recallFromInbox(cfg, contextDir, sessionDir, *file)
+		if err != nil {
+			return err
+		}
+		out.Stage = "inbox"
+		out.DeadLetterPath = store.ShadowRelativePath(sessionDir, dst)
+	}
+	// A recalled message was withdrawn, so post_retention = "copy" must not
+	// keep an audit copy claiming it was sent.
+	if err := store.RemovePostArchiveCopy(sessionDir, *file); err != nil {
+		fmt.Fprintf(ctx.stderr, "postman recall: WARNING: removing post/archive copy: %v\n", err)
+	}
+
+	payload := journal.MessageRecalledPayload{
+		MessageID:      *file,
+		From:           info.From,
+		To:             info.To,
+		Stage:          out.Stage,
+		DeadLetterPath: out.DeadLetterPath,
+	}
+	if err := appendCommandEvent(sessionDir, filepath.Base(contextDir), filepath.Base(sessionDir), journal.MessageRecalledEventType, journal.VisibilityOperatorVisible, payload, "", ctx.now()); err != nil {
+		fmt.Fprintf(ctx.stderr, "postman recall: WARNING: recording %s event: %v\n", journal.MessageRecalledEventType, err)
+	}
+
+	return json.NewEncoder(ctx.stdout).Encode(out)
+}
+
+// recallFromInbox moves a delivered but unread message from any session
+// inbox in the context into the sender's dead-letter/. Sessions are listed
+// from contextDir and their inboxes resolved through cfg.SessionDir.
+func recallFromInbox(cfg *config.Config, contextDir, sessionDir, filename string) (string, error) {
+	sessions, err := os.ReadDir(contextDir)
+	if err != nil {
+		return "", fmt.Errorf("reading context dir: %w", err)
+	}
+	dst := store.DeadLetterPath(sessionDir, filename, message.DlSuffixRecalled)
+	for _, session := range sessions {
+		if !session.IsDir() {
+			continue
+		}
+		inboxRoot := filepath.Join(cfg.SessionDir(filepath.Dir(contextDir), filepath.Base(contextDir), session.Name()), "inbox")
+		nodes, err := os.ReadDir(inboxRoot)
+		if err != nil {
+			continue
+		}
+		for _, node := range nodes {
+			if !node.IsDir() {
+				continue
+			}
+			err := store.MoveToDeadLetter(filepath.Join(inboxRoot, node.Name(), filename), dst)
+			if err == nil {
+				return dst, nil
+			}
+			if !errors.Is(err, os.ErrNotExist) {
+				return "", fmt.Errorf("recalling from inbox/%s: %w", node.Name(), err)
+			}
+		}
+	}
+	return "", fmt.Errorf("--file %q: not in post/ or any unread inbox; it was already read or never sent", filename)
+}
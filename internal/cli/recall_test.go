@@ -0,0 +1,162 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/journal"
+	"github.com/i9wa4/tmux-a2a-postman/internal/store"
+)
+
+const recallFilename = "20260415-010203-from-orchestrator-to-worker.md"
+
+func setupRecallSession(t *testing.T) (string, commandContext) {
+	t.Helper()
+	sessionDir := filepath.Join(t.TempDir(), "ctx-recall", "main")
+	if err := config.CreateSessionDirs(sessionDir); err != nil {
+		t.Fatalf("CreateSessionDirs: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(sessionDir, "inbox", "worker"), 0o700); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	ctx := commandContext{
+		resolveInboxPath: func(args []string) (string, error) {
+			return filepath.Join(sessionDir, "inbox", "orchestrator"), nil
+		},
+		loadConfig: func(string) (*config.Config, error) {
+			return config.DefaultConfig(), nil
+		},
+	}
+	return sessionDir, ctx
+}
+
+func writeRecallMessage(t *testing.T, path string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(messageFixture("orchestrator", "worker", "wrong")), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+}
+
+func runRecallForTest(t *testing.T, ctx commandContext, args ...string) recallOutput {
+	t.Helper()
+	var stdout bytes.Buffer
+	ctx.stdout = &stdout
+	if err := runRecallWithContext(ctx, args); err != nil {
+		t.Fatalf("runRecallWithContext: %v", err)
+	}
+	var out recallOutput
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		t.Fatalf("json.Unmarshal(%q): %v", stdout.String(), err)
+	}
+	return out
+}
+
+func recalledEventStages(t *testing.T, sessionDir string) []string {
+	t.Helper()
+	events, err := journal.Replay(sessionDir)
+	if err != nil {
+		t.Fatalf("journal.Replay: %v", err)
+	}
+	var stages []string
+	for _, event := range events {
+		if event.Type != journal.MessageRecalledEventType {
+			continue
+		}
+		var payload journal.MessageRecalledPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			t.Fatalf("json.Unmarshal payload: %v", err)
+		}
+		stages = append(stages, payload.Stage)
+	}
+	return stages
+}
+
+func TestRunRecall_RemovesUndeliveredPost(t *testing.T) {
+	sessionDir, ctx := setupRecallSession(t)
+	postPath := filepath.Join(sessionDir, "post", recallFilename)
+	writeRecallMessage(t, postPath)
+
+	out := runRecallForTest(t, ctx, "--file", recallFilename)
+
+	if out.Status != "recalled" || out.Stage != "post" || out.DeadLetterPath != "" {
+		t.Fatalf("output = %#v, want post-stage recall", out)
+	}
+	if _, err := os.Stat(postPath); !os.IsNotExist(err) {
+		t.Fatalf("post file still present after recall: %v", err)
+	}
+	if got := recalledEventStages(t, sessionDir); len(got) != 1 || got[0] != "post" {
+		t.Fatalf("recalled events = %v, want [post]", got)
+	}
+}
+
+func TestRunRecall_MovesDeliveredUnreadMessageToDeadLetter(t *testing.T) {
+	sessionDir, ctx := setupRecallSession(t)
+	inboxPath := filepath.Join(sessionDir, "inbox", "worker", recallFilename)
+	writeRecallMessage(t, inboxPath)
+
+	out := runRecallForTest(t, ctx, "--file", recallFilename)
+
+	wantDeadLetter := filepath.Join("dead-letter", "20260415-010203-from-orchestrator-to-worker-dl-recalled.md")
+	if out.Stage != "inbox" || out.DeadLetterPath != wantDeadLetter {
+		t.Fatalf("output = %#v, want inbox-stage recall to %s", out, wantDeadLetter)
+	}
+	if _, err := os.Stat(inboxPath); !os.IsNotExist(err) {
+		t.Fatalf("inbox file still present after recall: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(sessionDir, wantDeadLetter)); err != nil {
+		t.Fatalf("recalled message missing from dead-letter/: %v", err)
+	}
+	if got := recalledEventStages(t, sessionDir); len(got) != 1 || got[0] != "inbox" {
+		t.Fatalf("recalled events = %v, want [inbox]", got)
+	}
+}
+
+func TestRunRecall_RemovesPostArchiveCopy(t *testing.T) {
+	sessionDir, ctx := setupRecallSession(t)
+	writeRecallMessage(t, filepath.Join(sessionDir, "inbox", "worker", recallFilename))
+	archivePath := filepath.Join(store.PostArchiveDir(sessionDir), recallFilename)
+	if err := os.MkdirAll(filepath.Dir(archivePath), 0o700); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	writeRecallMessage(t, archivePath)
+
+	if out := runRecallForTest(t, ctx, "--file", recallFilename); out.Stage != "inbox" {
+		t.Fatalf("output = %#v, want inbox-stage recall", out)
+	}
+	if _, err := os.Stat(archivePath); !os.IsNotExist(err) {
+		t.Fatalf("post/archive copy still present after recall: %v", err)
+	}
+}
+
+func TestRunRecall_RejectsUnrecallableMessages(t *testing.T) {
+	sessionDir, ctx := setupRecallSession(t)
+	readPath := filepath.Join(sessionDir, "read", recallFilename)
+	writeRecallMessage(t, readPath)
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "missing file", args: nil, wantErr: "--file is required"},
+		{name: "path not bare", args: []string{"--file", "../post/" + recallFilename}, wantErr: "bare message .md filename"},
+		{name: "other sender", args: []string{"--file", "20260415-010203-from-worker-to-orchestrator.md"}, wantErr: "only the sender can recall"},
+		{name: "already read", args: []string{"--file", recallFilename}, wantErr: "already read or never sent"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runRecallWithContext(ctx, tt.args)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("runRecallWithContext(%v) error = %v, want %q", tt.args, err, tt.wantErr)
+			}
+		})
+	}
+	if _, err := os.Stat(readPath); err != nil {
+		t.Fatalf("read/ message disturbed by failed recall: %v", err)
+	}
+}
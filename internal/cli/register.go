@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/cliutil"
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+)
+
+// registerMarkerName is written into the node inbox so operators can tell a
+// self-registered node from one that appeared by sending mail.
+const registerMarkerName = ".registered"
+
+// RunRegister creates the caller's session and inbox dirs so discovery sees
+// the pane before it has sent anything.
+func RunRegister(args []string) error {
+	return runRegisterWithContext(defaultCommandContext(), args)
+}
+
+type registerOutput struct {
+	Status     string `json:"status"`
+	Node       string `json:"node"`
+	Session    string `json:"session"`
+	ContextID  string `json:"context_id"`
+	SessionDir string `json:"session_dir"`
+}
+
+type registerMarker struct {
+	PaneID       string `json:"pane_id,omitempty"`
+	RegisteredAt string `json:"registered_at"`
+}
+
+func runRegisterWithContext(ctx commandContext, args []string) error {
+	ctx = ctx.withDefaults()
+	fs := flag.NewFlagSet("register", flag.ContinueOnError)
+	cliutil.SetUsageWithoutContextID(fs)
+	contextID := fs.String("context-id", "", "context ID")
+	configPath := fs.String("config", "", "path to config file (optional)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := ctx.loadConfig(*configPath)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	baseDir := config.ResolveBaseDir(cfg.BaseDir)
+
+	nodeName := ctx.getTmuxPaneName(cfg.PaneNameFormat())
+	if nodeName == "" {
+		return fmt.Errorf("node name auto-detection failed: set tmux pane title")
+	}
+	if err := cliutil.ValidateOutboundNodeName("auto-detected pane title", nodeName); err != nil {
+		return err
+	}
+	sessionName := ctx.getTmuxSessionName()
+	if sessionName == "" {
+		return fmt.Errorf("tmux session name required (run inside tmux)")
+	}
+	sessionName, err = config.ValidateSessionName(sessionName)
+	if err != nil {
+		return fmt.Errorf("invalid session name: %w", err)
+	}
+
+	var resolvedContextID string
+	if *contextID != "" {
+		resolvedContextID, err = ctx.resolveContextID(*contextID)
+		if err != nil {
+			return err
+		}
+	} else if resolvedContextID, err = ctx.resolveContextSession(baseDir, sessionName); err != nil {
+		// A fresh session has no dir yet, so no context owns it; fall back
+		// to the current user's daemon, wherever it runs.
+		daemonContextID, _, ok := config.FindCurrentUserDaemon(baseDir)
+		if !ok {
+			return err
+		}
+		resolvedContextID = daemonContextID
+	}
+
+	sessionDir := cfg.SessionDir(baseDir, resolvedContextID, sessionName)
+	if err := cfg.CreateMultiSessionDirs(filepath.Join(baseDir, resolvedContextID), sessionName); err != nil {
+		return fmt.Errorf("creating session dirs: %w", err)
+	}
+	nodeInbox := filepath.Join(sessionDir, "inbox", nodeName)
+	if err := os.MkdirAll(nodeInbox, 0o700); err != nil {
+		return fmt.Errorf("creating inbox: %w", err)
+	}
+	marker, err := json.Marshal(registerMarker{
+		PaneID:       ctx.getTmuxPaneID(),
+		RegisteredAt: ctx.now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(nodeInbox, registerMarkerName), append(marker, '\n'), 0o600); err != nil {
+		return fmt.Errorf("writing register marker: %w", err)
+	}
+
+	return json.NewEncoder(ctx.stdout).Encode(registerOutput{
+		Status:     "registered",
+		Node:       nodeName,
+		Session:    sessionName,
+		ContextID:  resolvedContextID,
+		SessionDir: sessionDir,
+	})
+}
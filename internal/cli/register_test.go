@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+)
+
+func TestRunRegister_CreatesDiscoverableSessionLayout(t *testing.T) {
+	baseDir := t.TempDir()
+	var stdout bytes.Buffer
+	ctx := commandContext{
+		stdout: &stdout,
+		loadConfig: func(string) (*config.Config, error) {
+			return &config.Config{BaseDir: baseDir}, nil
+		},
+		getTmuxPaneName:    func(string) string { return "worker" },
+		getTmuxSessionName: func() string { return "review" },
+		getTmuxPaneID:      func() string { return "%7" },
+		now:                func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) },
+	}
+
+	if err := runRegisterWithContext(ctx, []string{"--context-id", "ctx-register"}); err != nil {
+		t.Fatalf("runRegisterWithContext: %v", err)
+	}
+
+	sessionDir := filepath.Join(baseDir, "ctx-register", "review")
+	var out registerOutput
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		t.Fatalf("json.Unmarshal(%q): %v", stdout.String(), err)
+	}
+	want := registerOutput{Status: "registered", Node: "worker", Session: "review", ContextID: "ctx-register", SessionDir: sessionDir}
+	if out != want {
+		t.Fatalf("output = %#v, want %#v", out, want)
+	}
+
+	// DiscoverNodes keeps a pane only when baseDir/contextID/session/inbox exists.
+	for _, dir := range []string{"inbox", "post", "draft", "read", "dead-letter", filepath.Join("inbox", "worker")} {
+		if info, err := os.Stat(filepath.Join(sessionDir, dir)); err != nil || !info.IsDir() {
+			t.Fatalf("%s missing after register: %v", dir, err)
+		}
+	}
+	raw, err := os.ReadFile(filepath.Join(sessionDir, "inbox", "worker", registerMarkerName))
+	if err != nil {
+		t.Fatalf("ReadFile(marker): %v", err)
+	}
+	var marker registerMarker
+	if err := json.Unmarshal(raw, &marker); err != nil {
+		t.Fatalf("json.Unmarshal(marker): %v", err)
+	}
+	if marker.PaneID != "%7" || marker.RegisteredAt != "2026-01-02T03:04:05Z" {
+		t.Fatalf("marker = %#v", marker)
+	}
+
+	// Re-running is harmless.
+	stdout.Reset()
+	if err := runRegisterWithContext(ctx, []string{"--context-id", "ctx-register"}); err != nil {
+		t.Fatalf("second runRegisterWithContext: %v", err)
+	}
+}
+
+func TestRunRegister_RequiresPaneTitle(t *testing.T) {
+	ctx := commandContext{
+		stdout: &bytes.Buffer{},
+		loadConfig: func(string) (*config.Config, error) {
+			return &config.Config{BaseDir: t.TempDir()}, nil
+		},
+		getTmuxPaneName:    func(string) string { return "" },
+		getTmuxSessionName: func() string { return "review" },
+	}
+	if err := runRegisterWithContext(ctx, []string{"--context-id", "ctx-register"}); err == nil {
+		t.Fatal("runRegisterWithContext() error = nil, want pane title error")
+	}
+}
@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"syscall"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/cliutil"
+)
+
+type reindexOutput struct {
+	Status        string `json:"status"`
+	Session       string `json:"session,omitempty"`
+	DaemonSession string `json:"daemon_session,omitempty"`
+	ContextID     string `json:"context_id,omitempty"`
+	PID           int    `json:"pid,omitempty"`
+}
+
+// RunReindex asks the running daemon to rebuild node discovery and its
+// filesystem watch set. The daemon performs the rebuild asynchronously and
+// reports it as a reindexed event.
+func RunReindex(stdout io.Writer, args []string) error {
+	fs := flag.NewFlagSet("reindex", flag.ContinueOnError)
+	cliutil.SetUsageWithoutContextID(fs)
+	configPath := fs.String("config", "", "path to config file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	target, status, err := resolveOwnedDaemon(*configPath)
+	if err != nil {
+		return err
+	}
+	output := reindexOutput{
+		Status:    status,
+		Session:   target.Session,
+		ContextID: target.ContextID,
+	}
+	if target.DaemonSession != "" && target.DaemonSession != target.Session {
+		output.DaemonSession = target.DaemonSession
+	}
+	if status != "" {
+		return json.NewEncoder(stdout).Encode(output)
+	}
+
+	proc, err := os.FindProcess(target.PID)
+	if err != nil {
+		return fmt.Errorf("finding process %d: %w", target.PID, err)
+	}
+	if err := proc.Signal(syscall.SIGUSR1); err != nil {
+		return fmt.Errorf("sending SIGUSR1 to pid %d: %w", target.PID, err)
+	}
+	output.Status = "reindex_requested"
+	output.PID = target.PID
+	return json.NewEncoder(stdout).Encode(output)
+}
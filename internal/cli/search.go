@@ -0,0 +1,191 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/cliutil"
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/envelope"
+	"github.com/i9wa4/tmux-a2a-postman/internal/message"
+	"github.com/i9wa4/tmux-a2a-postman/internal/nodeaddr"
+	"github.com/i9wa4/tmux-a2a-postman/internal/store"
+)
+
+// RunSearch finds messages in a session's inbox/, read/, and dead-letter/
+// whose sender body or filename contains a query.
+func RunSearch(args []string) error {
+	return runSearchWithContext(defaultCommandContext(), args)
+}
+
+type searchOutput struct {
+	Query   string        `json:"query"`
+	Session string        `json:"session"`
+	Matches []searchMatch `json:"matches"`
+}
+
+type searchMatch struct {
+	MessageID    string `json:"message_id"`
+	From         string `json:"from"`
+	To           string `json:"to"`
+	Timestamp    string `json:"timestamp,omitempty"`
+	StorageState string `json:"storage_state"`
+	Path         string `json:"path"`
+}
+
+// searchDirs are the session subdirectories search scans, with the storage
+// state reported for each.
+var searchDirs = []struct {
+	dir   string
+	state string
+}{
+	{"inbox", "unread"},
+	{"read", "read"},
+	{"dead-letter", "dead_letter"},
+}
+
+func runSearchWithContext(ctx commandContext, args []string) error {
+	ctx = ctx.withDefaults()
+	fs := flag.NewFlagSet("search", flag.ContinueOnError)
+	cliutil.SetUsageWithoutContextID(fs)
+	contextID := fs.String("context-id", "", "Context ID (optional, auto-resolved from tmux session)")
+	configPath := fs.String("config", "", "path to config file (optional)")
+	sessionName := fs.String("session", "", "tmux session name (optional, defaults to current tmux session)")
+	query := fs.String("query", "", "case-insensitive text to find in the message body or filename (required)")
+	from := fs.String("from", "", "only messages from this node")
+	to := fs.String("to", "", "only messages to this node")
+	jsonOutput := fs.Bool("json", false, "print JSON instead of a table")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *query == "" {
+		return fmt.Errorf("--query is required")
+	}
+
+	cfg, err := ctx.loadConfig(*configPath)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	baseDir := config.ResolveBaseDir(cfg.BaseDir)
+	session := *sessionName
+	if session == "" {
+		if session = ctx.getTmuxSessionName(); session == "" {
+			return fmt.Errorf("tmux session name required (run inside tmux or pass --session)")
+		}
+	}
+	if session, err = config.ValidateSessionName(session); err != nil {
+		return err
+	}
+	resolvedContextID := ""
+	if *contextID != "" {
+		resolvedContextID, err = ctx.resolveContextID(*contextID)
+	} else {
+		resolvedContextID, err = ctx.resolveContextSession(baseDir, session)
+	}
+	if err != nil {
+		return err
+	}
+
+	matches, err := searchMessages(cfg.SessionDir(baseDir, resolvedContextID, session), *query, *from, *to)
+	if err != nil {
+		return err
+	}
+
+	if *jsonOutput {
+		return json.NewEncoder(ctx.stdout).Encode(searchOutput{Query: *query, Session: session, Matches: matches})
+	}
+	if len(matches) == 0 {
+		_, _ = fmt.Fprintf(ctx.stdout, "no messages match %q\n", *query)
+		return nil
+	}
+	w := tabwriter.NewWriter(ctx.stdout, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "TIMESTAMP\tFROM\tTO\tSTATE\tPATH")
+	for _, match := range matches {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", match.Timestamp, match.From, match.To, match.StorageState, match.Path)
+	}
+	return w.Flush()
+}
+
+// searchMessages walks the session's mailbox dirs and returns matching
+// messages sorted by filename, which starts with the send timestamp.
+func searchMessages(sessionDir, query, from, to string) ([]searchMatch, error) {
+	needle := strings.ToLower(query)
+	matches := []searchMatch{}
+	for _, d := range searchDirs {
+		root := filepath.Join(sessionDir, d.dir)
+		err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if entry.IsDir() {
+				if path == store.ReadArchiveDir(sessionDir) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if filepath.Ext(entry.Name()) != ".md" {
+				return nil
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			body, _ := envelope.SenderBodyFromContent(string(content))
+			if !strings.Contains(strings.ToLower(entry.Name()), needle) && !strings.Contains(strings.ToLower(body), needle) {
+				return nil
+			}
+			match := searchMatchFor(path, entry.Name(), d.state, string(content))
+			if from != "" && nodeaddr.Simple(match.From) != nodeaddr.Simple(from) {
+				return nil
+			}
+			if to != "" && nodeaddr.Simple(match.To) != nodeaddr.Simple(to) {
+				return nil
+			}
+			matches = append(matches, match)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("searching %s: %w", d.dir, err)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return filepath.Base(matches[i].Path) < filepath.Base(matches[j].Path)
+	})
+	return matches, nil
+}
+
+// searchMatchFor fills sender, recipient, and timestamp from the envelope,
+// falling back to the filename for mail without one.
+func searchMatchFor(path, filename, state, content string) searchMatch {
+	payload := parseMessageContent(content, filename)
+	match := searchMatch{
+		MessageID:    payload.MessageID,
+		From:         payload.From,
+		To:           payload.To,
+		Timestamp:    payload.Timestamp,
+		StorageState: state,
+		Path:         path,
+	}
+	if info, err := message.ParseMessageFilename(filename); err == nil {
+		if match.From == "" {
+			match.From = info.From
+		}
+		if match.To == "" {
+			match.To = info.To
+		}
+		if match.Timestamp == "" {
+			match.Timestamp = info.Timestamp
+		}
+	}
+	return match
+}
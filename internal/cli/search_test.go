@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+)
+
+func TestRunSearch_FindsMatchesAndAppliesFilters(t *testing.T) {
+	baseDir := t.TempDir()
+	sessionDir := filepath.Join(baseDir, "ctx-search", "main")
+	write := func(rel, body string) {
+		t.Helper()
+		path := filepath.Join(sessionDir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			t.Fatalf("MkdirAll: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+			t.Fatalf("WriteFile(%s): %v", rel, err)
+		}
+	}
+	write("read/20260502-090000-from-boss-to-worker.md", "Please DEPLOY the staging build.")
+	write("inbox/critic/20260502-091000-from-boss-to-critic.md", "Review the deploy plan.")
+	write("dead-letter/20260502-092000-from-worker-to-ghost.md", "deploy finished")
+	write("read/20260502-093000-from-boss-to-worker.md", "Lunch at noon.")
+
+	search := func(args ...string) searchOutput {
+		t.Helper()
+		var stdout bytes.Buffer
+		ctx := commandContext{
+			stdout: &stdout,
+			loadConfig: func(string) (*config.Config, error) {
+				return &config.Config{BaseDir: baseDir}, nil
+			},
+			resolveContextID: func(id string) (string, error) { return id, nil },
+		}
+		args = append([]string{"--context-id", "ctx-search", "--session", "main", "--json"}, args...)
+		if err := runSearchWithContext(ctx, args); err != nil {
+			t.Fatalf("runSearchWithContext(%v): %v", args, err)
+		}
+		var out searchOutput
+		if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+			t.Fatalf("json.Unmarshal(%q): %v", stdout.String(), err)
+		}
+		return out
+	}
+	files := func(out searchOutput) []string {
+		var names []string
+		for _, match := range out.Matches {
+			names = append(names, match.StorageState+":"+filepath.Base(match.Path))
+		}
+		return names
+	}
+
+	all := files(search("--query", "deploy"))
+	want := []string{
+		"read:20260502-090000-from-boss-to-worker.md",
+		"unread:20260502-091000-from-boss-to-critic.md",
+		"dead_letter:20260502-092000-from-worker-to-ghost.md",
+	}
+	if len(all) != len(want) {
+		t.Fatalf("matches = %q, want %q", all, want)
+	}
+	for i := range want {
+		if all[i] != want[i] {
+			t.Fatalf("matches = %q, want %q", all, want)
+		}
+	}
+
+	fromBoss := search("--query", "deploy", "--from", "boss", "--to", "worker")
+	if len(fromBoss.Matches) != 1 || fromBoss.Matches[0].From != "boss" || fromBoss.Matches[0].To != "worker" {
+		t.Fatalf("--from boss --to worker matches = %+v, want only the boss->worker deploy message", fromBoss.Matches)
+	}
+
+	if none := search("--query", "deploy", "--from", "critic"); len(none.Matches) != 0 {
+		t.Fatalf("--from critic matches = %+v, want none", none.Matches)
+	}
+}
@@ -91,7 +91,7 @@ type sendInputRequestDetail struct {
 	ReadEventID    string `json:"read_event_id,omitempty"`
 }
 
-type sendToPaneFunc func(paneID, message string, enterDelay, tmuxTimeout time.Duration, enterCount int, bypassCooldown bool, verifyDelay time.Duration, maxRetries int) error
+type sendToPaneFunc func(paneID, message string, preEnterDelay, enterDelay, tmuxTimeout time.Duration, enterCount int, bypassCooldown bool, verifyDelay time.Duration, maxRetries int) error
 
 var (
 	sendBodyStdin           io.Reader = os.Stdin
@@ -102,11 +102,11 @@ var (
 
 // performCLINotification sends a synchronous pane notification from the CLI.
 // Returns cliNotifySkipped when paneID is empty, cliNotifyOK on success, cliNotifyFailed on error.
-func performCLINotification(paneID, notificationMsg string, enterDelay, tmuxTimeout time.Duration, enterCount int, bypassCooldown bool, verifyDelay time.Duration, maxRetries int, fn sendToPaneFunc) cliNotifyStatus {
+func performCLINotification(paneID, notificationMsg string, preEnterDelay, enterDelay, tmuxTimeout time.Duration, enterCount int, bypassCooldown bool, verifyDelay time.Duration, maxRetries int, fn sendToPaneFunc) cliNotifyStatus {
 	if paneID == "" {
 		return cliNotifySkipped
 	}
-	if err := fn(paneID, notificationMsg, enterDelay, tmuxTimeout, enterCount, bypassCooldown, verifyDelay, maxRetries); err != nil {
+	if err := fn(paneID, notificationMsg, preEnterDelay, enterDelay, tmuxTimeout, enterCount, bypassCooldown, verifyDelay, maxRetries); err != nil {
 		return cliNotifyFailed
 	}
 	return cliNotifyOK
@@ -199,7 +199,7 @@ func runSendHeredocWithContext(ctx commandContext, args []string) error {
 	}
 	baseDir := config.ResolveBaseDir(cfg.BaseDir)
 
-	sender := ctx.getTmuxPaneName()
+	sender := ctx.getTmuxPaneName(cfg.PaneNameFormat())
 	if sender == "" {
 		return fmt.Errorf("sender auto-detection failed: set tmux pane title")
 	}
@@ -223,6 +223,7 @@ func runSendHeredocWithContext(ctx commandContext, args []string) error {
 		}
 		recipient = resolution.Address
 	}
+	recipient = cfg.CanonicalNodeName(recipient)
 	if err := cliutil.ValidateNodeAddress("--to", recipient); err != nil {
 		return err
 	}
@@ -307,9 +308,9 @@ func runSendHeredocWithContext(ctx commandContext, args []string) error {
 		return fmt.Errorf("edge violation: %q cannot send to %q — not allowed; allowed recipients: %s",
 			sender, recipient, canTalkTo)
 	}
-	sessionDir := filepath.Join(baseDir, resolvedContextID, sessionName)
+	sessionDir := cfg.SessionDir(baseDir, resolvedContextID, sessionName)
 	beforeInputRequests, beforeInputRequestsOK := projectSendInputRequestState(sessionDir, sessionName)
-	draftDir := filepath.Join(sessionDir, "draft")
+	draftDir := cfg.ResolveDraftDir(sessionDir)
 	if err := os.MkdirAll(draftDir, 0o700); err != nil {
 		return fmt.Errorf("creating draft directory: %w", err)
 	}
@@ -351,7 +352,7 @@ func runSendHeredocWithContext(ctx commandContext, args []string) error {
 		"timestamp":                      now.Format(time.RFC3339),
 		"can_talk_to":                    canTalkTo,
 		"contacts_section":               contactSectionForViewpoint(cfg, workspaceTopology, talksToList, senderFullName),
-		"session_dir":                    filepath.Join(baseDir, resolvedContextID, sessionName),
+		"session_dir":                    sessionDir,
 		"reply_command":                  strings.ReplaceAll(envelope.RenderReplyCommand(cfg.ReplyCommand, resolvedContextID, recipient), "<recipient>", recipient),
 		"message_id":                     filename,
 		"reply_policy":                   generatedReplyPolicyMarker,
@@ -372,7 +373,7 @@ func runSendHeredocWithContext(ctx commandContext, args []string) error {
 	}
 
 	timeout := time.Duration(cfg.TmuxTimeout * float64(time.Second))
-	content = template.ExpandTemplate(content, vars, timeout, cfg.AllowShellForDraftTemplate())
+	content = template.ExpandTemplate(content, vars, timeout, cfg.AllowShellForDraftTemplate(), cfg.TemplateOptions())
 
 	stripped, err := notification.StripVT(bodyText)
 	if err != nil {
@@ -434,7 +435,7 @@ func runSendHeredocWithContext(ctx commandContext, args []string) error {
 		footerVars["input_request_id"] = inputRequestID
 		footerVars["fills_input_request_id"] = *fillsInputRequestID
 		footerVars["reply_arguments"] = replyArgumentsForMessage(filename, inputRequestID)
-		footer = template.ExpandTemplate(cfg.MessageFooter, footerVars, timeout, cfg.AllowShellForMessageFooter())
+		footer = template.ExpandTemplate(cfg.MessageFooter, footerVars, timeout, cfg.AllowShellForMessageFooter(), cfg.TemplateOptions())
 	}
 	content = renderSendBody(content, stripped, footer)
 
@@ -486,7 +487,7 @@ func runSendHeredocWithContext(ctx commandContext, args []string) error {
 		return fmt.Errorf("writing draft: %w", err)
 	}
 
-	postDir := filepath.Clean(filepath.Join(draftDir, "..", "post"))
+	postDir := filepath.Join(sessionDir, "post")
 	if err := os.MkdirAll(postDir, 0o700); err != nil {
 		return fmt.Errorf("creating post/ directory: %w", err)
 	}
@@ -500,9 +501,10 @@ func runSendHeredocWithContext(ctx commandContext, args []string) error {
 	}
 	var notifyStatus cliNotifyStatus
 	if status == sendStatusProcessed {
-		freshNodes, _ := ctx.discoverNodes(baseDir, resolvedContextID, sessionName)
+		freshNodes, _ := ctx.discoverNodes(baseDir, resolvedContextID, sessionName, cfg)
 		var paneID string
-		if freshNodes != nil {
+		// FIFO-transport nodes are notified by the daemon through their pipe.
+		if freshNodes != nil && cfg.GetNodeConfig(nodeaddr.Simple(recipient)).Transport != config.NodeTransportFifo {
 			fullKey := discovery.ResolveNodeName(recipient, sessionName, freshNodes)
 			if nodeInfo, ok := freshNodes[fullKey]; ok {
 				paneID = nodeInfo.PaneID
@@ -514,13 +516,14 @@ func runSendHeredocWithContext(ctx commandContext, args []string) error {
 		if nd := cfg.GetNodeConfig(recipientSimpleName).EnterDelay; nd != 0 {
 			enterDelay = time.Duration(nd * float64(time.Second))
 		}
+		preEnterDelay := time.Duration(cfg.GetNodeConfig(recipientSimpleName).PreEnterDelay * float64(time.Second))
 		tmuxTimeout := time.Duration(cfg.TmuxTimeout * float64(time.Second))
 		enterCount := cfg.GetNodeConfig(recipientSimpleName).EnterCount
 		if enterCount == 0 {
 			enterCount = 1
 		}
 		verifyDelay := time.Duration(cfg.EnterVerifyDelay * float64(time.Second))
-		notifyStatus = performCLINotification(paneID, notificationMsg, enterDelay, tmuxTimeout, enterCount, true, verifyDelay, cfg.EnterRetryMax, notification.SendToPane)
+		notifyStatus = performCLINotification(paneID, notificationMsg, preEnterDelay, enterDelay, tmuxTimeout, enterCount, true, verifyDelay, cfg.EnterRetryMax, notification.SendToPane)
 	}
 	output := sendOutput{
 		Sent:                filename,
@@ -937,14 +940,13 @@ func getNodeTemplate(cfg *config.Config, nodeName string) string {
 	if cfg == nil || cfg.Nodes == nil {
 		return ""
 	}
-	nodeConfig, exists := cfg.Nodes[nodeName]
-	if !exists {
-		nodeConfig, exists = cfg.Nodes[strings.SplitN(nodeName, ":", 2)[len(strings.SplitN(nodeName, ":", 2))-1]]
+	if _, exists := cfg.Nodes[nodeName]; !exists {
+		nodeName = strings.SplitN(nodeName, ":", 2)[len(strings.SplitN(nodeName, ":", 2))-1]
 	}
-	if !exists {
+	if _, exists := cfg.Nodes[nodeName]; !exists {
 		return ""
 	}
-	tmpl := nodeConfig.Template
+	tmpl := cfg.GetNodeConfig(nodeName).Template
 	if cfg.CommonTemplate != "" && tmpl != "" {
 		return cfg.CommonTemplate + "\n\n" + tmpl
 	}
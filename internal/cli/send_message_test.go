@@ -121,7 +121,7 @@ func testSendCommandContext(baseDir string, stdin io.Reader, stdout io.Writer) c
 		contextHasLiveDaemon: func(string, string) bool {
 			return false
 		},
-		getTmuxPaneName: func() string {
+		getTmuxPaneName: func(string) string {
 			return "messenger"
 		},
 		getTmuxSessionName: func() string {
@@ -1274,6 +1274,47 @@ role = "worker"
 	}
 }
 
+func TestRunSendMessage_CustomDraftDirIsCreatedAndUsed(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "postman.toml")
+	configContent := `[postman]
+draft_dir = "scratch/drafts"
+message_footer = ""
+edges = ["orchestrator --- worker"]
+
+[orchestrator]
+role = "orchestrator"
+
+[worker]
+role = "worker"
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0o600); err != nil {
+		t.Fatalf("WriteFile config: %v", err)
+	}
+	installFakeTmuxForCLI(t, tmpDir, "test-session", "orchestrator")
+
+	if err := runSendHeredocWithBody(t, "hello", []string{
+		"--config", configPath,
+		"--context-id", "ctx-draft-dir",
+		"--to", "worker",
+	}); err != nil {
+		t.Fatalf("RunSendMessage: %v", err)
+	}
+
+	sessionDir := filepath.Join(tmpDir, "ctx-draft-dir", "test-session")
+	info, err := os.Stat(filepath.Join(sessionDir, "scratch", "drafts"))
+	if err != nil || !info.IsDir() {
+		t.Fatalf("custom draft_dir not created: info=%v err=%v", info, err)
+	}
+	entries, err := os.ReadDir(filepath.Join(sessionDir, "post"))
+	if err != nil {
+		t.Fatalf("ReadDir post: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("post entry count = %d, want 1 (draft staged in custom dir must still land in session post/)", len(entries))
+	}
+}
+
 func TestRunSendMessage_DraftTemplatePreservesMultilineReplyCommand(t *testing.T) {
 	tmpDir := t.TempDir()
 	configPath := filepath.Join(tmpDir, "postman.toml")
@@ -3573,11 +3614,11 @@ role = "worker"
 
 func TestPerformCLINotification_SkippedWhenPaneEmpty(t *testing.T) {
 	var called bool
-	fn := func(_ string, _ string, _ time.Duration, _ time.Duration, _ int, _ bool, _ time.Duration, _ int) error {
+	fn := func(_ string, _ string, _ time.Duration, _ time.Duration, _ time.Duration, _ int, _ bool, _ time.Duration, _ int) error {
 		called = true
 		return nil
 	}
-	status := performCLINotification("", "msg", 0, 0, 1, true, 0, 0, fn)
+	status := performCLINotification("", "msg", 0, 0, 0, 1, true, 0, 0, fn)
 	if status != cliNotifySkipped {
 		t.Errorf("status = %q, want %q", status, cliNotifySkipped)
 	}
@@ -3588,11 +3629,11 @@ func TestPerformCLINotification_SkippedWhenPaneEmpty(t *testing.T) {
 
 func TestPerformCLINotification_OKOnSuccess(t *testing.T) {
 	var gotPaneID string
-	fn := func(paneID string, _ string, _ time.Duration, _ time.Duration, _ int, _ bool, _ time.Duration, _ int) error {
+	fn := func(paneID string, _ string, _ time.Duration, _ time.Duration, _ time.Duration, _ int, _ bool, _ time.Duration, _ int) error {
 		gotPaneID = paneID
 		return nil
 	}
-	status := performCLINotification("%1", "msg", 0, 0, 1, true, 0, 0, fn)
+	status := performCLINotification("%1", "msg", 0, 0, 0, 1, true, 0, 0, fn)
 	if status != cliNotifyOK {
 		t.Errorf("status = %q, want %q", status, cliNotifyOK)
 	}
@@ -3602,10 +3643,10 @@ func TestPerformCLINotification_OKOnSuccess(t *testing.T) {
 }
 
 func TestPerformCLINotification_FailedOnError(t *testing.T) {
-	fn := func(_ string, _ string, _ time.Duration, _ time.Duration, _ int, _ bool, _ time.Duration, _ int) error {
+	fn := func(_ string, _ string, _ time.Duration, _ time.Duration, _ time.Duration, _ int, _ bool, _ time.Duration, _ int) error {
 		return fmt.Errorf("tmux error")
 	}
-	status := performCLINotification("%gone", "msg", 0, 0, 1, true, 0, 0, fn)
+	status := performCLINotification("%gone", "msg", 0, 0, 0, 1, true, 0, 0, fn)
 	if status != cliNotifyFailed {
 		t.Errorf("status = %q, want %q", status, cliNotifyFailed)
 	}
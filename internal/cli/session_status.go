@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
-	"path/filepath"
 	"reflect"
 	"strings"
 	"time"
@@ -166,7 +165,7 @@ func collectResolvedSessionStatusWithContext(ctx commandContext, contextIDFlag,
 		result.RuntimeDiagnostics = diagnostics
 	}
 	if options.IncludeTasks {
-		sessionDir := filepath.Join(target.baseDir, target.contextID, target.sessionName)
+		sessionDir := target.cfg.SessionDir(target.baseDir, target.contextID, target.sessionName)
 		result.Tasks = statusTaskRunProjection(sessionDir, target.sessionName)
 	}
 	return result, true, nil
@@ -201,7 +200,7 @@ func collectRuntimeDiagnosticsFromDaemonWithContext(ctx commandContext, target s
 	if target.baseDir == "" || target.contextID == "" || target.sessionName == "" {
 		return nil, fmt.Errorf("runtime diagnostics require an active daemon context")
 	}
-	sessionDir := filepath.Join(target.baseDir, target.contextID, target.sessionName)
+	sessionDir := target.cfg.SessionDir(target.baseDir, target.contextID, target.sessionName)
 	response, err := ctx.roundTripDaemonSubmit(sessionDir, projection.DaemonSubmitRequest{
 		Command: projection.DaemonSubmitRuntimeDiagnostics,
 	}, daemonSubmitTimeout(target.cfg.TmuxTimeout))
@@ -251,7 +250,7 @@ func refreshProjectedSessionStatus(baseDir, contextID, sessionName string, cfg *
 		return unavailableSessionStatus(contextID, sessionName), nil
 	}
 
-	sessionDir := filepath.Join(baseDir, contextID, sessionName)
+	sessionDir := cfg.SessionDir(baseDir, contextID, sessionName)
 	projected, projectedOK := projectedSessionStatus(sessionDir)
 
 	live, err := collectLiveSessionStatus(baseDir, contextID, sessionName, cfg)
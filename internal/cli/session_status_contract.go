@@ -77,7 +77,7 @@ func orderedEdgeNodeNames(edges []string) []string {
 }
 
 func collectSessionStatus(baseDir, contextID, sessionName string, cfg *config.Config) (status.SessionStatus, error) {
-	sessionDir := filepath.Join(baseDir, contextID, sessionName)
+	sessionDir := cfg.SessionDir(baseDir, contextID, sessionName)
 	if !ownsCanonicalSessionStatus(baseDir, contextID, sessionName) {
 		return unavailableSessionStatus(contextID, sessionName), nil
 	}
@@ -310,7 +310,7 @@ func collectSessionStatusWithInboxCounts(baseDir, contextID, sessionName string,
 		return result, nil
 	}
 
-	nodes, _, err := discovery.DiscoverNodesWithCollisions(baseDir, contextID, sessionName)
+	nodes, _, err := discovery.DiscoverNodesWithCollisions(baseDir, contextID, sessionName, cfg)
 	if err != nil {
 		return status.SessionStatus{}, fmt.Errorf("discovering nodes: %w", err)
 	}
@@ -323,12 +323,12 @@ func collectSessionStatusWithInboxCounts(baseDir, contextID, sessionName string,
 		edgeNodeRank[nodeName] = idx
 	}
 
-	sessionDir := filepath.Join(baseDir, contextID, sessionName)
+	sessionDir := cfg.SessionDir(baseDir, contextID, sessionName)
 	paneActivity := loadPaneActivityEvidence(filepath.Join(baseDir, contextID, "pane-activity.json"))
 	queues := collectSessionQueues(sessionDir)
 	now := time.Now()
 	inputRequests, useInputRequests := projectedInputRequestCounts(sessionDir, sessionName, now, inputRequestStaleAfterSeconds(cfg))
-	panes, err := discoverSessionPanes(sessionName)
+	panes, err := discoverSessionPanes(sessionName, cfg.PaneNameFormat())
 	if err != nil {
 		return status.SessionStatus{}, err
 	}
@@ -584,7 +584,7 @@ func collectSessionQueues(sessionDir string) status.SessionQueues {
 	}
 }
 
-func discoverSessionPanes(sessionName string) ([]sessionPane, error) {
+func discoverSessionPanes(sessionName, paneNameFormat string) ([]sessionPane, error) {
 	windowListOut, err := exec.Command(
 		"tmux",
 		"list-windows",
@@ -612,7 +612,7 @@ func discoverSessionPanes(sessionName string) ([]sessionPane, error) {
 			"-t",
 			sessionName+":"+windowIndex,
 			"-F",
-			"#{window_index}\t#{pane_index}\t#{pane_id}\t#{pane_title}\t#{pane_current_command}",
+			"#{window_index}\t#{pane_index}\t#{pane_id}\t"+paneNameFormat+"\t#{pane_current_command}",
 		).CombinedOutput()
 		if err != nil {
 			if strings.Contains(string(out), "can't find window") {
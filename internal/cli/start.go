@@ -3,6 +3,7 @@ package cli
 import (
 	"context"
 	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"os"
@@ -34,6 +35,10 @@ import (
 	"github.com/i9wa4/tmux-a2a-postman/internal/tui"
 )
 
+// shutdownDrainGrace is how long past shutdown_drain_timeout_seconds the
+// process waits for the daemon loop to finish draining.
+const shutdownDrainGrace = 2 * time.Second
+
 // safeGo starts a goroutine with panic recovery (Issue #57).
 func safeGo(name string, events chan<- tui.DaemonEvent, fn func()) {
 	go func() {
@@ -182,7 +187,32 @@ func recordDirectPingDelivered(nodeKey string, nodeInfo discovery.NodeInfo, reso
 	}
 }
 
+// RunStart parses start-only flags and starts the daemon.
+func RunStart(contextID, configPath, logFilePath string, args []string) error {
+	fs := flag.NewFlagSet("start", flag.ContinueOnError)
+	cliutil.SetUsageWithoutContextID(fs)
+	validateRouting := fs.Bool("validate-routing", false, "warn about nodes that cannot reach ui_node through edges before starting")
+	strict := fs.Bool("strict", false, "with --validate-routing, exit instead of starting when a warning is found")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("start: unexpected arguments: %v", fs.Args())
+	}
+	if *strict && !*validateRouting {
+		return fmt.Errorf("start: --strict requires --validate-routing")
+	}
+	return runStart(contextID, configPath, logFilePath, startOptions{
+		ValidateRouting: *validateRouting,
+		Strict:          *strict,
+	})
+}
+
 func RunStartWithFlags(contextID, configPath, logFilePath string) error {
+	return runStart(contextID, configPath, logFilePath, startOptions{})
+}
+
+func runStart(contextID, configPath, logFilePath string, opts startOptions) error {
 	// Auto-generate context ID if not specified
 	if contextID == "" {
 		contextID = fmt.Sprintf("%s-%04x",
@@ -229,6 +259,18 @@ func RunStartWithFlags(contextID, configPath, logFilePath string) error {
 	log.SetFlags(log.LstdFlags)
 	log.Printf("postman: daemon starting (context=%s, log=%s)\n", contextID, logPath)
 
+	if opts.ValidateRouting && cfg.UINode == "" {
+		log.Println("postman: routing validation skipped: ui_node is not configured")
+	} else if opts.ValidateRouting {
+		warnings := routingWarnings(cfg, adjacency)
+		for _, warning := range warnings {
+			log.Printf("postman: WARNING: %s\n", warning)
+		}
+		if opts.Strict && len(warnings) > 0 {
+			return fmt.Errorf("start: routing validation failed: %s", strings.Join(warnings, "; "))
+		}
+	}
+
 	tmuxSessionName := config.GetTmuxSessionName()
 	sessionName := tmuxSessionName
 	if sessionName == "" {
@@ -239,7 +281,7 @@ func RunStartWithFlags(contextID, configPath, logFilePath string) error {
 			return fmt.Errorf("start: invalid session name: %w", err)
 		}
 	}
-	sessionDir := filepath.Join(contextDir, sessionName)
+	sessionDir := cfg.SessionDir(baseDir, contextID, sessionName)
 
 	startPreflight := planStartPreflight(startPreflightInput{
 		BaseDir:         baseDir,
@@ -261,7 +303,7 @@ func RunStartWithFlags(contextID, configPath, logFilePath string) error {
 	}
 	defer func() { _ = userLockObj.Release() }()
 
-	if err := config.CreateMultiSessionDirs(contextDir, sessionName); err != nil {
+	if err := cfg.CreateMultiSessionDirs(contextDir, sessionName); err != nil {
 		return fmt.Errorf("creating session directories: %w", err)
 	}
 	journalManager := journal.NewManager(contextID, os.Getpid())
@@ -281,7 +323,9 @@ func RunStartWithFlags(contextID, configPath, logFilePath string) error {
 		defer func() { _ = lockObj.Release() }()
 	}
 
-	pidPath := filepath.Join(sessionDir, "postman.pid")
+	// The PID file stays under base_dir even when [session.<name>] moves
+	// the message directories, so status and stop can find the daemon.
+	pidPath := filepath.Join(contextDir, sessionName, "postman.pid")
 	if err := config.WriteSessionPIDFile(pidPath, os.Getpid()); err != nil {
 		return fmt.Errorf("writing PID file: %w", err)
 	}
@@ -295,7 +339,8 @@ func RunStartWithFlags(contextID, configPath, logFilePath string) error {
 	readDir := filepath.Join(sessionDir, "read")
 
 	// Drain stale post/ messages (Issue #207)
-	if drained := message.DrainStalePost(sessionDir, cfg.MessageTTLSeconds); drained > 0 {
+	drained := message.DrainStalePost(sessionDir, cfg)
+	if drained > 0 {
 		log.Printf("postman: drained %d stale post/ messages at startup\n", drained)
 	}
 	if removed, err := cleanupExpiredRuntimeState(baseDir, contextID, cfg.RetentionPeriodDays, time.Now()); err != nil {
@@ -325,7 +370,7 @@ func RunStartWithFlags(contextID, configPath, logFilePath string) error {
 	defer func() { _ = watcher.Close() }()
 
 	// Reclaim panes from dead daemon contexts (#272)
-	if out, err := exec.Command("tmux", "list-panes", "-a", "-F", "#{pane_id} #{session_name} #{pane_title}").CombinedOutput(); err == nil {
+	if out, err := exec.Command("tmux", "list-panes", "-a", "-F", "#{pane_id} #{session_name} "+cfg.PaneNameFormat()).CombinedOutput(); err == nil {
 		for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
 			if line == "" {
 				continue
@@ -350,7 +395,7 @@ func RunStartWithFlags(contextID, configPath, logFilePath string) error {
 	}
 
 	// Discover nodes at startup (before watching, edge-filtered)
-	nodes, startupCollisions, err := discovery.DiscoverNodesWithCollisions(baseDir, contextID, sessionName)
+	nodes, startupCollisions, err := discovery.DiscoverNodesWithCollisions(baseDir, contextID, sessionName, cfg)
 	if err != nil {
 		// WARNING: log but continue - nodes can be empty
 		log.Printf("⚠️  postman: node discovery failed: %v\n", err)
@@ -384,7 +429,7 @@ func RunStartWithFlags(contextID, configPath, logFilePath string) error {
 				log.Printf("🚨 startup-rediscovery panic: %v\n", r)
 			}
 		}()
-		fresh, _, err := discovery.DiscoverNodesWithCollisions(baseDir, contextID, sessionName)
+		fresh, _, err := discovery.DiscoverNodesWithCollisions(baseDir, contextID, sessionName, cfg)
 		if err != nil {
 			log.Printf("⚠️  postman: startup re-discovery failed: %v\n", err)
 			return
@@ -403,53 +448,20 @@ func RunStartWithFlags(contextID, configPath, logFilePath string) error {
 		log.Printf("⚠️  postman: pane collision: %s: %s displaced by %s\n", collision.NodeKey, collision.LoserPaneID, collision.WinnerPaneID)
 	}
 
-	// Watch all discovered session directories
-	watchedDirs := make(map[string]bool)
+	// Ensure session directories exist for discovered nodes. The daemon
+	// runtime adds their watches so max_watched_dirs is enforced in one place.
 	for nodeName, nodeInfo := range nodes {
-		// Ensure session directories exist for discovered nodes
 		if err := config.CreateSessionDirs(nodeInfo.SessionDir); err != nil {
 			log.Printf("⚠️  postman: warning: could not create session dirs for %s: %v\n", nodeName, err)
 			continue
 		}
-
-		nodePostDir := filepath.Join(nodeInfo.SessionDir, "post")
-		nodeInboxDir := filepath.Join(nodeInfo.SessionDir, "inbox")
-
-		if !watchedDirs[nodePostDir] {
-			if err := watcher.Add(nodePostDir, fswatcher.All); err != nil {
-				log.Printf("⚠️  postman: warning: could not watch %s post directory: %v\n", nodeName, err)
-			} else {
-				watchedDirs[nodePostDir] = true
-			}
-		}
-		if !watchedDirs[nodeInboxDir] {
-			if err := watcher.Add(nodeInboxDir, fswatcher.All); err != nil {
-				log.Printf("⚠️  postman: warning: could not watch %s inbox directory: %v\n", nodeName, err)
-			} else {
-				watchedDirs[nodeInboxDir] = true
-			}
-		}
-		nodeReadDir := filepath.Join(nodeInfo.SessionDir, "read")
-		if !watchedDirs[nodeReadDir] {
-			if err := watcher.Add(nodeReadDir, fswatcher.All); err != nil {
-				log.Printf("⚠️  postman: warning: could not watch %s read directory: %v\n", nodeName, err)
-			} else {
-				watchedDirs[nodeReadDir] = true
-			}
-		}
-		submitRequestsDir := projection.DaemonSubmitRequestsDir(nodeInfo.SessionDir)
 		if err := projection.EnsureDaemonSubmitDirs(nodeInfo.SessionDir); err != nil {
 			log.Printf("postman: WARNING: component=%s event=dirs_create_failed submit_path=%s node=%s err=%v\n", projection.SubmitPathDaemon, projection.SubmitPathDaemon, nodeName, err)
-		} else if !watchedDirs[submitRequestsDir] {
-			if err := watcher.Add(submitRequestsDir, fswatcher.All); err != nil {
-				log.Printf("postman: WARNING: component=%s event=watch_failed submit_path=%s node=%s err=%v\n", projection.SubmitPathDaemon, projection.SubmitPathDaemon, nodeName, err)
-			} else {
-				watchedDirs[submitRequestsDir] = true
-			}
 		}
 	}
 
-	// Also watch default session directories (for postman's own messages)
+	// Watch default session directories (for postman's own messages)
+	watchedDirs := make(map[string]bool)
 	if !watchedDirs[postDir] {
 		if err := watcher.Add(postDir, fswatcher.All); err != nil {
 			return fmt.Errorf("watching post directory: %w", err)
@@ -499,6 +511,7 @@ func RunStartWithFlags(contextID, configPath, logFilePath string) error {
 
 	// Issue #71: Create state management instances
 	daemonState := daemon.NewDaemonState(cfg.StartupDrainWindowSeconds, contextID)
+	daemonState.RecordStartupTTLDrain(drained, time.Now())
 	daemonState.AutoEnableSessionIfNew(sessionName)
 	for _, activatedSession := range startupActivatedSessions {
 		daemonState.AutoEnableSessionIfNew(activatedSession)
@@ -508,6 +521,22 @@ func RunStartWithFlags(contextID, configPath, logFilePath string) error {
 	}
 	idleTracker := idle.NewIdleTracker()
 
+	// SIGUSR1 (sent by `reindex`) rebuilds discovery and watch state.
+	reindexCh := make(chan os.Signal, 1)
+	signal.Notify(reindexCh, syscall.SIGUSR1)
+	defer signal.Stop(reindexCh)
+	safeGo("reindex-signal-handler", nil, func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-reindexCh:
+				log.Println("postman: received SIGUSR1, reindexing discovery and watches")
+				daemonState.RequestReindex()
+			}
+		}
+	})
+
 	// Start pane capture check goroutine (hybrid idle detection)
 	idleTracker.StartPaneCaptureCheck(ctx, cfg, baseDir, contextID, sessionName, func(nodes map[string]discovery.NodeInfo, targets []idle.CompactionPingTarget) {
 		sendCompactionPings(contextID, cfg, idleTracker, nodes, targets)
@@ -519,7 +548,9 @@ func RunStartWithFlags(contextID, configPath, logFilePath string) error {
 	safeGo("tui-status-relay", nil, func() {
 		relayDaemonEventsToTUI(ctx, daemonEvents, tuiEvents, baseDir, contextID, cfg)
 	})
+	daemonLoopDone := make(chan struct{})
 	safeGo("daemon-loop", daemonEvents, func() {
+		defer close(daemonLoopDone)
 		daemon.RunDaemonLoop(ctx, baseDir, sessionDir, contextID, cfg, watcher, adjacency, nodes, knownNodes, daemonEvents, resolvedConfigPath, nil, nil, daemonState, idleTracker, &sharedNodes, sessionName)
 	})
 
@@ -556,7 +587,7 @@ func RunStartWithFlags(contextID, configPath, logFilePath string) error {
 	}
 
 	// Send initial inbox messages (worker node)
-	if nodeName := config.GetTmuxPaneName(); nodeName != "" {
+	if nodeName := config.GetTmuxPaneName(cfg.PaneNameFormat()); nodeName != "" {
 		msgList := message.ScanInboxMessages(filepath.Join(inboxDir, nodeName))
 		daemonEvents <- tui.DaemonEvent{
 			Type: "inbox_update",
@@ -578,6 +609,57 @@ func RunStartWithFlags(contextID, configPath, logFilePath string) error {
 			case cmd := <-tuiCommands:
 				// Issue #47: Handle TUI commands
 				switch cmd.Type {
+				case "session_toggle":
+					state := "disabled"
+					if cmd.Value == "on" {
+						// Enable through the same activation path as PING so
+						// ownership is checked and the marker is published.
+						activatedNodes, activationErr := activateSessionForPing(baseDir, contextDir, contextID, sessionName, cmd.Target, cfg, watcher, watchedDirs)
+						if errors.Is(activationErr, errPingSessionOwned) {
+							log.Printf("postman: enable blocked for session %s — %v\n", cmd.Target, activationErr)
+							daemonEvents <- tui.DaemonEvent{
+								Type:    "status_update",
+								Message: fmt.Sprintf("Session %s is owned by another daemon", cmd.Target),
+								Details: map[string]interface{}{"session": cmd.Target},
+							}
+							break
+						}
+						if activationErr != nil {
+							log.Printf("postman: session activation failed on %s: %v\n", cmd.Target, activationErr)
+							daemonEvents <- tui.DaemonEvent{
+								Type:    "status_update",
+								Message: fmt.Sprintf("Failed to activate session %s", cmd.Target),
+								Details: map[string]interface{}{"session": cmd.Target},
+							}
+							break
+						}
+						daemonState.SetSessionEnabled(cmd.Target, true)
+						sharedNodes.Store(&activatedNodes)
+						state = "enabled"
+					} else {
+						if err := config.SetSessionEnabledMarker(contextID, cmd.Target, false); err != nil {
+							log.Printf("postman: WARNING: failed to clear enabled-session marker for %s: %v\n", cmd.Target, err)
+						}
+						daemonState.SetSessionEnabled(cmd.Target, false)
+					}
+					daemonEvents <- tui.DaemonEvent{
+						Type:    "status_update",
+						Message: fmt.Sprintf("Session %s %s", cmd.Target, state),
+						Details: map[string]interface{}{"session": cmd.Target},
+					}
+				case "node_mute":
+					muted := cmd.Value == "on"
+					daemonState.SetNodeMuted(cmd.Target, muted)
+					state := "unmuted"
+					if muted {
+						state = "muted"
+					}
+					sessionName, _, _ := strings.Cut(cmd.Target, ":")
+					daemonEvents <- tui.DaemonEvent{
+						Type:    "status_update",
+						Message: fmt.Sprintf("Node %s %s", cmd.Target, state),
+						Details: map[string]interface{}{"session": sessionName},
+					}
 				case "send_ping":
 					cachedPtr := sharedNodes.Load()
 					var freshNodes map[string]discovery.NodeInfo
@@ -595,7 +677,7 @@ func RunStartWithFlags(contextID, configPath, logFilePath string) error {
 						activationBlocked := false
 						// Attempt a fresh discovery before giving up (catches panes
 						// that set titles after startup or after the last scan).
-						freshDiscovered, _, discErr := discovery.DiscoverNodesWithCollisions(baseDir, contextID, sessionName)
+						freshDiscovered, _, discErr := discovery.DiscoverNodesWithCollisions(baseDir, contextID, sessionName, cfg)
 						if discErr == nil && len(freshDiscovered) > 0 {
 							freshNodes = filterDiscoveredActivationNodes(freshDiscovered, activationNodesFilter)
 							sharedNodes.Store(&freshNodes)
@@ -767,6 +849,16 @@ func RunStartWithFlags(contextID, configPath, logFilePath string) error {
 		log.Println("postman: TUI exited (unknown state)")
 	}
 
+	// On a signal the daemon loop drains post/ before exiting; wait for it,
+	// bounded by the drain budget plus slack for shutdown bookkeeping.
+	if ctx.Err() != nil && cfg.ShutdownDrainTimeoutSeconds > 0 {
+		select {
+		case <-daemonLoopDone:
+		case <-time.After(time.Duration(cfg.ShutdownDrainTimeoutSeconds*float64(time.Second)) + shutdownDrainGrace):
+			log.Println("postman: WARNING: shutdown drain did not finish in time")
+		}
+	}
+
 	log.Println("postman: daemon exiting normally")
 	return nil
 }
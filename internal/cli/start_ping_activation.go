@@ -39,12 +39,12 @@ func activateStartupSessions(baseDir, contextDir, contextID, selfSession string,
 			continue
 		}
 
-		preClaimed := preclaimSessionCandidatePanes(targetSession, contextID, candidateNodes)
+		preClaimed := preclaimSessionCandidatePanes(targetSession, contextID, cfg.PaneNameFormat(), candidateNodes)
 		if preClaimed == 0 {
 			continue
 		}
 
-		if err := config.CreateMultiSessionDirs(contextDir, targetSession); err != nil {
+		if err := cfg.CreateMultiSessionDirs(contextDir, targetSession); err != nil {
 			log.Printf("postman: WARNING: failed to create startup session dirs for %s: %v\n", targetSession, err)
 			continue
 		}
@@ -69,17 +69,17 @@ func activateSessionForPing(baseDir, contextDir, contextID, selfSession, targetS
 		return nil, fmt.Errorf("%w: %s", errPingSessionOwned, owner)
 	}
 
-	if err := config.CreateMultiSessionDirs(contextDir, targetSession); err != nil {
+	if err := cfg.CreateMultiSessionDirs(contextDir, targetSession); err != nil {
 		return nil, fmt.Errorf("creating session directories for %s: %w", targetSession, err)
 	}
 	if err := config.SetSessionEnabledMarker(contextID, targetSession, true); err != nil {
 		return nil, fmt.Errorf("publishing enabled-session marker for %s: %w", targetSession, err)
 	}
-	registerWatchedSessionDirs(watcher, watchedDirs, filepath.Join(contextDir, targetSession))
+	registerWatchedSessionDirs(watcher, watchedDirs, cfg.SessionDir(baseDir, contextID, targetSession))
 
 	candidateNodes := activationNodeNames(cfg)
-	preClaimed := preclaimSessionCandidatePanes(targetSession, contextID, candidateNodes)
-	refreshed, _, err := discovery.DiscoverNodesWithCollisions(baseDir, contextID, selfSession)
+	preClaimed := preclaimSessionCandidatePanes(targetSession, contextID, cfg.PaneNameFormat(), candidateNodes)
+	refreshed, _, err := discovery.DiscoverNodesWithCollisions(baseDir, contextID, selfSession, cfg)
 	if err != nil {
 		_ = config.SetSessionEnabledMarker(contextID, targetSession, false)
 		return nil, fmt.Errorf("discovering nodes for %s: %w", targetSession, err)
@@ -122,8 +122,8 @@ func activationNodeNames(cfg *config.Config) map[string]bool {
 	return candidateNodes
 }
 
-func preclaimSessionCandidatePanes(sessionName, contextID string, candidateNodes map[string]bool) int {
-	out, err := exec.Command("tmux", "list-panes", "-s", "-t", sessionName, "-F", "#{pane_id} #{pane_title}").Output()
+func preclaimSessionCandidatePanes(sessionName, contextID, paneNameFormat string, candidateNodes map[string]bool) int {
+	out, err := exec.Command("tmux", "list-panes", "-s", "-t", sessionName, "-F", "#{pane_id} "+paneNameFormat).Output()
 	if err != nil {
 		log.Printf("postman: WARNING: failed to list panes for session %s: %v\n", sessionName, err)
 		return 0
@@ -187,7 +187,7 @@ func TestActivateStartupSessions_DefaultMakesForeignSessionDiscoverableAndOwned(
 		t.Fatalf("ResolveContextIDFromSession(%q) = %q, want %q", targetSession, resolvedContextID, contextID)
 	}
 
-	nodes, _, err := discovery.DiscoverNodesWithCollisions(baseDir, contextID, selfSession)
+	nodes, _, err := discovery.DiscoverNodesWithCollisions(baseDir, contextID, selfSession, nil)
 	if err != nil {
 		t.Fatalf("DiscoverNodesWithCollisions: %v", err)
 	}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+)
+
+// startOptions holds start-only flags parsed after the subcommand.
+type startOptions struct {
+	ValidateRouting bool
+	Strict          bool
+}
+
+// routingWarnings reports configured nodes that cannot reach the UI node
+// through the edge graph. Edges are bidirectional, so a BFS from the UI node
+// finds every node that can reach it. Without a ui_node there is nothing to
+// reach, so there are no warnings.
+func routingWarnings(cfg *config.Config, adjacency map[string][]string) []string {
+	if cfg.UINode == "" {
+		return nil
+	}
+
+	reachable := map[string]bool{cfg.UINode: true}
+	queue := []string{cfg.UINode}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for _, neighbor := range adjacency[node] {
+			if !reachable[neighbor] {
+				reachable[neighbor] = true
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+
+	var isolated []string
+	for _, name := range cfg.OrderedNodeNames() {
+		if name == "postman" || reachable[name] {
+			continue
+		}
+		isolated = append(isolated, name)
+	}
+	sort.Strings(isolated)
+
+	warnings := make([]string, 0, len(isolated))
+	for _, name := range isolated {
+		warnings = append(warnings, fmt.Sprintf("node %q cannot reach ui_node %q through edges", name, cfg.UINode))
+	}
+	return warnings
+}
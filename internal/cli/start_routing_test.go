@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+)
+
+func routingTestConfig(t *testing.T, uiNode string, edges ...string) (*config.Config, map[string][]string) {
+	t.Helper()
+	cfg := config.DefaultConfig()
+	cfg.UINode = uiNode
+	cfg.Edges = edges
+	cfg.Nodes = map[string]config.NodeConfig{}
+	for name := range config.GetEdgeNodeNames(edges) {
+		cfg.Nodes[name] = config.NodeConfig{}
+	}
+	adjacency, err := config.ParseEdges(edges)
+	if err != nil {
+		t.Fatalf("ParseEdges: %v", err)
+	}
+	return cfg, adjacency
+}
+
+func TestRoutingWarnings_ConnectedGraphHasNoWarnings(t *testing.T) {
+	cfg, adjacency := routingTestConfig(t, "messenger",
+		"messenger --- orchestrator",
+		"orchestrator --- worker --- critic",
+	)
+
+	if got := routingWarnings(cfg, adjacency); len(got) != 0 {
+		t.Fatalf("routingWarnings() = %v, want none", got)
+	}
+}
+
+func TestRoutingWarnings_ReportsIsolatedNodes(t *testing.T) {
+	cfg, adjacency := routingTestConfig(t, "messenger",
+		"messenger --- orchestrator",
+		"worker --- critic",
+	)
+	cfg.Nodes["loner"] = config.NodeConfig{}
+
+	got := routingWarnings(cfg, adjacency)
+	if len(got) != 3 {
+		t.Fatalf("routingWarnings() = %v, want critic, loner and worker", got)
+	}
+	for i, name := range []string{"critic", "loner", "worker"} {
+		if !strings.Contains(got[i], `node "`+name+`" cannot reach ui_node "messenger"`) {
+			t.Fatalf("routingWarnings()[%d] = %q, want %s isolation warning", i, got[i], name)
+		}
+	}
+}
+
+func TestRoutingWarnings_MissingUINode(t *testing.T) {
+	cfg, adjacency := routingTestConfig(t, "", "messenger --- orchestrator")
+
+	// No ui_node means the check is skipped, so --strict must not fail.
+	if got := routingWarnings(cfg, adjacency); len(got) != 0 {
+		t.Fatalf("routingWarnings() = %v, want none without ui_node", got)
+	}
+}
+
+func TestRunStart_StrictRequiresValidateRouting(t *testing.T) {
+	err := RunStart("", "", "", []string{"--strict"})
+	if err == nil || !strings.Contains(err.Error(), "--strict requires --validate-routing") {
+		t.Fatalf("RunStart(--strict) error = %v, want --validate-routing requirement", err)
+	}
+}
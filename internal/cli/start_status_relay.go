@@ -6,6 +6,7 @@ import (
 	"sort"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/i9wa4/tmux-a2a-postman/internal/config"
 	"github.com/i9wa4/tmux-a2a-postman/internal/status"
@@ -58,34 +59,104 @@ func relayDaemonEventsToTUIWithStatusRefresher(
 		statusWG.Wait()
 	}()
 
+	relay := func(event tui.DaemonEvent) bool {
+		if !forwardTUIEvent(ctx, tuiEvents, event) {
+			return false
+		}
+		refreshKnownSessions(knownSessions, event)
+		if !shouldRefreshSessionStatus(event.Type) || len(knownSessions) == 0 {
+			return true
+		}
+
+		request := sessionStatusRequest{
+			generation:   latestStatusGeneration.Add(1),
+			sessionNames: sortedSessionNames(knownSessions),
+		}
+		return requestSessionStatusRefresh(ctx, statusRequests, request)
+	}
+
+	coalescer := newTUIUpdateCoalescer(cfg)
+	var flushTimer <-chan time.Time
+	flush := func() bool {
+		flushTimer = nil
+		for _, event := range coalescer.drain() {
+			if !relay(event) {
+				return false
+			}
+		}
+		return true
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case event, ok := <-rawEvents:
-			if !ok {
+		case <-flushTimer:
+			if !flush() {
 				return
 			}
-
-			if !forwardTUIEvent(ctx, tuiEvents, event) {
+		case event, ok := <-rawEvents:
+			if !ok {
+				flush()
 				return
 			}
-			refreshKnownSessions(knownSessions, event)
-			if !shouldRefreshSessionStatus(event.Type) || len(knownSessions) == 0 {
+			if coalescer.hold(event) {
+				if flushTimer == nil {
+					flushTimer = time.After(coalescer.window)
+				}
 				continue
 			}
-
-			request := sessionStatusRequest{
-				generation:   latestStatusGeneration.Add(1),
-				sessionNames: sortedSessionNames(knownSessions),
-			}
-			if !requestSessionStatusRefresh(ctx, statusRequests, request) {
+			if !relay(event) {
 				return
 			}
 		}
 	}
 }
 
+// tuiUpdateCoalescer holds snapshot-style TUI updates for a short window and
+// releases only the latest of each type, so bursts cost one re-render.
+type tuiUpdateCoalescer struct {
+	window  time.Duration
+	pending map[string]tui.DaemonEvent
+	order   []string
+}
+
+func newTUIUpdateCoalescer(cfg *config.Config) *tuiUpdateCoalescer {
+	c := &tuiUpdateCoalescer{pending: make(map[string]tui.DaemonEvent)}
+	if cfg != nil && cfg.TUIUpdateCoalesceSeconds > 0 {
+		c.window = time.Duration(cfg.TUIUpdateCoalesceSeconds * float64(time.Second))
+	}
+	return c
+}
+
+// hold keeps event for the next drain and reports whether it did. Only
+// events that fully replace the previous one of their type are held:
+// pane_state_update and session snapshot status/config updates.
+func (c *tuiUpdateCoalescer) hold(event tui.DaemonEvent) bool {
+	if c.window <= 0 {
+		return false
+	}
+	if event.Type != "pane_state_update" && !isSessionSnapshotEvent(event) {
+		return false
+	}
+	if _, ok := c.pending[event.Type]; !ok {
+		c.order = append(c.order, event.Type)
+	}
+	c.pending[event.Type] = event
+	return true
+}
+
+// drain returns the held events in first-arrival order of their types.
+func (c *tuiUpdateCoalescer) drain() []tui.DaemonEvent {
+	events := make([]tui.DaemonEvent, 0, len(c.order))
+	for _, eventType := range c.order {
+		events = append(events, c.pending[eventType])
+		delete(c.pending, eventType)
+	}
+	c.order = c.order[:0]
+	return events
+}
+
 func requestSessionStatusRefresh(ctx context.Context, statusRequests chan sessionStatusRequest, request sessionStatusRequest) bool {
 	select {
 	case statusRequests <- request:
@@ -623,3 +623,36 @@ func TestRelayDaemonEventsToTUI_SkipsCanonicalHealthForForeignOwnedSession(t *te
 		t.Fatalf("health.VisibleState = %q, want %q for foreign-owned session", health.VisibleState, "unavailable")
 	}
 }
+
+func TestRelayDaemonEventsToTUI_CoalescesRapidPaneStateUpdates(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg := config.DefaultConfig()
+	cfg.TUIUpdateCoalesceSeconds = 0.05
+	rawEvents := make(chan tui.DaemonEvent, 4)
+	tuiEvents := make(chan tui.DaemonEvent, 8)
+	go relayDaemonEventsToTUI(ctx, rawEvents, tuiEvents, t.TempDir(), "ctx", cfg)
+
+	for _, message := range []string{"first", "second", "third"} {
+		rawEvents <- tui.DaemonEvent{Type: "pane_state_update", Message: message}
+	}
+	rawEvents <- tui.DaemonEvent{Type: "message_received", Message: "mail"}
+
+	if got := <-tuiEvents; got.Type != "message_received" {
+		t.Fatalf("first forwarded event = %#v, want message_received to pass through", got)
+	}
+	select {
+	case got := <-tuiEvents:
+		if got.Type != "pane_state_update" || got.Message != "third" {
+			t.Fatalf("coalesced event = %#v, want the latest pane_state_update", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("coalesced pane_state_update was never flushed")
+	}
+	select {
+	case extra := <-tuiEvents:
+		t.Fatalf("unexpected extra event after coalescing: %#v", extra)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
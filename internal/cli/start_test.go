@@ -675,6 +675,8 @@ func TestCleanupExpiredRuntimeState_PreservesLiveAndDurablePaths(t *testing.T) {
 	staleUnknown := filepath.Join(staleContextDir, "scratch-cache")
 
 	writeRuntimeSnapshotFixture(t, staleSnapshotDir)
+	staleArchiveCopy := filepath.Join(staleSessionDir, "post", "archive", "20260301-000000-from-boss-to-worker.md")
+	writeRuntimeFileFixture(t, staleArchiveCopy, "audit copy")
 	writeRuntimeFileFixture(t, staleLog, "old log")
 	writeRuntimeFileFixture(t, stalePaneActivity, "{}")
 	if err := os.MkdirAll(staleUnknown, 0o700); err != nil {
@@ -699,6 +701,7 @@ func TestCleanupExpiredRuntimeState_PreservesLiveAndDurablePaths(t *testing.T) {
 	assertPathExists(t, liveLog)
 	assertPathMissing(t, staleSessionDir)
 	assertPathMissing(t, staleSnapshotDir)
+	assertPathMissing(t, staleArchiveCopy)
 	assertPathMissing(t, staleLog)
 	assertPathMissing(t, stalePaneActivity)
 	assertPathExists(t, staleUnknown)
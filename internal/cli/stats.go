@@ -0,0 +1,147 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/cliutil"
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/projection"
+)
+
+// RunStats summarizes one node's message activity from the session journal.
+func RunStats(args []string) error {
+	return runStatsWithContext(defaultCommandContext(), args)
+}
+
+type statsOutput struct {
+	Node                      string  `json:"node"`
+	Session                   string  `json:"session"`
+	Since                     string  `json:"since,omitempty"`
+	Until                     string  `json:"until,omitempty"`
+	Received                  int     `json:"received"`
+	Sent                      int     `json:"sent"`
+	DeadLettered              int     `json:"dead_lettered"`
+	Read                      int     `json:"read"`
+	AverageReadLatencySeconds float64 `json:"average_read_latency_seconds"`
+	DroppedBalls              int     `json:"dropped_balls"`
+	DroppedAfterSeconds       float64 `json:"dropped_after_seconds"`
+}
+
+func runStatsWithContext(ctx commandContext, args []string) error {
+	ctx = ctx.withDefaults()
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	cliutil.SetUsageWithoutContextID(fs)
+	contextID := fs.String("context-id", "", "Context ID (optional, auto-resolved from tmux session)")
+	configPath := fs.String("config", "", "path to config file (optional)")
+	sessionName := fs.String("session", "", "tmux session name (optional, defaults to current tmux session)")
+	node := fs.String("node", "", "node to summarize")
+	since := fs.String("since", "", "start of the range: RFC3339 time or a duration ago such as 24h (default: all history)")
+	until := fs.String("until", "", "end of the range: RFC3339 time or a duration ago (default: now)")
+	droppedAfter := fs.Float64("dropped-after-seconds", 0, "unread age that counts as a dropped ball (default: input_request_stale_seconds, else 3600)")
+	jsonOutput := fs.Bool("json", false, "print JSON instead of a table")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *node == "" {
+		return fmt.Errorf("--node is required")
+	}
+	if *droppedAfter < 0 {
+		return fmt.Errorf("--dropped-after-seconds must be >= 0")
+	}
+
+	now := ctx.now()
+	window := projection.NodeStatsRange{Now: now}
+	var err error
+	if window.Since, err = parseStatsTime(*since, now); err != nil {
+		return fmt.Errorf("--since: %w", err)
+	}
+	if window.Until, err = parseStatsTime(*until, now); err != nil {
+		return fmt.Errorf("--until: %w", err)
+	}
+	if !window.Since.IsZero() && !window.Until.IsZero() && !window.Since.Before(window.Until) {
+		return fmt.Errorf("--since must be before --until")
+	}
+
+	cfg, err := ctx.loadConfig(*configPath)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	threshold := *droppedAfter
+	if threshold == 0 {
+		threshold = cfg.InputRequestStaleSeconds
+	}
+	if threshold <= 0 {
+		threshold = projection.DefaultInputRequestStaleAfterSeconds
+	}
+	window.DroppedAfter = time.Duration(threshold * float64(time.Second))
+
+	baseDir := config.ResolveBaseDir(cfg.BaseDir)
+	session := *sessionName
+	if session == "" {
+		if session = ctx.getTmuxSessionName(); session == "" {
+			return fmt.Errorf("tmux session name required (run inside tmux or pass --session)")
+		}
+	}
+	if session, err = config.ValidateSessionName(session); err != nil {
+		return err
+	}
+	resolvedContextID := ""
+	if *contextID != "" {
+		resolvedContextID, err = ctx.resolveContextID(*contextID)
+	} else {
+		resolvedContextID, err = ctx.resolveContextSession(baseDir, session)
+	}
+	if err != nil {
+		return err
+	}
+
+	stats, err := projection.ProjectNodeStats(cfg.SessionDir(baseDir, resolvedContextID, session), session, *node, window)
+	if err != nil {
+		return fmt.Errorf("reading journal: %w", err)
+	}
+	out := statsOutput{
+		Node:                      *node,
+		Session:                   session,
+		Received:                  stats.Received,
+		Sent:                      stats.Sent,
+		DeadLettered:              stats.DeadLettered,
+		Read:                      stats.Read,
+		AverageReadLatencySeconds: stats.AverageReadLatency.Seconds(),
+		DroppedBalls:              stats.DroppedBalls,
+		DroppedAfterSeconds:       threshold,
+	}
+	if !window.Since.IsZero() {
+		out.Since = window.Since.UTC().Format(time.RFC3339)
+	}
+	if !window.Until.IsZero() {
+		out.Until = window.Until.UTC().Format(time.RFC3339)
+	}
+
+	if *jsonOutput {
+		return json.NewEncoder(ctx.stdout).Encode(out)
+	}
+	w := tabwriter.NewWriter(ctx.stdout, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "NODE\tRECEIVED\tSENT\tDEAD-LETTERED\tAVG READ LATENCY\tDROPPED BALLS")
+	_, _ = fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%s\t%d\n", out.Node, out.Received, out.Sent, out.DeadLettered, stats.AverageReadLatency.Round(time.Second), out.DroppedBalls)
+	return w.Flush()
+}
+
+// parseStatsTime accepts an RFC3339 time or a duration meaning that long
+// before now. An empty value is the zero time (open range).
+func parseStatsTime(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("want an RFC3339 time or a duration, got %q", value)
+	}
+	return t, nil
+}
@@ -34,50 +34,22 @@ func RunStop(stdout io.Writer, args []string) error {
 		return err
 	}
 
-	cfg, err := config.LoadConfig(*configPath)
-	if err != nil {
-		return fmt.Errorf("loading config: %w", err)
-	}
-	baseDir := config.ResolveBaseDir(cfg.BaseDir)
-
-	sessionName := config.GetTmuxSessionName()
-	if sessionName == "" {
-		return fmt.Errorf("tmux session name required (run inside tmux)")
-	}
-	sessionName, err = config.ValidateSessionName(sessionName)
+	target, status, err := resolveOwnedDaemon(*configPath)
 	if err != nil {
 		return err
 	}
-
-	contextID, err := config.ResolveContextIDFromSession(baseDir, sessionName)
-	if err != nil {
-		if strings.Contains(err.Error(), "no active postman found") {
-			return json.NewEncoder(stdout).Encode(stopOutput{
-				Status:  "not_running",
-				Session: sessionName,
-			})
-		}
-		return err
-	}
-
-	daemonSessionName := config.FindContextSessionName(baseDir, contextID)
-	if daemonSessionName == "" || !config.IsSessionPIDOwnedByCurrentUser(baseDir, contextID, daemonSessionName) {
+	if status != "" {
 		output := stopOutput{
-			Status:    "not_owned",
-			Session:   sessionName,
-			ContextID: contextID,
+			Status:    status,
+			Session:   target.Session,
+			ContextID: target.ContextID,
 		}
-		if daemonSessionName != "" && daemonSessionName != sessionName {
-			output.DaemonSession = daemonSessionName
+		if target.DaemonSession != "" && target.DaemonSession != target.Session {
+			output.DaemonSession = target.DaemonSession
 		}
 		return json.NewEncoder(stdout).Encode(output)
 	}
-
-	pidPath := filepath.Join(baseDir, contextID, daemonSessionName, "postman.pid")
-	pid, err := config.ReadSessionPIDFile(pidPath)
-	if err != nil {
-		return err
-	}
+	baseDir, contextID, daemonSessionName, pid := target.BaseDir, target.ContextID, target.DaemonSession, target.PID
 
 	proc, err := os.FindProcess(pid)
 	if err != nil {
@@ -92,11 +64,11 @@ func RunStop(stdout io.Writer, args []string) error {
 		if !config.IsSessionPIDAlive(baseDir, contextID, daemonSessionName) {
 			output := stopOutput{
 				Status:    "stopped",
-				Session:   sessionName,
+				Session:   target.Session,
 				ContextID: contextID,
 				PID:       pid,
 			}
-			if daemonSessionName != sessionName {
+			if daemonSessionName != target.Session {
 				output.DaemonSession = daemonSessionName
 			}
 			return json.NewEncoder(stdout).Encode(output)
@@ -108,3 +80,54 @@ func RunStop(stdout io.Writer, args []string) error {
 		pid, stopTimeoutSeconds, pid,
 	)
 }
+
+// ownedDaemon identifies the daemon process serving the current tmux session.
+type ownedDaemon struct {
+	BaseDir       string
+	Session       string
+	DaemonSession string
+	ContextID     string
+	PID           int
+}
+
+// resolveOwnedDaemon locates the running daemon for the current tmux session.
+// When there is no daemon this user may signal, status is "not_running" or
+// "not_owned" and the returned target carries only what was resolved.
+func resolveOwnedDaemon(configPath string) (ownedDaemon, string, error) {
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		return ownedDaemon{}, "", fmt.Errorf("loading config: %w", err)
+	}
+	baseDir := config.ResolveBaseDir(cfg.BaseDir)
+
+	sessionName := config.GetTmuxSessionName()
+	if sessionName == "" {
+		return ownedDaemon{}, "", fmt.Errorf("tmux session name required (run inside tmux)")
+	}
+	sessionName, err = config.ValidateSessionName(sessionName)
+	if err != nil {
+		return ownedDaemon{}, "", err
+	}
+	target := ownedDaemon{BaseDir: baseDir, Session: sessionName}
+
+	contextID, err := config.ResolveContextIDFromSession(baseDir, sessionName)
+	if err != nil {
+		if strings.Contains(err.Error(), "no active postman found") {
+			return target, "not_running", nil
+		}
+		return ownedDaemon{}, "", err
+	}
+	target.ContextID = contextID
+
+	target.DaemonSession = config.FindContextSessionName(baseDir, contextID)
+	if target.DaemonSession == "" || !config.IsSessionPIDOwnedByCurrentUser(baseDir, contextID, target.DaemonSession) {
+		return target, "not_owned", nil
+	}
+
+	pidPath := filepath.Join(baseDir, contextID, target.DaemonSession, "postman.pid")
+	target.PID, err = config.ReadSessionPIDFile(pidPath)
+	if err != nil {
+		return ownedDaemon{}, "", err
+	}
+	return target, "", nil
+}
@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/cliutil"
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/discovery"
+	"github.com/i9wa4/tmux-a2a-postman/internal/nodeaddr"
+)
+
+// RunTalksTo prints the nodes a node can send to according to the
+// configured edges, with whether each one currently has a pane.
+func RunTalksTo(args []string) error {
+	return runTalksToWithContext(defaultCommandContext(), args)
+}
+
+type talksToNeighbor struct {
+	Node       string `json:"node"`
+	Discovered bool   `json:"discovered"`
+	PaneID     string `json:"pane_id,omitempty"`
+	Activity   string `json:"activity,omitempty"`
+}
+
+type talksToOutput struct {
+	Node     string            `json:"node"`
+	Session  string            `json:"session,omitempty"`
+	Resolved bool              `json:"resolved"` // false when panes could not be discovered
+	TalksTo  []talksToNeighbor `json:"talks_to"`
+}
+
+func runTalksToWithContext(ctx commandContext, args []string) error {
+	ctx = ctx.withDefaults()
+	fs := flag.NewFlagSet("talks-to", flag.ContinueOnError)
+	cliutil.SetUsageWithoutContextID(fs)
+	contextID := fs.String("context-id", "", "context ID")
+	configPath := fs.String("config", "", "path to config file (optional)")
+	node := fs.String("node", "", "node whose neighbors to print; session:node is accepted (required)")
+	session := fs.String("session", "", "session to check neighbor panes in (default: current tmux session)")
+	jsonOutput := fs.Bool("json", false, "print JSON instead of a table")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *node == "" {
+		return fmt.Errorf("--node is required")
+	}
+	if err := cliutil.ValidateNodeAddress("--node", *node); err != nil {
+		return err
+	}
+
+	cfg, err := ctx.loadConfig(*configPath)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	adjacency, err := config.ParseEdges(cfg.Edges)
+	if err != nil {
+		return fmt.Errorf("parsing edges: %w", err)
+	}
+
+	targetSession, nodeName, hasSession := nodeaddr.Split(*node)
+	if !hasSession {
+		targetSession = *session
+	} else if *session != "" && *session != targetSession {
+		return fmt.Errorf("--node %q conflicts with --session %q", *node, *session)
+	}
+	if _, ok := adjacency[nodeName]; !ok {
+		known := make([]string, 0, len(adjacency))
+		for name := range adjacency {
+			known = append(known, name)
+		}
+		sort.Strings(known)
+		return fmt.Errorf("node %q is not in any edge (known: %s)", nodeName, strings.Join(known, ", "))
+	}
+	neighbors := config.GetTalksTo(adjacency, nodeName)
+	slices.Sort(neighbors)
+
+	if targetSession == "" {
+		targetSession = ctx.getTmuxSessionName()
+	}
+	out := talksToOutput{Node: nodeName, Session: targetSession, TalksTo: make([]talksToNeighbor, 0, len(neighbors))}
+	var nodes map[string]discovery.NodeInfo
+	var paneActivity map[string]paneActivityEvidence
+	if targetSession != "" {
+		baseDir := config.ResolveBaseDir(cfg.BaseDir)
+		var resolvedContextID string
+		if *contextID != "" {
+			resolvedContextID, err = ctx.resolveContextID(*contextID)
+		} else {
+			resolvedContextID, err = ctx.resolveContextSession(baseDir, targetSession)
+		}
+		if err == nil {
+			nodes, err = ctx.discoverNodes(baseDir, resolvedContextID, targetSession, cfg)
+		}
+		if err != nil {
+			_, _ = fmt.Fprintf(ctx.stderr, "talks-to: pane discovery skipped: %v\n", err)
+		} else {
+			out.Resolved = true
+			paneActivity = loadPaneActivityEvidence(filepath.Join(baseDir, resolvedContextID, "pane-activity.json"))
+		}
+	}
+	for _, neighbor := range neighbors {
+		entry := talksToNeighbor{Node: neighbor}
+		if info, ok := nodes[targetSession+":"+neighbor]; ok && info.PaneID != "" {
+			entry.Discovered = true
+			entry.PaneID = info.PaneID
+			entry.Activity = paneActivity[info.PaneID].Status
+		}
+		out.TalksTo = append(out.TalksTo, entry)
+	}
+
+	if *jsonOutput {
+		return json.NewEncoder(ctx.stdout).Encode(out)
+	}
+	if len(out.TalksTo) == 0 {
+		_, _ = fmt.Fprintf(ctx.stdout, "%s talks to no nodes\n", nodeName)
+		return nil
+	}
+	w := tabwriter.NewWriter(ctx.stdout, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "NODE\tPANE\tACTIVITY")
+	for _, entry := range out.TalksTo {
+		pane, activity := "-", "-"
+		switch {
+		case !out.Resolved:
+			pane = "?"
+		case entry.Discovered:
+			pane = entry.PaneID
+			if entry.Activity != "" {
+				activity = entry.Activity
+			}
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", entry.Node, pane, activity)
+	}
+	return w.Flush()
+}
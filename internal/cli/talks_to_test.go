@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/discovery"
+)
+
+func TestRunTalksTo_PrintsChainNeighbors(t *testing.T) {
+	var stdout bytes.Buffer
+	ctx := commandContext{
+		stdout: &stdout,
+		loadConfig: func(string) (*config.Config, error) {
+			return &config.Config{BaseDir: t.TempDir(), Edges: []string{"orchestrator --- worker --- critic", "critic --- auditor"}}, nil
+		},
+		getTmuxSessionName: func() string { return "main" },
+		resolveContextID:   func(id string) (string, error) { return id, nil },
+		discoverNodes: func(baseDir, contextID, selfSession string, _ discovery.Settings) (map[string]discovery.NodeInfo, error) {
+			return map[string]discovery.NodeInfo{
+				"review:critic":  {PaneID: "%13", SessionName: "review"},
+				"review:auditor": {PaneID: "%14", SessionName: "review"},
+			}, nil
+		},
+	}
+
+	if err := runTalksToWithContext(ctx, []string{"--context-id", "ctx-test", "--node", "review:worker", "--json"}); err != nil {
+		t.Fatalf("runTalksToWithContext: %v", err)
+	}
+	var out talksToOutput
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		t.Fatalf("json.Unmarshal(%q): %v", stdout.String(), err)
+	}
+	if out.Node != "worker" || out.Session != "review" || !out.Resolved {
+		t.Fatalf("output header = %+v, want worker in resolved session review", out)
+	}
+	want := []talksToNeighbor{
+		{Node: "critic", Discovered: true, PaneID: "%13"},
+		{Node: "orchestrator"},
+	}
+	if len(out.TalksTo) != len(want) {
+		t.Fatalf("talks_to = %+v, want %+v", out.TalksTo, want)
+	}
+	for i := range want {
+		if out.TalksTo[i] != want[i] {
+			t.Fatalf("talks_to[%d] = %+v, want %+v", i, out.TalksTo[i], want[i])
+		}
+	}
+
+	stdout.Reset()
+	if err := runTalksToWithContext(ctx, []string{"--context-id", "ctx-test", "--node", "critic", "--session", "review"}); err != nil {
+		t.Fatalf("runTalksToWithContext(table): %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[1], "auditor") || !strings.Contains(lines[1], "%14") || !strings.HasPrefix(lines[2], "worker") {
+		t.Fatalf("table = %q, want auditor (%%14) then worker", stdout.String())
+	}
+
+	err := runTalksToWithContext(ctx, []string{"--node", "stranger"})
+	if err == nil || !strings.Contains(err.Error(), `node "stranger" is not in any edge`) {
+		t.Fatalf("unknown node error = %v", err)
+	}
+}
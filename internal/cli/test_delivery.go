@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/cliutil"
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/nodeaddr"
+	"github.com/i9wa4/tmux-a2a-postman/internal/notification"
+	"github.com/i9wa4/tmux-a2a-postman/internal/paneutil"
+)
+
+// testDeliveryCaptureLines is how much scrollback the marker check reads, so
+// a marker the agent already scrolled past still counts.
+const testDeliveryCaptureLines = 100
+
+// RunTestDelivery sends a marker to a node's pane through the notification
+// path and captures the pane to confirm the keys arrived.
+func RunTestDelivery(args []string) error {
+	return runTestDeliveryWithContext(defaultCommandContext(), args)
+}
+
+type testDeliveryOutput struct {
+	Status  string `json:"status"`
+	Node    string `json:"node"`
+	Session string `json:"session"`
+	PaneID  string `json:"pane_id"`
+	Marker  string `json:"marker"`
+}
+
+func runTestDeliveryWithContext(ctx commandContext, args []string) error {
+	ctx = ctx.withDefaults()
+	fs := flag.NewFlagSet("test-delivery", flag.ContinueOnError)
+	cliutil.SetUsageWithoutContextID(fs)
+	contextID := fs.String("context-id", "", "context ID")
+	configPath := fs.String("config", "", "path to config file (optional)")
+	node := fs.String("node", "", "node whose pane to test; session:node is accepted (required)")
+	session := fs.String("session", "", "session of the node (default: current tmux session)")
+	waitSeconds := fs.Float64("wait-seconds", 1, "how long to wait after sending before capturing the pane")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *node == "" {
+		return fmt.Errorf("--node is required")
+	}
+	if err := cliutil.ValidateNodeAddress("--node", *node); err != nil {
+		return err
+	}
+	if *waitSeconds < 0 {
+		return fmt.Errorf("--wait-seconds must be >= 0")
+	}
+
+	cfg, err := ctx.loadConfig(*configPath)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	baseDir := config.ResolveBaseDir(cfg.BaseDir)
+
+	targetSession, nodeName, hasSession := nodeaddr.Split(*node)
+	if !hasSession {
+		targetSession = *session
+	} else if *session != "" && *session != targetSession {
+		return fmt.Errorf("--node %q conflicts with --session %q", *node, *session)
+	}
+	if targetSession == "" {
+		targetSession = ctx.getTmuxSessionName()
+	}
+	if targetSession == "" {
+		return fmt.Errorf("--session required outside tmux")
+	}
+
+	var resolvedContextID string
+	if *contextID != "" {
+		resolvedContextID, err = ctx.resolveContextID(*contextID)
+	} else {
+		resolvedContextID, err = ctx.resolveContextSession(baseDir, targetSession)
+	}
+	if err != nil {
+		return err
+	}
+
+	nodes, err := ctx.discoverNodes(baseDir, resolvedContextID, targetSession, cfg)
+	if err != nil {
+		return fmt.Errorf("discovering nodes: %w", err)
+	}
+	nodeKey := targetSession + ":" + nodeName
+	info, ok := nodes[nodeKey]
+	if !ok || info.PaneID == "" {
+		var known []string
+		for key := range nodes {
+			if strings.HasPrefix(key, targetSession+":") {
+				known = append(known, nodeaddr.Simple(key))
+			}
+		}
+		sort.Strings(known)
+		if len(known) == 0 {
+			return fmt.Errorf("node %q has no pane in session %q (no nodes discovered there)", nodeName, targetSession)
+		}
+		return fmt.Errorf("node %q has no pane in session %q (known: %s)", nodeName, targetSession, strings.Join(known, ", "))
+	}
+
+	// Same delays and Enter handling as a delivery notification to this node.
+	marker := fmt.Sprintf("postman-test-delivery-%d", ctx.now().UnixNano())
+	nodeCfg := cfg.GetNodeConfig(nodeName)
+	enterDelay := time.Duration(cfg.EnterDelay * float64(time.Second))
+	if nodeCfg.EnterDelay != 0 {
+		enterDelay = time.Duration(nodeCfg.EnterDelay * float64(time.Second))
+	}
+	enterCount := nodeCfg.EnterCount
+	if enterCount == 0 {
+		enterCount = 1
+	}
+	message := marker + " (postman connectivity check; no reply needed)"
+	if err := notification.SendToPane(info.PaneID, message,
+		time.Duration(nodeCfg.PreEnterDelay*float64(time.Second)), enterDelay,
+		time.Duration(cfg.TmuxTimeout*float64(time.Second)), enterCount, true,
+		time.Duration(cfg.EnterVerifyDelay*float64(time.Second)), cfg.EnterRetryMax); err != nil {
+		return fmt.Errorf("sending marker to pane %s: %w", info.PaneID, err)
+	}
+
+	time.Sleep(time.Duration(*waitSeconds * float64(time.Second)))
+	captured, err := paneutil.CaptureRecentContent(info.PaneID, testDeliveryCaptureLines)
+	if err != nil {
+		return fmt.Errorf("sent marker but could not capture pane %s: %w", info.PaneID, err)
+	}
+
+	out := testDeliveryOutput{
+		Status:  "delivered",
+		Node:    nodeName,
+		Session: targetSession,
+		PaneID:  info.PaneID,
+		Marker:  marker,
+	}
+	if !strings.Contains(captured, marker) {
+		out.Status = "not_visible"
+	}
+	if err := json.NewEncoder(ctx.stdout).Encode(out); err != nil {
+		return err
+	}
+	if out.Status != "delivered" {
+		return fmt.Errorf("marker not visible in pane %s after %gs: tmux accepted the keys but the pane did not show them (copy mode, a full-screen program that does not echo input, or a slow pane; retry with a longer --wait-seconds)", info.PaneID, *waitSeconds)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/discovery"
+	"github.com/i9wa4/tmux-a2a-postman/internal/tmuxtest"
+)
+
+func TestRunTestDelivery_SendsMarkerThenCapturesPane(t *testing.T) {
+	now := time.Date(2026, time.May, 2, 9, 0, 0, 0, time.UTC)
+	marker := fmt.Sprintf("postman-test-delivery-%d", now.UnixNano())
+	tests := []struct {
+		name       string
+		capture    string
+		wantStatus string
+		wantErr    bool
+	}{
+		{name: "visible", capture: "$ " + marker + " (postman connectivity check; no reply needed)\n", wantStatus: "delivered"},
+		{name: "not visible", capture: "$ \n", wantStatus: "not_visible", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := tmuxtest.Install(t, tmuxtest.WithPane(tmuxtest.Pane{ID: "%12", SessionName: "review", Title: "worker", Capture: tt.capture}))
+			var stdout bytes.Buffer
+			ctx := commandContext{
+				stdout: &stdout,
+				loadConfig: func(string) (*config.Config, error) {
+					return &config.Config{TmuxTimeout: 1}, nil
+				},
+				getTmuxSessionName: func() string { return "main" },
+				resolveContextID:   func(id string) (string, error) { return id, nil },
+				discoverNodes: func(baseDir, contextID, selfSession string, _ discovery.Settings) (map[string]discovery.NodeInfo, error) {
+					return map[string]discovery.NodeInfo{"review:worker": {PaneID: "%12", SessionName: "review"}}, nil
+				},
+				now: func() time.Time { return now },
+			}
+
+			err := runTestDeliveryWithContext(ctx, []string{"--context-id", "ctx-test", "--node", "review:worker", "--wait-seconds", "0"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runTestDeliveryWithContext error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !strings.Contains(err.Error(), "marker not visible in pane %12") {
+				t.Fatalf("error = %q, want it to name the pane and the missing marker", err)
+			}
+
+			// The marker is pasted and submitted before the pane is captured.
+			log := strings.Join(fake.Invocations(), "\n")
+			last := -1
+			for _, step := range []string{"set-buffer", marker, "paste-buffer -t %12", "send-keys -t %12 C-m", "capture-pane -p -t %12 -S -100"} {
+				idx := strings.Index(log, step)
+				if idx <= last {
+					t.Fatalf("tmux log missing %q after the previous step:\n%s", step, log)
+				}
+				last = idx
+			}
+			var out testDeliveryOutput
+			if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+				t.Fatalf("json.Unmarshal(%q): %v", stdout.String(), err)
+			}
+			if want := (testDeliveryOutput{Status: tt.wantStatus, Node: "worker", Session: "review", PaneID: "%12", Marker: marker}); out != want {
+				t.Fatalf("output = %#v, want %#v", out, want)
+			}
+		})
+	}
+}
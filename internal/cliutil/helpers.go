@@ -44,7 +44,7 @@ func ResolveInboxPath(args []string) (string, error) {
 
 	baseDir := config.ResolveBaseDir(cfg.BaseDir)
 
-	nodeName := config.GetTmuxPaneName()
+	nodeName := config.GetTmuxPaneName(cfg.PaneNameFormat())
 	if nodeName == "" {
 		return "", fmt.Errorf("node name auto-detection failed: set tmux pane title")
 	}
@@ -74,7 +74,7 @@ func ResolveInboxPath(args []string) (string, error) {
 		}
 	}
 
-	inboxPath := filepath.Join(baseDir, resolvedContextID, sessionName, "inbox", nodeName)
+	inboxPath := filepath.Join(cfg.SessionDir(baseDir, resolvedContextID, sessionName), "inbox", nodeName)
 	return inboxPath, nil
 }
 
@@ -4,20 +4,28 @@ import (
 	_ "embed"
 	"fmt"
 	"log"
+	"maps"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/BurntSushi/toml"
 	"github.com/i9wa4/tmux-a2a-postman/internal/binding"
+	"github.com/i9wa4/tmux-a2a-postman/internal/template"
 )
 
 const (
 	DefaultDaemonSubmitWorkerLimit = 8
 	MaxDaemonSubmitWorkerLimit     = 16
+
+	DefaultSessionDirCreateAttempts     = 3
+	DefaultSessionDirCreateRetrySeconds = 0.1
 )
 
 //go:embed postman.default.toml
@@ -35,18 +43,35 @@ type Config struct {
 	EnterRetryMax       int     `toml:"enter_retry_max"`            // Max C-m retries on pane capture unchanged (0 = disabled)
 
 	// Node state thresholds.
-	NodeActiveSeconds                float64 `toml:"node_active_seconds"`                   // 0-N seconds since pane change: active
-	NodeStaleSeconds                 float64 `toml:"node_stale_seconds"`                    // Memory cleanup threshold for pane capture
-	InputRequestStaleSeconds         float64 `toml:"input_request_stale_seconds"`           // Status projection threshold for stale unfilled input requests
-	VerdictGraceSeconds              float64 `toml:"verdict_grace_seconds"`                 // Grace period for requester verdict stamps after filled reply-required input requests
-	VerdictDebtCap                   int     `toml:"verdict_debt_cap"`                      // Maximum unstamped fills a requester may carry before new reply-required sends are refused
-	MessageTTLSeconds                float64 `toml:"message_ttl_seconds"`                   // Stale post/ drain TTL; 0 = disabled
-	RetentionPeriodDays              int     `toml:"retention_period_days"`                 // Inactive runtime cleanup threshold in days; 0 = disabled
-	DaemonSubmitQueueWarnThresholdMs int64   `toml:"daemon_submit_queue_warn_threshold_ms"` // Queue wait WARNING threshold in ms; 0 = use default (30 000)
-	MinDeliveryGapSeconds            float64 `toml:"min_delivery_gap_seconds"`              // Duplicate delivery rate limit; 0 = disabled
-	StartupDrainWindowSeconds        float64 `toml:"startup_drain_window_seconds"`          // Session-enabled bypass window after daemon start; 0 = disabled (#217)
-	AutoPingDelaySeconds             float64 `toml:"auto_ping_delay_seconds"`               // Delay from discovery/replacement to first auto-PING
-	DaemonSubmitWorkerLimit          int     `toml:"daemon_submit_worker_limit"`            // Daemon-submit worker concurrency; clamped to MaxDaemonSubmitWorkerLimit
+	NodeActiveSeconds                  float64 `toml:"node_active_seconds"`                   // 0-N seconds since pane change: active
+	NodeStaleSeconds                   float64 `toml:"node_stale_seconds"`                    // Memory cleanup threshold for pane capture
+	ActivityHysteresisSeconds          float64 `toml:"activity_hysteresis_seconds"`           // Extra quiet time an active pane needs before it reports idle
+	MaxWatchedDirs                     int     `toml:"max_watched_dirs"`                      // Node dir watch cap; overflow dirs are polled on the scan tick; 0 = unlimited
+	InputRequestStaleSeconds           float64 `toml:"input_request_stale_seconds"`           // Status projection threshold for stale unfilled input requests
+	VerdictGraceSeconds                float64 `toml:"verdict_grace_seconds"`                 // Grace period for requester verdict stamps after filled reply-required input requests
+	VerdictDebtCap                     int     `toml:"verdict_debt_cap"`                      // Maximum unstamped fills a requester may carry before new reply-required sends are refused
+	MessageTTLSeconds                  float64 `toml:"message_ttl_seconds"`                   // Stale post/ drain TTL; 0 = disabled
+	RetentionPeriodDays                int     `toml:"retention_period_days"`                 // Inactive runtime cleanup threshold in days; 0 = disabled
+	DaemonSubmitQueueWarnThresholdMs   int64   `toml:"daemon_submit_queue_warn_threshold_ms"` // Queue wait WARNING threshold in ms; 0 = use default (30 000)
+	MinDeliveryGapSeconds              float64 `toml:"min_delivery_gap_seconds"`              // Duplicate delivery rate limit; 0 = disabled
+	MaxMessagesPerMinute               int     `toml:"max_messages_per_minute"`               // Per-sender sliding-window flood limit; 0 = unlimited
+	MaxMessagesPerHour                 int     `toml:"max_messages_per_hour"`                 // Per-session messages delivered in any sliding hour before dead-lettering as "session quota exceeded"; 0 = unlimited
+	MaxMessageBytes                    int64   `toml:"max_message_bytes"`                     // Dead-letter posts larger than this as "too large"; 0 = unlimited
+	SerializePerNodeTimeoutSeconds     float64 `toml:"serialize_per_node_timeout_seconds"`    // serialize_per_node gives up holding after the oldest unread mail is this old; 0 = hold until read
+	StartupDrainWindowSeconds          float64 `toml:"startup_drain_window_seconds"`          // Session-enabled bypass window after daemon start; 0 = disabled (#217)
+	AutoPingDelaySeconds               float64 `toml:"auto_ping_delay_seconds"`               // Delay from discovery/replacement to first auto-PING
+	DaemonSubmitWorkerLimit            int     `toml:"daemon_submit_worker_limit"`            // Daemon-submit worker concurrency; clamped to MaxDaemonSubmitWorkerLimit
+	SessionDirCreateAttempts           int     `toml:"session_dir_create_attempts"`           // Tries at creating a new node's session dirs before giving up; 0 = use default (3)
+	SessionDirCreateRetrySeconds       float64 `toml:"session_dir_create_retry_seconds"`      // First delay between those tries, doubled each retry; 0 = use default (0.1)
+	MeshSummaryIntervalSeconds         float64 `toml:"mesh_summary_interval_seconds"`         // Period of the mesh_summary rollup event; 0 = disabled
+	ReadArchivalSeconds                float64 `toml:"read_archival_seconds"`                 // read/ messages older than this are packed into read/archive/*.tar.gz; 0 = disabled
+	ShutdownDrainTimeoutSeconds        float64 `toml:"shutdown_drain_timeout_seconds"`        // Budget for delivering leftover post/ mail on shutdown; 0 = exit without draining
+	MaxClockSkewSeconds                float64 `toml:"max_clock_skew_seconds"`                // Filename timestamps further ahead of the daemon clock are flagged as clock skew; 0 = disabled
+	CriticalEdgeSilenceSeconds         float64 `toml:"critical_edge_silence_seconds"`         // @critical edges without a delivery for this long emit critical_edge_silent; 0 = disabled
+	EmptySessionAlertSeconds           float64 `toml:"empty_session_alert_seconds"`           // Enabled sessions with no discovered node for this long emit empty_session; 0 = disabled
+	TUIUpdateCoalesceSeconds           float64 `toml:"tui_update_coalesce_seconds"`           // Window in which snapshot TUI updates of one type collapse to the latest; 0 = forward each
+	NotificationFailureThreshold       int     `toml:"notification_failure_threshold"`        // Consecutive pane notification failures that open a node's notification circuit; 0 = disabled
+	NotificationCircuitCooldownSeconds float64 `toml:"notification_circuit_cooldown_seconds"` // How long an open notification circuit skips the node's pane before probing again
 
 	// Pane capture settings (hybrid idle detection)
 	PaneCaptureEnabled         *bool   `toml:"pane_capture_enabled"` // nil = use default (true) (#219)
@@ -54,9 +79,54 @@ type Config struct {
 	PaneCaptureMaxPanes        int     `toml:"pane_capture_max_panes"`
 	PaneCaptureTailLines       int     `toml:"pane_capture_tail_lines"`
 	ActivityWindowSeconds      float64 `toml:"activity_window_seconds"`
+	// Activity signal the idle tracker samples each capture tick: "capture"
+	// (default) hashes the pane contents, "heartbeat" watches the mtime of
+	// heartbeat/<node> in the session dir, and "signal" runs
+	// activity_signal_command and compares its stdout
+	ActivityDetector      string `toml:"activity_detector"`
+	ActivitySignalCommand string `toml:"activity_signal_command"`
 
 	// Paths
-	BaseDir string `toml:"base_dir"`
+	BaseDir  string `toml:"base_dir"`
+	DraftDir string `toml:"draft_dir"` // Draft staging dir: absolute, or relative to the session dir (default: draft)
+	// Go layout of the message filename timestamp prefix; "unix" = epoch seconds
+	FilenameTimestampFormat string `toml:"filename_timestamp_format"`
+	// What happens to a delivered post/ file: "move" (default) or "copy",
+	// which keeps the sent bytes under post/archive/ as an audit trail
+	PostRetention string `toml:"post_retention"`
+	// Shell command run with each message body on stdin before delivery; its
+	// stdout replaces the body (e.g. to redact secrets). On failure or timeout
+	// the original body is delivered and a warning logged
+	ContentFilterCommand        string  `toml:"content_filter_command"`
+	ContentFilterTimeoutSeconds float64 `toml:"content_filter_timeout_seconds"`
+	// Where a pane's node name comes from: "title" (default) or
+	// "user_option", which reads the @a2a_node pane option and falls back
+	// to the title when it is unset
+	NodeIdentity string `toml:"node_identity"`
+	// Node that receives mail addressed to an unknown recipient, annotated
+	// with the intended recipient, instead of dead-lettering it ("" = off)
+	CatchAllNode string `toml:"catch_all_node"`
+	// What happens to mail for a node whose pane has disappeared: "deliver"
+	// (default) keeps the normal path, "hold" leaves it in post/ until the
+	// pane returns, "dead_letter" dead-letters it as "pane not running"
+	DeadPanePolicy string `toml:"dead_pane_policy"`
+	// What happens to mail sent along a missing edge: "block" (default)
+	// warns the sender and dead-letters it, "warn_only" warns but delivers,
+	// "silent" dead-letters it without warning
+	EdgeViolationMode string `toml:"edge_violation_mode"`
+	// What happens to mail whose body is empty: "deliver" (default),
+	// "dead_letter" with a note to the sender, or "warn_sender", which
+	// delivers it and tells the sender
+	EmptyBodyPolicy string `toml:"empty_body_policy"`
+	// What the daemon does with a file in post/ that is not .md: "ignore"
+	// (default), "warn" with a non_md_in_post event, or "dead_letter", which
+	// also moves it to dead-letter/
+	NonMDPostPolicy string `toml:"non_md_post_policy"`
+	// File of KEY=VALUE lines (or a .toml table) whose keys templates read as
+	// {secret:KEY}; relative paths resolve against the config directory and
+	// world-readable files are refused ("" = no secrets)
+	SecretsFile string            `toml:"secrets_file"`
+	Secrets     map[string]string `toml:"-"`
 	// Message templates
 	NotificationTemplate         string            `toml:"notification_template"`
 	DaemonMessageTemplate        string            `toml:"daemon_message_template"`         // Unified envelope for daemon-originated PING
@@ -67,6 +137,7 @@ type Config struct {
 	EdgeViolationWarningTemplate string            `toml:"edge_violation_warning_template"` // Issue #80: Warning message for routing denied
 	EdgeViolationWarningMode     string            `toml:"edge_violation_warning_mode"`     // Issue #92: "compact" or "verbose" (default: compact)
 	MessageFooter                string            `toml:"message_footer"`                  // Footer appended to outgoing messages by `send` after message content
+	DeadLetterFeedbackTemplate   string            `toml:"dead_letter_feedback_template"`   // Sender note for dead-lettered mail (empty = built-in text)
 
 	// Global settings
 	Edges                          []string                        `toml:"edges"`
@@ -79,20 +150,88 @@ type Config struct {
 	DeprecatedCommandApproverNodes []DeprecatedCommandApproverNode `toml:"-"` // Ignored legacy TOML approver keys surfaced in get-status
 
 	// Node-specific configurations (loaded from [nodename] sections)
-	Nodes map[string]NodeConfig
+	Nodes map[string]NodeConfig `toml:"-"`
 	// NodeOrder preserves first-seen node definition order across merged config files.
 	NodeOrder []string `toml:"-"`
 
 	// Node-level defaults applied to all nodes (loaded from [node_defaults] section)
-	NodeDefaults NodeConfig
+	NodeDefaults NodeConfig `toml:"-"`
+
+	// Per-session overrides (loaded from [session.<name>] sections)
+	Sessions map[string]SessionConfig `toml:"-"`
 
 	// Shell template execution opt-in (#security)
 	AllowShellTemplates bool `toml:"allow_shell_templates"`
+	// Template $(...) commands that may run at once under this config; the
+	// rest queue for a slot. 0 = unlimited
+	TemplateShellMaxConcurrent int `toml:"template_shell_max_concurrent"`
+	// Shared by every expansion under this config; set by LoadConfig
+	shellLimiter *template.ShellLimiter
+
+	// Sender verification opt-in: dead-letter messages whose originating pane
+	// belongs to a node other than the filename sender.
+	VerifySender *bool `toml:"verify_sender"`
+
+	// Dead-letter feedback opt-in: write a sender note for every dead-letter
+	// reason with a trustworthy sender, not only the default set.
+	DeadLetterFeedback *bool `toml:"dead_letter_feedback"`
+
+	// One-at-a-time handoff: hold mail in post/ while the recipient still
+	// has unread inbox mail, up to serialize_per_node_timeout_seconds.
+	SerializePerNode *bool `toml:"serialize_per_node"`
+
+	// Count a freshly detected compaction as pane activity, so an agent that
+	// sits still while it compacts does not read as idle or dropping mail.
+	CompactionCountsAsActivity *bool `toml:"compaction_counts_as_activity"`
+
+	// After a pane restart, re-send the notification for each message still
+	// unread in the node's inbox, so the new agent learns it has mail.
+	RenotifyOnRestart *bool `toml:"renotify_on_restart"`
+
+	// Build extra edges from each [<node>] talks_to list, so topology can be
+	// declared per node instead of only as an edge list.
+	InferEdgesFromTalksTo *bool `toml:"infer_edges_from_talks_to"`
+
+	// Reject edges that use an em or en dash instead of "---" rather than
+	// rewriting them with a warning.
+	StrictEdges *bool `toml:"strict_edges"`
+
+	// Append the delivered message's inbox file path to every pane
+	// notification, so the agent knows which file to move to read/.
+	NotificationIncludePath *bool `toml:"notification_include_path"`
+
+	// Debug aid: on each pane content change, write a line diff of the
+	// previous and current capture to pane-capture-diffs/ in the context dir.
+	PaneCaptureDiffLog *bool `toml:"pane_capture_diff_log"`
+
+	// Quiet-mesh scanning: after adaptive_scan_idle_seconds without a
+	// watcher event, or while every session is disabled, double the scan
+	// and pane-capture intervals per scan up to adaptive_scan_max_seconds;
+	// the next event restores scan_interval_seconds.
+	AdaptiveScan            *bool   `toml:"adaptive_scan"`
+	AdaptiveScanIdleSeconds float64 `toml:"adaptive_scan_idle_seconds"`
+	AdaptiveScanMaxSeconds  float64 `toml:"adaptive_scan_max_seconds"`
+
+	// TUI status indicators keyed by TUIIndicatorStates. tui_symbols replaces
+	// the built-in emoji; tui_colors is a lipgloss color ("2", "#00ff00")
+	// applied to the symbol. Unset states keep the defaults.
+	TUISymbols map[string]string `toml:"tui_symbols"`
+	TUIColors  map[string]string `toml:"tui_colors"`
+
+	// TUI session groups: label -> tmux session names. The left pane lists a
+	// group's sessions together under its label, and c collapses the group
+	// to one row showing its worst state.
+	TUIGroups map[string][]string `toml:"tui_groups"`
 
 	directTemplateRootTrust map[string]bool
-	uiNodeSet               bool
-	verdictGraceSecondsSet  bool
-	verdictDebtCapSet       bool
+	// origins maps "section.field" to the file that last set it (see SettingOrigins).
+	origins                map[string]string
+	uiNodeSet              bool
+	verdictGraceSecondsSet bool
+	verdictDebtCapSet      bool
+	// serialize_per_node_timeout_seconds was set explicitly, so an overlay's
+	// 0 (hold until read) overrides the base value
+	serializePerNodeTimeoutSet bool
 }
 
 type CommandApprovalPolicy struct {
@@ -115,7 +254,33 @@ type NodeConfig struct {
 	Role       string  `toml:"role"`
 	EnterCount int     `toml:"enter_count"`         // Issue #126: Number of Enter keystrokes to send (0/1 = single, 2+ = double)
 	EnterDelay float64 `toml:"enter_delay_seconds"` // 0 = use global default
-}
+	// Wait before pasting the notification text, for agent TUIs that are
+	// momentarily busy; 0 = no wait.
+	PreEnterDelay float64 `toml:"pre_enter_delay_seconds"`
+	Transport     string  `toml:"transport"` // Notification transport: "tmux" (default) or "fifo"
+	FifoPath      string  `toml:"fifo_path"` // Named pipe written when transport = "fifo"
+	// Pane hint for mail to this node; empty = the global notification_template.
+	NotificationTemplate string `toml:"notification_template"`
+	// PING body for this node, expanded with its node variables; empty = the
+	// built-in PING line.
+	PingTemplate string `toml:"ping_template"`
+	// Text placed before and after the built notification, for REPLs that
+	// need a leading command or trailing submit token in the pasted text.
+	NotificationPrefix string `toml:"notification_prefix"`
+	NotificationSuffix string `toml:"notification_suffix"`
+	// Other names this node answers to: mail addressed to an alias is
+	// delivered here. Edges still use the canonical name.
+	Aliases []string `toml:"aliases"`
+	// Nodes this node talks to; with infer_edges_from_talks_to each entry
+	// becomes an edge, in addition to the explicit edges.
+	TalksTo []string `toml:"talks_to"`
+}
+
+// Node notification transports.
+const (
+	NodeTransportTmux = "tmux"
+	NodeTransportFifo = "fifo"
+)
 
 // WorkspaceTreeNodeConfig describes one node in the explicit workspace tree hierarchy.
 type WorkspaceTreeNodeConfig struct {
@@ -193,7 +358,7 @@ func appendUniqueNodeNames(order []string, names ...string) []string {
 }
 
 func isReservedNodeSection(name string) bool {
-	return name == "postman" || name == "node_defaults"
+	return name == "postman" || name == "node_defaults" || name == "session"
 }
 
 func orderedTOMLNodeNames(md toml.MetaData) []string {
@@ -290,8 +455,178 @@ func edgeNodeNamesInOrder(edges []string) []string {
 	return order
 }
 
+// edgeWildcard as an edge endpoint stands for every other known node, e.g.
+// "orchestrator --- *" connects the hub to all nodes.
+const edgeWildcard = "*"
+
+// expandEdgeWildcards rewrites each "hub --- *" edge into one edge per node
+// named in a node section or in another edge, keeping the edge's
+// annotations, so adjacency and the TUI see a fully enumerated topology.
+func (cfg *Config) expandEdgeWildcards() error {
+	if cfg == nil {
+		return nil
+	}
+	var plain, wildcard []string
+	for _, edge := range cfg.Edges {
+		if containsString(splitEdgeNodeNames(edge), edgeWildcard) {
+			wildcard = append(wildcard, edge)
+		} else {
+			plain = append(plain, edge)
+		}
+	}
+	if len(wildcard) == 0 {
+		return nil
+	}
+
+	known := cfg.OrderedNodeNames()
+	for _, name := range edgeNodeNamesInOrder(plain) {
+		known = appendUniqueNodeNames(known, name)
+	}
+
+	expanded := make([]string, 0, len(cfg.Edges))
+	for _, edge := range cfg.Edges {
+		nodes := splitEdgeNodeNames(edge)
+		if !containsString(nodes, edgeWildcard) {
+			expanded = append(expanded, edge)
+			continue
+		}
+		if len(nodes) != 2 || nodes[0] == nodes[1] {
+			return fmt.Errorf("invalid wildcard edge (use \"hub --- *\"): %q", edge)
+		}
+		hub := nodes[0]
+		if hub == edgeWildcard {
+			hub = nodes[1]
+		}
+		annotations, err := edgeAnnotations(edge)
+		if err != nil {
+			return err
+		}
+		for _, name := range known {
+			if name == hub || name == "postman" {
+				continue
+			}
+			expanded = append(expanded, hub+" --- "+name+annotations)
+		}
+	}
+	cfg.Edges = expanded
+	return nil
+}
+
+// edgeAnnotations returns the edge's @weight=, @methods=, and @critical
+// annotations in canonical form with a leading space, or "" when it has none.
+func edgeAnnotations(edge string) (string, error) {
+	_, methods, err := splitEdgeMethods(edge)
+	if err != nil {
+		return "", err
+	}
+	_, weight, err := splitEdgeWeight(edge)
+	if err != nil {
+		return "", err
+	}
+	_, critical, silenceSeconds, err := splitEdgeCritical(edge)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if strings.Contains(edge, edgeWeightAnnotation) {
+		fmt.Fprintf(&b, " %s%d", edgeWeightAnnotation, weight)
+	}
+	if len(methods) > 0 {
+		fmt.Fprintf(&b, " %s%s", edgeMethodsAnnotation, strings.Join(methods, ","))
+	}
+	if critical {
+		b.WriteString(" " + edgeCriticalAnnotation)
+		if silenceSeconds > 0 {
+			fmt.Fprintf(&b, "=%d", silenceSeconds)
+		}
+	}
+	return b.String(), nil
+}
+
+// edgeWeightAnnotation marks an optional trailing edge weight, e.g.
+// "orchestrator --- worker @weight=3".
+const edgeWeightAnnotation = "@weight="
+
+// edgeMethodsAnnotation restricts which message methods an edge carries, e.g.
+// "orchestrator --- worker @methods=task/assign,review/*". Patterns use
+// path.Match syntax; edges without it permit every method.
+const edgeMethodsAnnotation = "@methods="
+
+// edgeCriticalAnnotation marks an edge whose silence should alert, e.g.
+// "concierge --- orchestrator @critical" or "@critical=600" to override
+// critical_edge_silence_seconds for that edge.
+const edgeCriticalAnnotation = "@critical"
+
+// splitEdgeCritical removes an optional @critical[=seconds] annotation from
+// anywhere in the edge. silenceSeconds is 0 when the annotation gives none.
+func splitEdgeCritical(edge string) (string, bool, int, error) {
+	fields := strings.Fields(edge)
+	for i, field := range fields {
+		if field != edgeCriticalAnnotation && !strings.HasPrefix(field, edgeCriticalAnnotation+"=") {
+			continue
+		}
+		silenceSeconds := 0
+		if raw, ok := strings.CutPrefix(field, edgeCriticalAnnotation+"="); ok {
+			seconds, err := strconv.Atoi(raw)
+			if err != nil || seconds < 1 {
+				return edge, false, 0, fmt.Errorf("invalid critical edge window %q (must be a positive number of seconds): %q", raw, edge)
+			}
+			silenceSeconds = seconds
+		}
+		rest := append(append([]string{}, fields[:i]...), fields[i+1:]...)
+		return strings.Join(rest, " "), true, silenceSeconds, nil
+	}
+	return edge, false, 0, nil
+}
+
+// splitEdgeMethods removes an optional @methods= annotation from anywhere in
+// the edge and returns its comma-separated patterns.
+func splitEdgeMethods(edge string) (string, []string, error) {
+	idx := strings.LastIndex(edge, edgeMethodsAnnotation)
+	if idx < 0 {
+		return edge, nil, nil
+	}
+	rest := edge[idx+len(edgeMethodsAnnotation):]
+	raw, tail := rest, ""
+	if end := strings.IndexAny(rest, " \t"); end >= 0 {
+		raw, tail = rest[:end], rest[end:]
+	}
+	var patterns []string
+	for _, pattern := range strings.Split(raw, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return edge, nil, fmt.Errorf("invalid edge method pattern %q: %q", pattern, edge)
+		}
+		patterns = append(patterns, pattern)
+	}
+	if len(patterns) == 0 {
+		return edge, nil, fmt.Errorf("empty edge method list: %q", edge)
+	}
+	return strings.TrimSpace(edge[:idx] + tail), patterns, nil
+}
+
+// splitEdgeWeight separates an edge definition from its optional weight
+// annotation. Edges without an annotation have weight 1.
+func splitEdgeWeight(edge string) (string, int, error) {
+	edge, _, _ = splitEdgeMethods(edge)
+	edge, _, _, _ = splitEdgeCritical(edge)
+	idx := strings.LastIndex(edge, edgeWeightAnnotation)
+	if idx < 0 {
+		return edge, 1, nil
+	}
+	raw := strings.TrimSpace(edge[idx+len(edgeWeightAnnotation):])
+	weight, err := strconv.Atoi(raw)
+	if err != nil || weight < 1 {
+		return edge, 1, fmt.Errorf("invalid edge weight %q (must be a positive integer): %q", raw, edge)
+	}
+	return strings.TrimSpace(edge[:idx]), weight, nil
+}
+
 func splitEdgeNodeNames(edge string) []string {
-	edge = strings.TrimSpace(edge)
+	edge, _, _ = splitEdgeWeight(strings.TrimSpace(edge))
 	if edge == "" {
 		return nil
 	}
@@ -318,6 +653,57 @@ func edgeSeparator(edge string) string {
 	return ""
 }
 
+// edgeDashTypos are what editors with smart punctuation turn "---" into.
+// Outside strict_edges they are rewritten to "---" with a warning.
+var edgeDashTypos = []string{"—", "–"}
+
+// edgeSeparatorTypos are other separators mistaken for "---"; they are
+// always rejected. Longer forms come first so "-->" wins over "--".
+var edgeSeparatorTypos = []string{"-->", "<->", "->", "=>", "--"}
+
+// misplacedEdgeSeparator returns the first look-alike separator in an edge
+// that lacks "---", with its 1-based column, or "" when there is none.
+func misplacedEdgeSeparator(edge string) (string, int) {
+	if edgeSeparator(edge) != "" {
+		return "", 0
+	}
+	found, foundAt := "", -1
+	for _, typo := range append(append([]string{}, edgeDashTypos...), edgeSeparatorTypos...) {
+		if idx := strings.Index(edge, typo); idx >= 0 && (foundAt < 0 || idx < foundAt) {
+			found, foundAt = typo, idx
+		}
+	}
+	if foundAt < 0 {
+		return "", 0
+	}
+	return found, utf8.RuneCountInString(edge[:foundAt]) + 1
+}
+
+// normalizeEdgeSeparators rewrites em/en-dash edges to "---" with a
+// warning, or rejects them under strict_edges. Other look-alike separators
+// are errors naming the edge and column.
+func (cfg *Config) normalizeEdgeSeparators() error {
+	if cfg == nil {
+		return nil
+	}
+	for i, edge := range cfg.Edges {
+		typo, column := misplacedEdgeSeparator(edge)
+		if typo == "" {
+			continue
+		}
+		if BoolVal(cfg.StrictEdges, false) || !slices.Contains(edgeDashTypos, typo) {
+			return fmt.Errorf("invalid edge separator %q at column %d (use '---'): %q", typo, column, edge)
+		}
+		normalized := edge
+		for _, dash := range edgeDashTypos {
+			normalized = strings.ReplaceAll(normalized, dash, "---")
+		}
+		log.Printf("warning: edge %q: replaced %q at column %d with '---'; set strict_edges = true to reject", edge, typo, column)
+		cfg.Edges[i] = normalized
+	}
+	return nil
+}
+
 func (cfg *Config) OrderedNodeNames() []string {
 	if cfg == nil {
 		return nil
@@ -350,6 +736,135 @@ func (cfg *Config) HasExplicitUINodeSetting() bool {
 	return cfg.uiNodeSet
 }
 
+// Activity detectors for activity_detector.
+const (
+	ActivityDetectorCapture   = "capture"
+	ActivityDetectorHeartbeat = "heartbeat"
+	ActivityDetectorSignal    = "signal"
+)
+
+// Post retention modes for delivered post/ files.
+const (
+	PostRetentionMove = "move"
+	PostRetentionCopy = "copy"
+)
+
+// KeepsPostArchive reports whether delivery leaves a copy in post/archive/.
+func (cfg *Config) KeepsPostArchive() bool {
+	return cfg != nil && cfg.PostRetention == PostRetentionCopy
+}
+
+// Dead pane policies for mail addressed to a node whose pane disappeared.
+const (
+	DeadPanePolicyDeliver    = "deliver"
+	DeadPanePolicyHold       = "hold"
+	DeadPanePolicyDeadLetter = "dead_letter"
+)
+
+// EffectiveDeadPanePolicy returns dead_pane_policy, defaulting to "deliver".
+func (cfg *Config) EffectiveDeadPanePolicy() string {
+	if cfg == nil || cfg.DeadPanePolicy == "" {
+		return DeadPanePolicyDeliver
+	}
+	return cfg.DeadPanePolicy
+}
+
+// Edge violation modes for mail sent along a missing edge.
+const (
+	EdgeViolationModeBlock    = "block"
+	EdgeViolationModeWarnOnly = "warn_only"
+	EdgeViolationModeSilent   = "silent"
+)
+
+// EffectiveEdgeViolationMode returns edge_violation_mode, defaulting to "block".
+func (cfg *Config) EffectiveEdgeViolationMode() string {
+	if cfg == nil || cfg.EdgeViolationMode == "" {
+		return EdgeViolationModeBlock
+	}
+	return cfg.EdgeViolationMode
+}
+
+// Empty body policies for mail with nothing after its frontmatter.
+const (
+	EmptyBodyPolicyDeliver    = "deliver"
+	EmptyBodyPolicyDeadLetter = "dead_letter"
+	EmptyBodyPolicyWarnSender = "warn_sender"
+)
+
+// EffectiveEmptyBodyPolicy returns empty_body_policy, defaulting to "deliver".
+func (cfg *Config) EffectiveEmptyBodyPolicy() string {
+	if cfg == nil || cfg.EmptyBodyPolicy == "" {
+		return EmptyBodyPolicyDeliver
+	}
+	return cfg.EmptyBodyPolicy
+}
+
+// Policies for files in post/ that are not .md.
+const (
+	NonMDPostPolicyIgnore     = "ignore"
+	NonMDPostPolicyWarn       = "warn"
+	NonMDPostPolicyDeadLetter = "dead_letter"
+)
+
+// EffectiveNonMDPostPolicy returns non_md_post_policy, defaulting to "ignore".
+func (cfg *Config) EffectiveNonMDPostPolicy() string {
+	if cfg == nil || cfg.NonMDPostPolicy == "" {
+		return NonMDPostPolicyIgnore
+	}
+	return cfg.NonMDPostPolicy
+}
+
+// TUIIndicatorStates are the keys accepted by tui_symbols and tui_colors.
+var TUIIndicatorStates = []string{"ready", "waiting", "pending", "stale", "inactive"}
+
+// TUISessionGroupLabels maps each session named in tui_groups to its group
+// label. A session listed under two labels stays with the first in sort
+// order.
+func (cfg *Config) TUISessionGroupLabels() map[string]string {
+	if cfg == nil || len(cfg.TUIGroups) == 0 {
+		return nil
+	}
+	labels := make(map[string]string)
+	for _, label := range slices.Sorted(maps.Keys(cfg.TUIGroups)) {
+		for _, session := range cfg.TUIGroups[label] {
+			if _, ok := labels[session]; !ok {
+				labels[session] = label
+			}
+		}
+	}
+	return labels
+}
+
+// DefaultFilenameTimestampFormat is the layout postman itself writes.
+const DefaultFilenameTimestampFormat = "20060102-150405"
+
+// EffectiveFilenameTimestampFormat returns filename_timestamp_format, or the
+// default layout when unset.
+func (cfg *Config) EffectiveFilenameTimestampFormat() string {
+	if cfg == nil || cfg.FilenameTimestampFormat == "" {
+		return DefaultFilenameTimestampFormat
+	}
+	return cfg.FilenameTimestampFormat
+}
+
+// EffectiveSessionDirCreateAttempts returns session_dir_create_attempts, or
+// the default when unset or below 1.
+func (cfg *Config) EffectiveSessionDirCreateAttempts() int {
+	if cfg == nil || cfg.SessionDirCreateAttempts < 1 {
+		return DefaultSessionDirCreateAttempts
+	}
+	return cfg.SessionDirCreateAttempts
+}
+
+// EffectiveSessionDirCreateRetrySeconds returns
+// session_dir_create_retry_seconds, or the default when unset or negative.
+func (cfg *Config) EffectiveSessionDirCreateRetrySeconds() float64 {
+	if cfg == nil || cfg.SessionDirCreateRetrySeconds <= 0 {
+		return DefaultSessionDirCreateRetrySeconds
+	}
+	return cfg.SessionDirCreateRetrySeconds
+}
+
 func (cfg *Config) EffectiveVerdictGraceSeconds(fallback int) int {
 	if cfg == nil {
 		return fallback
@@ -427,6 +942,7 @@ func loadEmbeddedConfig() (*Config, error) {
 		}
 		cfg.verdictGraceSecondsSet = tomlHasField(md, "postman", "verdict_grace_seconds")
 		cfg.verdictDebtCapSet = tomlHasField(md, "postman", "verdict_debt_cap")
+		cfg.serializePerNodeTimeoutSet = tomlHasField(md, "postman", "serialize_per_node_timeout_seconds")
 	}
 
 	// Decode [nodename] sections (everything except reserved sections)
@@ -566,6 +1082,48 @@ func mergeConfig(base, override *Config) {
 	if override.BaseDir != "" {
 		base.BaseDir = override.BaseDir
 	}
+	if override.DraftDir != "" {
+		base.DraftDir = override.DraftDir
+	}
+	if override.FilenameTimestampFormat != "" {
+		base.FilenameTimestampFormat = override.FilenameTimestampFormat
+	}
+	if override.ActivityDetector != "" {
+		base.ActivityDetector = override.ActivityDetector
+	}
+	if override.ActivitySignalCommand != "" {
+		base.ActivitySignalCommand = override.ActivitySignalCommand
+	}
+	if override.PostRetention != "" {
+		base.PostRetention = override.PostRetention
+	}
+	if override.NodeIdentity != "" {
+		base.NodeIdentity = override.NodeIdentity
+	}
+	if override.CatchAllNode != "" {
+		base.CatchAllNode = override.CatchAllNode
+	}
+	if override.DeadPanePolicy != "" {
+		base.DeadPanePolicy = override.DeadPanePolicy
+	}
+	if override.SecretsFile != "" {
+		base.SecretsFile = override.SecretsFile
+	}
+	if override.NonMDPostPolicy != "" {
+		base.NonMDPostPolicy = override.NonMDPostPolicy
+	}
+	if override.EmptyBodyPolicy != "" {
+		base.EmptyBodyPolicy = override.EmptyBodyPolicy
+	}
+	if override.EdgeViolationMode != "" {
+		base.EdgeViolationMode = override.EdgeViolationMode
+	}
+	if override.ContentFilterCommand != "" {
+		base.ContentFilterCommand = override.ContentFilterCommand
+	}
+	if override.ContentFilterTimeoutSeconds != 0 {
+		base.ContentFilterTimeoutSeconds = override.ContentFilterTimeoutSeconds
+	}
 	if override.NotificationTemplate != "" {
 		base.NotificationTemplate = override.NotificationTemplate
 	}
@@ -588,6 +1146,30 @@ func mergeConfig(base, override *Config) {
 			}
 		}
 	}
+	if len(override.TUISymbols) > 0 {
+		if base.TUISymbols == nil {
+			base.TUISymbols = make(map[string]string)
+		}
+		for state, symbol := range override.TUISymbols {
+			base.TUISymbols[state] = symbol
+		}
+	}
+	if len(override.TUIColors) > 0 {
+		if base.TUIColors == nil {
+			base.TUIColors = make(map[string]string)
+		}
+		for state, color := range override.TUIColors {
+			base.TUIColors[state] = color
+		}
+	}
+	if len(override.TUIGroups) > 0 {
+		if base.TUIGroups == nil {
+			base.TUIGroups = make(map[string][]string)
+		}
+		for label, sessions := range override.TUIGroups {
+			base.TUIGroups[label] = sessions
+		}
+	}
 	if len(override.CompactionSkillCatalogs) > 0 {
 		if base.CompactionSkillCatalogs == nil {
 			base.CompactionSkillCatalogs = make(map[string]string)
@@ -601,6 +1183,9 @@ func mergeConfig(base, override *Config) {
 	if override.EdgeViolationWarningTemplate != "" {
 		base.EdgeViolationWarningTemplate = override.EdgeViolationWarningTemplate
 	}
+	if override.DeadLetterFeedbackTemplate != "" {
+		base.DeadLetterFeedbackTemplate = override.DeadLetterFeedbackTemplate
+	}
 	if override.EdgeViolationWarningMode != "" {
 		base.EdgeViolationWarningMode = override.EdgeViolationWarningMode
 	}
@@ -650,6 +1235,12 @@ func mergeConfig(base, override *Config) {
 	if override.NodeStaleSeconds != 0 {
 		base.NodeStaleSeconds = override.NodeStaleSeconds
 	}
+	if override.MaxWatchedDirs != 0 {
+		base.MaxWatchedDirs = override.MaxWatchedDirs
+	}
+	if override.ActivityHysteresisSeconds != 0 {
+		base.ActivityHysteresisSeconds = override.ActivityHysteresisSeconds
+	}
 	if override.InputRequestStaleSeconds != 0 {
 		base.InputRequestStaleSeconds = override.InputRequestStaleSeconds
 	}
@@ -663,6 +1254,52 @@ func mergeConfig(base, override *Config) {
 	if override.MinDeliveryGapSeconds != 0 {
 		base.MinDeliveryGapSeconds = override.MinDeliveryGapSeconds
 	}
+	if override.CriticalEdgeSilenceSeconds != 0 {
+		base.CriticalEdgeSilenceSeconds = override.CriticalEdgeSilenceSeconds
+	}
+	if override.EmptySessionAlertSeconds != 0 {
+		base.EmptySessionAlertSeconds = override.EmptySessionAlertSeconds
+	}
+	if override.TUIUpdateCoalesceSeconds != 0 {
+		base.TUIUpdateCoalesceSeconds = override.TUIUpdateCoalesceSeconds
+	}
+	if override.AdaptiveScanIdleSeconds != 0 {
+		base.AdaptiveScanIdleSeconds = override.AdaptiveScanIdleSeconds
+	}
+	if override.AdaptiveScanMaxSeconds != 0 {
+		base.AdaptiveScanMaxSeconds = override.AdaptiveScanMaxSeconds
+	}
+	if override.NotificationFailureThreshold != 0 {
+		base.NotificationFailureThreshold = override.NotificationFailureThreshold
+	}
+	if override.NotificationCircuitCooldownSeconds != 0 {
+		base.NotificationCircuitCooldownSeconds = override.NotificationCircuitCooldownSeconds
+	}
+	if override.MaxMessagesPerMinute != 0 {
+		base.MaxMessagesPerMinute = override.MaxMessagesPerMinute
+	}
+	if override.MaxMessagesPerHour != 0 {
+		base.MaxMessagesPerHour = override.MaxMessagesPerHour
+	}
+	if override.MaxMessageBytes != 0 {
+		base.MaxMessageBytes = override.MaxMessageBytes
+	}
+	if override.SerializePerNodeTimeoutSeconds != 0 || override.serializePerNodeTimeoutSet {
+		base.SerializePerNodeTimeoutSeconds = override.SerializePerNodeTimeoutSeconds
+		base.serializePerNodeTimeoutSet = base.serializePerNodeTimeoutSet || override.serializePerNodeTimeoutSet
+	}
+	if override.MeshSummaryIntervalSeconds != 0 {
+		base.MeshSummaryIntervalSeconds = override.MeshSummaryIntervalSeconds
+	}
+	if override.ReadArchivalSeconds != 0 {
+		base.ReadArchivalSeconds = override.ReadArchivalSeconds
+	}
+	if override.ShutdownDrainTimeoutSeconds != 0 {
+		base.ShutdownDrainTimeoutSeconds = override.ShutdownDrainTimeoutSeconds
+	}
+	if override.MaxClockSkewSeconds != 0 {
+		base.MaxClockSkewSeconds = override.MaxClockSkewSeconds
+	}
 	if override.StartupDrainWindowSeconds != 0 {
 		base.StartupDrainWindowSeconds = override.StartupDrainWindowSeconds
 	}
@@ -683,6 +1320,9 @@ func mergeConfig(base, override *Config) {
 	if override.PaneCaptureTailLines != 0 {
 		base.PaneCaptureTailLines = override.PaneCaptureTailLines
 	}
+	if override.TemplateShellMaxConcurrent != 0 {
+		base.TemplateShellMaxConcurrent = override.TemplateShellMaxConcurrent
+	}
 	if override.RetentionPeriodDays != 0 {
 		base.RetentionPeriodDays = override.RetentionPeriodDays
 	}
@@ -696,6 +1336,12 @@ func mergeConfig(base, override *Config) {
 	if override.DaemonSubmitWorkerLimit != 0 {
 		base.DaemonSubmitWorkerLimit = override.DaemonSubmitWorkerLimit
 	}
+	if override.SessionDirCreateAttempts != 0 {
+		base.SessionDirCreateAttempts = override.SessionDirCreateAttempts
+	}
+	if override.SessionDirCreateRetrySeconds != 0 {
+		base.SessionDirCreateRetrySeconds = override.SessionDirCreateRetrySeconds
+	}
 	if len(override.WorkspaceTree) > 0 {
 		base.WorkspaceTree = override.WorkspaceTree
 	}
@@ -704,6 +1350,36 @@ func mergeConfig(base, override *Config) {
 	if override.PaneCaptureEnabled != nil {
 		base.PaneCaptureEnabled = override.PaneCaptureEnabled
 	}
+	if override.VerifySender != nil {
+		base.VerifySender = override.VerifySender
+	}
+	if override.DeadLetterFeedback != nil {
+		base.DeadLetterFeedback = override.DeadLetterFeedback
+	}
+	if override.SerializePerNode != nil {
+		base.SerializePerNode = override.SerializePerNode
+	}
+	if override.CompactionCountsAsActivity != nil {
+		base.CompactionCountsAsActivity = override.CompactionCountsAsActivity
+	}
+	if override.RenotifyOnRestart != nil {
+		base.RenotifyOnRestart = override.RenotifyOnRestart
+	}
+	if override.InferEdgesFromTalksTo != nil {
+		base.InferEdgesFromTalksTo = override.InferEdgesFromTalksTo
+	}
+	if override.StrictEdges != nil {
+		base.StrictEdges = override.StrictEdges
+	}
+	if override.NotificationIncludePath != nil {
+		base.NotificationIncludePath = override.NotificationIncludePath
+	}
+	if override.PaneCaptureDiffLog != nil {
+		base.PaneCaptureDiffLog = override.PaneCaptureDiffLog
+	}
+	if override.AdaptiveScan != nil {
+		base.AdaptiveScan = override.AdaptiveScan
+	}
 
 	// Edges: replace if override is non-empty
 	if len(override.Edges) > 0 {
@@ -711,6 +1387,21 @@ func mergeConfig(base, override *Config) {
 	}
 	base.recordNodeNames(override.NodeOrder...)
 
+	// Sessions: field-level merge per session
+	for name, overSession := range override.Sessions {
+		if base.Sessions == nil {
+			base.Sessions = make(map[string]SessionConfig)
+		}
+		baseSession := base.Sessions[name]
+		if overSession.BaseDir != "" {
+			baseSession.BaseDir = overSession.BaseDir
+		}
+		if overSession.MaxMessagesPerHour != 0 {
+			baseSession.MaxMessagesPerHour = overSession.MaxMessagesPerHour
+		}
+		base.Sessions[name] = baseSession
+	}
+
 	// Nodes: field-level merge per node
 	for name, overNode := range override.Nodes {
 		baseNode := base.Nodes[name]
@@ -726,16 +1417,70 @@ func mergeConfig(base, override *Config) {
 		if overNode.EnterDelay != 0 {
 			baseNode.EnterDelay = overNode.EnterDelay
 		}
+		if overNode.PreEnterDelay != 0 {
+			baseNode.PreEnterDelay = overNode.PreEnterDelay
+		}
+		if overNode.Transport != "" {
+			baseNode.Transport = overNode.Transport
+		}
+		if overNode.FifoPath != "" {
+			baseNode.FifoPath = overNode.FifoPath
+		}
+		if overNode.NotificationTemplate != "" {
+			baseNode.NotificationTemplate = overNode.NotificationTemplate
+		}
+		if overNode.PingTemplate != "" {
+			baseNode.PingTemplate = overNode.PingTemplate
+		}
+		if overNode.NotificationPrefix != "" {
+			baseNode.NotificationPrefix = overNode.NotificationPrefix
+		}
+		if overNode.NotificationSuffix != "" {
+			baseNode.NotificationSuffix = overNode.NotificationSuffix
+		}
+		if len(overNode.Aliases) > 0 {
+			baseNode.Aliases = overNode.Aliases
+		}
+		if len(overNode.TalksTo) > 0 {
+			baseNode.TalksTo = overNode.TalksTo
+		}
 		base.Nodes[name] = baseNode
 	}
 
 	// NodeDefaults: field-level merge
+	if override.NodeDefaults.Template != "" {
+		base.NodeDefaults.Template = override.NodeDefaults.Template
+	}
+	if override.NodeDefaults.Role != "" {
+		base.NodeDefaults.Role = override.NodeDefaults.Role
+	}
 	if override.NodeDefaults.EnterCount != 0 {
 		base.NodeDefaults.EnterCount = override.NodeDefaults.EnterCount
 	}
 	if override.NodeDefaults.EnterDelay != 0 {
 		base.NodeDefaults.EnterDelay = override.NodeDefaults.EnterDelay
 	}
+	if override.NodeDefaults.PreEnterDelay != 0 {
+		base.NodeDefaults.PreEnterDelay = override.NodeDefaults.PreEnterDelay
+	}
+	if override.NodeDefaults.Transport != "" {
+		base.NodeDefaults.Transport = override.NodeDefaults.Transport
+	}
+	if override.NodeDefaults.FifoPath != "" {
+		base.NodeDefaults.FifoPath = override.NodeDefaults.FifoPath
+	}
+	if override.NodeDefaults.NotificationTemplate != "" {
+		base.NodeDefaults.NotificationTemplate = override.NodeDefaults.NotificationTemplate
+	}
+	if override.NodeDefaults.PingTemplate != "" {
+		base.NodeDefaults.PingTemplate = override.NodeDefaults.PingTemplate
+	}
+	if override.NodeDefaults.NotificationPrefix != "" {
+		base.NodeDefaults.NotificationPrefix = override.NodeDefaults.NotificationPrefix
+	}
+	if override.NodeDefaults.NotificationSuffix != "" {
+		base.NodeDefaults.NotificationSuffix = override.NodeDefaults.NotificationSuffix
+	}
 }
 
 // LoadConfig loads configuration from a TOML file (Python format).
@@ -802,6 +1547,7 @@ func LoadConfig(path string) (*Config, error) {
 			cfg.uiNodeSet = tomlHasField(md, "postman", "ui_node")
 			cfg.verdictGraceSecondsSet = tomlHasField(md, "postman", "verdict_grace_seconds")
 			cfg.verdictDebtCapSet = tomlHasField(md, "postman", "verdict_debt_cap")
+			cfg.serializePerNodeTimeoutSet = tomlHasField(md, "postman", "serialize_per_node_timeout_seconds")
 			cfg.DeprecatedCommandApproverNodes = deprecatedCommandApproverNodes(postmanPrim, md)
 		}
 
@@ -825,6 +1571,14 @@ func LoadConfig(path string) (*Config, error) {
 			}
 		}
 
+		// Decode [session.<name>] sections if they exist
+		if sessionsPrim, ok := rootSections["session"]; ok {
+			if err := md.PrimitiveDecode(sessionsPrim, &cfg.Sessions); err != nil {
+				return nil, fmt.Errorf("decoding [session] sections: %w", err)
+			}
+		}
+		cfg.recordTOMLOrigins(md, configPath)
+
 		// Issue #50: Load node files from nodes/ directory
 		configDir := filepath.Dir(configPath)
 		nodesDir := filepath.Join(configDir, "nodes")
@@ -854,6 +1608,7 @@ func LoadConfig(path string) (*Config, error) {
 					cfg.Nodes[name] = node // override if exists in postman.toml
 					cfg.recordNodeNames(name)
 				}
+				cfg.recordTOMLOrigins(md2, nodeFile)
 			}
 		}
 	}
@@ -875,9 +1630,11 @@ func LoadConfig(path string) (*Config, error) {
 				node := cfg.Nodes[nodeName]
 				if nc.Template != "" {
 					node.Template = nc.Template
+					cfg.recordOrigin(nodeName+".template", mdFile)
 				}
 				if nc.Role != "" {
 					node.Role = nc.Role
+					cfg.recordOrigin(nodeName+".role", mdFile)
 				}
 				cfg.Nodes[nodeName] = node
 				cfg.recordNodeNames(nodeName)
@@ -886,14 +1643,37 @@ func LoadConfig(path string) (*Config, error) {
 	}
 	if xdgMarkdownPath != "" {
 		if mdCfg, err := loadMarkdownConfig(xdgMarkdownPath); err == nil {
+			before := cfg.flattenedSettings()
 			mergeConfig(cfg, mdCfg)
+			cfg.recordChangedOrigins(before, xdgMarkdownPath)
 		} else {
 			log.Printf("warning: skipping %s: %v", xdgMarkdownPath, err)
 		}
 	}
 
 	cfg.initDirectTemplateRootTrust()
+	if cfg.SecretsFile != "" {
+		secretsDir := xdgConfigDir
+		if configPath != "" {
+			secretsDir = filepath.Dir(configPath)
+		}
+		secretsPath, err := resolveSecretsPath(secretsDir, cfg.SecretsFile)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Secrets, err = LoadSecrets(secretsPath); err != nil {
+			return nil, err
+		}
+	}
+	cfg.shellLimiter = template.NewShellLimiter(cfg.TemplateShellMaxConcurrent)
 
+	if err := cfg.normalizeEdgeSeparators(); err != nil {
+		return nil, err
+	}
+	if err := cfg.expandEdgeWildcards(); err != nil {
+		return nil, err
+	}
+	cfg.appendTalksToEdges()
 	cfg.ensureNodesForEdges()
 
 	// Embedded defaults intentionally allow an empty topology. Preserve that
@@ -928,9 +1708,22 @@ func ResolveConfigPath() string {
 
 // ParseEdges parses edge definitions into an adjacency map.
 // Edge format: "A --- B --- C", which creates bidirectional edges A↔B, B↔C.
-// Returns error for invalid formats.
+// An optional trailing "@weight=N" applies to every hop of the edge; each
+// node's neighbors are ordered by descending weight, then first-seen order.
+// An optional "@methods=a/b,c/*" limits the message methods the edge carries
+// (see ParseEdgeMethods). Returns error for invalid formats.
 func ParseEdges(edges []string) (map[string][]string, error) {
 	result := make(map[string][]string)
+	weights, err := ParseEdgeWeights(edges)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := ParseEdgeMethods(edges); err != nil {
+		return nil, err
+	}
+	if _, err := ParseCriticalEdges(edges); err != nil {
+		return nil, err
+	}
 
 	for _, edge := range edges {
 		edge = strings.TrimSpace(edge)
@@ -941,6 +1734,9 @@ func ParseEdges(edges []string) (map[string][]string, error) {
 		nodes := splitEdgeNodeNames(edge)
 		if len(nodes) < 2 {
 			if edgeSeparator(edge) == "" {
+				if typo, column := misplacedEdgeSeparator(edge); typo != "" {
+					return nil, fmt.Errorf("invalid edge format (found %q at column %d, want '---'): %q", typo, column, edge)
+				}
 				return nil, fmt.Errorf("invalid edge format (missing '---'): %q", edge)
 			}
 			return nil, fmt.Errorf("invalid edge format (need at least 2 nodes): %q", edge)
@@ -960,9 +1756,136 @@ func ParseEdges(edges []string) (map[string][]string, error) {
 		}
 	}
 
+	for node, neighbors := range result {
+		sort.SliceStable(neighbors, func(i, j int) bool {
+			return weights[node][neighbors[i]] > weights[node][neighbors[j]]
+		})
+	}
+
+	return result, nil
+}
+
+// ParseEdgeWeights returns the weight of every edge hop keyed by both
+// endpoints. Hops without a "@weight=N" annotation have weight 1; when the
+// same hop appears more than once, the last definition wins.
+func ParseEdgeWeights(edges []string) (map[string]map[string]int, error) {
+	result := make(map[string]map[string]int)
+	set := func(from, to string, weight int) {
+		if result[from] == nil {
+			result[from] = make(map[string]int)
+		}
+		result[from][to] = weight
+	}
+	for _, edge := range edges {
+		edge = strings.TrimSpace(edge)
+		if edge == "" {
+			continue
+		}
+		_, weight, err := splitEdgeWeight(edge)
+		if err != nil {
+			return nil, err
+		}
+		nodes := splitEdgeNodeNames(edge)
+		for i := 0; i < len(nodes)-1; i++ {
+			set(nodes[i], nodes[i+1], weight)
+			set(nodes[i+1], nodes[i], weight)
+		}
+	}
+	return result, nil
+}
+
+// ParseEdgeMethods returns the method patterns of every restricted edge hop
+// keyed by both endpoints. Hops without a "@methods=" annotation are absent
+// and permit every method; when the same hop appears more than once, the
+// last definition wins.
+func ParseEdgeMethods(edges []string) (map[string]map[string][]string, error) {
+	result := make(map[string]map[string][]string)
+	set := func(from, to string, patterns []string) {
+		if patterns == nil {
+			delete(result[from], to)
+			return
+		}
+		if result[from] == nil {
+			result[from] = make(map[string][]string)
+		}
+		result[from][to] = patterns
+	}
+	for _, edge := range edges {
+		edge = strings.TrimSpace(edge)
+		if edge == "" {
+			continue
+		}
+		_, patterns, err := splitEdgeMethods(edge)
+		if err != nil {
+			return nil, err
+		}
+		nodes := splitEdgeNodeNames(edge)
+		for i := 0; i < len(nodes)-1; i++ {
+			set(nodes[i], nodes[i+1], patterns)
+			set(nodes[i+1], nodes[i], patterns)
+		}
+	}
 	return result, nil
 }
 
+// CriticalEdge is one hop marked @critical. SilenceSeconds is the edge's own
+// window, or 0 to use critical_edge_silence_seconds.
+type CriticalEdge struct {
+	A, B           string
+	SilenceSeconds int
+}
+
+// ParseCriticalEdges returns every hop of the edges marked @critical, once
+// per node pair in definition order; a later definition of the same pair
+// replaces its window.
+func ParseCriticalEdges(edges []string) ([]CriticalEdge, error) {
+	var result []CriticalEdge
+	index := make(map[string]int)
+	for _, edge := range edges {
+		edge = strings.TrimSpace(edge)
+		if edge == "" {
+			continue
+		}
+		_, critical, silenceSeconds, err := splitEdgeCritical(edge)
+		if err != nil {
+			return nil, err
+		}
+		if !critical {
+			continue
+		}
+		nodes := splitEdgeNodeNames(edge)
+		for i := 0; i < len(nodes)-1; i++ {
+			a, b := nodes[i], nodes[i+1]
+			if b < a {
+				a, b = b, a
+			}
+			key := a + "\x00" + b
+			if existing, ok := index[key]; ok {
+				result[existing].SilenceSeconds = silenceSeconds
+				continue
+			}
+			index[key] = len(result)
+			result = append(result, CriticalEdge{A: a, B: b, SilenceSeconds: silenceSeconds})
+		}
+	}
+	return result, nil
+}
+
+// EdgePermitsMethod reports whether the from→to hop carries method.
+// Unrestricted hops permit everything.
+func EdgePermitsMethod(methods map[string]map[string][]string, from, to, method string) bool {
+	patterns, ok := methods[from][to]
+	if !ok {
+		return true
+	}
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, method); matched {
+			return true
+		}
+	}
+	return false
+}
+
 // GetEdgeNodeNames extracts all unique node names from edge definitions.
 func GetEdgeNodeNames(edges []string) map[string]bool {
 	adjacency, err := ParseEdges(edges)
@@ -1016,6 +1939,19 @@ func ResolveBaseDir(configBaseDir string) string {
 	return filepath.Join(stateHome, "tmux-a2a-postman")
 }
 
+// ResolveDraftDir returns the draft staging directory for sessionDir.
+// An empty draft_dir keeps the per-session draft/ directory; a relative
+// draft_dir is resolved against sessionDir.
+func (cfg *Config) ResolveDraftDir(sessionDir string) string {
+	if cfg == nil || cfg.DraftDir == "" {
+		return filepath.Join(sessionDir, "draft")
+	}
+	if filepath.IsAbs(cfg.DraftDir) {
+		return filepath.Clean(cfg.DraftDir)
+	}
+	return filepath.Join(sessionDir, cfg.DraftDir)
+}
+
 // CreateSessionDirs creates the session directory structure.
 // Creates: sessionDir/{inbox,post,draft,read,dead-letter}
 func CreateSessionDirs(sessionDir string) error {
@@ -1037,6 +1973,7 @@ func CreateSessionDirs(sessionDir string) error {
 // CreateMultiSessionDirs creates the multi-session directory structure.
 // For multi-session support: contextDir = baseDir/contextID, sessionName = tmux session name
 // Creates: contextDir/sessionName/{inbox,post,draft,read,dead-letter}
+// Use (*Config).CreateMultiSessionDirs to honor [session.<name>] base_dir.
 func CreateMultiSessionDirs(contextDir, sessionName string) error {
 	sessionDir := filepath.Join(contextDir, sessionName)
 	return CreateSessionDirs(sessionDir)
@@ -1190,7 +2127,10 @@ func FindCurrentUserDaemon(baseDir string) (string, string, bool) {
 	return "", "", false
 }
 
-func enabledSessionOwner(baseDir, sessionName string) string {
+// SessionEnabledMarkerContext returns the context named by sessionName's
+// @a2a_session_on_ marker, or "" when the session is not marked enabled. It
+// does not check that the context's daemon is still alive.
+func SessionEnabledMarkerContext(sessionName string) string {
 	if sessionName == "" {
 		return ""
 	}
@@ -1198,12 +2138,12 @@ func enabledSessionOwner(baseDir, sessionName string) string {
 	if err != nil {
 		return ""
 	}
-	value := strings.TrimSpace(string(out))
-	if value == "" {
-		return ""
-	}
-	owner, _, _ := strings.Cut(value, ":")
-	owner = strings.TrimSpace(owner)
+	owner, _, _ := strings.Cut(strings.TrimSpace(string(out)), ":")
+	return strings.TrimSpace(owner)
+}
+
+func enabledSessionOwner(baseDir, sessionName string) string {
+	owner := SessionEnabledMarkerContext(sessionName)
 	if owner == "" {
 		return ""
 	}
@@ -1367,13 +2307,14 @@ func GetTmuxPaneID() string {
 	return strings.TrimSpace(string(output))
 }
 
-// GetTmuxPaneName returns the current tmux pane title.
+// GetTmuxPaneName returns the current pane's node name, expanding format
+// (see (*Config).PaneNameFormat).
 // Uses TMUX_PANE env var to target the originating pane, not the currently focused pane.
 // Fails closed (returns empty) if TMUX_PANE is set but targeted lookup fails.
-func GetTmuxPaneName() string {
+func GetTmuxPaneName(format string) string {
 	paneID := os.Getenv("TMUX_PANE")
 	if paneID != "" {
-		cmd := exec.Command("tmux", "display-message", "-t", paneID, "-p", "#{pane_title}")
+		cmd := exec.Command("tmux", "display-message", "-t", paneID, "-p", format)
 		output, err := cmd.Output()
 		if err != nil {
 			return "" // fail closed
@@ -1381,7 +2322,7 @@ func GetTmuxPaneName() string {
 		return strings.TrimSpace(string(output))
 	}
 	// TMUX_PANE absent: untargeted fallback (existing behavior)
-	cmd := exec.Command("tmux", "display-message", "-p", "#{pane_title}")
+	cmd := exec.Command("tmux", "display-message", "-p", format)
 	output, err := cmd.Output()
 	if err != nil {
 		return ""
@@ -1391,8 +2332,14 @@ func GetTmuxPaneName() string {
 
 // GetNodeConfig returns the effective NodeConfig for the given node name,
 // applying NodeDefaults as base with node-specific config merged on top.
+// cfg.Nodes holds only what each node section set, so readers of node
+// fields go through here to see [node_defaults].
 func (cfg *Config) GetNodeConfig(name string) NodeConfig {
 	result := cfg.NodeDefaults
+	// Aliases and talks_to describe one node, so [node_defaults] never
+	// hands them out.
+	result.Aliases = nil
+	result.TalksTo = nil
 	specific, ok := cfg.Nodes[name]
 	if !ok {
 		return result
@@ -1409,5 +2356,32 @@ func (cfg *Config) GetNodeConfig(name string) NodeConfig {
 	if specific.EnterDelay != 0 {
 		result.EnterDelay = specific.EnterDelay
 	}
+	if specific.PreEnterDelay != 0 {
+		result.PreEnterDelay = specific.PreEnterDelay
+	}
+	if specific.Transport != "" {
+		result.Transport = specific.Transport
+	}
+	if specific.FifoPath != "" {
+		result.FifoPath = specific.FifoPath
+	}
+	if specific.NotificationTemplate != "" {
+		result.NotificationTemplate = specific.NotificationTemplate
+	}
+	if specific.PingTemplate != "" {
+		result.PingTemplate = specific.PingTemplate
+	}
+	if specific.NotificationPrefix != "" {
+		result.NotificationPrefix = specific.NotificationPrefix
+	}
+	if specific.NotificationSuffix != "" {
+		result.NotificationSuffix = specific.NotificationSuffix
+	}
+	if len(specific.Aliases) > 0 {
+		result.Aliases = specific.Aliases
+	}
+	if len(specific.TalksTo) > 0 {
+		result.TalksTo = specific.TalksTo
+	}
 	return result
 }
@@ -235,6 +235,230 @@ edges = [
 	}
 }
 
+func TestLoadConfig_TemplateShellLimiterPerConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("XDG_CONFIG_HOME", tmpDir)
+	configPath := filepath.Join(tmpDir, "postman.toml")
+	if err := os.WriteFile(configPath, []byte("[postman]\nedges = [\"orchestrator --- worker\"]\ntemplate_shell_max_concurrent = 0\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile config: %v", err)
+	}
+	unlimited, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+
+	if err := os.WriteFile(configPath, []byte("[postman]\nedges = [\"orchestrator --- worker\"]\ntemplate_shell_max_concurrent = 2\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile config: %v", err)
+	}
+	capped, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+
+	if capped.TemplateOptions().Shell == nil {
+		t.Fatal("capped config has no shell limiter")
+	}
+	if capped.TemplateOptions().Shell != capped.TemplateOptions().Shell {
+		t.Fatal("expansions under one config do not share a shell limiter")
+	}
+	// Loading the capped config leaves the first one unlimited.
+	if unlimited.TemplateOptions().Shell != nil {
+		t.Fatal("template_shell_max_concurrent = 0 config picked up a shell limiter")
+	}
+}
+
+func TestLoadConfig_SecretsFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("XDG_CONFIG_HOME", tmpDir)
+	configPath := filepath.Join(tmpDir, "postman.toml")
+	secretsPath := filepath.Join(tmpDir, "secrets.env")
+
+	content := `
+[postman]
+secrets_file = "secrets.env"
+edges = ["orchestrator --- worker"]
+`
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile config: %v", err)
+	}
+	if err := os.WriteFile(secretsPath, []byte("# deploy creds\nexport API_TOKEN=\"s3cret\"\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile secrets: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if got := cfg.Secrets["API_TOKEN"]; got != "s3cret" {
+		t.Fatalf("Secrets[API_TOKEN] = %q, want %q", got, "s3cret")
+	}
+	if got := template.ExpandTemplate("token={secret:API_TOKEN} missing={secret:NOPE}", nil, time.Second, false, cfg.TemplateOptions()); got != "token=s3cret missing={secret:NOPE}" {
+		t.Fatalf("ExpandTemplate = %q", got)
+	}
+
+	if err := os.Chmod(secretsPath, 0o644); err != nil {
+		t.Fatalf("Chmod: %v", err)
+	}
+	_, err = LoadConfig(configPath)
+	if err == nil || !strings.Contains(err.Error(), "world-readable") {
+		t.Fatalf("LoadConfig with world-readable secrets error = %v, want world-readable refusal", err)
+	}
+	if strings.Contains(err.Error(), "s3cret") {
+		t.Fatalf("error leaks the secret value: %v", err)
+	}
+}
+
+func TestLoadConfig_InferEdgesFromTalksTo(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("XDG_CONFIG_HOME", tmpDir)
+	configPath := filepath.Join(tmpDir, "postman.toml")
+
+	content := `
+[postman]
+edges = ["orchestrator --- worker"]
+infer_edges_from_talks_to = true
+
+[orchestrator]
+role = "lead"
+talks_to = ["worker", "critic"]
+
+[worker]
+role = "builder"
+talks_to = ["orchestrator", "critic"]
+
+[critic]
+role = "reviewer"
+talks_to = ["worker"]
+`
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile config: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	// The explicit edge is kept; talks_to adds only the hops it lacks, once
+	// per pair.
+	wantEdges := []string{"orchestrator --- worker", "orchestrator --- critic", "worker --- critic"}
+	if !reflect.DeepEqual(cfg.Edges, wantEdges) {
+		t.Fatalf("Edges = %q, want %q", cfg.Edges, wantEdges)
+	}
+	adjacency, err := ParseEdges(cfg.Edges)
+	if err != nil {
+		t.Fatalf("ParseEdges: %v", err)
+	}
+	wantAdjacency := map[string][]string{
+		"orchestrator": {"worker", "critic"},
+		"worker":       {"orchestrator", "critic"},
+		"critic":       {"orchestrator", "worker"},
+	}
+	if !reflect.DeepEqual(adjacency, wantAdjacency) {
+		t.Fatalf("adjacency = %v, want %v", adjacency, wantAdjacency)
+	}
+
+	// critic lists its peers without orchestrator, which lists critic.
+	var warnings []string
+	for _, ve := range ValidateConfig(cfg) {
+		if strings.HasSuffix(ve.Field, ".talks_to") {
+			warnings = append(warnings, ve.Field+": "+ve.Message)
+		}
+	}
+	want := []string{"orchestrator.talks_to: orchestrator lists critic, but critic.talks_to omits orchestrator; the inferred edge connects both ways"}
+	if !reflect.DeepEqual(warnings, want) {
+		t.Fatalf("talks_to warnings = %q, want %q", warnings, want)
+	}
+}
+
+func TestAppendTalksToEdges_OffLeavesEdgesAlone(t *testing.T) {
+	cfg := &Config{
+		Edges: []string{"a --- b"},
+		Nodes: map[string]NodeConfig{"a": {TalksTo: []string{"c"}}},
+	}
+	cfg.appendTalksToEdges()
+	if !reflect.DeepEqual(cfg.Edges, []string{"a --- b"}) {
+		t.Fatalf("Edges = %q, want the explicit edge only", cfg.Edges)
+	}
+	errs := validateNodeTalksTo(cfg)
+	if len(errs) != 1 || errs[0].Severity != "warning" || !strings.Contains(errs[0].Message, "infer_edges_from_talks_to") {
+		t.Fatalf("validateNodeTalksTo = %v, want one warning that talks_to is ignored", errs)
+	}
+}
+
+func TestLoadConfig_SessionBaseDir(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("XDG_CONFIG_HOME", tmpDir)
+	configPath := filepath.Join(tmpDir, "postman.toml")
+	baseDir := filepath.Join(tmpDir, "state")
+	fastDir := filepath.Join(tmpDir, "fast")
+
+	content := `
+[postman]
+edges = ["orchestrator --- worker"]
+
+[session.fast]
+base_dir = "` + fastDir + `"
+
+[session.vault]
+base_dir = "~/vault"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile config: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if _, ok := cfg.Nodes["session"]; ok {
+		t.Fatal("[session] sections were loaded as a node")
+	}
+	if got := cfg.Sessions["fast"].BaseDir; got != fastDir {
+		t.Fatalf("Sessions[fast].BaseDir = %q, want %q", got, fastDir)
+	}
+	if errs := ValidateConfig(cfg); len(errs) != 0 {
+		t.Fatalf("ValidateConfig = %v, want none", errs)
+	}
+
+	tests := []struct {
+		session string
+		want    string
+	}{
+		{session: "fast", want: filepath.Join(fastDir, "ctx", "fast")},
+		{session: "vault", want: filepath.Join(tmpDir, "vault", "ctx", "vault")},
+		{session: "main", want: filepath.Join(baseDir, "ctx", "main")},
+	}
+	for _, tt := range tests {
+		if got := cfg.SessionDir(baseDir, "ctx", tt.session); got != tt.want {
+			t.Errorf("SessionDir(%q) = %q, want %q", tt.session, got, tt.want)
+		}
+	}
+
+	if err := cfg.CreateMultiSessionDirs(filepath.Join(baseDir, "ctx"), "fast"); err != nil {
+		t.Fatalf("CreateMultiSessionDirs: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(fastDir, "ctx", "fast", "inbox")); err != nil {
+		t.Fatalf("overridden inbox not created: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(baseDir, "ctx", "fast", "inbox")); !os.IsNotExist(err) {
+		t.Fatalf("inbox created under the global base_dir: %v", err)
+	}
+
+	cfg.Sessions["bad"] = SessionConfig{BaseDir: "relative/dir"}
+	errs := ValidateConfig(cfg)
+	if len(errs) != 1 || errs[0].Field != "session.bad.base_dir" {
+		t.Fatalf("ValidateConfig = %v, want one session.bad.base_dir error", errs)
+	}
+}
+
 func TestLoadConfig_WorkspaceTree(t *testing.T) {
 	tmpDir := t.TempDir()
 	t.Chdir(tmpDir)
@@ -767,6 +991,28 @@ func TestParseEdges(t *testing.T) {
 			edges:   []string{"orchestrator"},
 			wantErr: true,
 		},
+		{
+			name: "weighted neighbors ordered first",
+			edges: []string{
+				"orchestrator --- worker-a",
+				"orchestrator --- worker-b @weight=3",
+			},
+			want: map[string][]string{
+				"orchestrator": {"worker-b", "worker-a"},
+				"worker-a":     {"orchestrator"},
+				"worker-b":     {"orchestrator"},
+			},
+		},
+		{
+			name:    "invalid weight rejected",
+			edges:   []string{"orchestrator --- worker @weight=0"},
+			wantErr: true,
+		},
+		{
+			name:    "non-numeric weight rejected",
+			edges:   []string{"orchestrator --- worker @weight=high"},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -797,6 +1043,162 @@ func TestParseEdges(t *testing.T) {
 	}
 }
 
+func TestParseEdgeWeights(t *testing.T) {
+	weights, err := ParseEdgeWeights([]string{
+		"orchestrator --- worker-a",
+		"messenger --- orchestrator --- worker-b @weight=3",
+	})
+	if err != nil {
+		t.Fatalf("ParseEdgeWeights() error = %v", err)
+	}
+	for _, tt := range []struct {
+		from, to string
+		want     int
+	}{
+		{"orchestrator", "worker-a", 1},
+		{"orchestrator", "worker-b", 3},
+		{"worker-b", "orchestrator", 3},
+		{"messenger", "orchestrator", 3},
+	} {
+		if got := weights[tt.from][tt.to]; got != tt.want {
+			t.Errorf("weights[%q][%q] = %d, want %d", tt.from, tt.to, got, tt.want)
+		}
+	}
+	if _, ok := weights["worker-a"]["worker-b"]; ok {
+		t.Errorf("weights[worker-a][worker-b] set for a hop that is not an edge")
+	}
+}
+
+func TestParseEdgeMethods(t *testing.T) {
+	edges := []string{
+		"orchestrator --- worker @methods=task/assign,review/*",
+		"orchestrator --- critic @weight=2",
+		"critic --- worker @methods=task/assign @weight=3",
+	}
+	if _, err := ParseEdges(edges); err != nil {
+		t.Fatalf("ParseEdges() error = %v", err)
+	}
+	methods, err := ParseEdgeMethods(edges)
+	if err != nil {
+		t.Fatalf("ParseEdgeMethods() error = %v", err)
+	}
+	for _, tt := range []struct {
+		from, to, method string
+		want             bool
+	}{
+		{"orchestrator", "worker", "task/assign", true},
+		{"worker", "orchestrator", "review/verdict", true},
+		{"orchestrator", "worker", "chat/message", false},
+		{"orchestrator", "critic", "chat/message", true},
+		{"critic", "worker", "task/assign", true},
+		{"critic", "worker", "message/send", false},
+	} {
+		if got := EdgePermitsMethod(methods, tt.from, tt.to, tt.method); got != tt.want {
+			t.Errorf("EdgePermitsMethod(%q, %q, %q) = %v, want %v", tt.from, tt.to, tt.method, got, tt.want)
+		}
+	}
+	weights, err := ParseEdgeWeights(edges)
+	if err != nil {
+		t.Fatalf("ParseEdgeWeights() error = %v", err)
+	}
+	if got := weights["critic"]["worker"]; got != 3 {
+		t.Errorf("weights[critic][worker] = %d, want 3 alongside @methods", got)
+	}
+
+	for _, edge := range []string{"a --- b @methods=", "a --- b @methods=[bad"} {
+		if _, err := ParseEdges([]string{edge}); err == nil {
+			t.Errorf("ParseEdges(%q) error = nil, want invalid method list", edge)
+		}
+	}
+}
+
+func TestParseCriticalEdges(t *testing.T) {
+	edges := []string{
+		"concierge --- orchestrator @critical",
+		"orchestrator --- worker @weight=2 @critical=600",
+		"orchestrator --- observer",
+	}
+	adjacency, err := ParseEdges(edges)
+	if err != nil {
+		t.Fatalf("ParseEdges() error = %v", err)
+	}
+	if got := adjacency["orchestrator"]; !reflect.DeepEqual(got, []string{"worker", "concierge", "observer"}) {
+		t.Errorf("adjacency[orchestrator] = %v, want annotations stripped and worker first by weight", got)
+	}
+	critical, err := ParseCriticalEdges(edges)
+	if err != nil {
+		t.Fatalf("ParseCriticalEdges() error = %v", err)
+	}
+	want := []CriticalEdge{
+		{A: "concierge", B: "orchestrator"},
+		{A: "orchestrator", B: "worker", SilenceSeconds: 600},
+	}
+	if !reflect.DeepEqual(critical, want) {
+		t.Errorf("ParseCriticalEdges() = %+v, want %+v", critical, want)
+	}
+
+	if _, err := ParseEdges([]string{"a --- b @critical=soon"}); err == nil {
+		t.Error("ParseEdges(@critical=soon) error = nil, want invalid window")
+	}
+}
+
+func TestCheckEdges(t *testing.T) {
+	report, err := CheckEdges([]string{
+		"orchestrator --- worker",
+		"worker --- worker",
+		"critic --- orchestrator --- critic",
+		"worker --- orchestrator @weight=2",
+	})
+	if err != nil {
+		t.Fatalf("CheckEdges() error = %v", err)
+	}
+	wantSelfLoops := []EdgeFinding{{From: "worker", To: "worker", Index: 1}}
+	if !reflect.DeepEqual(report.SelfLoops, wantSelfLoops) {
+		t.Errorf("SelfLoops = %+v, want %+v", report.SelfLoops, wantSelfLoops)
+	}
+	wantDuplicates := []EdgeFinding{
+		{From: "orchestrator", To: "critic", Index: 2, FirstIndex: 2},
+		{From: "worker", To: "orchestrator", Index: 3, FirstIndex: 0},
+	}
+	if !reflect.DeepEqual(report.Duplicates, wantDuplicates) {
+		t.Errorf("Duplicates = %+v, want %+v", report.Duplicates, wantDuplicates)
+	}
+	if len(report.Asymmetric) != 0 {
+		t.Errorf("Asymmetric = %+v, want none for undirected edges", report.Asymmetric)
+	}
+
+	oneWay := asymmetricHops(map[string][]string{
+		"boss":   {"worker", "critic"},
+		"worker": {"boss"},
+	})
+	wantOneWay := []EdgeFinding{{From: "boss", To: "critic", Index: -1}}
+	if !reflect.DeepEqual(oneWay, wantOneWay) {
+		t.Errorf("asymmetricHops() = %+v, want %+v", oneWay, wantOneWay)
+	}
+}
+
+func TestResolveDraftDir(t *testing.T) {
+	sessionDir := filepath.Join("/state", "ctx", "main")
+	absolute := filepath.Join(t.TempDir(), "shared-drafts")
+	tests := []struct {
+		name     string
+		draftDir string
+		want     string
+	}{
+		{name: "default", draftDir: "", want: filepath.Join(sessionDir, "draft")},
+		{name: "relative", draftDir: "scratch", want: filepath.Join(sessionDir, "scratch")},
+		{name: "absolute", draftDir: absolute, want: absolute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{DraftDir: tt.draftDir}
+			if got := cfg.ResolveDraftDir(sessionDir); got != tt.want {
+				t.Fatalf("ResolveDraftDir() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestConfig_Fallback(t *testing.T) {
 	tmpDir := t.TempDir()
 	xdgConfigHome := filepath.Join(tmpDir, "xdg-config")
@@ -1224,7 +1626,7 @@ func TestGetTmuxPaneName(t *testing.T) {
 		t.Setenv("PATH", tmpDir+":"+origPath)
 		t.Setenv("TMUX_PANE", "%42")
 
-		got := GetTmuxPaneName()
+		got := GetTmuxPaneName(DefaultConfig().PaneNameFormat())
 		if got != "test-pane-title" {
 			t.Errorf("GetTmuxPaneName() = %q, want %q", got, "test-pane-title")
 		}
@@ -1253,7 +1655,7 @@ func TestGetTmuxPaneName(t *testing.T) {
 		t.Setenv("PATH", tmpDir+":"+origPath)
 		t.Setenv("TMUX_PANE", "")
 
-		got := GetTmuxPaneName()
+		got := GetTmuxPaneName(DefaultConfig().PaneNameFormat())
 		if got != "test-pane-title" {
 			t.Errorf("GetTmuxPaneName() = %q, want %q", got, "test-pane-title")
 		}
@@ -1387,6 +1789,50 @@ func TestMergeConfig_VerdictDebtCapExplicitZeroOverride(t *testing.T) {
 	}
 }
 
+func TestMergeConfig_SerializePerNodeTimeoutExplicitZeroOverride(t *testing.T) {
+	base := DefaultConfig()
+	base.SerializePerNodeTimeoutSeconds = 300
+
+	mergeConfig(base, &Config{Nodes: make(map[string]NodeConfig)})
+	if base.SerializePerNodeTimeoutSeconds != 300 {
+		t.Fatalf("SerializePerNodeTimeoutSeconds: got %v, want 300 (unset override should not change base)", base.SerializePerNodeTimeoutSeconds)
+	}
+
+	mergeConfig(base, &Config{
+		Nodes:                      make(map[string]NodeConfig),
+		serializePerNodeTimeoutSet: true,
+	})
+	if base.SerializePerNodeTimeoutSeconds != 0 {
+		t.Errorf("SerializePerNodeTimeoutSeconds: got %v, want explicit zero override", base.SerializePerNodeTimeoutSeconds)
+	}
+}
+
+func TestMergeConfig_BoolOverrides(t *testing.T) {
+	base := DefaultConfig()
+	base.VerifySender = new(true)
+	base.StrictEdges = new(true)
+	base.AdaptiveScan = new(false)
+
+	override := &Config{
+		Nodes:        make(map[string]NodeConfig),
+		VerifySender: new(false),
+		AdaptiveScan: new(true),
+	}
+
+	mergeConfig(base, override)
+
+	if BoolVal(base.VerifySender, true) {
+		t.Error("VerifySender: got true, want explicit false override")
+	}
+	if !BoolVal(base.AdaptiveScan, false) {
+		t.Error("AdaptiveScan: got false, want true override")
+	}
+	// Unset override field should not change base
+	if !BoolVal(base.StrictEdges, false) {
+		t.Error("StrictEdges: got false, want true (unset override should not change base)")
+	}
+}
+
 func TestMergeConfig_NodeMerge(t *testing.T) {
 	base := DefaultConfig()
 	base.Nodes = map[string]NodeConfig{
@@ -2036,7 +2482,7 @@ role = "worker"
 		t.Fatal("AllowShellTemplates = false, want true from explicit trusted base")
 	}
 
-	got := template.ExpandTemplate(cfg.DraftTemplate, map[string]string{}, 5*time.Second, cfg.AllowShellForDraftTemplate())
+	got := template.ExpandTemplate(cfg.DraftTemplate, map[string]string{}, 5*time.Second, cfg.AllowShellForDraftTemplate(), cfg.TemplateOptions())
 	if got != "trusted explicit-config-base" {
 		t.Fatalf("explicit trusted base draft template = %q, want %q", got, "trusted explicit-config-base")
 	}
@@ -2089,3 +2535,204 @@ func TestWarnDeprecatedKeys(t *testing.T) {
 		}
 	})
 }
+
+func TestCanonicalNodeName(t *testing.T) {
+	cfg := &Config{Nodes: map[string]NodeConfig{
+		"orchestrator": {Aliases: []string{"orch", "lead"}},
+		"worker":       {},
+	}}
+	for in, want := range map[string]string{
+		"orch":         "orchestrator",
+		"lead":         "orchestrator",
+		"review:orch":  "review:orchestrator",
+		"orchestrator": "orchestrator",
+		"worker":       "worker",
+		"stranger":     "stranger",
+	} {
+		if got := cfg.CanonicalNodeName(in); got != want {
+			t.Errorf("CanonicalNodeName(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	cfg.Nodes["critic"] = NodeConfig{Aliases: []string{"lead", "worker"}}
+	var messages []string
+	for _, ve := range validateNodeAliases(cfg) {
+		messages = append(messages, ve.Error())
+	}
+	joined := strings.Join(messages, "\n")
+	if !strings.Contains(joined, `alias "lead" is already claimed by critic`) || !strings.Contains(joined, `alias "worker" is also a node name`) {
+		t.Fatalf("validateNodeAliases = %q, want duplicate and node-name conflicts", joined)
+	}
+}
+
+func TestGetNodeConfig_PreEnterDelayInheritsNodeDefaults(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.NodeDefaults.PreEnterDelay = 1.5
+	cfg.Nodes = map[string]NodeConfig{
+		"worker": {},
+		"slow":   {PreEnterDelay: 4},
+	}
+
+	if got := cfg.GetNodeConfig("worker").PreEnterDelay; got != 1.5 {
+		t.Errorf("worker PreEnterDelay = %v, want node_defaults 1.5", got)
+	}
+	if got := cfg.GetNodeConfig("slow").PreEnterDelay; got != 4 {
+		t.Errorf("slow PreEnterDelay = %v, want node override 4", got)
+	}
+}
+
+func TestLoadConfig_NodeDefaultsResolvedAtLookup(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("XDG_CONFIG_HOME", tmpDir)
+	configPath := filepath.Join(tmpDir, "postman.toml")
+
+	content := `
+[postman]
+edges = ["orchestrator --- worker --- reviewer"]
+
+[node_defaults]
+role = "agent"
+enter_count = 3
+transport = "tmux"
+
+[orchestrator]
+role = "coordinator"
+
+[worker]
+enter_count = 1
+`
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if got := cfg.GetNodeConfig("orchestrator"); got.Role != "coordinator" || got.EnterCount != 3 || got.Transport != "tmux" {
+		t.Errorf("orchestrator = %+v, want explicit role with inherited enter_count and transport", got)
+	}
+	if got := cfg.GetNodeConfig("worker"); got.Role != "agent" || got.EnterCount != 1 {
+		t.Errorf("worker = %+v, want inherited role and explicit enter_count 1", got)
+	}
+	if got := cfg.GetNodeConfig("reviewer"); got.Role != "agent" || got.EnterCount != 3 {
+		t.Errorf("reviewer (edge-only) = %+v, want all fields from node_defaults", got)
+	}
+
+	// The node sections keep only what they set, so a later change to
+	// [node_defaults] still reaches every node.
+	if got := cfg.Nodes["reviewer"]; got.Role != "" || got.EnterCount != 0 {
+		t.Errorf("cfg.Nodes[reviewer] = %+v, want node_defaults left out of the node section", got)
+	}
+	cfg.NodeDefaults.EnterCount = 2
+	if got := cfg.GetNodeConfig("reviewer").EnterCount; got != 2 {
+		t.Errorf("reviewer EnterCount after node_defaults change = %d, want 2", got)
+	}
+}
+
+func TestLoadConfig_WildcardEdgeConnectsHubToAllNodes(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("XDG_CONFIG_HOME", tmpDir)
+	configPath := filepath.Join(tmpDir, "postman.toml")
+
+	content := `
+[postman]
+edges = ["hub --- * @weight=2", "worker-a --- worker-b"]
+
+[critic]
+role = "reviewer"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if _, ok := cfg.Nodes["*"]; ok {
+		t.Fatalf("wildcard leaked into nodes: %v", cfg.OrderedNodeNames())
+	}
+	adjacency, err := ParseEdges(cfg.Edges)
+	if err != nil {
+		t.Fatalf("ParseEdges(%q): %v", cfg.Edges, err)
+	}
+	weights, err := ParseEdgeWeights(cfg.Edges)
+	if err != nil {
+		t.Fatalf("ParseEdgeWeights: %v", err)
+	}
+	for _, node := range []string{"critic", "worker-a", "worker-b"} {
+		if !containsString(adjacency["hub"], node) || !containsString(adjacency[node], "hub") {
+			t.Errorf("hub <-> %s not routable: adjacency = %v", node, adjacency)
+		}
+		if weights["hub"][node] != 2 {
+			t.Errorf("weight hub -> %s = %d, want 2 carried from the wildcard edge", node, weights["hub"][node])
+		}
+	}
+	if len(adjacency["hub"]) != 3 {
+		t.Errorf("adjacency[hub] = %v, want exactly the three known nodes", adjacency["hub"])
+	}
+	if containsString(adjacency["worker-a"], "critic") {
+		t.Errorf("wildcard connected non-hub nodes: %v", adjacency["worker-a"])
+	}
+}
+
+func TestLoadConfig_WildcardEdgeRejectsChains(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("XDG_CONFIG_HOME", tmpDir)
+	configPath := filepath.Join(tmpDir, "postman.toml")
+	content := "[postman]\nedges = [\"a --- * --- b\"]\n"
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if _, err := LoadConfig(configPath); err == nil || !strings.Contains(err.Error(), "wildcard") {
+		t.Fatalf("LoadConfig() error = %v, want wildcard edge error", err)
+	}
+}
+
+func TestLoadConfig_EmDashEdgeNormalizedUnlessStrict(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("XDG_CONFIG_HOME", tmpDir)
+	configPath := filepath.Join(tmpDir, "postman.toml")
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	originalOutput := log.Writer()
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(originalOutput) })
+
+	write("[postman]\nedges = [\"boss — worker\"]\n")
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig() lenient: %v", err)
+	}
+	if len(cfg.Edges) != 1 || cfg.Edges[0] != "boss --- worker" {
+		t.Fatalf("Edges = %q, want [\"boss --- worker\"]", cfg.Edges)
+	}
+	if !strings.Contains(buf.String(), "column 6") {
+		t.Fatalf("warning missing separator column: %q", buf.String())
+	}
+
+	write("[postman]\nstrict_edges = true\nedges = [\"boss — worker\"]\n")
+	if _, err := LoadConfig(configPath); err == nil || !strings.Contains(err.Error(), "at column 6") || !strings.Contains(err.Error(), "boss — worker") {
+		t.Fatalf("LoadConfig() strict error = %v, want separator error naming edge and column", err)
+	}
+
+	write("[postman]\nedges = [\"boss -> worker\"]\n")
+	if _, err := LoadConfig(configPath); err == nil || !strings.Contains(err.Error(), `"->" at column 6`) {
+		t.Fatalf("LoadConfig() arrow error = %v, want rejected separator", err)
+	}
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+)
+
+// EffectiveSections returns cfg in the same section layout postman.toml uses:
+// a [postman] table with every global setting (defaults included), a
+// [node_defaults] table, and one table per configured node. Settings that are
+// not TOML-configurable (Mermaid designations, rendered skill catalogs) are
+// omitted so the result can be pasted back into postman.toml.
+func EffectiveSections(cfg *Config) (map[string]interface{}, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("config is nil")
+	}
+	postman, err := tomlTable(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("encoding [postman] section: %w", err)
+	}
+	nodeDefaults, err := tomlTable(cfg.NodeDefaults)
+	if err != nil {
+		return nil, fmt.Errorf("encoding [node_defaults] section: %w", err)
+	}
+	sections := map[string]interface{}{
+		"postman":       postman,
+		"node_defaults": nodeDefaults,
+	}
+	if len(cfg.Sessions) > 0 {
+		sessions := make(map[string]interface{}, len(cfg.Sessions))
+		for name, session := range cfg.Sessions {
+			table, err := tomlTable(session)
+			if err != nil {
+				return nil, fmt.Errorf("encoding [session.%s] section: %w", name, err)
+			}
+			sessions[name] = table
+		}
+		sections["session"] = sessions
+	}
+	for _, name := range cfg.OrderedNodeNames() {
+		if isReservedNodeSection(name) {
+			continue
+		}
+		node, err := tomlTable(cfg.GetNodeConfig(name))
+		if err != nil {
+			return nil, fmt.Errorf("encoding [%s] section: %w", name, err)
+		}
+		sections[name] = node
+	}
+	return sections, nil
+}
+
+// tomlTable round-trips v through the TOML encoder so the result is keyed by
+// toml tags rather than Go field names.
+func tomlTable(v interface{}) (map[string]interface{}, error) {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	table := make(map[string]interface{})
+	if _, err := toml.Decode(buf.String(), &table); err != nil {
+		return nil, err
+	}
+	return table, nil
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"slices"
+	"strings"
+)
+
+// EdgeFinding is one suspicious hop in the edges list. Index points at the
+// edges[] entry that defines it; FirstIndex, for duplicates, at the entry
+// that defined it first.
+type EdgeFinding struct {
+	From       string `json:"from"`
+	To         string `json:"to"`
+	Index      int    `json:"index"`
+	FirstIndex int    `json:"first_index"`
+}
+
+// EdgeCheckReport lists topology definitions that load but are likely
+// authoring mistakes.
+type EdgeCheckReport struct {
+	SelfLoops  []EdgeFinding `json:"self_loops"`
+	Duplicates []EdgeFinding `json:"duplicates"`
+	// Asymmetric lists hops reachable in only one direction in the parsed
+	// adjacency.
+	Asymmetric []EdgeFinding `json:"asymmetric"`
+}
+
+// Count returns the total number of findings.
+func (r EdgeCheckReport) Count() int {
+	return len(r.SelfLoops) + len(r.Duplicates) + len(r.Asymmetric)
+}
+
+// CheckEdges reports self-loops, hops defined more than once (in either
+// order, across or within chains), and one-way hops in edges.
+func CheckEdges(edges []string) (EdgeCheckReport, error) {
+	report := EdgeCheckReport{
+		SelfLoops:  []EdgeFinding{},
+		Duplicates: []EdgeFinding{},
+	}
+	adjacency, err := ParseEdges(edges)
+	if err != nil {
+		return EdgeCheckReport{}, err
+	}
+
+	firstSeen := make(map[string]int)
+	for i, edge := range edges {
+		nodes := splitEdgeNodeNames(edge)
+		for j := 0; j < len(nodes)-1; j++ {
+			from, to := nodes[j], nodes[j+1]
+			if from == to {
+				report.SelfLoops = append(report.SelfLoops, EdgeFinding{From: from, To: to, Index: i})
+				continue
+			}
+			a, b := from, to
+			if b < a {
+				a, b = b, a
+			}
+			key := a + "\x00" + b
+			if first, ok := firstSeen[key]; ok {
+				report.Duplicates = append(report.Duplicates, EdgeFinding{From: from, To: to, Index: i, FirstIndex: first})
+				continue
+			}
+			firstSeen[key] = i
+		}
+	}
+	report.Asymmetric = asymmetricHops(adjacency)
+	return report, nil
+}
+
+// asymmetricHops returns from→to hops whose reverse is missing, sorted for
+// stable output. Index is -1 because adjacency no longer knows the source
+// line.
+func asymmetricHops(adjacency map[string][]string) []EdgeFinding {
+	findings := []EdgeFinding{}
+	for from, neighbors := range adjacency {
+		for _, to := range neighbors {
+			if from != to && !slices.Contains(adjacency[to], from) {
+				findings = append(findings, EdgeFinding{From: from, To: to, Index: -1})
+			}
+		}
+	}
+	slices.SortFunc(findings, func(x, y EdgeFinding) int {
+		if c := strings.Compare(x.From, y.From); c != 0 {
+			return c
+		}
+		return strings.Compare(x.To, y.To)
+	})
+	return slices.CompactFunc(findings, func(x, y EdgeFinding) bool {
+		return x.From == y.From && x.To == y.To
+	})
+}
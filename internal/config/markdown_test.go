@@ -2067,7 +2067,7 @@ role = "worker"
 		t.Fatal("AllowShellTemplates = false, want true from trusted XDG base")
 	}
 
-	got := template.ExpandTemplate(cfg.MessageFooter, map[string]string{}, 5*time.Second, cfg.AllowShellForMessageFooter())
+	got := template.ExpandTemplate(cfg.MessageFooter, map[string]string{}, 5*time.Second, cfg.AllowShellForMessageFooter(), cfg.TemplateOptions())
 	if got != "Trusted footer trusted-xdg-markdown-footer" {
 		t.Fatalf("trusted XDG Markdown footer = %q, want %q", got, "Trusted footer trusted-xdg-markdown-footer")
 	}
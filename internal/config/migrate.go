@@ -0,0 +1,127 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// MigrationChange records one line rewritten by MigratePythonConfig.
+// Line is 1-based; Before and After are the full original and rewritten lines.
+type MigrationChange struct {
+	Line   int
+	Before string
+	After  string
+	Reason string
+}
+
+// pythonRenamedKeys maps keys the Python postman accepted to their current name.
+var pythonRenamedKeys = map[string]string{
+	"auto_enable_new_agents": "auto_enable_new_sessions",
+}
+
+// pythonRemovedKeys maps keys that have no TOML equivalent to a hint shown
+// in the commented-out line.
+var pythonRemovedKeys = map[string]string{
+	"startup_delay_seconds": "no longer used",
+	"command_approver_node": "mark the approver with the Mermaid command_approver_node class in postman.md",
+}
+
+var (
+	tomlKeyLinePattern   = regexp.MustCompile(`^(\s*)([A-Za-z0-9_-]+)(\s*=.*)$`)
+	tomlQuotedPattern    = regexp.MustCompile(`"[^"]*"`)
+	legacyEdgeArrowRegex = regexp.MustCompile(`\s*(?:<-+>?|-+>|-{2,})\s*`)
+)
+
+// MigratePythonConfig rewrites a Python-postman style postman.toml into the
+// current format line by line, so comments and layout survive. Directed edge
+// arrows ("-->", "<-->", "--") become the bidirectional "---" separator,
+// renamed keys take their current name, and keys without an equivalent are
+// commented out. The result must parse and its edges must be valid.
+func MigratePythonConfig(raw []byte) ([]byte, []MigrationChange, error) {
+	lines := strings.Split(string(raw), "\n")
+	var changes []MigrationChange
+	inEdges := false
+	for i, line := range lines {
+		migrated, reason := line, ""
+		if match := tomlKeyLinePattern.FindStringSubmatch(line); match != nil {
+			key := match[2]
+			if key == "edges" {
+				inEdges = true
+			} else if renamed, ok := pythonRenamedKeys[key]; ok {
+				migrated = match[1] + renamed + match[3]
+				reason = fmt.Sprintf("renamed %s to %s", key, renamed)
+			} else if hint, ok := pythonRemovedKeys[key]; ok {
+				migrated = match[1] + "# " + strings.TrimSpace(line) + " # removed: " + hint
+				reason = fmt.Sprintf("removed %s", key)
+			}
+		}
+		if inEdges {
+			migrated = tomlQuotedPattern.ReplaceAllStringFunc(migrated, normalizeLegacyEdge)
+			if migrated != line {
+				reason = "normalized edge separator to ---"
+			}
+			if strings.Contains(stripTOMLComment(line), "]") {
+				inEdges = false
+			}
+		}
+		if migrated != line {
+			lines[i] = migrated
+			changes = append(changes, MigrationChange{Line: i + 1, Before: line, After: migrated, Reason: reason})
+		}
+	}
+	out := []byte(strings.Join(lines, "\n"))
+
+	var parsed struct {
+		Postman struct {
+			Edges []string `toml:"edges"`
+		} `toml:"postman"`
+	}
+	if _, err := toml.Decode(string(out), &parsed); err != nil {
+		return nil, nil, fmt.Errorf("migrated config does not parse: %w", err)
+	}
+	if _, err := ParseEdges(parsed.Postman.Edges); err != nil {
+		return nil, nil, fmt.Errorf("migrated config has invalid edges: %w", err)
+	}
+	return out, changes, nil
+}
+
+// normalizeLegacyEdge rewrites one quoted edge string, keeping any
+// @weight= annotation intact.
+func normalizeLegacyEdge(quoted string) string {
+	edge := strings.Trim(quoted, `"`)
+	edge, weight, hasWeight := strings.Cut(edge, edgeWeightAnnotation)
+	changed := false
+	edge = legacyEdgeArrowRegex.ReplaceAllStringFunc(edge, func(separator string) string {
+		if strings.TrimSpace(separator) == "---" {
+			return separator
+		}
+		changed = true
+		return " --- "
+	})
+	if !changed {
+		return quoted
+	}
+	edge = strings.TrimSpace(edge)
+	if hasWeight {
+		edge += " " + edgeWeightAnnotation + weight
+	}
+	return `"` + edge + `"`
+}
+
+func stripTOMLComment(line string) string {
+	inString := false
+	for i, r := range line {
+		switch r {
+		case '"':
+			inString = !inString
+		case '#':
+			if !inString {
+				return line[:i]
+			}
+		}
+	}
+	return line
+}
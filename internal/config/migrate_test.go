@@ -0,0 +1,78 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMigratePythonConfigConvertsEdgesAndKeys(t *testing.T) {
+	raw := strings.Join([]string{
+		"[postman]",
+		"# topology",
+		"edges = [",
+		`  "orchestrator --> worker",`,
+		`  "worker <--> critic @weight=2",`,
+		`  "critic --- observer",`,
+		"]",
+		"auto_enable_new_agents = false",
+		"startup_delay_seconds = 3",
+		"",
+		"[worker]",
+		`role = "implementer"`,
+	}, "\n")
+
+	got, changes, err := MigratePythonConfig([]byte(raw))
+	if err != nil {
+		t.Fatalf("MigratePythonConfig: %v", err)
+	}
+	want := strings.Join([]string{
+		"[postman]",
+		"# topology",
+		"edges = [",
+		`  "orchestrator --- worker",`,
+		`  "worker --- critic @weight=2",`,
+		`  "critic --- observer",`,
+		"]",
+		"auto_enable_new_sessions = false",
+		"# startup_delay_seconds = 3 # removed: no longer used",
+		"",
+		"[worker]",
+		`role = "implementer"`,
+	}, "\n")
+	if string(got) != want {
+		t.Fatalf("migrated config =\n%s\nwant\n%s", got, want)
+	}
+
+	var lines []int
+	for _, change := range changes {
+		lines = append(lines, change.Line)
+	}
+	if !reflect.DeepEqual(lines, []int{4, 5, 8, 9}) {
+		t.Fatalf("changed lines = %v, want [4 5 8 9]", lines)
+	}
+
+	again, changes, err := MigratePythonConfig(got)
+	if err != nil {
+		t.Fatalf("MigratePythonConfig(migrated): %v", err)
+	}
+	if string(again) != want || len(changes) != 0 {
+		t.Fatalf("migration is not idempotent: changes=%v", changes)
+	}
+}
+
+func TestMigratePythonConfigKeepsHyphenatedNodeNames(t *testing.T) {
+	got, _, err := MigratePythonConfig([]byte(`[postman]` + "\n" + `edges = ["code-reviewer-->worker-1"]`))
+	if err != nil {
+		t.Fatalf("MigratePythonConfig: %v", err)
+	}
+	if !strings.Contains(string(got), `"code-reviewer --- worker-1"`) {
+		t.Fatalf("migrated config = %q, want hyphenated node names preserved", got)
+	}
+}
+
+func TestMigratePythonConfigRejectsUnparseableResult(t *testing.T) {
+	if _, _, err := MigratePythonConfig([]byte("[postman\nedges = [")); err == nil {
+		t.Fatal("MigratePythonConfig accepted invalid TOML")
+	}
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// CanonicalNodeName maps an address whose node part is a declared alias to
+// the node that owns it, keeping any session prefix. Other addresses come
+// back unchanged.
+func (cfg *Config) CanonicalNodeName(address string) string {
+	if cfg == nil {
+		return address
+	}
+	sessionName, nodeName, hasSession := strings.Cut(address, ":")
+	if !hasSession {
+		sessionName, nodeName = "", address
+	}
+	if _, ok := cfg.Nodes[nodeName]; ok {
+		return address
+	}
+	for name, node := range cfg.Nodes {
+		for _, alias := range node.Aliases {
+			if strings.TrimSpace(alias) != nodeName {
+				continue
+			}
+			if hasSession {
+				return sessionName + ":" + name
+			}
+			return name
+		}
+	}
+	return address
+}
+
+// validateNodeAliases rejects an alias that is also a node name or is
+// claimed by two nodes, since either would make delivery ambiguous.
+func validateNodeAliases(cfg *Config) []ValidationError {
+	var errors []ValidationError
+	owners := make(map[string]string)
+	names := make([]string, 0, len(cfg.Nodes))
+	for name := range cfg.Nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, alias := range cfg.Nodes[name].Aliases {
+			alias = strings.TrimSpace(alias)
+			field := fmt.Sprintf("%s.aliases", name)
+			switch {
+			case alias == "":
+				errors = append(errors, ValidationError{Field: field, Message: "empty alias", Severity: "error"})
+			case alias == name:
+				continue
+			case hasNode(cfg, alias):
+				errors = append(errors, ValidationError{Field: field, Message: fmt.Sprintf("alias %q is also a node name", alias), Severity: "error"})
+			case owners[alias] != "":
+				errors = append(errors, ValidationError{Field: field, Message: fmt.Sprintf("alias %q is already claimed by %s", alias, owners[alias]), Severity: "error"})
+			default:
+				owners[alias] = name
+			}
+		}
+	}
+	return errors
+}
+
+func hasNode(cfg *Config, name string) bool {
+	_, ok := cfg.Nodes[name]
+	return ok
+}
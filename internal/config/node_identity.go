@@ -0,0 +1,27 @@
+package config
+
+// Node identity sources for node_identity.
+const (
+	NodeIdentityTitle      = "title"
+	NodeIdentityUserOption = "user_option"
+)
+
+// NodeIdentityOption is the pane user option read under
+// node_identity = "user_option", e.g. `tmux set -p @a2a_node worker`.
+const NodeIdentityOption = "@a2a_node"
+
+const (
+	paneTitleFormat      = "#{pane_title}"
+	paneUserOptionFormat = "#{?" + NodeIdentityOption + ",#{" + NodeIdentityOption + "}," + paneTitleFormat + "}"
+)
+
+// PaneNameFormat returns the tmux format that expands to a pane's node
+// name: the pane title, or under node_identity = "user_option" the
+// @a2a_node option with the title as fallback. tmux evaluates the fallback
+// itself, so batched list-panes calls need no extra show-options round trip.
+func (cfg *Config) PaneNameFormat() string {
+	if cfg != nil && cfg.NodeIdentity == NodeIdentityUserOption {
+		return paneUserOptionFormat
+	}
+	return paneTitleFormat
+}
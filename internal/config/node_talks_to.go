@@ -0,0 +1,75 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// appendTalksToEdges adds a "node --- peer" edge for every [<node>] talks_to
+// entry that the explicit edges do not already connect, when
+// infer_edges_from_talks_to is set. Edges are bidirectional, so a pair
+// listed from both sides becomes one edge.
+func (cfg *Config) appendTalksToEdges() {
+	if cfg == nil || !BoolVal(cfg.InferEdgesFromTalksTo, false) {
+		return
+	}
+	// Invalid explicit edges are reported by validation; the inferred ones
+	// only need to avoid repeating hops that parse.
+	adjacency, _ := ParseEdges(cfg.Edges)
+	connected := func(a, b string) bool {
+		return slices.Contains(adjacency[a], b)
+	}
+	added := make(map[[2]string]bool)
+	for _, name := range cfg.OrderedNodeNames() {
+		for _, peer := range cfg.Nodes[name].TalksTo {
+			peer = strings.TrimSpace(peer)
+			if peer == "" || peer == name || connected(name, peer) || added[[2]string{peer, name}] || added[[2]string{name, peer}] {
+				continue
+			}
+			added[[2]string{name, peer}] = true
+			cfg.Edges = append(cfg.Edges, name+" --- "+peer)
+		}
+	}
+}
+
+// validateNodeTalksTo warns about talks_to lists that have no effect or that
+// disagree: A listing B while B lists its peers without A still connects
+// both ways, which is rarely what B's author meant.
+func validateNodeTalksTo(cfg *Config) []ValidationError {
+	var errors []ValidationError
+	for _, name := range cfg.OrderedNodeNames() {
+		talksTo := cfg.Nodes[name].TalksTo
+		if len(talksTo) == 0 {
+			continue
+		}
+		field := fmt.Sprintf("%s.talks_to", name)
+		if !BoolVal(cfg.InferEdgesFromTalksTo, false) {
+			errors = append(errors, ValidationError{
+				Field:    field,
+				Message:  "talks_to is ignored unless infer_edges_from_talks_to = true",
+				Severity: "warning",
+			})
+			continue
+		}
+		for _, peer := range talksTo {
+			peer = strings.TrimSpace(peer)
+			switch {
+			case peer == "":
+				errors = append(errors, ValidationError{Field: field, Message: "empty talks_to entry", Severity: "warning"})
+			case peer == name:
+				errors = append(errors, ValidationError{Field: field, Message: "node lists itself in talks_to", Severity: "warning"})
+			default:
+				peerTalksTo := cfg.Nodes[peer].TalksTo
+				if len(peerTalksTo) > 0 && !slices.ContainsFunc(peerTalksTo, func(s string) bool { return strings.TrimSpace(s) == name }) {
+					errors = append(errors, ValidationError{
+						Field:    field,
+						Message:  fmt.Sprintf("%s lists %s, but %s.talks_to omits %s; the inferred edge connects both ways", name, peer, peer, name),
+						Severity: "warning",
+					})
+				}
+			}
+		}
+	}
+	return errors
+}
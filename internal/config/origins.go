@@ -0,0 +1,91 @@
+package config
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/BurntSushi/toml"
+)
+
+// OriginEmbeddedDefaults names settings no config file touched.
+const OriginEmbeddedDefaults = "embedded defaults"
+
+// SettingOrigin is one effective setting and the file that last set it.
+type SettingOrigin struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+// recordOrigin notes that source set key ("section.field").
+func (cfg *Config) recordOrigin(key, source string) {
+	if cfg.origins == nil {
+		cfg.origins = make(map[string]string)
+	}
+	cfg.origins[key] = source
+}
+
+// recordTOMLOrigins credits every section.field key present in a decoded TOML
+// file to source. Nested tables are credited at their top-level field.
+func (cfg *Config) recordTOMLOrigins(md toml.MetaData, source string) {
+	for _, key := range md.Keys() {
+		if len(key) < 2 {
+			continue
+		}
+		cfg.recordOrigin(key[0]+"."+key[1], source)
+	}
+}
+
+// flattenedSettings returns the effective settings keyed "section.field",
+// with values rendered as compact JSON.
+func (cfg *Config) flattenedSettings() map[string]string {
+	sections, err := EffectiveSections(cfg)
+	if err != nil {
+		return nil
+	}
+	flat := make(map[string]string)
+	for section, table := range sections {
+		fields, ok := table.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for field, value := range fields {
+			rendered, err := json.Marshal(value)
+			if err != nil {
+				continue
+			}
+			flat[section+"."+field] = string(rendered)
+		}
+	}
+	return flat
+}
+
+// recordChangedOrigins credits source with every setting whose value differs
+// from before. Used for layers, such as Markdown overlays, that are merged
+// rather than decoded key by key.
+func (cfg *Config) recordChangedOrigins(before map[string]string, source string) {
+	for key, value := range cfg.flattenedSettings() {
+		if previous, ok := before[key]; !ok || previous != value {
+			cfg.recordOrigin(key, source)
+		}
+	}
+}
+
+// SettingOrigins lists every effective setting with the file that set it,
+// sorted by key. Settings left at their embedded value report
+// OriginEmbeddedDefaults.
+func (cfg *Config) SettingOrigins() []SettingOrigin {
+	flat := cfg.flattenedSettings()
+	origins := make([]SettingOrigin, 0, len(flat))
+	for key, value := range flat {
+		source, ok := cfg.origins[key]
+		if !ok {
+			source = OriginEmbeddedDefaults
+		}
+		origins = append(origins, SettingOrigin{Key: key, Value: value, Source: source})
+	}
+	sort.Slice(origins, func(i, j int) bool {
+		return origins[i].Key < origins[j].Key
+	})
+	return origins
+}
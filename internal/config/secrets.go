@@ -0,0 +1,87 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/i9wa4/tmux-a2a-postman/internal/template"
+)
+
+// TemplateOptions returns the template.Options for expansions under cfg, so
+// {secret:NAME} resolves against this config's secrets_file and $(...)
+// commands share this config's template_shell_max_concurrent slots.
+func (cfg *Config) TemplateOptions() template.Options {
+	if cfg == nil {
+		return template.Options{}
+	}
+	return template.Options{Secrets: cfg.Secrets, Shell: cfg.shellLimiter}
+}
+
+// LoadSecrets reads secrets_file: a flat TOML table of strings when the name
+// ends in .toml, otherwise KEY=VALUE lines as in an env file. Secret files
+// readable by everyone are refused. Errors never include secret values.
+func LoadSecrets(path string) (map[string]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("secrets_file: %w", err)
+	}
+	if info.Mode().Perm()&0o004 != 0 {
+		return nil, fmt.Errorf("secrets_file %s is world-readable (mode %04o); run chmod 600 on it", path, info.Mode().Perm())
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("secrets_file: %w", err)
+	}
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		secrets := map[string]string{}
+		if _, err := toml.Decode(string(data), &secrets); err != nil {
+			return nil, fmt.Errorf("secrets_file %s: every key must be a top-level string: %w", path, err)
+		}
+		return secrets, nil
+	}
+	return parseEnvSecrets(path, string(data))
+}
+
+func parseEnvSecrets(path, data string) (map[string]string, error) {
+	secrets := map[string]string{}
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("secrets_file %s:%d: want KEY=VALUE", path, lineNo)
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		secrets[key] = value
+	}
+	return secrets, scanner.Err()
+}
+
+// resolveSecretsPath expands "~/" and resolves relative paths against the
+// directory of the config file that named them.
+func resolveSecretsPath(configDir, secretsFile string) (string, error) {
+	path := strings.TrimSpace(secretsFile)
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("expand secrets_file %s: %w", secretsFile, err)
+		}
+		return filepath.Clean(filepath.Join(home, strings.TrimPrefix(path[1:], "/"))), nil
+	}
+	if filepath.IsAbs(path) || configDir == "" {
+		return filepath.Clean(path), nil
+	}
+	return filepath.Clean(filepath.Join(configDir, path)), nil
+}
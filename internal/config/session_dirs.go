@@ -0,0 +1,136 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// SessionConfig holds per-session settings from a [session.<name>] section.
+type SessionConfig struct {
+	// Root for this session's message directories instead of base_dir, e.g.
+	// a faster or encrypted volume; the session's directories live under
+	// <base_dir>/<contextId>/<session>
+	BaseDir string `toml:"base_dir"`
+	// Overrides max_messages_per_hour for this session
+	MaxMessagesPerHour int `toml:"max_messages_per_hour"`
+}
+
+// SessionMessageQuota returns the hourly message quota for sessionName: its
+// [session.<name>] max_messages_per_hour when set, otherwise the global
+// max_messages_per_hour. 0 means unlimited.
+func (cfg *Config) SessionMessageQuota(sessionName string) int {
+	if cfg == nil {
+		return 0
+	}
+	if quota := cfg.Sessions[sessionName].MaxMessagesPerHour; quota != 0 {
+		return quota
+	}
+	return cfg.MaxMessagesPerHour
+}
+
+// HasSessionMessageQuota reports whether any session has an hourly quota.
+func (cfg *Config) HasSessionMessageQuota() bool {
+	if cfg == nil {
+		return false
+	}
+	if cfg.MaxMessagesPerHour > 0 {
+		return true
+	}
+	for _, session := range cfg.Sessions {
+		if session.MaxMessagesPerHour > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// SessionBaseDir returns the base directory for sessionName: its
+// [session.<name>] base_dir override when set, otherwise baseDir.
+func (cfg *Config) SessionBaseDir(baseDir, sessionName string) string {
+	if cfg == nil {
+		return baseDir
+	}
+	if dir := expandSessionBaseDir(cfg.Sessions[sessionName].BaseDir); dir != "" {
+		return dir
+	}
+	return baseDir
+}
+
+// HasSessionBaseDir reports whether sessionName's directories are moved by a
+// [session.<name>] base_dir.
+func (cfg *Config) HasSessionBaseDir(sessionName string) bool {
+	return cfg != nil && expandSessionBaseDir(cfg.Sessions[sessionName].BaseDir) != ""
+}
+
+// SessionDir returns the directory holding sessionName's inbox, post, read,
+// and other message directories for contextID. Every command and the daemon
+// resolve session directories through it, so a [session.<name>] base_dir
+// moves them for all of them.
+func (cfg *Config) SessionDir(baseDir, contextID, sessionName string) string {
+	return filepath.Join(cfg.SessionBaseDir(baseDir, sessionName), contextID, sessionName)
+}
+
+// CreateMultiSessionDirs creates sessionName's message directories at
+// SessionDir. A session with a [session.<name>] base_dir still gets its
+// directory under contextDir, since ownership checks and the daemon PID file
+// look for it there.
+func (cfg *Config) CreateMultiSessionDirs(contextDir, sessionName string) error {
+	defaultDir := filepath.Join(contextDir, sessionName)
+	sessionDir := cfg.SessionDir(filepath.Dir(contextDir), filepath.Base(contextDir), sessionName)
+	if sessionDir == defaultDir {
+		return CreateMultiSessionDirs(contextDir, sessionName)
+	}
+	if err := os.MkdirAll(defaultDir, 0o700); err != nil {
+		return err
+	}
+	return CreateSessionDirs(sessionDir)
+}
+
+func expandSessionBaseDir(dir string) string {
+	dir = strings.TrimSpace(dir)
+	if dir == "~" || strings.HasPrefix(dir, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, strings.TrimPrefix(dir[1:], "/"))
+	}
+	if dir == "" {
+		return ""
+	}
+	return filepath.Clean(dir)
+}
+
+func validateSessionConfigs(cfg *Config) []ValidationError {
+	names := make([]string, 0, len(cfg.Sessions))
+	for name := range cfg.Sessions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errors []ValidationError
+	for _, name := range names {
+		field := fmt.Sprintf("session.%s.base_dir", name)
+		dir := strings.TrimSpace(cfg.Sessions[name].BaseDir)
+		switch {
+		case dir == "" && cfg.Sessions[name].MaxMessagesPerHour != 0:
+			// A quota-only section keeps base_dir.
+		case dir == "":
+			errors = append(errors, ValidationError{
+				Field:    field,
+				Message:  "base_dir is empty",
+				Severity: "error",
+			})
+		case !filepath.IsAbs(dir) && dir != "~" && !strings.HasPrefix(dir, "~/"):
+			errors = append(errors, ValidationError{
+				Field:    field,
+				Message:  fmt.Sprintf("base_dir %q must be an absolute path or start with ~/", dir),
+				Severity: "error",
+			})
+		}
+	}
+	return errors
+}
@@ -6,6 +6,7 @@ const (
 	directTemplateRootDraft                = "draft_template"
 	directTemplateRootEdgeViolationWarning = "edge_violation_warning_template"
 	directTemplateRootMessageFooter        = "message_footer"
+	directTemplateRootDeadLetterFeedback   = "dead_letter_feedback_template"
 )
 
 func (cfg *Config) initDirectTemplateRootTrust() {
@@ -15,6 +16,7 @@ func (cfg *Config) initDirectTemplateRootTrust() {
 		directTemplateRootDraft:                true,
 		directTemplateRootEdgeViolationWarning: true,
 		directTemplateRootMessageFooter:        true,
+		directTemplateRootDeadLetterFeedback:   true,
 	}
 }
 
@@ -51,3 +53,7 @@ func (cfg *Config) AllowShellForEdgeViolationWarningTemplate() bool {
 func (cfg *Config) AllowShellForMessageFooter() bool {
 	return cfg.allowShellForDirectTemplateRoot(directTemplateRootMessageFooter)
 }
+
+func (cfg *Config) AllowShellForDeadLetterFeedbackTemplate() bool {
+	return cfg.allowShellForDirectTemplateRoot(directTemplateRootDeadLetterFeedback)
+}
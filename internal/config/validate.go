@@ -2,6 +2,8 @@ package config
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/i9wa4/tmux-a2a-postman/internal/binding"
@@ -43,6 +45,30 @@ func ValidateConfig(cfg *Config) []ValidationError {
 	// Rule 1: edges node reference check (severity: error)
 	// IMPORTANT: "postman" is a reserved name and should be skipped (not an error)
 	for i, edge := range cfg.Edges {
+		if _, _, err := splitEdgeMethods(strings.TrimSpace(edge)); err != nil {
+			errors = append(errors, ValidationError{
+				Field:    fmt.Sprintf("edges[%d]", i),
+				Message:  err.Error(),
+				Severity: "error",
+			})
+			continue
+		}
+		if _, _, _, err := splitEdgeCritical(strings.TrimSpace(edge)); err != nil {
+			errors = append(errors, ValidationError{
+				Field:    fmt.Sprintf("edges[%d]", i),
+				Message:  err.Error(),
+				Severity: "error",
+			})
+			continue
+		}
+		if _, _, err := splitEdgeWeight(strings.TrimSpace(edge)); err != nil {
+			errors = append(errors, ValidationError{
+				Field:    fmt.Sprintf("edges[%d]", i),
+				Message:  err.Error(),
+				Severity: "error",
+			})
+			continue
+		}
 		nodeNames := splitEdgeNodeNames(edge)
 		if strings.TrimSpace(edge) != "" && len(nodeNames) < 2 {
 			errors = append(errors, ValidationError{
@@ -89,6 +115,153 @@ func ValidateConfig(cfg *Config) []ValidationError {
 		}
 	}
 
+	// Rule 2b: Node transport check (severity: error)
+	for _, nodeName := range cfg.OrderedNodeNames() {
+		node := cfg.GetNodeConfig(nodeName)
+		switch node.Transport {
+		case "", NodeTransportTmux:
+		case NodeTransportFifo:
+			if strings.TrimSpace(node.FifoPath) == "" {
+				errors = append(errors, ValidationError{
+					Field:    fmt.Sprintf("nodes.%s.fifo_path", nodeName),
+					Message:  "fifo_path is required when transport is \"fifo\"",
+					Severity: "error",
+				})
+			}
+		default:
+			errors = append(errors, ValidationError{
+				Field:    fmt.Sprintf("nodes.%s.transport", nodeName),
+				Message:  fmt.Sprintf("unknown transport %q (use \"tmux\" or \"fifo\")", node.Transport),
+				Severity: "error",
+			})
+		}
+	}
+
+	// Rule 2c: Post retention mode check (severity: error)
+	switch cfg.PostRetention {
+	case "", PostRetentionMove, PostRetentionCopy:
+	default:
+		errors = append(errors, ValidationError{
+			Field:    "post_retention",
+			Message:  fmt.Sprintf("unknown post_retention %q (use \"move\" or \"copy\")", cfg.PostRetention),
+			Severity: "error",
+		})
+	}
+
+	// Rule 2d: TUI indicator state keys (severity: warning)
+	for _, indicators := range []struct {
+		field   string
+		entries map[string]string
+	}{{"tui_symbols", cfg.TUISymbols}, {"tui_colors", cfg.TUIColors}} {
+		for _, state := range slices.Sorted(maps.Keys(indicators.entries)) {
+			if !slices.Contains(TUIIndicatorStates, state) {
+				errors = append(errors, ValidationError{
+					Field:    indicators.field,
+					Message:  fmt.Sprintf("unknown state %q (use one of %s)", state, strings.Join(TUIIndicatorStates, ", ")),
+					Severity: "warning",
+				})
+			}
+		}
+	}
+
+	// Rule 2e: Node identity source check (severity: error)
+	switch cfg.NodeIdentity {
+	case "", NodeIdentityTitle, NodeIdentityUserOption:
+	default:
+		errors = append(errors, ValidationError{
+			Field:    "node_identity",
+			Message:  fmt.Sprintf("unknown node_identity %q (use \"title\" or \"user_option\")", cfg.NodeIdentity),
+			Severity: "error",
+		})
+	}
+
+	// Rule 2f: Dead pane policy check (severity: error)
+	switch cfg.DeadPanePolicy {
+	case "", DeadPanePolicyDeliver, DeadPanePolicyHold, DeadPanePolicyDeadLetter:
+	default:
+		errors = append(errors, ValidationError{
+			Field:    "dead_pane_policy",
+			Message:  fmt.Sprintf("unknown dead_pane_policy %q (use \"deliver\", \"hold\", or \"dead_letter\")", cfg.DeadPanePolicy),
+			Severity: "error",
+		})
+	}
+
+	// Rule 2g: Edge violation mode check (severity: error)
+	switch cfg.EdgeViolationMode {
+	case "", EdgeViolationModeBlock, EdgeViolationModeWarnOnly, EdgeViolationModeSilent:
+	default:
+		errors = append(errors, ValidationError{
+			Field:    "edge_violation_mode",
+			Message:  fmt.Sprintf("unknown edge_violation_mode %q (use \"block\", \"warn_only\", or \"silent\")", cfg.EdgeViolationMode),
+			Severity: "error",
+		})
+	}
+
+	// Rule 2h: Node alias check (severity: error)
+	errors = append(errors, validateNodeAliases(cfg)...)
+
+	// Rule 2i: Empty body policy check (severity: error)
+	switch cfg.EmptyBodyPolicy {
+	case "", EmptyBodyPolicyDeliver, EmptyBodyPolicyDeadLetter, EmptyBodyPolicyWarnSender:
+	default:
+		errors = append(errors, ValidationError{
+			Field:    "empty_body_policy",
+			Message:  fmt.Sprintf("unknown empty_body_policy %q (use \"deliver\", \"dead_letter\", or \"warn_sender\")", cfg.EmptyBodyPolicy),
+			Severity: "error",
+		})
+	}
+
+	// Rule 2j: Session base_dir check (severity: error)
+	errors = append(errors, validateSessionConfigs(cfg)...)
+
+	// Rule 2k: Non-markdown post policy check (severity: error)
+	switch cfg.NonMDPostPolicy {
+	case "", NonMDPostPolicyIgnore, NonMDPostPolicyWarn, NonMDPostPolicyDeadLetter:
+	default:
+		errors = append(errors, ValidationError{
+			Field:    "non_md_post_policy",
+			Message:  fmt.Sprintf("unknown non_md_post_policy %q (use \"ignore\", \"warn\", or \"dead_letter\")", cfg.NonMDPostPolicy),
+			Severity: "error",
+		})
+	}
+
+	// Rule 2l: talks_to consistency check (severity: warning)
+	errors = append(errors, validateNodeTalksTo(cfg)...)
+
+	// Rule 2m: TUI group membership check (severity: warning)
+	if groups := cfg.TUISessionGroupLabels(); groups != nil {
+		for _, label := range slices.Sorted(maps.Keys(cfg.TUIGroups)) {
+			for _, session := range cfg.TUIGroups[label] {
+				if owner := groups[session]; owner != label {
+					errors = append(errors, ValidationError{
+						Field:    "tui_groups",
+						Message:  fmt.Sprintf("session %q is in groups %q and %q; the TUI shows it under %q", session, owner, label, owner),
+						Severity: "warning",
+					})
+				}
+			}
+		}
+	}
+
+	// Rule 2n: Activity detector check (severity: error)
+	switch cfg.ActivityDetector {
+	case "", ActivityDetectorCapture, ActivityDetectorHeartbeat:
+	case ActivityDetectorSignal:
+		if strings.TrimSpace(cfg.ActivitySignalCommand) == "" {
+			errors = append(errors, ValidationError{
+				Field:    "activity_signal_command",
+				Message:  "activity_detector \"signal\" needs activity_signal_command",
+				Severity: "error",
+			})
+		}
+	default:
+		errors = append(errors, ValidationError{
+			Field:    "activity_detector",
+			Message:  fmt.Sprintf("unknown activity_detector %q (use \"capture\", \"heartbeat\", or \"signal\")", cfg.ActivityDetector),
+			Severity: "error",
+		})
+	}
+
 	// Rule 3: Duplicate edges check (severity: warning)
 	edgeMap := make(map[string]int)
 	for i, edge := range cfg.Edges {
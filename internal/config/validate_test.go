@@ -1,6 +1,7 @@
 package config
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -358,3 +359,28 @@ func TestResolveCommandApproverNode(t *testing.T) {
 		t.Fatalf("ResolveCommandApproverNode on a nil Config = (%q, %v), want (\"\", false)", name, valid)
 	}
 }
+
+func TestValidateConfig_NodeTransport(t *testing.T) {
+	cfg := &Config{
+		Edges: []string{"worker --- headless --- typo"},
+		Nodes: map[string]NodeConfig{
+			"worker":   {Transport: NodeTransportFifo, FifoPath: "/tmp/worker.fifo"},
+			"headless": {Transport: NodeTransportFifo},
+			"typo":     {Transport: "pipe"},
+		},
+	}
+
+	got := map[string]bool{}
+	for _, ve := range ValidateConfig(cfg) {
+		if ve.Severity == "error" {
+			got[ve.Field] = true
+		}
+	}
+	want := map[string]bool{
+		"nodes.headless.fifo_path": true,
+		"nodes.typo.transport":     true,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("validation error fields = %v, want %v", got, want)
+	}
+}
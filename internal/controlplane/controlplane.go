@@ -21,6 +21,7 @@ type HandKind string
 
 const (
 	HandKindTmux HandKind = "tmux"
+	HandKindFifo HandKind = "fifo"
 
 	BrainRuntimeUnknown = agentruntime.Unknown
 )
@@ -80,6 +81,7 @@ func (t Target) PostPath(filename string) string {
 
 type PaneDelivery struct {
 	Content        string
+	PreEnterDelay  time.Duration
 	EnterDelay     time.Duration
 	TmuxTimeout    time.Duration
 	EnterCount     int
@@ -109,7 +111,7 @@ type HandAdapter interface {
 
 type TmuxHandAdapter struct {
 	ProbeRuntime func(paneID string) (string, error)
-	SendToPane   func(paneID string, message string, enterDelay time.Duration, tmuxTimeout time.Duration, enterCount int, bypassCooldown bool, verifyDelay time.Duration, maxRetries int) error
+	SendToPane   func(paneID string, message string, preEnterDelay time.Duration, enterDelay time.Duration, tmuxTimeout time.Duration, enterCount int, bypassCooldown bool, verifyDelay time.Duration, maxRetries int) error
 }
 
 func (TmuxHandAdapter) Kind() HandKind {
@@ -143,6 +145,7 @@ func (a TmuxHandAdapter) Deliver(target Target, delivery PaneDelivery) error {
 	return sendToPane(
 		target.Hand.Address,
 		delivery.Content,
+		delivery.PreEnterDelay,
 		delivery.EnterDelay,
 		delivery.TmuxTimeout,
 		enterCount,
@@ -153,6 +156,12 @@ func (a TmuxHandAdapter) Deliver(target Target, delivery PaneDelivery) error {
 }
 
 func (TmuxHandAdapter) DeliverSystemMessage(target Target, delivery SystemMessageDelivery) (SystemMessageResult, error) {
+	return deliverSystemMessageToInbox(target, delivery)
+}
+
+// deliverSystemMessageToInbox writes a system message straight into the
+// recipient inbox; every hand kind shares this mailbox path.
+func deliverSystemMessageToInbox(target Target, delivery SystemMessageDelivery) (SystemMessageResult, error) {
 	recipientInbox := target.InboxDir()
 	if err := os.MkdirAll(recipientInbox, 0o700); err != nil {
 		return SystemMessageResult{}, fmt.Errorf("creating recipient inbox: %w", err)
@@ -184,6 +193,8 @@ func DefaultHandAdapter(target Target) (HandAdapter, error) {
 	switch target.Hand.Kind {
 	case HandKindTmux:
 		return TmuxHandAdapter{}, nil
+	case HandKindFifo:
+		return FifoHandAdapter{}, nil
 	default:
 		return nil, fmt.Errorf("unsupported hand kind %q", target.Hand.Kind)
 	}
@@ -45,6 +45,7 @@ func TestTmuxHandAdapterDeliverUsesHandAttachment(t *testing.T) {
 	var (
 		gotPaneID         string
 		gotMessage        string
+		gotPreEnterDelay  time.Duration
 		gotEnterDelay     time.Duration
 		gotTimeout        time.Duration
 		gotEnterCount     int
@@ -62,9 +63,10 @@ func TestTmuxHandAdapterDeliverUsesHandAttachment(t *testing.T) {
 			}
 			return "codex", nil
 		},
-		SendToPane: func(paneID string, message string, enterDelay time.Duration, tmuxTimeout time.Duration, enterCount int, bypassCooldown bool, verifyDelay time.Duration, maxRetries int) error {
+		SendToPane: func(paneID string, message string, preEnterDelay time.Duration, enterDelay time.Duration, tmuxTimeout time.Duration, enterCount int, bypassCooldown bool, verifyDelay time.Duration, maxRetries int) error {
 			gotPaneID = paneID
 			gotMessage = message
+			gotPreEnterDelay = preEnterDelay
 			gotEnterDelay = enterDelay
 			gotTimeout = tmuxTimeout
 			gotEnterCount = enterCount
@@ -84,6 +86,7 @@ func TestTmuxHandAdapterDeliverUsesHandAttachment(t *testing.T) {
 		Hand:        HandAttachment{Kind: HandKindTmux, Address: "%99"},
 	}, PaneDelivery{
 		Content:        "notice worker",
+		PreEnterDelay:  4 * time.Millisecond,
 		EnterDelay:     5 * time.Millisecond,
 		TmuxTimeout:    1 * time.Second,
 		EnterCount:     0,
@@ -101,6 +104,9 @@ func TestTmuxHandAdapterDeliverUsesHandAttachment(t *testing.T) {
 	if gotMessage != "notice worker" {
 		t.Fatalf("SendToPane message = %q, want %q", gotMessage, "notice worker")
 	}
+	if gotPreEnterDelay != 4*time.Millisecond {
+		t.Fatalf("SendToPane preEnterDelay = %s, want %s", gotPreEnterDelay, 4*time.Millisecond)
+	}
 	if gotEnterDelay != 5*time.Millisecond {
 		t.Fatalf("SendToPane enterDelay = %s, want %s", gotEnterDelay, 5*time.Millisecond)
 	}
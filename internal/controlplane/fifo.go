@@ -0,0 +1,81 @@
+package controlplane
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrFifoNoReader reports that nobody opened the FIFO for reading before the
+// delivery timeout ran out, so the notification was never written.
+var ErrFifoNoReader = errors.New("no reader")
+
+const (
+	fifoDefaultTimeout  = 5 * time.Second
+	fifoOpenRetryPeriod = 50 * time.Millisecond
+)
+
+// FifoHandAdapter delivers notifications by writing them to a named pipe,
+// for headless agents that read instructions from a FIFO instead of a pane.
+type FifoHandAdapter struct{}
+
+func (FifoHandAdapter) Kind() HandKind {
+	return HandKindFifo
+}
+
+// Deliver writes the notification as one newline-terminated record. The open
+// never blocks: while no reader has the FIFO open it retries until
+// delivery.TmuxTimeout elapses and then reports the missing reader.
+func (FifoHandAdapter) Deliver(target Target, delivery PaneDelivery) error {
+	if target.Hand.Kind != HandKindFifo {
+		return fmt.Errorf("fifo hand adapter cannot deliver to %q", target.Hand.Kind)
+	}
+	path := target.Hand.Address
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("fifo %s: %w", path, err)
+	}
+	if info.Mode()&os.ModeNamedPipe == 0 {
+		return fmt.Errorf("fifo %s: not a named pipe", path)
+	}
+
+	timeout := delivery.TmuxTimeout
+	if timeout <= 0 {
+		timeout = fifoDefaultTimeout
+	}
+	deadline := time.Now().Add(timeout)
+	var fifo *os.File
+	for {
+		fifo, err = os.OpenFile(path, os.O_WRONLY|syscall.O_NONBLOCK, 0)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, syscall.ENXIO) {
+			return fmt.Errorf("opening fifo %s: %w", path, err)
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("fifo %s: %w within %s", path, ErrFifoNoReader, timeout)
+		}
+		time.Sleep(fifoOpenRetryPeriod)
+	}
+	defer func() { _ = fifo.Close() }()
+
+	if err := fifo.SetWriteDeadline(deadline); err != nil {
+		return fmt.Errorf("fifo %s: setting write deadline: %w", path, err)
+	}
+	payload := delivery.Content
+	if !strings.HasSuffix(payload, "\n") {
+		payload += "\n"
+	}
+	if _, err := fifo.WriteString(payload); err != nil {
+		return fmt.Errorf("writing fifo %s: %w", path, err)
+	}
+	return nil
+}
+
+func (FifoHandAdapter) DeliverSystemMessage(target Target, delivery SystemMessageDelivery) (SystemMessageResult, error) {
+	return deliverSystemMessageToInbox(target, delivery)
+}
@@ -0,0 +1,81 @@
+package controlplane
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func makeTestFifo(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "agent.fifo")
+	if err := syscall.Mkfifo(path, 0o600); err != nil {
+		t.Fatalf("Mkfifo: %v", err)
+	}
+	return path
+}
+
+func TestFifoHandAdapterDeliverWritesNotificationToReader(t *testing.T) {
+	path := makeTestFifo(t)
+	received := make(chan string, 1)
+	go func() {
+		reader, err := os.Open(path)
+		if err != nil {
+			received <- "open error: " + err.Error()
+			return
+		}
+		defer func() { _ = reader.Close() }()
+		data, _ := io.ReadAll(reader)
+		received <- string(data)
+	}()
+
+	adapter, err := DefaultHandAdapter(Target{Hand: HandAttachment{Kind: HandKindFifo, Address: path}})
+	if err != nil {
+		t.Fatalf("DefaultHandAdapter: %v", err)
+	}
+	target := Target{ActorID: "worker", Hand: HandAttachment{Kind: HandKindFifo, Address: path}}
+	if err := adapter.Deliver(target, PaneDelivery{Content: "You've got mail", TmuxTimeout: 2 * time.Second}); err != nil {
+		t.Fatalf("Deliver: %v", err)
+	}
+
+	select {
+	case got := <-received:
+		if got != "You've got mail\n" {
+			t.Fatalf("fifo payload = %q, want %q", got, "You've got mail\n")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("reader did not receive the notification")
+	}
+}
+
+func TestFifoHandAdapterDeliverTimesOutWithoutReader(t *testing.T) {
+	path := makeTestFifo(t)
+	target := Target{ActorID: "worker", Hand: HandAttachment{Kind: HandKindFifo, Address: path}}
+
+	start := time.Now()
+	err := FifoHandAdapter{}.Deliver(target, PaneDelivery{Content: "hello", TmuxTimeout: 150 * time.Millisecond})
+	if !errors.Is(err, ErrFifoNoReader) {
+		t.Fatalf("Deliver without reader error = %v, want no reader error", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("Deliver blocked for %s, want bounded by timeout", elapsed)
+	}
+}
+
+func TestFifoHandAdapterDeliverRejectsRegularFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "not-a-fifo")
+	if err := os.WriteFile(path, []byte("keep"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	target := Target{Hand: HandAttachment{Kind: HandKindFifo, Address: path}}
+	if err := (FifoHandAdapter{}).Deliver(target, PaneDelivery{Content: "hello"}); err == nil {
+		t.Fatal("Deliver to a regular file succeeded, want error")
+	}
+	if data, _ := os.ReadFile(path); string(data) != "keep" {
+		t.Fatalf("regular file was modified: %q", data)
+	}
+}
@@ -0,0 +1,95 @@
+package daemon
+
+import (
+	"time"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+)
+
+// Defaults for adaptive_scan when its knobs are unset.
+const (
+	defaultAdaptiveScanIdle = 5 * time.Minute
+	defaultAdaptiveScanMax  = 30 * time.Second
+)
+
+// adaptiveScan stretches the pane scan interval while the mesh is quiet.
+// Once no watcher event has arrived for the idle window, or every session is
+// disabled, each scan doubles the interval up to the cap; the next event
+// snaps it back to scan_interval_seconds.
+type adaptiveScan struct {
+	enabled      bool
+	base         time.Duration
+	max          time.Duration
+	idleAfter    time.Duration
+	current      time.Duration
+	lastActivity time.Time
+}
+
+func newAdaptiveScan(cfg *config.Config, now time.Time) *adaptiveScan {
+	a := &adaptiveScan{lastActivity: now}
+	if cfg == nil {
+		return a
+	}
+	a.base = time.Duration(cfg.ScanInterval * float64(time.Second))
+	a.current = a.base
+	a.enabled = config.BoolVal(cfg.AdaptiveScan, false) && a.base > 0
+	a.idleAfter = defaultAdaptiveScanIdle
+	if cfg.AdaptiveScanIdleSeconds > 0 {
+		a.idleAfter = time.Duration(cfg.AdaptiveScanIdleSeconds * float64(time.Second))
+	}
+	a.max = defaultAdaptiveScanMax
+	if cfg.AdaptiveScanMaxSeconds > 0 {
+		a.max = time.Duration(cfg.AdaptiveScanMaxSeconds * float64(time.Second))
+	}
+	if a.max < a.base {
+		a.max = a.base
+	}
+	return a
+}
+
+// next returns the interval to use after a scan and whether it changed.
+func (a *adaptiveScan) next(now time.Time, anySessionEnabled bool) (time.Duration, bool) {
+	if !a.enabled {
+		return a.current, false
+	}
+	if anySessionEnabled && now.Sub(a.lastActivity) < a.idleAfter {
+		return a.current, false
+	}
+	grown := min(a.current*2, a.max)
+	if grown == a.current {
+		return a.current, false
+	}
+	a.current = grown
+	return a.current, true
+}
+
+// activity records an event and returns the base interval and whether the
+// interval had grown.
+func (a *adaptiveScan) activity(now time.Time) (time.Duration, bool) {
+	a.lastActivity = now
+	if a.current == a.base {
+		return a.current, false
+	}
+	a.current = a.base
+	return a.current, true
+}
+
+// captureStride is how many pane-capture ticks to skip between captures so
+// capture slows down in step with the scan.
+func (a *adaptiveScan) captureStride() int {
+	if a.base <= 0 {
+		return 1
+	}
+	return max(1, int(a.current/a.base))
+}
+
+// anySessionEnabled reports whether some discovered node sits in an enabled
+// session.
+func (rt *daemonRuntime) anySessionEnabled() bool {
+	for _, nodeInfo := range rt.nodes {
+		if rt.daemonState.IsSessionEnabled(nodeInfo.SessionName) {
+			return true
+		}
+	}
+	return false
+}
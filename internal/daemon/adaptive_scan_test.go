@@ -0,0 +1,57 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+)
+
+func TestAdaptiveScan_GrowsWhileQuietAndResetsOnActivity(t *testing.T) {
+	start := time.Date(2026, time.May, 2, 9, 0, 0, 0, time.UTC)
+	scan := newAdaptiveScan(&config.Config{
+		ScanInterval:            1,
+		AdaptiveScan:            new(true),
+		AdaptiveScanIdleSeconds: 60,
+		AdaptiveScanMaxSeconds:  5,
+	}, start)
+
+	if got, changed := scan.next(start.Add(30*time.Second), true); changed || got != time.Second {
+		t.Fatalf("next() before idle window = %s (changed %v), want 1s unchanged", got, changed)
+	}
+
+	var grown []time.Duration
+	for i := range 4 {
+		got, _ := scan.next(start.Add(time.Minute+time.Duration(i)*time.Second), true)
+		grown = append(grown, got)
+	}
+	want := []time.Duration{2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i := range want {
+		if grown[i] != want[i] {
+			t.Fatalf("intervals while quiet = %v, want %v", grown, want)
+		}
+	}
+	if stride := scan.captureStride(); stride != 5 {
+		t.Fatalf("captureStride() = %d, want 5", stride)
+	}
+
+	if got, changed := scan.activity(start.Add(2 * time.Minute)); !changed || got != time.Second {
+		t.Fatalf("activity() = %s (changed %v), want reset to 1s", got, changed)
+	}
+	if got, changed := scan.next(start.Add(2*time.Minute+time.Second), true); changed || got != time.Second {
+		t.Fatalf("next() right after a delivery = %s (changed %v), want 1s", got, changed)
+	}
+
+	// With every session disabled the interval grows without waiting.
+	if got, changed := scan.next(start.Add(2*time.Minute+2*time.Second), false); !changed || got != 2*time.Second {
+		t.Fatalf("next() with no enabled session = %s (changed %v), want 2s", got, changed)
+	}
+}
+
+func TestAdaptiveScan_DisabledKeepsBaseInterval(t *testing.T) {
+	start := time.Date(2026, time.May, 2, 9, 0, 0, 0, time.UTC)
+	scan := newAdaptiveScan(&config.Config{ScanInterval: 1}, start)
+	if got, changed := scan.next(start.Add(time.Hour), false); changed || got != time.Second {
+		t.Fatalf("next() with adaptive_scan off = %s (changed %v), want 1s", got, changed)
+	}
+}
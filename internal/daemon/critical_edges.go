@@ -0,0 +1,100 @@
+package daemon
+
+import (
+	"fmt"
+	"log"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/nodeaddr"
+	"github.com/i9wa4/tmux-a2a-postman/internal/tui"
+)
+
+// EventCriticalEdgeSilent reports an @critical edge with no delivery in
+// either direction for longer than its window.
+const EventCriticalEdgeSilent = "critical_edge_silent"
+
+// lastEdgeDelivery returns the latest delivery between a and b in either
+// direction, or the zero time when there has been none. Routes are keyed
+// "from:to" as in the post filename; the recipient may carry a session
+// prefix.
+func (ds *DaemonState) lastEdgeDelivery(a, b string) time.Time {
+	ds.lastDeliveryMu.RLock()
+	defer ds.lastDeliveryMu.RUnlock()
+	var latest time.Time
+	for route, at := range ds.lastDeliveryBySenderRecipient {
+		from, to, ok := strings.Cut(route, ":")
+		if !ok {
+			continue
+		}
+		to = nodeaddr.Simple(to)
+		if ((from == a && to == b) || (from == b && to == a)) && at.After(latest) {
+			latest = at
+		}
+	}
+	return latest
+}
+
+// checkCriticalEdges emits critical_edge_silent once per quiet spell for each
+// @critical edge whose last delivery, or the daemon start when it has none,
+// is older than the edge's window. A new delivery re-arms the alert.
+func (rt *daemonRuntime) checkCriticalEdges(now time.Time) {
+	if rt.cfg == nil || rt.daemonState == nil {
+		return
+	}
+	edges, err := config.ParseCriticalEdges(rt.cfg.Edges)
+	if err != nil || len(edges) == 0 {
+		return
+	}
+	if rt.criticalEdgeAlerts == nil {
+		rt.criticalEdgeAlerts = make(map[string]time.Time)
+	}
+	for _, edge := range edges {
+		seconds := float64(edge.SilenceSeconds)
+		if seconds == 0 {
+			seconds = rt.cfg.CriticalEdgeSilenceSeconds
+		}
+		if seconds <= 0 {
+			continue
+		}
+		window := time.Duration(seconds * float64(time.Second))
+		key := edge.A + " --- " + edge.B
+		last := rt.daemonState.lastEdgeDelivery(edge.A, edge.B)
+		since := last
+		if since.IsZero() {
+			since = rt.daemonState.startedAt
+		}
+		if now.Sub(since) <= window {
+			delete(rt.criticalEdgeAlerts, key)
+			continue
+		}
+		if alertedFor, alerted := rt.criticalEdgeAlerts[key]; alerted && alertedFor.Equal(last) {
+			continue
+		}
+		rt.criticalEdgeAlerts[key] = last
+
+		silent := now.Sub(since).Round(time.Second)
+		msg := fmt.Sprintf("Critical edge silent: %s for %s", key, silent)
+		log.Printf("postman: WARNING: critical edge %s has carried no delivery for %s (window %s)\n", key, silent, window)
+		tui.SendEventNonBlocking(rt.events, tui.DaemonEvent{
+			Type:    EventCriticalEdgeSilent,
+			Message: msg,
+			Details: map[string]interface{}{
+				"edge":           key,
+				"silent_seconds": int(silent / time.Second),
+				"window_seconds": int(window / time.Second),
+			},
+		})
+		rt.displayTmuxMessage("postman: " + msg)
+	}
+}
+
+func (rt *daemonRuntime) displayTmuxMessage(msg string) {
+	if rt.displayMessage != nil {
+		rt.displayMessage(msg)
+		return
+	}
+	_ = exec.Command("tmux", "display-message", msg).Run()
+}
@@ -0,0 +1,63 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/tui"
+)
+
+func TestCheckCriticalEdges_AlertsOnlyForSilentCriticalEdge(t *testing.T) {
+	start := time.Date(2026, time.May, 21, 5, 0, 0, 0, time.UTC)
+	ds := newDaemonStateWithClock(0, "ctx-main", func() time.Time { return start })
+	events := make(chan tui.DaemonEvent, 10)
+	var displayed []string
+	rt := &daemonRuntime{
+		cfg: &config.Config{
+			Edges: []string{
+				"concierge --- orchestrator @critical",
+				"orchestrator --- observer",
+			},
+			CriticalEdgeSilenceSeconds: 600,
+		},
+		daemonState:    ds,
+		events:         events,
+		displayMessage: func(msg string) { displayed = append(displayed, msg) },
+	}
+
+	// Both edges carry traffic; neither alerts while within the window.
+	ds.finishDeliveryRoute("concierge:orchestrator", time.Time{}, false, true, start.Add(time.Minute))
+	ds.finishDeliveryRoute("orchestrator:main:observer", time.Time{}, false, true, start.Add(time.Minute))
+	rt.checkCriticalEdges(start.Add(5 * time.Minute))
+	if len(events) != 0 {
+		t.Fatalf("events = %d before the window elapsed, want 0", len(events))
+	}
+
+	// Both edges go quiet past the window: only the critical one alerts.
+	rt.checkCriticalEdges(start.Add(20 * time.Minute))
+	if len(events) != 1 {
+		t.Fatalf("events = %d, want 1 critical_edge_silent", len(events))
+	}
+	event := <-events
+	if event.Type != EventCriticalEdgeSilent || event.Details["edge"] != "concierge --- orchestrator" {
+		t.Fatalf("event = %+v, want critical_edge_silent for concierge --- orchestrator", event)
+	}
+	if len(displayed) != 1 {
+		t.Fatalf("tmux messages = %v, want one", displayed)
+	}
+
+	// The same quiet spell does not alert again.
+	rt.checkCriticalEdges(start.Add(30 * time.Minute))
+	if len(events) != 0 {
+		t.Fatalf("events = %d on repeat check, want 0", len(events))
+	}
+
+	// A delivery in the reverse direction re-arms the alert.
+	ds.finishDeliveryRoute("orchestrator:concierge", time.Time{}, false, true, start.Add(31*time.Minute))
+	rt.checkCriticalEdges(start.Add(35 * time.Minute))
+	rt.checkCriticalEdges(start.Add(45 * time.Minute))
+	if len(events) != 1 {
+		t.Fatalf("events = %d after a new quiet spell, want 1", len(events))
+	}
+}
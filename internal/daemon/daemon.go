@@ -3,6 +3,7 @@ package daemon
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -26,6 +27,7 @@ import (
 	"github.com/i9wa4/tmux-a2a-postman/internal/msgtrace"
 	"github.com/i9wa4/tmux-a2a-postman/internal/projection"
 	"github.com/i9wa4/tmux-a2a-postman/internal/runtimeprofile"
+	"github.com/i9wa4/tmux-a2a-postman/internal/status"
 	"github.com/i9wa4/tmux-a2a-postman/internal/tui"
 	"github.com/i9wa4/tmux-a2a-postman/internal/uinode"
 	"github.com/i9wa4/tmux-a2a-postman/internal/verdictgate"
@@ -80,10 +82,10 @@ func safeAfterFunc(d time.Duration, name string, events chan<- tui.DaemonEvent,
 				stack := debug.Stack()
 				log.Printf("🚨 PANIC in timer callback %q: %v\n%s\n", name, r, string(stack))
 				if events != nil {
-					events <- tui.DaemonEvent{
+					tui.SendEventNonBlocking(events, tui.DaemonEvent{
 						Type:    "error",
 						Message: fmt.Sprintf("Internal error in %s (recovered)", name),
-					}
+					})
 				}
 			}
 		}()
@@ -417,6 +419,43 @@ func writeRuntimeProfileFile(outputPath, requestID string, data []byte, force bo
 	return nil
 }
 
+// handleDaemonSubmitAck archives one inbox message of request.Node to read/
+// and journals the ack. A message that already left the inbox answers Empty.
+func handleDaemonSubmitAck(sessionDir string, request projection.DaemonSubmitRequest) (projection.DaemonSubmitResponse, error) {
+	if request.RequestID == "" {
+		return projection.DaemonSubmitResponse{}, fmt.Errorf("daemon submit ack missing request_id")
+	}
+	if request.Node == "" || request.Filename == "" {
+		return projection.DaemonSubmitResponse{}, fmt.Errorf("daemon submit ack missing node or filename")
+	}
+	if filepath.Base(request.Filename) != request.Filename {
+		return projection.DaemonSubmitResponse{}, fmt.Errorf("daemon submit ack filename %q is not a bare filename", request.Filename)
+	}
+	response := projection.DaemonSubmitResponse{
+		RequestID: request.RequestID,
+		Command:   request.Command,
+		HandledAt: time.Now().UTC().Format(time.RFC3339),
+	}
+	readPath, err := message.ArchiveInboxMessage(filepath.Join(sessionDir, "inbox", request.Node, request.Filename), request.Filename)
+	if errors.Is(err, os.ErrNotExist) {
+		response.Empty = true
+		return response, nil
+	}
+	if err != nil {
+		return response, err
+	}
+	sessionName := filepath.Base(sessionDir)
+	if err := journal.RecordProcessEvent(sessionDir, sessionName, projection.NodeAckedEventType, journal.VisibilityOperatorVisible, projection.NodeAckedEventPayload{
+		NodeKey:  sessionName + ":" + request.Node,
+		Filename: request.Filename,
+	}, time.Now()); err != nil {
+		log.Printf("postman: WARNING: failed to journal ack of %s by %s: %v\n", request.Filename, request.Node, err)
+	}
+	response.Filename = request.Filename
+	response.MarkdownPath = readPath
+	return response, nil
+}
+
 func recordDaemonSubmitPopRead(sessionDir, readPath, filename, fallbackContent string) {
 	// fallbackContent was read directly from the inbox message before it was
 	// archived to readPath, so it is already known-good. Re-reading readPath
@@ -443,6 +482,8 @@ type daemonSubmitProcessResult struct {
 	Filename                 string
 	PostPath                 string
 	ProjectionSyncSessionDir string
+	// AckNode is the node whose inbox message an ack request archived.
+	AckNode string
 }
 
 func (r daemonSubmitProcessResult) hasPostDispatch() bool {
@@ -508,6 +549,12 @@ func processDaemonSubmitRequest(requestPath string) (daemonSubmitProcessResult,
 		if err == nil && !response.Empty {
 			result.ProjectionSyncSessionDir = sessionDir
 		}
+	case projection.DaemonSubmitAck:
+		response, err = handleDaemonSubmitAck(sessionDir, request)
+		if err == nil && !response.Empty {
+			result.ProjectionSyncSessionDir = sessionDir
+			result.AckNode = request.Node
+		}
 	case projection.DaemonSubmitRuntimeProfile:
 		response, err = handleDaemonSubmitRuntimeProfile(sessionDir, request)
 	default:
@@ -584,6 +631,10 @@ type DaemonState struct {
 	drainWindow                   time.Duration // Startup drain window duration (#217)
 	enabledSessions               map[string]bool
 	enabledSessionsMu             sync.RWMutex
+	mutedNodes                    map[string]bool // Session-prefixed node keys whose mail arrives without a pane notification
+	mutedNodesMu                  sync.RWMutex
+	deadPaneNodes                 map[string]bool // Session-prefixed node keys whose pane disappeared and has not returned
+	deadPaneNodesMu               sync.RWMutex
 	prevPaneStates                map[string]uinode.PaneInfo // Issue #98: Track previous pane states for restart detection
 	prevPaneStatesMu              sync.RWMutex               // Issue #98: Mutex for prevPaneStates
 	prevPaneToNode                map[string]string          // Track previous pane ID -> node key mapping for restart detection
@@ -591,6 +642,16 @@ type DaemonState struct {
 	reservedDeliveryByRoute       map[string]time.Time       // Issue #393: in-flight rate-limit reservations (sender:recipient -> time)
 	lastDeliveryMu                sync.RWMutex               // Issue #211: Mutex for lastDeliveryBySenderRecipient
 	nonDaemonDeliveryBudget       *nonDaemonDeliveryBudget   // Issue #572: bounded concurrency for post/auto-PING/manual-PING delivery
+	senderMessageTimes            map[string][]time.Time     // Per-sender reserved message times within the rate-limit window
+	senderRateLimitNotifiedAt     map[string]time.Time       // Last rate_limited event per sender (once per window)
+	senderRateMu                  sync.Mutex
+	sessionQuotaTimes             map[string][]time.Time // Per-session reserved message times within the quota window
+	sessionQuotaNotifiedAt        map[string]time.Time   // Last session_quota event per session (once per window)
+	sessionQuotaMu                sync.Mutex
+	deadLetterTimes               []time.Time                   // Dead-letter outcomes for the mesh_summary rollup
+	deadLetterReasons             status.DeadLetterReasonCounts // Dead-letters by cause since start
+	deadLetterMu                  sync.Mutex
+	reindexRequests               chan struct{} // Pending reindex request (SIGUSR1); buffered, coalesced
 	clock                         func() time.Time
 }
 
@@ -610,11 +671,18 @@ func newDaemonStateWithClock(drainWindowSeconds float64, contextID string, clock
 		startedAt:                     clock(),
 		drainWindow:                   time.Duration(drainWindowSeconds * float64(time.Second)),
 		enabledSessions:               make(map[string]bool),
+		mutedNodes:                    make(map[string]bool),
+		deadPaneNodes:                 make(map[string]bool),
 		prevPaneStates:                make(map[string]uinode.PaneInfo), // Issue #98
 		prevPaneToNode:                make(map[string]string),          // paneID -> nodeKey mapping
 		lastDeliveryBySenderRecipient: make(map[string]time.Time),       // Issue #211
 		reservedDeliveryByRoute:       make(map[string]time.Time),
 		nonDaemonDeliveryBudget:       newNonDaemonDeliveryBudget(clock),
+		senderMessageTimes:            make(map[string][]time.Time),
+		senderRateLimitNotifiedAt:     make(map[string]time.Time),
+		sessionQuotaTimes:             make(map[string][]time.Time),
+		sessionQuotaNotifiedAt:        make(map[string]time.Time),
+		reindexRequests:               make(chan struct{}, 1),
 		clock:                         clock,
 	}
 }
@@ -712,6 +780,199 @@ func (ds *DaemonState) finishDeliveryRoute(route string, reservedAt time.Time, h
 	}
 }
 
+// senderRateWindow is the sliding window for max_messages_per_minute.
+const senderRateWindow = time.Minute
+
+// recentTimes returns the times after cutoff, reusing the backing array.
+func recentTimes(times []time.Time, cutoff time.Time) []time.Time {
+	kept := times[:0]
+	for _, t := range times {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	return kept
+}
+
+// reserveSenderRate reserves one of sender's limit messages in the last
+// senderRateWindow, in the same locked step as the check, so concurrent
+// deliveries cannot all pass before any of them counts. limited means the
+// budget was already spent and nothing was reserved; notify is true for the
+// first rejection of a sender per window. A message that ends up not
+// delivered hands its slot back through releaseSenderRate.
+func (ds *DaemonState) reserveSenderRate(sender string, limit int, now time.Time) (limited, notify bool) {
+	if limit <= 0 {
+		return false, false
+	}
+	ds.senderRateMu.Lock()
+	defer ds.senderRateMu.Unlock()
+	if ds.senderMessageTimes == nil {
+		ds.senderMessageTimes = make(map[string][]time.Time)
+	}
+	if ds.senderRateLimitNotifiedAt == nil {
+		ds.senderRateLimitNotifiedAt = make(map[string]time.Time)
+	}
+
+	kept := recentTimes(ds.senderMessageTimes[sender], now.Add(-senderRateWindow))
+	if len(kept) < limit {
+		ds.senderMessageTimes[sender] = append(kept, now)
+		return false, false
+	}
+	ds.senderMessageTimes[sender] = kept
+	if notifiedAt, ok := ds.senderRateLimitNotifiedAt[sender]; ok && now.Sub(notifiedAt) < senderRateWindow {
+		return true, false
+	}
+	ds.senderRateLimitNotifiedAt[sender] = now
+	return true, true
+}
+
+// releaseSenderRate hands back sender's newest reservation.
+func (ds *DaemonState) releaseSenderRate(sender string) {
+	ds.senderRateMu.Lock()
+	defer ds.senderRateMu.Unlock()
+	if times := ds.senderMessageTimes[sender]; len(times) > 0 {
+		ds.senderMessageTimes[sender] = times[:len(times)-1]
+	}
+}
+
+// sessionQuotaPeriod is the sliding window for max_messages_per_hour.
+const sessionQuotaPeriod = time.Hour
+
+// recentSessionQuotaTimes drops delivery times older than the quota window.
+// The caller holds sessionQuotaMu.
+func (ds *DaemonState) recentSessionQuotaTimes(session string, now time.Time) []time.Time {
+	if ds.sessionQuotaTimes == nil {
+		ds.sessionQuotaTimes = make(map[string][]time.Time)
+	}
+	kept := recentTimes(ds.sessionQuotaTimes[session], now.Add(-sessionQuotaPeriod))
+	ds.sessionQuotaTimes[session] = kept
+	return kept
+}
+
+// reserveSessionQuota reserves one of session's limit messages in the last
+// sessionQuotaPeriod, checking and counting under one lock like
+// reserveSenderRate. exceeded means the quota was already used up and
+// nothing was reserved; notify is true at most once per period. A message
+// that ends up not delivered hands its slot back through
+// releaseSessionQuota.
+func (ds *DaemonState) reserveSessionQuota(session string, limit int, now time.Time) (exceeded, notify bool) {
+	if limit <= 0 {
+		return false, false
+	}
+	ds.sessionQuotaMu.Lock()
+	defer ds.sessionQuotaMu.Unlock()
+	if kept := ds.recentSessionQuotaTimes(session, now); len(kept) < limit {
+		ds.sessionQuotaTimes[session] = append(kept, now)
+		return false, false
+	}
+	if ds.sessionQuotaNotifiedAt == nil {
+		ds.sessionQuotaNotifiedAt = make(map[string]time.Time)
+	}
+	if notifiedAt, ok := ds.sessionQuotaNotifiedAt[session]; ok && now.Sub(notifiedAt) < sessionQuotaPeriod {
+		return true, false
+	}
+	ds.sessionQuotaNotifiedAt[session] = now
+	return true, true
+}
+
+// releaseSessionQuota hands back session's newest reservation.
+func (ds *DaemonState) releaseSessionQuota(session string) {
+	ds.sessionQuotaMu.Lock()
+	defer ds.sessionQuotaMu.Unlock()
+	if times := ds.sessionQuotaTimes[session]; len(times) > 0 {
+		ds.sessionQuotaTimes[session] = times[:len(times)-1]
+	}
+}
+
+// RequestReindex asks the daemon loop to rebuild discovery and watch state.
+// Requests made while one is already pending are coalesced.
+func (ds *DaemonState) RequestReindex() {
+	select {
+	case ds.reindexRequests <- struct{}{}:
+	default:
+	}
+}
+
+func (ds *DaemonState) reindexRequested() <-chan struct{} {
+	if ds == nil {
+		return nil
+	}
+	return ds.reindexRequests
+}
+
+// recordDeadLetter notes one dead-lettered post for the mesh_summary rollup.
+func (ds *DaemonState) recordDeadLetter(now time.Time) {
+	if ds == nil {
+		return
+	}
+	ds.deadLetterMu.Lock()
+	ds.deadLetterTimes = append(ds.deadLetterTimes, now)
+	ds.deadLetterMu.Unlock()
+}
+
+// recordDeadLetterReason counts one dead-letter under its cause. reason is
+// the failure_reason of the delivery event.
+func (ds *DaemonState) recordDeadLetterReason(reason string) {
+	if ds == nil {
+		return
+	}
+	ds.deadLetterMu.Lock()
+	defer ds.deadLetterMu.Unlock()
+	switch reason {
+	case "parse error":
+		ds.deadLetterReasons.ParseError++
+	case "unknown recipient", "unknown recipient session":
+		ds.deadLetterReasons.UnknownRecipient++
+	case "routing denied", "method not permitted":
+		ds.deadLetterReasons.RoutingDenied++
+	case "sender session disabled", "recipient session disabled":
+		ds.deadLetterReasons.SessionDisabled++
+	case "ttl expired":
+		ds.deadLetterReasons.Expired++
+	default:
+		ds.deadLetterReasons.Other++
+	}
+}
+
+// RecordStartupTTLDrain counts the post/ messages DrainStalePost moved to
+// dead-letter before the daemon loop started, so they show up as expired in
+// mesh_summary.
+func (ds *DaemonState) RecordStartupTTLDrain(count int, now time.Time) {
+	for range count {
+		ds.recordDeadLetter(now)
+		ds.recordDeadLetterReason("ttl expired")
+	}
+}
+
+// deadLetterReasonCounts returns the per-cause dead-letter totals.
+func (ds *DaemonState) deadLetterReasonCounts() status.DeadLetterReasonCounts {
+	if ds == nil {
+		return status.DeadLetterReasonCounts{}
+	}
+	ds.deadLetterMu.Lock()
+	defer ds.deadLetterMu.Unlock()
+	return ds.deadLetterReasons
+}
+
+// recentDeadLetters returns the number of dead-letters within window of now
+// and forgets older ones.
+func (ds *DaemonState) recentDeadLetters(window time.Duration, now time.Time) int {
+	if ds == nil {
+		return 0
+	}
+	ds.deadLetterMu.Lock()
+	defer ds.deadLetterMu.Unlock()
+	cutoff := now.Add(-window)
+	kept := ds.deadLetterTimes[:0]
+	for _, t := range ds.deadLetterTimes {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	ds.deadLetterTimes = kept
+	return len(kept)
+}
+
 // filterNodesByEdges removes nodes from the map whose raw name (after session prefix)
 // is not listed in the configured edges. Modifies the map in place.
 func filterNodesByEdges(nodes map[string]discovery.NodeInfo, edges []string) {
@@ -826,6 +1087,28 @@ func runDaemonLoopWithWatcherEvents(
 	defer inboxCheckTicker.Stop()
 	runtimeDiagnosticsTicker := time.NewTicker(runtimeDiagnosticsLogInterval)
 	defer runtimeDiagnosticsTicker.Stop()
+	deliveryLogPruneTicker := time.NewTicker(deliveryLogPruneInterval)
+	defer deliveryLogPruneTicker.Stop()
+	var meshSummaryC <-chan time.Time
+	if interval := meshSummaryInterval(cfg); interval > 0 {
+		meshSummaryTicker := time.NewTicker(interval)
+		defer meshSummaryTicker.Stop()
+		meshSummaryC = meshSummaryTicker.C
+	}
+	var readArchivalC <-chan time.Time
+	if interval := readArchivalCheckInterval(cfg); interval > 0 {
+		readArchivalTicker := time.NewTicker(interval)
+		defer readArchivalTicker.Stop()
+		readArchivalC = readArchivalTicker.C
+	}
+
+	adaptive := newAdaptiveScan(cfg, runtime.now())
+	applyScanInterval := func(interval time.Duration) {
+		scanTicker.Reset(interval)
+		if idleTracker != nil {
+			idleTracker.SetCaptureStride(adaptive.captureStride())
+		}
+	}
 
 	runtime.bootstrap()
 	runtime.logRuntimeDiagnosticsSnapshot("startup", runtime.now())
@@ -833,6 +1116,7 @@ func runDaemonLoopWithWatcherEvents(
 	for {
 		select {
 		case <-ctx.Done():
+			runtime.drainPendingPosts(shutdownDrainTimeout(cfg))
 			runtime.handleContextDone()
 			runtime.waitForMailboxProjectionSyncs()
 			return
@@ -842,6 +1126,9 @@ func runDaemonLoopWithWatcherEvents(
 				return
 			}
 			runtime.handleWatcherEvent(event)
+			if interval, changed := adaptive.activity(runtime.now()); changed {
+				applyScanInterval(interval)
+			}
 		case err, ok := <-watcherErrors:
 			if !ok {
 				runtime.waitForMailboxProjectionSyncs()
@@ -850,14 +1137,27 @@ func runDaemonLoopWithWatcherEvents(
 			runtime.handleWatcherError(err)
 		case workerResult := <-runtime.daemonSubmitResults:
 			runtime.handleDaemonSubmitResult(workerResult)
+		case retry := <-runtime.sessionDirRetries:
+			runtime.createNodeWatchDirs(retry)
 		case <-scanTicker.C:
 			runtime.handleScanTick()
+			if interval, changed := adaptive.next(runtime.now(), runtime.anySessionEnabled()); changed {
+				applyScanInterval(interval)
+			}
 		case <-sessionScanTicker.C:
 			runtime.handleSessionScanTick()
 		case <-inboxCheckTicker.C:
 			runtime.handleInboxCheckTick()
 		case <-runtimeDiagnosticsTicker.C:
 			runtime.logRuntimeDiagnosticsSnapshot("interval", runtime.now())
+		case <-deliveryLogPruneTicker.C:
+			runtime.pruneDeliveryLogs(runtime.now())
+		case <-meshSummaryC:
+			runtime.handleMeshSummaryTick()
+		case <-readArchivalC:
+			runtime.handleReadArchivalTick()
+		case <-daemonState.reindexRequested():
+			runtime.handleReindex()
 		}
 	}
 }
@@ -899,6 +1199,54 @@ func (ds *DaemonState) SetSessionEnabled(sessionName string, enabled bool) {
 	ds.persistSessionEnabledMarker(sessionName, enabled)
 }
 
+// SetNodeMuted mutes or unmutes pane notifications for nodeKey
+// ("session:node"). Muted nodes still receive mail in their inbox.
+func (ds *DaemonState) SetNodeMuted(nodeKey string, muted bool) {
+	ds.mutedNodesMu.Lock()
+	if muted {
+		ds.mutedNodes[nodeKey] = true
+	} else {
+		delete(ds.mutedNodes, nodeKey)
+	}
+	ds.mutedNodesMu.Unlock()
+	log.Printf("postman: node mute change: node=%s muted=%v ts=%s\n",
+		nodeKey, muted, ds.now().UTC().Format(time.RFC3339Nano))
+}
+
+// IsNodeMuted reports whether pane notifications for nodeKey are muted.
+func (ds *DaemonState) IsNodeMuted(nodeKey string) bool {
+	if ds == nil {
+		return false
+	}
+	ds.mutedNodesMu.RLock()
+	defer ds.mutedNodesMu.RUnlock()
+	return ds.mutedNodes[nodeKey]
+}
+
+// IsNodePaneDead reports whether nodeKey lost its pane and has not been
+// rediscovered since (dead_pane_policy).
+func (ds *DaemonState) IsNodePaneDead(nodeKey string) bool {
+	if ds == nil {
+		return false
+	}
+	ds.deadPaneNodesMu.RLock()
+	defer ds.deadPaneNodesMu.RUnlock()
+	return ds.deadPaneNodes[nodeKey]
+}
+
+// forgetRevivedPanes clears the dead-pane mark of every node present in
+// nodes, i.e. rediscovered in a live pane.
+func (ds *DaemonState) forgetRevivedPanes(nodes map[string]discovery.NodeInfo) {
+	ds.deadPaneNodesMu.Lock()
+	defer ds.deadPaneNodesMu.Unlock()
+	for nodeKey := range ds.deadPaneNodes {
+		if _, ok := nodes[nodeKey]; ok {
+			delete(ds.deadPaneNodes, nodeKey)
+			log.Printf("postman: pane returned for node %s\n", nodeKey)
+		}
+	}
+}
+
 func (ds *DaemonState) persistSessionEnabledMarker(sessionName string, enabled bool) {
 	// Persist cross-daemon state in tmux server option (best-effort).
 	key := "@a2a_session_on_" + sessionName
@@ -1022,7 +1370,7 @@ func (ds *DaemonState) checkPaneRestarts(paneStates map[string]uinode.PaneInfo,
 			restartedNodeKeys = append(restartedNodeKeys, nodeKey)
 
 			// Send TUI event
-			events <- tui.DaemonEvent{
+			tui.SendEventNonBlocking(events, tui.DaemonEvent{
 				Type:    "pane_restart",
 				Message: fmt.Sprintf("Pane restart detected: %s (old: %s, new: %s)", nodeKey, oldPaneID, currentPaneID),
 				Details: map[string]interface{}{
@@ -1031,7 +1379,7 @@ func (ds *DaemonState) checkPaneRestarts(paneStates map[string]uinode.PaneInfo,
 					"new_pane_id": currentPaneID,
 					"pane_info":   currentInfo,
 				},
-			}
+			})
 		}
 	}
 
@@ -1076,13 +1424,20 @@ func (ds *DaemonState) checkPaneDisappearance(currentPaneStates map[string]uinod
 				}
 
 				// Send pane_disappeared event to TUI
-				events <- tui.DaemonEvent{
+				tui.SendEventNonBlocking(events, tui.DaemonEvent{
 					Type:    "pane_disappeared",
 					Message: fmt.Sprintf("Pane disappeared: %s (node: %s)", prevPaneID, nodeKey),
 					Details: details,
-				}
+				})
 				log.Printf("postman: pane disappeared for node %s (paneID: %s, inbox: %d)\n", nodeKey, prevPaneID, inboxCount)
 
+				// A node already rediscovered in another pane is not dead.
+				if _, rediscovered := knownNodes[nodeKey]; !rediscovered {
+					ds.deadPaneNodesMu.Lock()
+					ds.deadPaneNodes[nodeKey] = true
+					ds.deadPaneNodesMu.Unlock()
+				}
+
 				// Group by session name
 				sessionName := nodeKey
 				if parts := strings.SplitN(nodeKey, ":", 2); len(parts) == 2 {
@@ -1096,7 +1451,7 @@ func (ds *DaemonState) checkPaneDisappearance(currentPaneStates map[string]uinod
 	// Emit session_collapsed event when 2+ panes from same session disappeared (Issue #209)
 	for sessionName, collapsedNodes := range disappearedBySession {
 		if len(collapsedNodes) >= 2 {
-			events <- tui.DaemonEvent{
+			tui.SendEventNonBlocking(events, tui.DaemonEvent{
 				Type:    "session_collapsed",
 				Message: fmt.Sprintf("Session collapsed: %s (%d panes disappeared)", sessionName, len(collapsedNodes)),
 				Details: map[string]interface{}{
@@ -1104,7 +1459,7 @@ func (ds *DaemonState) checkPaneDisappearance(currentPaneStates map[string]uinod
 					"nodes":   collapsedNodes,
 					"count":   len(collapsedNodes),
 				},
-			}
+			})
 			log.Printf("postman: session collapsed: %s (%d panes disappeared: %v)\n", sessionName, len(collapsedNodes), collapsedNodes)
 		}
 	}
@@ -14,9 +14,12 @@ import (
 	"time"
 
 	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/discovery"
+	"github.com/i9wa4/tmux-a2a-postman/internal/idle"
 	"github.com/i9wa4/tmux-a2a-postman/internal/journal"
 	"github.com/i9wa4/tmux-a2a-postman/internal/projection"
 	"github.com/i9wa4/tmux-a2a-postman/internal/runtimeprofile"
+	"github.com/i9wa4/tmux-a2a-postman/internal/tui"
 )
 
 func verdictGateSendContent(from, to, messageID, replyPolicy, inputRequestID string) string {
@@ -1004,6 +1007,56 @@ func TestProcessDaemonSubmitRequest_PopArchivesUnreadMessage(t *testing.T) {
 	}
 }
 
+func TestProcessDaemonSubmitRequest_AckArchivesAndRecordsAck(t *testing.T) {
+	sessionDir := filepath.Join(t.TempDir(), "review-session")
+	if err := config.CreateSessionDirs(sessionDir); err != nil {
+		t.Fatalf("CreateSessionDirs: %v", err)
+	}
+	inboxDir := filepath.Join(sessionDir, "inbox", "worker")
+	if err := os.MkdirAll(inboxDir, 0o700); err != nil {
+		t.Fatalf("MkdirAll inbox: %v", err)
+	}
+	filename := "20260414-033200-from-orchestrator-to-worker.md"
+	if err := os.WriteFile(filepath.Join(inboxDir, filename), []byte("---\nparams:\n  from: orchestrator\n  to: worker\n---\n\nbody\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	requestPath, err := projection.WriteDaemonSubmitRequest(sessionDir, projection.DaemonSubmitRequest{
+		RequestID: "req-ack",
+		Command:   projection.DaemonSubmitAck,
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		Node:      "worker",
+		Filename:  filename,
+	})
+	if err != nil {
+		t.Fatalf("WriteDaemonSubmitRequest: %v", err)
+	}
+
+	result, err := processDaemonSubmitRequest(requestPath)
+	if err != nil {
+		t.Fatalf("processDaemonSubmitRequest: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(sessionDir, "read", filename)); err != nil {
+		t.Fatalf("acked message missing from read/: %v", err)
+	}
+	if result.AckNode != "worker" {
+		t.Fatalf("result.AckNode = %q, want worker", result.AckNode)
+	}
+
+	tracker := idle.NewIdleTracker()
+	nodeKey := "review-session:worker"
+	tracker.UpdateReceiveActivity(nodeKey)
+	rt := &daemonRuntime{
+		sessionDir:  sessionDir,
+		nodes:       map[string]discovery.NodeInfo{},
+		events:      make(chan tui.DaemonEvent, 4),
+		idleTracker: tracker,
+	}
+	rt.handleDaemonSubmitResult(daemonSubmitRuntimeResult{requestPath: requestPath, result: result})
+	if tracker.HasUnackedReceipt(nodeKey) {
+		t.Fatalf("ack did not clear %s unacknowledged receipt", nodeKey)
+	}
+}
+
 func TestProcessDaemonSubmitRequest_PopRecordsReadBeforeProjectionSync(t *testing.T) {
 	sessionDir := filepath.Join(t.TempDir(), "review-session")
 	if err := config.CreateSessionDirs(sessionDir); err != nil {
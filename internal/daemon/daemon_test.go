@@ -1,13 +1,19 @@
 package daemon
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
 	"github.com/i9wa4/tmux-a2a-postman/internal/discovery"
+	"github.com/i9wa4/tmux-a2a-postman/internal/idle"
 	"github.com/i9wa4/tmux-a2a-postman/internal/message"
+	"github.com/i9wa4/tmux-a2a-postman/internal/tmuxtest"
 	"github.com/i9wa4/tmux-a2a-postman/internal/tui"
 	"github.com/i9wa4/tmux-a2a-postman/internal/uinode"
 )
@@ -46,6 +52,113 @@ func TestCheckPaneRestarts_IgnoresWinnerSwapWhileOldPaneStillLive(t *testing.T)
 	}
 }
 
+func TestRenotifyRestartedNodes_ResendsUnreadInboxOnly(t *testing.T) {
+	fake := tmuxtest.Install(t, tmuxtest.WithPane(tmuxtest.Pane{ID: "%11", SessionName: "review", Title: "worker"}))
+	sessionDir := t.TempDir()
+	for _, dir := range []string{"inbox/worker", "read"} {
+		if err := os.MkdirAll(filepath.Join(sessionDir, dir), 0o700); err != nil {
+			t.Fatalf("MkdirAll: %v", err)
+		}
+	}
+	unread := []string{
+		"20260201-040000-from-boss-to-worker.md",
+		"20260201-040500-from-critic-to-worker.md",
+	}
+	read := "20260201-030000-from-boss-to-worker.md"
+	for _, name := range unread {
+		if err := os.WriteFile(filepath.Join(sessionDir, "inbox", "worker", name), []byte("body\n"), 0o600); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(sessionDir, "read", read), []byte("body\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	ds := NewDaemonState(0, "ctx-main")
+	ds.prevPaneStates = map[string]uinode.PaneInfo{"%10": {}}
+	ds.prevPaneToNode = map[string]string{"%10": "review:worker"}
+	nodes := map[string]discovery.NodeInfo{
+		"review:worker": {PaneID: "%11", SessionName: "review", SessionDir: sessionDir},
+	}
+	restarted := ds.checkPaneRestarts(map[string]uinode.PaneInfo{"%11": {}}, map[string]string{"%11": "review:worker"}, nodes, make(chan tui.DaemonEvent, 1))
+	if len(restarted) != 1 || restarted[0] != "review:worker" {
+		t.Fatalf("checkPaneRestarts() = %#v, want review:worker", restarted)
+	}
+
+	rt := &daemonRuntime{
+		cfg: &config.Config{
+			TmuxTimeout:          1,
+			RenotifyOnRestart:    new(true),
+			NotificationTemplate: "mail: {filename}",
+		},
+		contextID: "ctx-main",
+	}
+	rt.renotifyRestartedNodes(restarted, nodes, nil)
+
+	log := strings.Join(fake.Invocations(), "\n")
+	last := -1
+	for _, name := range unread {
+		idx := strings.Index(log, "mail: "+name)
+		if idx <= last {
+			t.Fatalf("tmux log missing notification for unread %s in order:\n%s", name, log)
+		}
+		last = idx
+	}
+	if strings.Contains(log, read) {
+		t.Fatalf("already-read %s was re-notified:\n%s", read, log)
+	}
+	if got := strings.Count(log, "paste-buffer -t %11"); got != len(unread) {
+		t.Fatalf("pastes to restarted pane = %d, want %d:\n%s", got, len(unread), log)
+	}
+}
+
+func TestScheduleRestartRenotify_WaitsForAutoPingDelay(t *testing.T) {
+	fake := tmuxtest.Install(t, tmuxtest.WithPane(tmuxtest.Pane{ID: "%11", SessionName: "review", Title: "worker"}))
+	sessionDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(sessionDir, "inbox", "worker"), 0o700); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	name := "20260201-040000-from-boss-to-worker.md"
+	if err := os.WriteFile(filepath.Join(sessionDir, "inbox", "worker", name), []byte("body\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	var delays []time.Duration
+	var names []string
+	var callbacks []func()
+	rt := &daemonRuntime{
+		cfg: &config.Config{
+			TmuxTimeout:          1,
+			RenotifyOnRestart:    new(true),
+			AutoPingDelaySeconds: 2.5,
+			NotificationTemplate: "mail: {filename}",
+		},
+		contextID:   "ctx-main",
+		idleTracker: idle.NewIdleTracker(),
+		nodes: map[string]discovery.NodeInfo{
+			"review:worker": {PaneID: "%11", SessionName: "review", SessionDir: sessionDir},
+		},
+		scheduleRuntimeTimer: func(delay time.Duration, name string, _ chan<- tui.DaemonEvent, callback func()) {
+			delays = append(delays, delay)
+			names = append(names, name)
+			callbacks = append(callbacks, callback)
+		},
+	}
+
+	rt.scheduleRestartRenotify([]string{"review:worker"})
+	if len(callbacks) != 1 || delays[0] != 2500*time.Millisecond || names[0] != "restart-renotify" {
+		t.Fatalf("scheduled = %v %v, want one restart-renotify after 2.5s", names, delays)
+	}
+	if log := strings.Join(fake.Invocations(), "\n"); strings.Contains(log, "mail: "+name) {
+		t.Fatalf("notification sent before the delay:\n%s", log)
+	}
+
+	callbacks[0]()
+	if log := strings.Join(fake.Invocations(), "\n"); !strings.Contains(log, "mail: "+name) {
+		t.Fatalf("tmux log missing re-notification after the delay:\n%s", log)
+	}
+}
+
 func TestDaemonStateDrainWindowUsesInjectedClock(t *testing.T) {
 	now := time.Date(2026, time.May, 21, 5, 0, 0, 0, time.UTC)
 	ds := newDaemonStateWithClock(10, "ctx-main", func() time.Time { return now })
@@ -222,3 +335,274 @@ func TestScanLiveInboxCounts_CountsUnreadInboxMarkdownFiles(t *testing.T) {
 		t.Fatalf("scanLiveInboxCounts review:worker = %d, want 2", got)
 	}
 }
+
+func TestSenderRateSlidingWindow(t *testing.T) {
+	ds := NewDaemonState(0, "ctx-main")
+	now := time.Date(2026, time.May, 21, 5, 0, 0, 0, time.UTC)
+	sender := "review:worker"
+
+	for i := 0; i < 2; i++ {
+		if limited, _ := ds.reserveSenderRate(sender, 2, now.Add(time.Duration(i)*time.Second)); limited {
+			t.Fatalf("message %d limited, want allowed within limit", i+1)
+		}
+	}
+	limited, notify := ds.reserveSenderRate(sender, 2, now.Add(2*time.Second))
+	if !limited || !notify {
+		t.Fatalf("third message = (limited %v, notify %v), want (true, true)", limited, notify)
+	}
+	limited, notify = ds.reserveSenderRate(sender, 2, now.Add(3*time.Second))
+	if !limited || notify {
+		t.Fatalf("fourth message = (limited %v, notify %v), want (true, false)", limited, notify)
+	}
+	if limited, _ := ds.reserveSenderRate("review:critic", 2, now.Add(3*time.Second)); limited {
+		t.Fatal("other sender limited, want independent windows")
+	}
+
+	// A released reservation frees its slot again.
+	ds.releaseSenderRate(sender)
+	if limited, _ := ds.reserveSenderRate(sender, 2, now.Add(4*time.Second)); limited {
+		t.Fatal("message after a release limited, want the slot back")
+	}
+
+	if limited, _ := ds.reserveSenderRate(sender, 2, now.Add(65*time.Second)); limited {
+		t.Fatal("message after window elapsed limited, want window reset")
+	}
+	if limited, _ := ds.reserveSenderRate(sender, 0, now.Add(65*time.Second)); limited {
+		t.Fatal("limit 0 limited a message, want unlimited")
+	}
+}
+
+func TestSessionQuota_DeadLettersOverflowUntilWindowResets(t *testing.T) {
+	sessionDir := filepath.Join(t.TempDir(), "test")
+	if err := config.CreateSessionDirs(sessionDir); err != nil {
+		t.Fatalf("config.CreateSessionDirs failed: %v", err)
+	}
+	now := time.Date(2026, time.May, 21, 5, 0, 0, 0, time.UTC)
+	events := make(chan tui.DaemonEvent, 10)
+	cfg := &config.Config{
+		EnterDelay:         0.1,
+		TmuxTimeout:        1.0,
+		MaxMessagesPerHour: 5,
+		Sessions:           map[string]config.SessionConfig{"test": {MaxMessagesPerHour: 2}},
+	}
+	rt := &daemonRuntime{
+		cfg:         cfg,
+		daemonState: newDaemonStateWithClock(0, "ctx-main", func() time.Time { return now }),
+		events:      events,
+		clock:       func() time.Time { return now },
+	}
+	nodes := map[string]discovery.NodeInfo{
+		"test:worker":       {PaneID: "%1", SessionName: "test", SessionDir: sessionDir},
+		"test:orchestrator": {PaneID: "%2", SessionName: "test", SessionDir: sessionDir},
+	}
+	adjacency := map[string][]string{"orchestrator": {"worker"}, "worker": {"orchestrator"}}
+
+	deliver := func(i int) {
+		t.Helper()
+		filename := fmt.Sprintf("20260201-04000%d-from-orchestrator-to-worker.md", i)
+		postPath := filepath.Join(sessionDir, "post", filename)
+		content := "---\nparams:\n  from: orchestrator\n  to: worker\n  timestamp: 2026-02-01T04:00:00Z\n---\n\nbusy\n"
+		if err := os.WriteFile(postPath, []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		if err := message.DeliverMessageWithOptions(postPath, "ctx-main", nodes, adjacency, cfg, func(string) bool { return true }, nil, idle.NewIdleTracker(), "", rt.deliverOptions(cfg)); err != nil {
+			t.Fatalf("DeliverMessageWithOptions(%d) failed: %v", i, err)
+		}
+	}
+
+	// The session override (2) wins over the global quota (5).
+	for i := range 3 {
+		deliver(i)
+	}
+	inbox := filepath.Join(sessionDir, "inbox", "worker")
+	if got := len(message.ScanInboxMessages(inbox)); got != 2 {
+		t.Fatalf("inbox count = %d, want 2 within the quota", got)
+	}
+	if _, err := os.Stat(filepath.Join(sessionDir, "dead-letter", "20260201-040002-from-orchestrator-to-worker-dl-session-quota.md")); err != nil {
+		t.Fatalf("message over the quota not dead-lettered as session quota: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("events = %d, want one session_quota", len(events))
+	}
+	if event := <-events; event.Type != EventSessionQuota || event.Details["session"] != "test" {
+		t.Fatalf("event = %+v, want session_quota for test", event)
+	}
+
+	// Still inside the hour: blocked again, without a second event.
+	now = now.Add(30 * time.Minute)
+	deliver(3)
+	if got := len(message.ScanInboxMessages(inbox)); got != 2 {
+		t.Fatalf("inbox count = %d inside the window, want 2", got)
+	}
+	if len(events) != 0 {
+		t.Fatalf("events = %d on repeat overflow, want 0", len(events))
+	}
+
+	// Once the earlier deliveries leave the hour, mail is accepted again.
+	now = now.Add(31 * time.Minute)
+	deliver(4)
+	if got := len(message.ScanInboxMessages(inbox)); got != 3 {
+		t.Fatalf("inbox count = %d after the window reset, want 3", got)
+	}
+}
+
+func TestSessionQuota_SlidesOverTheLastHour(t *testing.T) {
+	now := time.Date(2026, time.May, 21, 5, 0, 0, 0, time.UTC)
+	ds := newDaemonStateWithClock(0, "ctx-main", func() time.Time { return now })
+
+	for _, at := range []time.Time{now, now.Add(50 * time.Minute)} {
+		if exceeded, _ := ds.reserveSessionQuota("test", 2, at); exceeded {
+			t.Fatalf("reserveSessionQuota at %s exceeded, want a slot", at)
+		}
+	}
+	if exceeded, notify := ds.reserveSessionQuota("test", 2, now.Add(55*time.Minute)); !exceeded || !notify {
+		t.Fatalf("reserveSessionQuota at limit = (%v, %v), want (true, true)", exceeded, notify)
+	}
+	if exceeded, _ := ds.reserveSessionQuota("other", 2, now.Add(55*time.Minute)); exceeded {
+		t.Fatal("other session exceeded, want independent quotas")
+	}
+
+	// Only the first delivery has left the hour, so one slot frees up
+	// rather than a whole new allowance at a window boundary.
+	later := now.Add(61 * time.Minute)
+	if exceeded, _ := ds.reserveSessionQuota("test", 2, later); exceeded {
+		t.Fatal("reserveSessionQuota after oldest delivery expired = true, want false")
+	}
+	if exceeded, _ := ds.reserveSessionQuota("test", 2, later); !exceeded {
+		t.Fatal("reserveSessionQuota with two deliveries in the last hour = false, want true")
+	}
+	ds.releaseSessionQuota("test")
+	if exceeded, _ := ds.reserveSessionQuota("test", 2, later); exceeded {
+		t.Fatal("reserveSessionQuota after a release = true, want the slot back")
+	}
+}
+
+func TestSessionQuota_RejectedMailLeavesQuotaUntouched(t *testing.T) {
+	sessionDir := filepath.Join(t.TempDir(), "test")
+	if err := config.CreateSessionDirs(sessionDir); err != nil {
+		t.Fatalf("config.CreateSessionDirs failed: %v", err)
+	}
+	now := time.Date(2026, time.May, 21, 5, 0, 0, 0, time.UTC)
+	cfg := &config.Config{
+		EnterDelay:         0.1,
+		TmuxTimeout:        1.0,
+		MaxMessagesPerHour: 2,
+	}
+	rt := &daemonRuntime{
+		cfg:         cfg,
+		daemonState: newDaemonStateWithClock(0, "ctx-main", func() time.Time { return now }),
+		events:      make(chan tui.DaemonEvent, 10),
+		clock:       func() time.Time { return now },
+	}
+	nodes := map[string]discovery.NodeInfo{
+		"test:worker":       {PaneID: "%1", SessionName: "test", SessionDir: sessionDir},
+		"test:orchestrator": {PaneID: "%2", SessionName: "test", SessionDir: sessionDir},
+	}
+	adjacency := map[string][]string{"orchestrator": {"worker"}, "worker": {"orchestrator"}}
+
+	deliver := func(i int, to string) {
+		t.Helper()
+		filename := fmt.Sprintf("20260201-04000%d-from-orchestrator-to-%s.md", i, to)
+		postPath := filepath.Join(sessionDir, "post", filename)
+		content := fmt.Sprintf("---\nparams:\n  from: orchestrator\n  to: %s\n  timestamp: 2026-02-01T04:00:00Z\n---\n\nbusy\n", to)
+		if err := os.WriteFile(postPath, []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		if err := message.DeliverMessageWithOptions(postPath, "ctx-main", nodes, adjacency, cfg, func(string) bool { return true }, nil, idle.NewIdleTracker(), "", rt.deliverOptions(cfg)); err != nil {
+			t.Fatalf("DeliverMessageWithOptions(%d) failed: %v", i, err)
+		}
+	}
+
+	// Mail to an unknown recipient is dead-lettered before delivery and
+	// must not use up the session quota.
+	deliver(0, "ghost")
+	deliver(1, "ghost")
+	deliver(2, "worker")
+	deliver(3, "worker")
+
+	inbox := filepath.Join(sessionDir, "inbox", "worker")
+	if got := len(message.ScanInboxMessages(inbox)); got != 2 {
+		t.Fatalf("inbox count = %d, want 2 after rejected mail", got)
+	}
+	if exceeded, _ := rt.daemonState.reserveSessionQuota("test", 2, now); !exceeded {
+		t.Fatal("quota not exceeded after two deliveries, want delivered mail charged")
+	}
+}
+
+// deliverBurstConcurrently posts count messages from one sender at once
+// through the daemon's delivery hooks and returns how many reached the inbox.
+func deliverBurstConcurrently(t *testing.T, cfg *config.Config, count int) int {
+	t.Helper()
+	sessionDir := filepath.Join(t.TempDir(), "test")
+	if err := config.CreateSessionDirs(sessionDir); err != nil {
+		t.Fatalf("config.CreateSessionDirs failed: %v", err)
+	}
+	now := time.Date(2026, time.May, 21, 5, 0, 0, 0, time.UTC)
+	rt := &daemonRuntime{
+		cfg:         cfg,
+		daemonState: newDaemonStateWithClock(0, "ctx-main", func() time.Time { return now }),
+		events:      make(chan tui.DaemonEvent, count),
+		clock:       func() time.Time { return now },
+	}
+	nodes := map[string]discovery.NodeInfo{
+		"test:worker":       {PaneID: "%1", SessionName: "test", SessionDir: sessionDir},
+		"test:orchestrator": {PaneID: "%2", SessionName: "test", SessionDir: sessionDir},
+	}
+	adjacency := map[string][]string{"orchestrator": {"worker"}, "worker": {"orchestrator"}}
+	opts := rt.deliverOptions(cfg)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, count)
+	for i := 0; i < count; i++ {
+		filename := fmt.Sprintf("20260201-0400%02d-from-orchestrator-to-worker.md", i)
+		postPath := filepath.Join(sessionDir, "post", filename)
+		content := "---\nparams:\n  from: orchestrator\n  to: worker\n  timestamp: 2026-02-01T04:00:00Z\n---\n\nburst\n"
+		if err := os.WriteFile(postPath, []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- message.DeliverMessageWithOptions(postPath, "ctx-main", nodes, adjacency, cfg, func(string) bool { return true }, nil, idle.NewIdleTracker(), "", opts)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("DeliverMessageWithOptions failed: %v", err)
+		}
+	}
+	return len(message.ScanInboxMessages(filepath.Join(sessionDir, "inbox", "worker")))
+}
+
+func TestSenderRate_ConcurrentBurstDeliversExactlyLimit(t *testing.T) {
+	cfg := &config.Config{EnterDelay: 0.1, TmuxTimeout: 1.0, MaxMessagesPerMinute: 3}
+	if got := deliverBurstConcurrently(t, cfg, 12); got != 3 {
+		t.Fatalf("inbox count = %d after a concurrent burst, want exactly max_messages_per_minute=3", got)
+	}
+}
+
+func TestSessionQuota_ConcurrentBurstDeliversExactlyQuota(t *testing.T) {
+	cfg := &config.Config{EnterDelay: 0.1, TmuxTimeout: 1.0, MaxMessagesPerHour: 3}
+	if got := deliverBurstConcurrently(t, cfg, 12); got != 3 {
+		t.Fatalf("inbox count = %d after a concurrent burst, want exactly max_messages_per_hour=3", got)
+	}
+}
+
+func TestRequestReindexCoalescesPendingRequests(t *testing.T) {
+	ds := NewDaemonState(0, "ctx")
+	ds.RequestReindex()
+	ds.RequestReindex()
+
+	select {
+	case <-ds.reindexRequested():
+	default:
+		t.Fatal("RequestReindex() did not queue a request")
+	}
+	select {
+	case <-ds.reindexRequested():
+		t.Fatal("RequestReindex() queued more than one pending request")
+	default:
+	}
+}
@@ -0,0 +1,93 @@
+package daemon
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/tui"
+)
+
+// EventEmptySession reports an enabled session that has had no discovered
+// node for longer than empty_session_alert_seconds.
+const EventEmptySession = "empty_session"
+
+// enabledSessionNames lists the sessions explicitly enabled, ignoring the
+// startup drain window.
+func (ds *DaemonState) enabledSessionNames() []string {
+	ds.enabledSessionsMu.RLock()
+	defer ds.enabledSessionsMu.RUnlock()
+	var names []string
+	for name, enabled := range ds.enabledSessions {
+		if enabled {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// checkEmptySessions emits empty_session once per empty spell for each
+// enabled session with no node in rt.nodes for longer than
+// empty_session_alert_seconds. A discovered node, or disabling the session,
+// re-arms the alert.
+func (rt *daemonRuntime) checkEmptySessions(now time.Time) {
+	if rt.cfg == nil || rt.daemonState == nil || rt.cfg.EmptySessionAlertSeconds <= 0 {
+		return
+	}
+	window := time.Duration(rt.cfg.EmptySessionAlertSeconds * float64(time.Second))
+	populated := make(map[string]bool)
+	for _, nodeInfo := range rt.nodes {
+		populated[nodeInfo.SessionName] = true
+	}
+	if rt.emptySessionSince == nil {
+		rt.emptySessionSince = make(map[string]time.Time)
+	}
+	if rt.emptySessionAlerted == nil {
+		rt.emptySessionAlerted = make(map[string]bool)
+	}
+	enabled := make(map[string]bool)
+	for _, sessionName := range rt.daemonState.enabledSessionNames() {
+		enabled[sessionName] = true
+		if populated[sessionName] {
+			delete(rt.emptySessionSince, sessionName)
+			delete(rt.emptySessionAlerted, sessionName)
+			continue
+		}
+		since, ok := rt.emptySessionSince[sessionName]
+		if !ok {
+			rt.emptySessionSince[sessionName] = now
+			continue
+		}
+		if now.Sub(since) <= window || rt.emptySessionAlerted[sessionName] {
+			continue
+		}
+		rt.emptySessionAlerted[sessionName] = true
+
+		empty := now.Sub(since).Round(time.Second)
+		msg := fmt.Sprintf("Empty session: %s has had no nodes for %s", sessionName, empty)
+		log.Printf("postman: WARNING: session %s is enabled but has had no discovered nodes for %s (window %s)\n", sessionName, empty, window)
+		tui.SendEventNonBlocking(rt.events, tui.DaemonEvent{
+			Type:    EventEmptySession,
+			Message: msg,
+			Details: map[string]interface{}{
+				"session":        sessionName,
+				"empty_seconds":  int(empty / time.Second),
+				"window_seconds": int(window / time.Second),
+			},
+		})
+	}
+	for sessionName := range rt.emptySessionSince {
+		if !enabled[sessionName] {
+			delete(rt.emptySessionSince, sessionName)
+			delete(rt.emptySessionAlerted, sessionName)
+		}
+	}
+}
+
+// emptySessionCount is the number of sessions currently past the
+// empty_session window, for mesh_summary.
+func (rt *daemonRuntime) emptySessionCount() int {
+	return len(rt.emptySessionAlerted)
+}
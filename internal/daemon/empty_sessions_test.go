@@ -0,0 +1,66 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/discovery"
+	"github.com/i9wa4/tmux-a2a-postman/internal/tui"
+)
+
+func TestCheckEmptySessions_WarnsAfterThresholdForEnabledSessionWithoutNodes(t *testing.T) {
+	start := time.Date(2026, time.May, 21, 5, 0, 0, 0, time.UTC)
+	ds := newDaemonStateWithClock(0, "ctx-main", func() time.Time { return start })
+	ds.enabledSessions["review"] = true
+	ds.enabledSessions["main"] = true
+	ds.enabledSessions["parked"] = false
+	events := make(chan tui.DaemonEvent, 10)
+	rt := &daemonRuntime{
+		cfg:         &config.Config{EmptySessionAlertSeconds: 600},
+		daemonState: ds,
+		events:      events,
+		nodes: map[string]discovery.NodeInfo{
+			"main:boss": {PaneID: "%1", SessionName: "main"},
+		},
+	}
+
+	// review is empty from the first check on; nothing fires inside the window.
+	rt.checkEmptySessions(start)
+	rt.checkEmptySessions(start.Add(5 * time.Minute))
+	if len(events) != 0 {
+		t.Fatalf("events = %d before the threshold, want 0", len(events))
+	}
+
+	// Past the threshold only the enabled, empty session warns.
+	rt.checkEmptySessions(start.Add(11 * time.Minute))
+	if len(events) != 1 {
+		t.Fatalf("events = %d, want 1 empty_session", len(events))
+	}
+	event := <-events
+	if event.Type != EventEmptySession || event.Details["session"] != "review" {
+		t.Fatalf("event = %+v, want empty_session for review", event)
+	}
+	if got := rt.emptySessionCount(); got != 1 {
+		t.Fatalf("emptySessionCount() = %d, want 1 for the mesh summary", got)
+	}
+
+	// The same empty spell does not warn again.
+	rt.checkEmptySessions(start.Add(20 * time.Minute))
+	if len(events) != 0 {
+		t.Fatalf("events = %d on repeat check, want 0", len(events))
+	}
+
+	// A node appearing clears the count and re-arms the warning.
+	rt.nodes["review:worker"] = discovery.NodeInfo{PaneID: "%2", SessionName: "review"}
+	rt.checkEmptySessions(start.Add(21 * time.Minute))
+	if got := rt.emptySessionCount(); got != 0 {
+		t.Fatalf("emptySessionCount() = %d after a node appeared, want 0", got)
+	}
+	delete(rt.nodes, "review:worker")
+	rt.checkEmptySessions(start.Add(22 * time.Minute))
+	rt.checkEmptySessions(start.Add(33 * time.Minute))
+	if len(events) != 1 {
+		t.Fatalf("events = %d after the session emptied again, want 1", len(events))
+	}
+}
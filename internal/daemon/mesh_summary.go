@@ -0,0 +1,103 @@
+package daemon
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/discovery"
+	"github.com/i9wa4/tmux-a2a-postman/internal/status"
+	"github.com/i9wa4/tmux-a2a-postman/internal/tui"
+)
+
+// meshSummaryInterval returns the mesh_summary period; 0 disables the event.
+func meshSummaryInterval(cfg *config.Config) time.Duration {
+	if cfg == nil || cfg.MeshSummaryIntervalSeconds <= 0 {
+		return 0
+	}
+	return time.Duration(cfg.MeshSummaryIntervalSeconds * float64(time.Second))
+}
+
+// buildMeshSummary rolls node activity, inbox depth, unacknowledged mail, and
+// recent dead-letters into one snapshot. paneStatus is keyed by pane ID and
+// unreadCounts by session-prefixed node key; nodes without a pane capture yet
+// count as stale.
+func buildMeshSummary(
+	nodes map[string]discovery.NodeInfo,
+	paneStatus map[string]string,
+	unreadCounts map[string]int,
+	hasUnackedReceipt func(nodeKey string) bool,
+	recentDeadLetters int,
+	window time.Duration,
+	now time.Time,
+) status.MeshSummary {
+	summary := status.MeshSummary{
+		ObservedAt:        now.UTC().Format(time.RFC3339),
+		WindowSeconds:     int(window / time.Second),
+		NodeCount:         len(nodes),
+		RecentDeadLetters: recentDeadLetters,
+	}
+	for nodeKey, nodeInfo := range nodes {
+		state := paneStatus[nodeInfo.PaneID]
+		switch state {
+		case "active":
+			summary.ActiveNodes++
+		case "idle":
+			summary.IdleNodes++
+		default:
+			summary.StaleNodes++
+		}
+		unread := unreadCounts[nodeKey]
+		summary.PendingInbox += unread
+		if unread > 0 && state != "active" && hasUnackedReceipt != nil && hasUnackedReceipt(nodeKey) {
+			summary.DroppedBalls++
+		}
+	}
+	return summary
+}
+
+func meshSummaryLogLine(summary status.MeshSummary) string {
+	return fmt.Sprintf(
+		"postman: component=daemon_runtime event=mesh_summary observed_at=%s window_seconds=%d node_count=%d active_nodes=%d idle_nodes=%d stale_nodes=%d pending_inbox=%d dropped_balls=%d recent_dead_letters=%d empty_sessions=%d dead_letters_by_reason=parse_error:%d,unknown_recipient:%d,routing_denied:%d,session_disabled:%d,expired:%d,other:%d\n",
+		summary.ObservedAt,
+		summary.WindowSeconds,
+		summary.NodeCount,
+		summary.ActiveNodes,
+		summary.IdleNodes,
+		summary.StaleNodes,
+		summary.PendingInbox,
+		summary.DroppedBalls,
+		summary.RecentDeadLetters,
+		summary.EmptySessions,
+		summary.DeadLettersByReason.ParseError,
+		summary.DeadLettersByReason.UnknownRecipient,
+		summary.DeadLettersByReason.RoutingDenied,
+		summary.DeadLettersByReason.SessionDisabled,
+		summary.DeadLettersByReason.Expired,
+		summary.DeadLettersByReason.Other,
+	)
+}
+
+func (rt *daemonRuntime) handleMeshSummaryTick() {
+	now := rt.now()
+	window := meshSummaryInterval(rt.cfg)
+	summary := buildMeshSummary(
+		rt.nodes,
+		rt.idleTracker.GetPaneActivityStatus(rt.cfg),
+		scanLiveInboxCounts(rt.nodes),
+		rt.idleTracker.HasUnackedReceipt,
+		rt.daemonState.recentDeadLetters(window, now),
+		window,
+		now,
+	)
+	summary.DeadLettersByReason = rt.daemonState.deadLetterReasonCounts()
+	summary.EmptySessions = rt.emptySessionCount()
+	log.Print(meshSummaryLogLine(summary))
+	tui.SendEventNonBlocking(rt.events, tui.DaemonEvent{
+		Type: "mesh_summary",
+		Details: map[string]interface{}{
+			"summary": summary,
+		},
+	})
+}
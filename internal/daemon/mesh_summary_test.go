@@ -0,0 +1,160 @@
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/discovery"
+	"github.com/i9wa4/tmux-a2a-postman/internal/idle"
+	"github.com/i9wa4/tmux-a2a-postman/internal/message"
+	"github.com/i9wa4/tmux-a2a-postman/internal/status"
+)
+
+func TestBuildMeshSummaryCountsSnapshot(t *testing.T) {
+	now := time.Date(2026, time.May, 21, 5, 0, 0, 0, time.UTC)
+	nodes := map[string]discovery.NodeInfo{
+		"main:boss":     {PaneID: "%1"},
+		"main:worker":   {PaneID: "%2"},
+		"review:critic": {PaneID: "%3"},
+		"review:new":    {PaneID: "%4"},
+	}
+	paneStatus := map[string]string{
+		"%1": "active",
+		"%2": "idle",
+		"%3": "stale",
+	}
+	unread := map[string]int{
+		"main:boss":     2,
+		"main:worker":   1,
+		"review:critic": 3,
+	}
+	unacked := map[string]bool{
+		"main:boss":   true, // active: still working on it
+		"main:worker": true,
+	}
+
+	got := buildMeshSummary(nodes, paneStatus, unread, func(key string) bool { return unacked[key] }, 5, time.Minute, now)
+
+	want := status.MeshSummary{
+		ObservedAt:        "2026-05-21T05:00:00Z",
+		WindowSeconds:     60,
+		NodeCount:         4,
+		ActiveNodes:       1,
+		IdleNodes:         1,
+		StaleNodes:        2,
+		PendingInbox:      6,
+		DroppedBalls:      1,
+		RecentDeadLetters: 5,
+	}
+	if got != want {
+		t.Fatalf("buildMeshSummary() = %+v, want %+v", got, want)
+	}
+}
+
+func TestRecentDeadLettersForgetsOutsideWindow(t *testing.T) {
+	ds := NewDaemonState(0, "ctx-main")
+	now := time.Date(2026, time.May, 21, 5, 0, 0, 0, time.UTC)
+	ds.recordDeadLetter(now.Add(-90 * time.Second))
+	ds.recordDeadLetter(now.Add(-30 * time.Second))
+	ds.recordDeadLetter(now)
+
+	if got := ds.recentDeadLetters(time.Minute, now); got != 2 {
+		t.Fatalf("recentDeadLetters() = %d, want 2", got)
+	}
+	if got := ds.recentDeadLetters(time.Minute, now.Add(2*time.Minute)); got != 0 {
+		t.Fatalf("recentDeadLetters() after window = %d, want 0", got)
+	}
+}
+
+func TestDeadLetterReasonCountsFollowDeliverMessageBranches(t *testing.T) {
+	sessionDir := filepath.Join(t.TempDir(), "test")
+	if err := config.CreateSessionDirs(sessionDir); err != nil {
+		t.Fatalf("config.CreateSessionDirs failed: %v", err)
+	}
+	nodes := map[string]discovery.NodeInfo{
+		"test:worker":       {PaneID: "%1", SessionName: "test", SessionDir: sessionDir},
+		"test:orchestrator": {PaneID: "%2", SessionName: "test", SessionDir: sessionDir},
+		"test:critic":       {PaneID: "%3", SessionName: "test", SessionDir: sessionDir},
+	}
+	adjacency := map[string][]string{
+		"orchestrator": {"worker"},
+		"worker":       {"orchestrator"},
+	}
+	cfg := &config.Config{EnterDelay: 0.1, TmuxTimeout: 1.0}
+	ds := NewDaemonState(0, "ctx-main")
+
+	deliver := func(filename, to string, sessionEnabled bool) {
+		t.Helper()
+		content := "---\nparams:\n  contextId: test-ctx\n  from: orchestrator\n  to: " + to + "\n  timestamp: 2026-02-01T03:00:00Z\n---\n\nbody\n"
+		postPath := filepath.Join(sessionDir, "post", filename)
+		if err := os.WriteFile(postPath, []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		events := make(chan message.DaemonEvent, 8)
+		isEnabled := func(string) bool { return sessionEnabled }
+		if err := message.DeliverMessage(postPath, "test-ctx", nodes, adjacency, cfg, isEnabled, events, idle.NewIdleTracker(), ""); err != nil {
+			t.Fatalf("DeliverMessage(%s) failed: %v", filename, err)
+		}
+		close(events)
+		for event := range events {
+			if messageEventSuppressesNormalDelivery(event) {
+				ds.recordDeadLetterReason(messageEventFailureReason(event))
+			}
+		}
+	}
+
+	deliver("not-a-message.md", "worker", true)
+	deliver("20260201-030000-from-orchestrator-to-ghost.md", "ghost", true)
+	deliver("20260201-030100-from-orchestrator-to-critic.md", "critic", true)
+	deliver("20260201-030200-from-orchestrator-to-critic.md", "critic", true)
+	deliver("20260201-030300-from-orchestrator-to-worker.md", "worker", false)
+
+	want := status.DeadLetterReasonCounts{
+		ParseError:       1,
+		UnknownRecipient: 1,
+		RoutingDenied:    2,
+		SessionDisabled:  1,
+	}
+	if got := ds.deadLetterReasonCounts(); got != want {
+		t.Fatalf("deadLetterReasonCounts() = %+v, want %+v", got, want)
+	}
+}
+
+func TestDeadLetterReasonCountsIncludeStartupTTLDrain(t *testing.T) {
+	sessionDir := filepath.Join(t.TempDir(), "test")
+	if err := config.CreateSessionDirs(sessionDir); err != nil {
+		t.Fatalf("config.CreateSessionDirs failed: %v", err)
+	}
+	stale := time.Now().Add(-time.Hour)
+	for _, filename := range []string{
+		"20260201-030000-from-orchestrator-to-worker.md",
+		"20260201-030100-from-worker-to-orchestrator.md",
+	} {
+		postPath := filepath.Join(sessionDir, "post", filename)
+		if err := os.WriteFile(postPath, []byte("body\n"), 0o644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		if err := os.Chtimes(postPath, stale, stale); err != nil {
+			t.Fatalf("Chtimes failed: %v", err)
+		}
+	}
+	cfg := &config.Config{MessageTTLSeconds: 60}
+	drained := message.DrainStalePost(sessionDir, cfg)
+	if drained != 2 {
+		t.Fatalf("DrainStalePost() = %d, want 2", drained)
+	}
+
+	ds := NewDaemonState(0, "ctx-main")
+	now := time.Date(2026, time.May, 21, 5, 0, 0, 0, time.UTC)
+	ds.RecordStartupTTLDrain(drained, now)
+
+	if got, want := ds.deadLetterReasonCounts(), (status.DeadLetterReasonCounts{Expired: 2}); got != want {
+		t.Fatalf("deadLetterReasonCounts() = %+v, want %+v", got, want)
+	}
+	if got := ds.recentDeadLetters(time.Minute, now); got != 2 {
+		t.Fatalf("recentDeadLetters() = %d, want 2", got)
+	}
+}
@@ -0,0 +1,62 @@
+package daemon
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/tui"
+)
+
+// nonMarkdownEventType reports a file in post/ that will never be delivered
+// because it is not .md.
+const nonMarkdownEventType = "non_md_in_post"
+
+// handleNonMarkdownPost applies non_md_post_policy to a file that landed in
+// post/ without the .md extension, e.g. a .txt an agent wrote by mistake.
+// Hidden files (editor swap and temp files) are left alone.
+func (rt *daemonRuntime) handleNonMarkdownPost(eventPath string) {
+	policy := rt.cfg.EffectiveNonMDPostPolicy()
+	filename := filepath.Base(eventPath)
+	if policy == config.NonMDPostPolicyIgnore || strings.HasPrefix(filename, ".") {
+		return
+	}
+	if info, err := os.Stat(eventPath); err != nil || info.IsDir() {
+		return
+	}
+
+	sessionDir := filepath.Dir(filepath.Dir(eventPath))
+	sessionName := filepath.Base(sessionDir)
+	details := map[string]interface{}{
+		"session": sessionName,
+		"file":    filename,
+		"policy":  policy,
+	}
+	text := fmt.Sprintf("%s in %s/post/ is not .md and will not be delivered", filename, sessionName)
+	if policy == config.NonMDPostPolicyDeadLetter {
+		dst := nonMarkdownDeadLetterPath(sessionDir, filename)
+		if err := os.Rename(eventPath, dst); err != nil {
+			log.Printf("postman: WARNING: failed to move %s to dead-letter/: %v\n", eventPath, err)
+		} else {
+			details["dead_letter"] = filepath.Base(dst)
+			text = fmt.Sprintf("%s in %s/post/ is not .md; moved to dead-letter/", filename, sessionName)
+		}
+	}
+
+	log.Printf("postman: WARNING: component=daemon_runtime event=%s session=%s file=%s policy=%s\n", nonMarkdownEventType, sessionName, filename, policy)
+	tui.SendEventNonBlocking(rt.events, tui.DaemonEvent{
+		Type:    nonMarkdownEventType,
+		Message: text,
+		Details: details,
+	})
+}
+
+// nonMarkdownDeadLetterPath keeps the original extension so the file still
+// opens as what it is: notes.txt becomes notes-dl-non-md.txt.
+func nonMarkdownDeadLetterPath(sessionDir, filename string) string {
+	ext := filepath.Ext(filename)
+	return filepath.Join(sessionDir, "dead-letter", strings.TrimSuffix(filename, ext)+"-dl-non-md"+ext)
+}
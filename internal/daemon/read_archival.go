@@ -0,0 +1,60 @@
+package daemon
+
+import (
+	"log"
+	"time"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/journal"
+	"github.com/i9wa4/tmux-a2a-postman/internal/projection"
+	"github.com/i9wa4/tmux-a2a-postman/internal/store"
+)
+
+// maxReadArchivalCheckInterval caps how long an old read/ message can wait
+// for the next archival pass.
+const maxReadArchivalCheckInterval = 10 * time.Minute
+
+// readArchivalAge returns read_archival_seconds; 0 disables archival.
+func readArchivalAge(cfg *config.Config) time.Duration {
+	if cfg == nil || cfg.ReadArchivalSeconds <= 0 {
+		return 0
+	}
+	return time.Duration(cfg.ReadArchivalSeconds * float64(time.Second))
+}
+
+// readArchivalCheckInterval returns how often to look for old read/
+// messages; 0 disables archival.
+func readArchivalCheckInterval(cfg *config.Config) time.Duration {
+	return min(readArchivalAge(cfg), maxReadArchivalCheckInterval)
+}
+
+// handleReadArchivalTick packs old read/ messages of every session into
+// read/archive/ and journals each one so the mailbox projection does not
+// write it back. Old post_retention = "copy" audit copies in post/archive/
+// are packed on the same schedule.
+func (rt *daemonRuntime) handleReadArchivalTick() {
+	age := readArchivalAge(rt.cfg)
+	if age <= 0 {
+		return
+	}
+	now := rt.now()
+	for _, sessionDir := range runtimeSessionDirs(rt.sessionDir, rt.nodes) {
+		archived, err := store.ArchiveOldReadMessages(sessionDir, age, now)
+		for _, path := range archived {
+			recordShadowMailboxPathEvent(path, projection.MailboxProjectionReadArchivedEventType, journal.VisibilityOperatorVisible, now)
+		}
+		if err != nil {
+			log.Printf("postman: WARNING: read archival failed for %s: %v\n", sessionDir, err)
+		}
+		if len(archived) > 0 {
+			log.Printf("postman: archived %d read message(s) older than %s in %s\n", len(archived), age, store.ReadArchiveDir(sessionDir))
+		}
+		posts, err := store.ArchiveOldPostCopies(sessionDir, age, now)
+		if err != nil {
+			log.Printf("postman: WARNING: post archive packing failed for %s: %v\n", sessionDir, err)
+		}
+		if len(posts) > 0 {
+			log.Printf("postman: packed %d post copy(ies) older than %s in %s\n", len(posts), age, store.PostArchiveDir(sessionDir))
+		}
+	}
+}
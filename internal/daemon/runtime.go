@@ -2,6 +2,7 @@ package daemon
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -53,10 +54,29 @@ type daemonRuntime struct {
 	nonDaemonDeliveryBudgetFallback *nonDaemonDeliveryBudget
 	idleTracker                     *idle.IdleTracker
 	clock                           func() time.Time
+	// createSessionDirs defaults to config.CreateSessionDirs; tests stub it.
+	createSessionDirs func(sessionDir string) error
+	// journaledPosts maps post/ paths the delivery log recorded as delivered
+	// by an earlier run to the content hash that was delivered; a re-scan
+	// dead-letters an identical replay instead of delivering it again.
+	journaledPosts map[string]string
+	// deliveredIDs indexes delivered message ids for duplicate suppression.
+	deliveredIDs *store.DeliveredMessageIDs
+	// criticalEdgeAlerts maps each alerted @critical edge to the last
+	// delivery it was silent since, so one quiet spell alerts once.
+	criticalEdgeAlerts map[string]time.Time
+	// emptySessionSince maps each enabled session without nodes to when it
+	// was first seen empty; emptySessionAlerted marks the ones already
+	// reported, so one empty spell alerts once.
+	emptySessionSince   map[string]time.Time
+	emptySessionAlerted map[string]bool
+	// displayMessage defaults to tmux display-message; tests stub it.
+	displayMessage func(msg string)
 
 	sharedNodes *atomic.Pointer[map[string]discovery.NodeInfo]
 
 	watchedDirs        map[string]bool
+	polledDirs         map[string]map[string]bool // Dirs past max_watched_dirs -> .md names already seen (read/ only)
 	claimedPanes       map[string]bool
 	prevPaneStatesJSON string
 	prevNodeCount      int
@@ -74,6 +94,7 @@ type daemonRuntime struct {
 	launchDaemonSubmitWorker      daemonSubmitWorkerLauncher
 	daemonSubmitSem               chan struct{}
 	daemonSubmitResults           chan daemonSubmitRuntimeResult
+	sessionDirRetries             chan sessionDirRetry
 	activeDaemonSubmitKeys        map[string]bool
 	daemonSubmitSaturationCount   int
 	daemonSubmitLastSaturatedAt   time.Time
@@ -92,6 +113,10 @@ type daemonSubmitWorkerLauncher func(worker func())
 
 // runtimeTimerScheduler owns callback scheduling while callbacks keep runtime
 // ownership of their state transitions.
+// sessionDirRetryQueueSize buffers session dir retries waiting for the
+// event loop.
+const sessionDirRetryQueueSize = 16
+
 type runtimeTimerScheduler func(delay time.Duration, name string, events chan<- tui.DaemonEvent, callback func())
 
 type daemonSubmitRuntimeResult struct {
@@ -161,6 +186,7 @@ func newDaemonRuntime(
 		idleTracker:                   idleTracker,
 		sharedNodes:                   sharedNodes,
 		watchedDirs:                   make(map[string]bool),
+		polledDirs:                    make(map[string]map[string]bool),
 		claimedPanes:                  make(map[string]bool),
 		prevSessionNodes:              make(map[string][]string),
 		activePostEvents:              make(map[string]bool),
@@ -169,10 +195,12 @@ func newDaemonRuntime(
 		launchDaemonSubmitWorker:      defaultDaemonSubmitWorkerLauncher,
 		daemonSubmitSem:               make(chan struct{}, daemonSubmitWorkerLimit),
 		daemonSubmitResults:           make(chan daemonSubmitRuntimeResult, daemonSubmitWorkerLimit),
+		sessionDirRetries:             make(chan sessionDirRetry, sessionDirRetryQueueSize),
 		activeDaemonSubmitKeys:        make(map[string]bool),
 		scheduleRuntimeTimer:          defaultRuntimeTimerScheduler,
 		activeMailboxProjectionSyncs:  make(map[string]bool),
 		pendingMailboxProjectionSyncs: make(map[string]bool),
+		deliveredIDs:                  store.NewDeliveredMessageIDs(),
 	}
 }
 
@@ -312,6 +340,7 @@ func resumeMailboxProjections(primarySessionDir string, nodes map[string]discove
 
 func (rt *daemonRuntime) bootstrap() {
 	rt.storeSharedNodes()
+	rt.watchNodeDirs(rt.nodes)
 
 	now := rt.now()
 	installShadowJournalManager(rt.sessionDir, rt.contextID, rt.selfSession, now)
@@ -322,6 +351,7 @@ func (rt *daemonRuntime) bootstrap() {
 	rt.recordPendingAutoPings(startupAutoPingNodeKeys(rt.nodes, rt.cfg), rt.nodes, "startup", now)
 	autoEnableSessions := config.BoolVal(rt.cfg.AutoEnableNewSessions, true)
 	rt.dispatchPendingAutoPings(rt.nodes, autoEnableSessions, now)
+	rt.loadDeliveryLog(now)
 	rt.dispatchPendingPostMessages()
 }
 
@@ -334,10 +364,10 @@ func (rt *daemonRuntime) handleContextDone() {
 	}
 	rt.daemonState.enabledSessionsMu.RUnlock()
 
-	rt.events <- tui.DaemonEvent{
+	tui.SendEventNonBlocking(rt.events, tui.DaemonEvent{
 		Type:    "channel_closed",
 		Message: "Shutting down",
-	}
+	})
 }
 
 type runtimeWatcherEventKind int
@@ -374,6 +404,10 @@ func (rt *daemonRuntime) handleWatcherEvent(event fswatcher.Event) {
 	case runtimeWatcherEventDaemonSubmitRequest:
 		rt.handleDaemonSubmitRequest(eventPath)
 	case runtimeWatcherEventPost:
+		if !strings.HasSuffix(eventPath, ".md") {
+			rt.handleNonMarkdownPost(eventPath)
+			return
+		}
 		rt.wakePostReconciler(eventPath)
 	case runtimeWatcherEventRead:
 		rt.handleReadWatcherEvent(eventPath, event.Op)
@@ -414,10 +448,10 @@ func (rt *daemonRuntime) dispatchDaemonSubmitRequest(requestPath string) daemonS
 	if rt.isRuntimeDiagnosticsSubmitRequest(requestPath) {
 		if err := rt.processRuntimeDiagnosticsSubmitRequest(requestPath); err != nil {
 			if rt.events != nil {
-				rt.events <- tui.DaemonEvent{
+				tui.SendEventNonBlocking(rt.events, tui.DaemonEvent{
 					Type:    "error",
 					Message: fmt.Sprintf("%s %s: %v", projection.SubmitPathDaemon, filepath.Base(requestPath), err),
-				}
+				})
 			}
 		}
 		return daemonSubmitDispatched
@@ -787,15 +821,18 @@ func (rt *daemonRuntime) handleDaemonSubmitResult(workerResult daemonSubmitRunti
 	rt.ensureDaemonSubmitRuntime()
 	delete(rt.activeDaemonSubmitKeys, workerResult.dispatchKey)
 	if workerResult.err != nil {
-		rt.events <- tui.DaemonEvent{
+		tui.SendEventNonBlocking(rt.events, tui.DaemonEvent{
 			Type:    "error",
 			Message: fmt.Sprintf("%s %s: %v", projection.SubmitPathDaemon, filepath.Base(workerResult.requestPath), workerResult.err),
-		}
+		})
 		return
 	}
 	if workerResult.result.ProjectionSyncSessionDir != "" {
 		rt.scheduleMailboxProjectionSync(workerResult.result.ProjectionSyncSessionDir)
 	}
+	if workerResult.result.AckNode != "" && rt.idleTracker != nil {
+		rt.idleTracker.RecordAck(filepath.Base(workerResult.result.SessionDir) + ":" + rt.cfg.CanonicalNodeName(workerResult.result.AckNode))
+	}
 	if workerResult.result.hasPostDispatch() {
 		log.Printf("postman: component=%s event=send_reconcile submit_path=%s session=%s file=%s\n",
 			projection.SubmitPathDaemon, projection.SubmitPathDaemon, filepath.Base(workerResult.result.SessionDir), workerResult.result.Filename)
@@ -936,6 +973,7 @@ func (rt *daemonRuntime) processActivePostEvent(eventPath, filename string) {
 		rt.logPaneIDChanges(freshNodes)
 		rt.nodes = freshNodes
 		rt.storeSharedNodes()
+		rt.daemonState.forgetRevivedPanes(freshNodes)
 		rt.dispatchPendingAutoPings(freshNodes, config.BoolVal(rt.cfg.AutoEnableNewSessions, true), now)
 
 		allSessions, _ := discovery.DiscoverAllSessions()
@@ -943,7 +981,7 @@ func (rt *daemonRuntime) processActivePostEvent(eventPath, filename string) {
 			allSessions = []string{}
 		}
 		snapshot := buildRuntimeStatusSnapshot(rt.nodes, allSessions, rt.daemonState.GetConfiguredSessionEnabled)
-		rt.events <- tui.DaemonEvent{
+		tui.SendEventNonBlocking(rt.events, tui.DaemonEvent{
 			Type:    "status_update",
 			Message: "Running",
 			Details: map[string]interface{}{
@@ -951,7 +989,7 @@ func (rt *daemonRuntime) processActivePostEvent(eventPath, filename string) {
 				"sessions":      snapshot.Sessions,
 				"session_nodes": snapshot.SessionNodes,
 			},
-		}
+		})
 	}
 
 	rt.dispatchPostDelivery(eventPath, filename, rt.nodes, rt.adjacency, rt.cfg, reservation)
@@ -994,6 +1032,22 @@ func (rt *daemonRuntime) scheduleRateLimitedPostRetry(eventPath, filename, from,
 	})
 }
 
+// heldPostRetryDelay paces re-checks of posts held by serialize_per_node.
+const heldPostRetryDelay = time.Second
+
+// scheduleHeldPostRetry re-submits a post that serialize_per_node left in
+// post/; the retry goes through the watcher path so it is deduplicated
+// against fresh events for the same file.
+func (rt *daemonRuntime) scheduleHeldPostRetry(eventPath string) {
+	scheduler := rt.scheduleRuntimeTimer
+	if scheduler == nil {
+		scheduler = defaultRuntimeTimerScheduler
+	}
+	scheduler(heldPostRetryDelay, "post-serialized-retry", rt.events, func() {
+		rt.handlePostWatcherEvent(eventPath, fswatcher.Create)
+	})
+}
+
 func (rt *daemonRuntime) retryActivePostDelivery(eventPath, filename string) {
 	if _, err := os.Stat(eventPath); os.IsNotExist(err) {
 		rt.finishPostEvent(eventPath)
@@ -1082,110 +1136,295 @@ func (rt *daemonRuntime) dispatchPostDelivery(eventPath, filename string, nodes
 			}
 		}()
 
-		postTraceFields := rt.postDeliveryTraceFields(eventPath, filename)
-		messageEvents := make(chan message.DaemonEvent, 1)
-		if msgInfo, parseErr := message.ParseMessageFilename(filename); parseErr == nil {
-			attemptFields := postTraceFields
-			attemptFields.DeliveryAttempt = 1
-			msgtrace.Log("delivery_attempt", attemptFields)
-			log.Printf("postman: deliver: picked up %s -> %s (file=%s)\n", msgInfo.From, msgInfo.To, filename)
-		}
-		if err := message.DeliverMessage(eventPath, rt.contextID, nodes, adjacency, cfg, rt.daemonState.IsSessionEnabled, messageEvents, rt.idleTracker, rt.selfSession); err != nil {
-			resultFields := postTraceFields
-			resultFields.DeliveryAttempt = 1
-			resultFields.Result = "error"
-			resultFields.Reason = err.Error()
-			msgtrace.Log("delivery_result", resultFields)
-			tui.SendEventNonBlocking(rt.events, tui.DaemonEvent{
-				Type:    "error",
-				Message: fmt.Sprintf("deliver %s: %v", filename, err),
-			})
-			return
+		var err error
+		deliveredNormally, err = rt.deliverPostNow(eventPath, filename, nodes, adjacency, cfg)
+		if errors.Is(err, message.ErrDeliveryHeld) {
+			rt.scheduleHeldPostRetry(eventPath)
 		}
+	}(eventPath, filename, nodes, adjacency, cfg)
+}
 
-		sourceSessionDir := filepath.Dir(filepath.Dir(eventPath))
-		sourceSessionName := filepath.Base(sourceSessionDir)
-		syncFields := postTraceFields
-		syncFields.DeliveryAttempt = 1
-		syncMailboxProjectionWithTraceFn(sourceSessionDir, syncFields)
-		if info, parseErr := message.ParseMessageFilename(filename); parseErr == nil {
-			recipientFullName := discovery.ResolveNodeName(info.To, sourceSessionName, nodes)
-			if nodeInfo, ok := nodes[recipientFullName]; ok {
-				recipientSyncFields := syncFields
-				recipientSyncFields.MessagePath = filepath.Join("inbox", nodeaddr.Simple(info.To), filename)
-				recipientSyncFields.TmuxSession = filepath.Base(nodeInfo.SessionDir)
-				syncMailboxProjectionWithTraceFn(nodeInfo.SessionDir, recipientSyncFields)
-			}
+// deliverPostNow delivers one post/ file on the calling goroutine and does
+// the daemon's bookkeeping for it: trace lines, mailbox projection sync,
+// dead-letter stats, and TUI events. It reports whether the message was
+// delivered normally; err is message.ErrDeliveryHeld for mail held by
+// serialize_per_node.
+func (rt *daemonRuntime) deliverPostNow(eventPath, filename string, nodes map[string]discovery.NodeInfo, adjacency map[string][]string, cfg *config.Config) (bool, error) {
+	postTraceFields := rt.postDeliveryTraceFields(eventPath, filename)
+	messageEvents := make(chan message.DaemonEvent, 1)
+	if msgInfo, parseErr := message.ParseMessageFilename(filename); parseErr == nil {
+		attemptFields := postTraceFields
+		attemptFields.DeliveryAttempt = 1
+		msgtrace.Log("delivery_attempt", attemptFields)
+		log.Printf("postman: deliver: picked up %s -> %s (file=%s)\n", msgInfo.From, msgInfo.To, filename)
+	}
+	if err := message.DeliverMessageWithOptions(eventPath, rt.contextID, nodes, adjacency, cfg, rt.daemonState.IsSessionEnabled, messageEvents, rt.idleTracker, rt.selfSession, rt.deliverOptions(cfg)); err != nil {
+		if errors.Is(err, message.ErrDeliveryHeld) {
+			return false, err
+		}
+		resultFields := postTraceFields
+		resultFields.DeliveryAttempt = 1
+		resultFields.Result = "error"
+		resultFields.Reason = err.Error()
+		msgtrace.Log("delivery_result", resultFields)
+		tui.SendEventNonBlocking(rt.events, tui.DaemonEvent{
+			Type:    "error",
+			Message: fmt.Sprintf("deliver %s: %v", filename, err),
+		})
+		return false, err
+	}
+
+	sourceSessionDir := filepath.Dir(filepath.Dir(eventPath))
+	sourceSessionName := filepath.Base(sourceSessionDir)
+	syncFields := postTraceFields
+	syncFields.DeliveryAttempt = 1
+	syncMailboxProjectionWithTraceFn(sourceSessionDir, syncFields)
+	if info, parseErr := message.ParseMessageFilename(filename); parseErr == nil {
+		recipientFullName := discovery.ResolveNodeName(info.To, sourceSessionName, nodes)
+		if nodeInfo, ok := nodes[recipientFullName]; ok {
+			recipientSyncFields := syncFields
+			recipientSyncFields.MessagePath = filepath.Join("inbox", nodeaddr.Simple(info.To), filename)
+			recipientSyncFields.TmuxSession = filepath.Base(nodeInfo.SessionDir)
+			syncMailboxProjectionWithTraceFn(nodeInfo.SessionDir, recipientSyncFields)
 		}
+	}
 
-		suppressNormalDelivery := false
-		var deliveryEvent message.DaemonEvent
-		select {
-		case msgEvent := <-messageEvents:
-			deliveryEvent = msgEvent
-			tui.SendEventNonBlocking(rt.events, tui.DaemonEvent{
-				Type:    msgEvent.Type,
-				Message: msgEvent.Message,
-				Details: msgEvent.Details,
-			})
-			suppressNormalDelivery = messageEventSuppressesNormalDelivery(msgEvent)
-		default:
+	suppressNormalDelivery := false
+	var deliveryEvent message.DaemonEvent
+	select {
+	case msgEvent := <-messageEvents:
+		deliveryEvent = msgEvent
+		tui.SendEventNonBlocking(rt.events, tui.DaemonEvent{
+			Type:    msgEvent.Type,
+			Message: msgEvent.Message,
+			Details: msgEvent.Details,
+		})
+		suppressNormalDelivery = messageEventSuppressesNormalDelivery(msgEvent)
+	default:
+	}
+	if suppressNormalDelivery {
+		reason := messageEventFailureReason(deliveryEvent)
+		if reason == "" {
+			reason = "dead_letter"
+		}
+		rt.daemonState.recordDeadLetter(rt.now())
+		rt.daemonState.recordDeadLetterReason(messageEventFailureReason(deliveryEvent))
+		deadLetterFields := msgtrace.Fields{
+			MessageID:       filename,
+			MessagePath:     shadowRelativePath(filepath.Dir(filepath.Dir(eventPath)), eventPath),
+			ContextID:       rt.contextID,
+			TmuxSession:     sourceSessionName,
+			DeliveryAttempt: 1,
+			Result:          "dead_letter",
+			Reason:          reason,
 		}
-		if suppressNormalDelivery {
-			reason := messageEventFailureReason(deliveryEvent)
-			if reason == "" {
-				reason = "dead_letter"
-			}
-			deadLetterFields := msgtrace.Fields{
-				MessageID:       filename,
-				MessagePath:     shadowRelativePath(filepath.Dir(filepath.Dir(eventPath)), eventPath),
-				ContextID:       rt.contextID,
-				TmuxSession:     sourceSessionName,
-				DeliveryAttempt: 1,
-				Result:          "dead_letter",
-				Reason:          reason,
-			}
-			if info, parseErr := message.ParseMessageFilename(filename); parseErr == nil {
-				deadLetterFields.Sender = info.From
-				deadLetterFields.Recipient = info.To
-			}
-			msgtrace.Log("delivery_result", deadLetterFields)
+		if info, parseErr := message.ParseMessageFilename(filename); parseErr == nil {
+			deadLetterFields.Sender = info.From
+			deadLetterFields.Recipient = info.To
 		}
+		msgtrace.Log("delivery_result", deadLetterFields)
+	}
 
-		if !suppressNormalDelivery {
-			deliveredNormally = true
-		}
+	// A repeated message id went to read/ without delivery.
+	if deliveryEvent.Type == message.EventDuplicateMessageID {
+		return false, nil
+	}
+	if suppressNormalDelivery {
+		return false, nil
+	}
 
-		if !suppressNormalDelivery {
-			tui.SendEventNonBlocking(rt.events, tui.DaemonEvent{
-				Type:    "message_received",
-				Message: fmt.Sprintf("Delivered: %s", filename),
-				Details: map[string]interface{}{
-					"session": sourceSessionName,
-				},
-			})
-		}
+	tui.SendEventNonBlocking(rt.events, tui.DaemonEvent{
+		Type:    "message_received",
+		Message: fmt.Sprintf("Delivered: %s", filename),
+		Details: map[string]interface{}{
+			"session": sourceSessionName,
+		},
+	})
+	if _, err := message.ParseMessageFilename(filename); err == nil {
+		nodeStates := rt.idleTracker.GetNodeStates()
+		tui.SendEventNonBlocking(rt.events, tui.DaemonEvent{
+			Type: "node_activity_update",
+			Details: map[string]interface{}{
+				"node_states": nodeStates,
+			},
+		})
+	}
+	return true, nil
+}
 
-		if !suppressNormalDelivery {
-			if _, err := message.ParseMessageFilename(filename); err == nil {
-				nodeStates := rt.idleTracker.GetNodeStates()
+// EventSessionQuota reports a session whose mail is being dead-lettered
+// because it used up max_messages_per_hour.
+const EventSessionQuota = "session_quota"
+
+func (rt *daemonRuntime) deliverOptions(cfg *config.Config) message.DeliverOptions {
+	opts := message.DeliverOptions{
+		Muted:        rt.daemonState.IsNodeMuted,
+		PaneDead:     rt.daemonState.IsNodePaneDead,
+		DeliveredIDs: rt.deliveredIDs,
+		ScheduleConfirm: func(delay time.Duration, check func()) {
+			scheduler := rt.scheduleRuntimeTimer
+			if scheduler == nil {
+				scheduler = defaultRuntimeTimerScheduler
+			}
+			scheduler(delay, "delivery-confirm", rt.events, check)
+		},
+		Corrections: rt.recordDeliveryCorrection,
+	}
+	if cfg.HasSessionMessageQuota() {
+		opts.SessionQuotaExceeded = func(session string) bool {
+			quota := cfg.SessionMessageQuota(session)
+			exceeded, notify := rt.daemonState.reserveSessionQuota(session, quota, rt.now())
+			if notify {
+				log.Printf("postman: WARNING: session %s exceeded max_messages_per_hour=%d; dead-lettering its mail until older deliveries leave the hour window\n", session, quota)
 				tui.SendEventNonBlocking(rt.events, tui.DaemonEvent{
-					Type: "node_activity_update",
+					Type:    EventSessionQuota,
+					Message: fmt.Sprintf("Session quota: %s exceeded %d messages/hour", session, quota),
 					Details: map[string]interface{}{
-						"node_states": nodeStates,
+						"session": session,
+						"limit":   quota,
 					},
 				})
 			}
+			return exceeded
 		}
-	}(eventPath, filename, nodes, adjacency, cfg)
+		opts.ReleaseSessionQuota = rt.daemonState.releaseSessionQuota
+	}
+	if cfg == nil || cfg.MaxMessagesPerMinute <= 0 {
+		return opts
+	}
+	limit := cfg.MaxMessagesPerMinute
+	opts.RateLimited = func(sender string) bool {
+		limited, notify := rt.daemonState.reserveSenderRate(sender, limit, rt.now())
+		if notify {
+			log.Printf("postman: WARNING: sender %s exceeded max_messages_per_minute=%d; dead-lettering excess mail\n", sender, limit)
+			tui.SendEventNonBlocking(rt.events, tui.DaemonEvent{
+				Type:    "rate_limited",
+				Message: fmt.Sprintf("Rate limited: %s exceeded %d messages/minute", sender, limit),
+				Details: map[string]interface{}{
+					"sender": sender,
+					"limit":  limit,
+				},
+			})
+		}
+		return limited
+	}
+	opts.ReleaseRateLimit = rt.daemonState.releaseSenderRate
+	return opts
 }
 
 func (rt *daemonRuntime) dispatchPendingPostMessages() {
-	if _, err := rt.postReconciler().ReconcileSessionDirs(runtimeSessionDirs(rt.sessionDir, rt.nodes), rt.handlePendingPost); err != nil {
+	if _, err := rt.postReconciler().ReconcileSessionDirs(runtimeSessionDirs(rt.sessionDir, rt.nodes), rt.skipJournaledPosts(rt.handlePendingPost)); err != nil {
 		log.Printf("postman: WARNING: failed to reconcile pending post messages: %v\n", err)
 	}
 }
 
+// recordDeliveryCorrection reports an event that revises an earlier delivery,
+// counting it as a dead-letter when it carries a failure reason.
+func (rt *daemonRuntime) recordDeliveryCorrection(event message.DaemonEvent) {
+	if reason := messageEventFailureReason(event); reason != "" {
+		rt.daemonState.recordDeadLetter(rt.now())
+		rt.daemonState.recordDeadLetterReason(reason)
+	}
+	tui.SendEventNonBlocking(rt.events, tui.DaemonEvent{
+		Type:    event.Type,
+		Message: event.Message,
+		Details: event.Details,
+	})
+}
+
+// deliveryLogRetention bounds how far back the delivery log is kept.
+const deliveryLogRetention = 7 * 24 * time.Hour
+
+// deliveryLogPruneInterval is how often a running daemon ages out delivery
+// log entries, so the log stays bounded between restarts.
+const deliveryLogPruneInterval = time.Hour
+
+// pruneDeliveryLogs drops delivery log entries older than the retention in
+// every session dir.
+func (rt *daemonRuntime) pruneDeliveryLogs(now time.Time) {
+	for _, sessionDir := range runtimeSessionDirs(rt.sessionDir, rt.nodes) {
+		if _, err := store.PruneDeliveryLog(sessionDir, deliveryLogRetention, now); err != nil {
+			log.Printf("postman: WARNING: delivery log prune failed for %s: %v\n", sessionDir, err)
+		}
+	}
+}
+
+// loadDeliveryLog prunes each session's delivery log by age and remembers the
+// posts it records as delivered, so a post that reappears in post/ with the
+// content already delivered is not delivered twice.
+func (rt *daemonRuntime) loadDeliveryLog(now time.Time) {
+	rt.pruneDeliveryLogs(now)
+	rt.journaledPosts = make(map[string]string)
+	for _, sessionDir := range runtimeSessionDirs(rt.sessionDir, rt.nodes) {
+		entries, err := store.ReadDeliveryLog(sessionDir)
+		if err != nil {
+			log.Printf("postman: WARNING: delivery log unreadable for %s: %v\n", sessionDir, err)
+			continue
+		}
+		for _, entry := range entries {
+			path := filepath.Join(sessionDir, "post", entry.Filename)
+			switch entry.Outcome {
+			case store.DeliveryOutcomeDelivered:
+				if entry.ContentHash != "" {
+					rt.journaledPosts[path] = entry.ContentHash
+				}
+			case store.DeliveryOutcomeUnconfirmed:
+				delete(rt.journaledPosts, path)
+			}
+		}
+	}
+}
+
+// skipJournaledPosts wraps handle so a post whose name and content the
+// delivery log already records as delivered is dead-lettered with a reason
+// rather than delivered again. A post that only reuses the filename is
+// delivered normally.
+func (rt *daemonRuntime) skipJournaledPosts(handle func(store.PendingPost)) func(store.PendingPost) {
+	return func(post store.PendingPost) {
+		hash, ok := rt.journaledPosts[post.Path]
+		if !ok {
+			handle(post)
+			return
+		}
+		delete(rt.journaledPosts, post.Path)
+		content, err := os.ReadFile(post.Path)
+		if err != nil || store.PostContentHash(content) != hash {
+			handle(post)
+			return
+		}
+		rt.deadLetterReplayedPost(post)
+	}
+}
+
+// deadLetterReplayedPost moves a post the delivery log already records as
+// delivered to dead-letter/ and reports it like any other dead-letter.
+func (rt *daemonRuntime) deadLetterReplayedPost(post store.PendingPost) {
+	const reason = "already delivered"
+	sessionDir := filepath.Dir(filepath.Dir(post.Path))
+	dst := store.DeadLetterPath(sessionDir, post.Filename, message.DlSuffixAlreadyDelivered)
+	if err := store.MoveToDeadLetter(post.Path, dst); err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("postman: WARNING: failed to dead-letter replayed post %s: %v\n", post.Filename, err)
+		}
+		return
+	}
+	log.Printf("postman: %s was already delivered with identical content (delivery log); dead-lettered as %s\n", post.Filename, filepath.Base(dst))
+	rt.daemonState.recordDeadLetter(rt.now())
+	rt.daemonState.recordDeadLetterReason(reason)
+	eventMessage := fmt.Sprintf("Dead-letter: %s (%s)", post.Filename, reason)
+	if info, err := message.ParseMessageFilename(post.Filename); err == nil {
+		eventMessage = fmt.Sprintf("Dead-letter: %s -> %s (%s)", info.From, info.To, reason)
+	}
+	tui.SendEventNonBlocking(rt.events, tui.DaemonEvent{
+		Type:    "message_received",
+		Message: eventMessage,
+		Details: map[string]interface{}{
+			"failure_reason": reason,
+			"filename":       post.Filename,
+		},
+	})
+}
+
 func (rt *daemonRuntime) beginPostEvent(eventPath string) bool {
 	rt.postEventsMu.Lock()
 	defer rt.postEventsMu.Unlock()
@@ -1207,7 +1446,8 @@ func (rt *daemonRuntime) handleReadWatcherEvent(eventPath string, op fswatcher.O
 		return
 	}
 	filename := filepath.Base(eventPath)
-	if !strings.HasSuffix(filename, ".md") {
+	// Suppressed duplicates are parked in read/ but were never read.
+	if !strings.HasSuffix(filename, ".md") || message.IsDuplicateReadFile(filename) {
 		return
 	}
 
@@ -1233,15 +1473,15 @@ func (rt *daemonRuntime) handleReadWatcherEvent(eventPath string, op fswatcher.O
 		return
 	}
 
-	prefixedKey := sourceSessionName + ":" + info.To
+	prefixedKey := sourceSessionName + ":" + rt.cfg.CanonicalNodeName(info.To)
 	rt.idleTracker.MarkNodeAlive(prefixedKey)
-	rt.events <- tui.DaemonEvent{
+	tui.SendEventNonBlocking(rt.events, tui.DaemonEvent{
 		Type: "node_alive",
 		Details: map[string]interface{}{
 			"node":   prefixedKey,
 			"source": "read_move",
 		},
-	}
+	})
 }
 
 func (rt *daemonRuntime) ensureMailboxProjectionSyncRuntime() {
@@ -1294,10 +1534,10 @@ func (rt *daemonRuntime) waitForMailboxProjectionSyncs() {
 }
 
 func (rt *daemonRuntime) handleWatcherError(err error) {
-	rt.events <- tui.DaemonEvent{
+	tui.SendEventNonBlocking(rt.events, tui.DaemonEvent{
 		Type:    "error",
 		Message: fmt.Sprintf("watcher error: %v", err),
-	}
+	})
 }
 
 func (rt *daemonRuntime) handleScanTick() {
@@ -1310,7 +1550,7 @@ func (rt *daemonRuntime) handleScanTick() {
 	rt.pruneWatchedDirs(freshNodes)
 	rt.claimNewPanes(freshNodes)
 	for _, collision := range scanCollisions {
-		rt.events <- tui.DaemonEvent{
+		tui.SendEventNonBlocking(rt.events, tui.DaemonEvent{
 			Type:    "pane_collision",
 			Message: fmt.Sprintf("[COLLISION] %s: %s displaced by %s", collision.NodeKey, collision.LoserPaneID, collision.WinnerPaneID),
 			Details: map[string]interface{}{
@@ -1318,7 +1558,7 @@ func (rt *daemonRuntime) handleScanTick() {
 				"winner_pane_id": collision.WinnerPaneID,
 				"loser_pane_id":  collision.LoserPaneID,
 			},
-		}
+		})
 	}
 
 	autoEnableSessions := config.BoolVal(rt.cfg.AutoEnableNewSessions, true)
@@ -1328,13 +1568,14 @@ func (rt *daemonRuntime) handleScanTick() {
 	rt.recordPendingAutoPings(newNodes, freshNodes, "discovered", now)
 	rt.nodes = freshNodes
 	rt.storeSharedNodes()
+	rt.daemonState.forgetRevivedPanes(freshNodes)
 
 	allSessions, err := discovery.DiscoverAllSessions()
 	if err != nil {
-		rt.events <- tui.DaemonEvent{
+		tui.SendEventNonBlocking(rt.events, tui.DaemonEvent{
 			Type:    "error",
 			Message: fmt.Sprintf("failed to discover all sessions: %v", err),
-		}
+		})
 		allSessions = []string{}
 	}
 
@@ -1350,43 +1591,49 @@ func (rt *daemonRuntime) handleScanTick() {
 				paneToNode[nodeInfo.PaneID] = nodeKey
 			}
 
-			rt.events <- tui.DaemonEvent{
+			tui.SendEventNonBlocking(rt.events, tui.DaemonEvent{
 				Type:    "pane_state_update",
 				Message: "Pane states updated",
 				Details: map[string]interface{}{
 					"pane_states":  paneStates,
 					"pane_to_node": paneToNode,
 				},
-			}
+			})
 
 			rt.daemonState.checkPaneDisappearance(paneStates, rt.daemonState.prevPaneToNode, rt.nodes, rt.events)
 			restartedNodes := rt.daemonState.checkPaneRestarts(paneStates, paneToNode, rt.nodes, rt.events)
 			rt.recordPendingAutoPings(restartedNodes, rt.nodes, "pane_restart", now)
+			if len(restartedNodes) > 0 && rt.cfg != nil && config.BoolVal(rt.cfg.RenotifyOnRestart, false) {
+				rt.scheduleRestartRenotify(restartedNodes)
+			}
 			rt.prevPaneStatesJSON = currentJSONStr
 		}
 	}
 
 	rt.dispatchPendingAutoPings(freshNodes, autoEnableSessions, now)
+	rt.checkCriticalEdges(now)
+	rt.checkEmptySessions(now)
+	rt.pollOverflowDirs()
 	rt.dispatchPendingDaemonSubmitRequests()
 	rt.dispatchPendingPostMessages()
 
 	nodeStates := rt.idleTracker.GetNodeStates()
-	rt.events <- tui.DaemonEvent{
+	tui.SendEventNonBlocking(rt.events, tui.DaemonEvent{
 		Type:    "node_activity_update",
 		Message: "Node activity updated",
 		Details: map[string]interface{}{
 			"node_states": nodeStates,
 		},
-	}
+	})
 }
 
 func (rt *daemonRuntime) handleSessionScanTick() {
 	allSessions, err := discovery.DiscoverAllSessions()
 	if err != nil {
-		rt.events <- tui.DaemonEvent{
+		tui.SendEventNonBlocking(rt.events, tui.DaemonEvent{
 			Type:    "error",
 			Message: fmt.Sprintf("failed to discover all sessions: %v", err),
-		}
+		})
 		return
 	}
 	if rt.activateNewSessionsFromScan(allSessions) {
@@ -1403,7 +1650,7 @@ func (rt *daemonRuntime) activateNewSessionsFromScan(allSessions []string) bool
 		return false
 	}
 
-	contextDir := filepath.Dir(rt.sessionDir)
+	contextDir := filepath.Join(rt.baseDir, rt.contextID)
 	candidateNodes := runtimeActivationNodeNames(rt.cfg)
 	activated := false
 	for _, targetSession := range allSessions {
@@ -1417,11 +1664,11 @@ func (rt *daemonRuntime) activateNewSessionsFromScan(allSessions []string) bool
 			continue
 		}
 
-		preClaimed := preclaimRuntimeSessionCandidatePanes(targetSession, rt.contextID, candidateNodes)
+		preClaimed := preclaimRuntimeSessionCandidatePanes(targetSession, rt.contextID, rt.cfg.PaneNameFormat(), candidateNodes)
 		if preClaimed == 0 {
 			continue
 		}
-		if err := config.CreateMultiSessionDirs(contextDir, targetSession); err != nil {
+		if err := rt.cfg.CreateMultiSessionDirs(contextDir, targetSession); err != nil {
 			log.Printf("postman: WARNING: failed to create auto-enabled session dirs for %s: %v\n", targetSession, err)
 			continue
 		}
@@ -1461,8 +1708,8 @@ func filterNodesByRuntimeCandidates(nodes map[string]discovery.NodeInfo, candida
 	}
 }
 
-func preclaimRuntimeSessionCandidatePanes(sessionName, contextID string, candidateNodes map[string]bool) int {
-	out, err := exec.Command("tmux", "list-panes", "-s", "-t", sessionName, "-F", "#{pane_id} #{pane_title}").Output()
+func preclaimRuntimeSessionCandidatePanes(sessionName, contextID, paneNameFormat string, candidateNodes map[string]bool) int {
+	out, err := exec.Command("tmux", "list-panes", "-s", "-t", sessionName, "-F", "#{pane_id} "+paneNameFormat).Output()
 	if err != nil {
 		log.Printf("postman: WARNING: failed to list panes for session %s: %v\n", sessionName, err)
 		return 0
@@ -1499,7 +1746,7 @@ func (rt *daemonRuntime) refreshNodesAfterSessionActivation(allSessions []string
 	rt.pruneWatchedDirs(freshNodes)
 	rt.claimNewPanes(freshNodes)
 	for _, collision := range scanCollisions {
-		rt.events <- tui.DaemonEvent{
+		tui.SendEventNonBlocking(rt.events, tui.DaemonEvent{
 			Type:    "pane_collision",
 			Message: fmt.Sprintf("[COLLISION] %s: %s displaced by %s", collision.NodeKey, collision.LoserPaneID, collision.WinnerPaneID),
 			Details: map[string]interface{}{
@@ -1507,7 +1754,7 @@ func (rt *daemonRuntime) refreshNodesAfterSessionActivation(allSessions []string
 				"winner_pane_id": collision.WinnerPaneID,
 				"loser_pane_id":  collision.LoserPaneID,
 			},
-		}
+		})
 	}
 	rt.pruneKnownNodes(freshNodes)
 	newNodes := rt.detectNewNodes(freshNodes)
@@ -1515,6 +1762,7 @@ func (rt *daemonRuntime) refreshNodesAfterSessionActivation(allSessions []string
 	rt.recordPendingAutoPings(newNodes, freshNodes, "discovered", now)
 	rt.nodes = freshNodes
 	rt.storeSharedNodes()
+	rt.daemonState.forgetRevivedPanes(freshNodes)
 	rt.dispatchPendingAutoPings(freshNodes, config.BoolVal(rt.cfg.AutoEnableNewSessions, true), now)
 	rt.emitStatusUpdateIfChanged(allSessions)
 }
@@ -1527,7 +1775,7 @@ func (rt *daemonRuntime) emitStatusUpdateIfChanged(allSessions []string) {
 	if !snapshot.changed(rt.prevNodeCount, rt.prevSessionNames, rt.prevSessionNodes) {
 		return
 	}
-	rt.events <- tui.DaemonEvent{
+	tui.SendEventNonBlocking(rt.events, tui.DaemonEvent{
 		Type:    "status_update",
 		Message: "Running",
 		Details: map[string]interface{}{
@@ -1535,23 +1783,23 @@ func (rt *daemonRuntime) emitStatusUpdateIfChanged(allSessions []string) {
 			"sessions":      snapshot.Sessions,
 			"session_nodes": snapshot.SessionNodes,
 		},
-	}
+	})
 	rt.prevNodeCount = snapshot.NodeCount
 	rt.prevSessionNames = snapshot.NormalizedSessionNames
 	rt.prevSessionNodes = snapshot.NormalizedSessionNodes
 }
 
 func (rt *daemonRuntime) handleInboxCheckTick() {
-	rt.events <- tui.DaemonEvent{
+	tui.SendEventNonBlocking(rt.events, tui.DaemonEvent{
 		Type: "inbox_unread_count_update",
 		Details: map[string]interface{}{
 			"unread_counts": scanLiveInboxCounts(rt.nodes),
 		},
-	}
+	})
 }
 
 func (rt *daemonRuntime) discoverNodes() (map[string]discovery.NodeInfo, []discovery.CollisionReport, error) {
-	freshNodes, collisions, err := discovery.DiscoverNodesWithCollisions(rt.baseDir, rt.contextID, rt.selfSession)
+	freshNodes, collisions, err := discovery.DiscoverNodesWithCollisions(rt.baseDir, rt.contextID, rt.selfSession, rt.cfg)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1567,45 +1815,73 @@ func (rt *daemonRuntime) storeSharedNodes() {
 	rt.sharedNodes.Store(&nodesSnapshot)
 }
 
+// sessionDirRetry is one attempt at creating a node's session dirs. Session
+// dirs can fail transiently while a container is still mounting or fixing
+// permissions, so failed attempts are retried from a timer, with the delay
+// doubling each time, and handed back to the event loop.
+type sessionDirRetry struct {
+	nodeName string
+	nodeInfo discovery.NodeInfo
+	attempt  int
+	delay    time.Duration
+}
+
 func (rt *daemonRuntime) ensureNodeWatchDirs(nodeName string, nodeInfo discovery.NodeInfo) {
-	if err := config.CreateSessionDirs(nodeInfo.SessionDir); err != nil {
-		rt.events <- tui.DaemonEvent{
+	rt.createNodeWatchDirs(sessionDirRetry{
+		nodeName: nodeName,
+		nodeInfo: nodeInfo,
+		attempt:  1,
+		delay:    time.Duration(rt.cfg.EffectiveSessionDirCreateRetrySeconds() * float64(time.Second)),
+	})
+}
+
+func (rt *daemonRuntime) createNodeWatchDirs(try sessionDirRetry) {
+	nodeName, nodeInfo := try.nodeName, try.nodeInfo
+	create := rt.createSessionDirs
+	if create == nil {
+		create = config.CreateSessionDirs
+	}
+	if err := create(nodeInfo.SessionDir); err != nil {
+		if attempts := rt.cfg.EffectiveSessionDirCreateAttempts(); try.attempt < attempts {
+			log.Printf("postman: WARNING: creating session dirs %s failed (attempt %d/%d): %v; retrying in %s\n", nodeInfo.SessionDir, try.attempt, attempts, err, try.delay)
+			rt.scheduleSessionDirRetry(try)
+			return
+		}
+		tui.SendEventNonBlocking(rt.events, tui.DaemonEvent{
 			Type:    "error",
 			Message: fmt.Sprintf("failed to create session dirs for %s: %v", nodeName, err),
-		}
+		})
 		return
 	}
 
-	nodePostDir := filepath.Join(nodeInfo.SessionDir, "post")
-	nodeInboxDir := filepath.Join(nodeInfo.SessionDir, "inbox")
-	nodeReadDir := filepath.Join(nodeInfo.SessionDir, "read")
+	rt.watchDir(filepath.Join(nodeInfo.SessionDir, "post"))
+	rt.watchDir(filepath.Join(nodeInfo.SessionDir, "inbox"))
+	rt.watchDir(filepath.Join(nodeInfo.SessionDir, "read"))
 
-	if !rt.watchedDirs[nodePostDir] {
-		if err := rt.watcher.Add(nodePostDir, fswatcher.All); err == nil {
-			rt.watchedDirs[nodePostDir] = true
-		}
-	}
-	if !rt.watchedDirs[nodeInboxDir] {
-		if err := rt.watcher.Add(nodeInboxDir, fswatcher.All); err == nil {
-			rt.watchedDirs[nodeInboxDir] = true
-		}
-	}
-	if !rt.watchedDirs[nodeReadDir] {
-		if err := rt.watcher.Add(nodeReadDir, fswatcher.All); err == nil {
-			rt.watchedDirs[nodeReadDir] = true
-		}
-	}
-
-	submitRequestsDir := projection.DaemonSubmitRequestsDir(nodeInfo.SessionDir)
 	if err := projection.EnsureDaemonSubmitDirs(nodeInfo.SessionDir); err != nil {
 		log.Printf("postman: WARNING: component=%s event=dirs_create_failed submit_path=%s node=%s err=%v\n", projection.SubmitPathDaemon, projection.SubmitPathDaemon, nodeName, err)
 		return
 	}
-	if !rt.watchedDirs[submitRequestsDir] {
-		if err := rt.watcher.Add(submitRequestsDir, fswatcher.All); err == nil {
-			rt.watchedDirs[submitRequestsDir] = true
-		}
+	rt.watchDir(projection.DaemonSubmitRequestsDir(nodeInfo.SessionDir))
+}
+
+// scheduleSessionDirRetry queues the next attempt after try.delay. The
+// timer only hands it to the event loop, which runs the attempt.
+func (rt *daemonRuntime) scheduleSessionDirRetry(try sessionDirRetry) {
+	next := try
+	next.attempt++
+	next.delay *= 2
+	if rt.sessionDirRetries == nil {
+		rt.sessionDirRetries = make(chan sessionDirRetry, sessionDirRetryQueueSize)
 	}
+	retries := rt.sessionDirRetries
+	scheduler := rt.scheduleRuntimeTimer
+	if scheduler == nil {
+		scheduler = defaultRuntimeTimerScheduler
+	}
+	scheduler(try.delay, "session-dir-retry", rt.events, func() {
+		retries <- next
+	})
 }
 
 func nodeWatchDirs(nodeInfo discovery.NodeInfo) []string {
@@ -1632,8 +1908,25 @@ func desiredWatchDirsForNodes(nodes map[string]discovery.NodeInfo) map[string]bo
 	return desired
 }
 
-func (rt *daemonRuntime) pruneWatchedDirs(freshNodes map[string]discovery.NodeInfo) {
+// desiredWatchDirs adds the daemon's own session dirs, which start watches
+// unconditionally, to the node dirs so pruning never drops them.
+func (rt *daemonRuntime) desiredWatchDirs(freshNodes map[string]discovery.NodeInfo) map[string]bool {
 	desired := desiredWatchDirsForNodes(freshNodes)
+	if rt.sessionDir != "" {
+		for _, dir := range nodeWatchDirs(discovery.NodeInfo{SessionDir: rt.sessionDir}) {
+			desired[dir] = true
+		}
+	}
+	return desired
+}
+
+func (rt *daemonRuntime) pruneWatchedDirs(freshNodes map[string]discovery.NodeInfo) {
+	desired := rt.desiredWatchDirs(freshNodes)
+	for dir := range rt.polledDirs {
+		if !desired[dir] {
+			delete(rt.polledDirs, dir)
+		}
+	}
 	for dir := range rt.watchedDirs {
 		if desired[dir] {
 			continue
@@ -1648,6 +1941,86 @@ func (rt *daemonRuntime) pruneWatchedDirs(freshNodes map[string]discovery.NodeIn
 	}
 }
 
+// reindexWatches rebuilds the watch set for freshNodes from scratch. Watches
+// for vanished session dirs are dropped, and every live dir is removed and
+// re-added so a watch lost to a moved or recreated dir is restored even when
+// watchedDirs still lists it. added counts dirs that were not watched before.
+func (rt *daemonRuntime) reindexWatches(freshNodes map[string]discovery.NodeInfo) (added, removed int) {
+	previous := make(map[string]bool, len(rt.watchedDirs))
+	for dir := range rt.watchedDirs {
+		previous[dir] = true
+	}
+	desired := rt.desiredWatchDirs(freshNodes)
+	for dir := range previous {
+		if rt.watcher != nil {
+			_ = rt.watcher.Remove(dir)
+		}
+		delete(rt.watchedDirs, dir)
+		if !desired[dir] {
+			removed++
+		}
+	}
+	// Polled overflow dirs get another chance at a real watch.
+	clear(rt.polledDirs)
+
+	nodeNames := make([]string, 0, len(freshNodes))
+	for nodeName := range freshNodes {
+		nodeNames = append(nodeNames, nodeName)
+	}
+	sort.Strings(nodeNames)
+	for _, nodeName := range nodeNames {
+		rt.ensureNodeWatchDirs(nodeName, freshNodes[nodeName])
+	}
+	if rt.sessionDir != "" {
+		for _, dir := range nodeWatchDirs(discovery.NodeInfo{SessionDir: rt.sessionDir}) {
+			rt.watchDir(dir)
+		}
+	}
+	for dir := range rt.watchedDirs {
+		if !previous[dir] {
+			added++
+		}
+	}
+	return added, removed
+}
+
+// handleReindex performs a full re-discovery and rebuilds the watch set,
+// recovering from watch drift without a daemon restart.
+func (rt *daemonRuntime) handleReindex() {
+	freshNodes, _, err := rt.discoverNodes()
+	if err != nil {
+		log.Printf("postman: WARNING: component=daemon_runtime event=reindex_failed err=%v\n", err)
+		tui.SendEventNonBlocking(rt.events, tui.DaemonEvent{
+			Type:    "error",
+			Message: fmt.Sprintf("reindex: node discovery failed: %v", err),
+		})
+		return
+	}
+
+	added, removed := rt.reindexWatches(freshNodes)
+	rt.pruneClaimedPanes(freshNodes)
+	rt.claimNewPanes(freshNodes)
+	rt.pruneKnownNodes(freshNodes)
+	newNodes := rt.detectNewNodes(freshNodes)
+	rt.recordPendingAutoPings(newNodes, freshNodes, "discovered", rt.now())
+	rt.nodes = freshNodes
+	rt.storeSharedNodes()
+	rt.daemonState.forgetRevivedPanes(freshNodes)
+	rt.dispatchPendingPostMessages()
+
+	log.Printf("postman: component=daemon_runtime event=reindexed node_count=%d watched_dir_count=%d added_watch_count=%d removed_watch_count=%d\n", len(freshNodes), len(rt.watchedDirs), added, removed)
+	tui.SendEventNonBlocking(rt.events, tui.DaemonEvent{
+		Type:    "reindexed",
+		Message: fmt.Sprintf("Reindexed: %d nodes, %d watched dirs (+%d/-%d)", len(freshNodes), len(rt.watchedDirs), added, removed),
+		Details: map[string]interface{}{
+			"node_count":          len(freshNodes),
+			"watched_dir_count":   len(rt.watchedDirs),
+			"added_watch_count":   added,
+			"removed_watch_count": removed,
+		},
+	})
+}
+
 func (rt *daemonRuntime) detectNewNodes(freshNodes map[string]discovery.NodeInfo) []string {
 	nodeNames := make([]string, 0, len(freshNodes))
 	for nodeName := range freshNodes {
@@ -1741,6 +2114,39 @@ func (rt *daemonRuntime) recordPendingAutoPings(nodeKeys []string, freshNodes ma
 	}
 }
 
+// scheduleRestartRenotify re-notifies restarted nodes once
+// auto_ping_delay_seconds has passed, giving the new pane the same time to
+// come up as its auto-PING. Pane notifications sleep between keystrokes, so
+// they run on the timer goroutine rather than the scan tick.
+func (rt *daemonRuntime) scheduleRestartRenotify(nodeKeys []string) {
+	delay := time.Duration(0)
+	if rt.cfg != nil && rt.cfg.AutoPingDelaySeconds > 0 {
+		delay = time.Duration(rt.cfg.AutoPingDelaySeconds * float64(time.Second))
+	}
+	nodes := cloneNodeInfoMap(rt.nodes)
+	scheduler := rt.scheduleRuntimeTimer
+	if scheduler == nil {
+		scheduler = defaultRuntimeTimerScheduler
+	}
+	scheduler(delay, "restart-renotify", rt.events, func() {
+		rt.renotifyRestartedNodes(nodeKeys, nodes, rt.idleTracker.GetLivenessMap())
+	})
+}
+
+// renotifyRestartedNodes re-sends the notifications for mail still unread in
+// each restarted node's inbox (renotify_on_restart).
+func (rt *daemonRuntime) renotifyRestartedNodes(nodeKeys []string, nodes map[string]discovery.NodeInfo, livenessMap map[string]bool) {
+	for _, nodeKey := range nodeKeys {
+		nodeInfo, ok := nodes[nodeKey]
+		if !ok {
+			continue
+		}
+		if renotified := message.RenotifyUnreadInbox(nodeKey, nodeInfo, rt.cfg, rt.adjacency, nodes, rt.contextID, livenessMap); len(renotified) > 0 {
+			log.Printf("postman: re-notified %s of %d unread inbox message(s) after pane restart\n", nodeKey, len(renotified))
+		}
+	}
+}
+
 func (rt *daemonRuntime) recordPendingAutoPing(nodeKey string, nodeInfo discovery.NodeInfo, reason string, now time.Time) {
 	if nodeInfo.SessionDir == "" || nodeInfo.SessionName == "" {
 		return
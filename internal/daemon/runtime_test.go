@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -22,17 +23,21 @@ import (
 	"github.com/i9wa4/tmux-a2a-postman/internal/discovery"
 	"github.com/i9wa4/tmux-a2a-postman/internal/idle"
 	"github.com/i9wa4/tmux-a2a-postman/internal/journal"
+	"github.com/i9wa4/tmux-a2a-postman/internal/message"
 	"github.com/i9wa4/tmux-a2a-postman/internal/msgtrace"
 	"github.com/i9wa4/tmux-a2a-postman/internal/projection"
 	"github.com/i9wa4/tmux-a2a-postman/internal/status"
+	"github.com/i9wa4/tmux-a2a-postman/internal/store"
 	"github.com/i9wa4/tmux-a2a-postman/internal/tui"
 )
 
 type recordingFilesystemWatcher struct {
+	added   []string
 	removed []string
 }
 
-func (w *recordingFilesystemWatcher) Add(string, fswatcher.Op) error {
+func (w *recordingFilesystemWatcher) Add(path string, _ fswatcher.Op) error {
+	w.added = append(w.added, path)
 	return nil
 }
 
@@ -2570,6 +2575,55 @@ func TestPruneWatchedDirsRemovesDirsForDisappearedNodes(t *testing.T) {
 	}
 }
 
+func TestReindexWatchesWatchesNewSessionAndDropsRemovedSession(t *testing.T) {
+	contextDir := t.TempDir()
+	keptSessionDir := filepath.Join(contextDir, "main")
+	newSessionDir := filepath.Join(contextDir, "review")
+	goneSessionDir := filepath.Join(contextDir, "gone")
+	keptNode := discovery.NodeInfo{SessionDir: keptSessionDir}
+	newNode := discovery.NodeInfo{SessionDir: newSessionDir}
+
+	watcher := &recordingFilesystemWatcher{}
+	events := make(chan tui.DaemonEvent, 4)
+	rt := &daemonRuntime{
+		watcher:     watcher,
+		watchedDirs: map[string]bool{},
+		events:      events,
+	}
+	for _, dir := range append(nodeWatchDirs(keptNode), nodeWatchDirs(discovery.NodeInfo{SessionDir: goneSessionDir})...) {
+		rt.watchedDirs[dir] = true
+	}
+
+	added, removed := rt.reindexWatches(map[string]discovery.NodeInfo{
+		"main:worker":   keptNode,
+		"review:critic": newNode,
+	})
+
+	if added != 4 || removed != 4 {
+		t.Fatalf("reindexWatches() = (added %d, removed %d), want (4, 4)", added, removed)
+	}
+	want := desiredWatchDirsForNodes(map[string]discovery.NodeInfo{"a": keptNode, "b": newNode})
+	if !reflect.DeepEqual(rt.watchedDirs, want) {
+		t.Fatalf("watchedDirs = %#v, want %#v", rt.watchedDirs, want)
+	}
+	for _, dir := range nodeWatchDirs(newNode) {
+		if _, err := os.Stat(dir); err != nil {
+			t.Fatalf("reindexWatches() did not create %s: %v", dir, err)
+		}
+	}
+	// Kept dirs are re-added so a watch the kernel silently dropped recovers.
+	for _, dir := range nodeWatchDirs(keptNode) {
+		if !slices.Contains(watcher.removed, dir) || !slices.Contains(watcher.added, dir) {
+			t.Fatalf("kept dir %s was not re-armed: removed=%v added=%v", dir, watcher.removed, watcher.added)
+		}
+	}
+	select {
+	case event := <-events:
+		t.Fatalf("unexpected event: %#v", event)
+	default:
+	}
+}
+
 func TestHandleScanTick_DisabledAutoEnableLeavesNewSessionPendingAndDisabled(t *testing.T) {
 	tmpDir := t.TempDir()
 	baseDir := filepath.Join(tmpDir, "state")
@@ -3675,3 +3729,175 @@ func TestLogPaneIDChanges_LogsRediscoveryAndCollapse(t *testing.T) {
 		t.Errorf("unexpected log for new node delta: %s", logOut)
 	}
 }
+
+func TestEnsureNodeWatchDirs_RetriesTransientSessionDirFailure(t *testing.T) {
+	sessionDir := filepath.Join(t.TempDir(), "main")
+	calls := 0
+	var delays []time.Duration
+	var timers []func()
+	watcher := &recordingFilesystemWatcher{}
+	events := make(chan tui.DaemonEvent, 4)
+	rt := &daemonRuntime{
+		cfg:               &config.Config{SessionDirCreateRetrySeconds: 0.25},
+		watcher:           watcher,
+		watchedDirs:       map[string]bool{},
+		polledDirs:        map[string]map[string]bool{},
+		events:            events,
+		sessionDirRetries: make(chan sessionDirRetry, 1),
+		scheduleRuntimeTimer: func(delay time.Duration, _ string, _ chan<- tui.DaemonEvent, callback func()) {
+			delays = append(delays, delay)
+			timers = append(timers, callback)
+		},
+		createSessionDirs: func(dir string) error {
+			calls++
+			if calls == 1 {
+				return os.ErrPermission
+			}
+			return config.CreateSessionDirs(dir)
+		},
+	}
+
+	rt.ensureNodeWatchDirs("main:worker", discovery.NodeInfo{SessionDir: sessionDir})
+
+	// The failure schedules a retry instead of sleeping on the caller.
+	if calls != 1 || len(timers) != 1 || delays[0] != 250*time.Millisecond {
+		t.Fatalf("after first failure: calls = %d, retry delays = %v, want 1 call and one 250ms retry", calls, delays)
+	}
+	if rt.watchedDirs[filepath.Join(sessionDir, "post")] {
+		t.Fatal("post/ watched before session dirs exist")
+	}
+
+	timers[0]()
+	rt.createNodeWatchDirs(<-rt.sessionDirRetries)
+
+	if calls != 2 {
+		t.Fatalf("createSessionDirs calls = %d, want 2 (one failure, one success)", calls)
+	}
+	if _, err := os.Stat(filepath.Join(sessionDir, "inbox")); err != nil {
+		t.Fatalf("inbox dir missing after retry: %v", err)
+	}
+	if !rt.watchedDirs[filepath.Join(sessionDir, "post")] {
+		t.Fatalf("post/ not watched after retry; watchedDirs=%v", rt.watchedDirs)
+	}
+	select {
+	case event := <-events:
+		t.Fatalf("unexpected event after successful retry: %#v", event)
+	default:
+	}
+}
+
+func TestEnsureNodeWatchDirs_GivesUpAfterAttempts(t *testing.T) {
+	calls := 0
+	var delays []time.Duration
+	var timers []func()
+	events := make(chan tui.DaemonEvent, 4)
+	rt := &daemonRuntime{
+		cfg:               &config.Config{SessionDirCreateAttempts: 3, SessionDirCreateRetrySeconds: 0.1},
+		events:            events,
+		sessionDirRetries: make(chan sessionDirRetry, 1),
+		scheduleRuntimeTimer: func(delay time.Duration, _ string, _ chan<- tui.DaemonEvent, callback func()) {
+			delays = append(delays, delay)
+			timers = append(timers, callback)
+		},
+		createSessionDirs: func(string) error {
+			calls++
+			return os.ErrPermission
+		},
+	}
+
+	rt.ensureNodeWatchDirs("main:worker", discovery.NodeInfo{SessionDir: "unused"})
+	for len(timers) > 0 {
+		next := timers[0]
+		timers = timers[1:]
+		next()
+		rt.createNodeWatchDirs(<-rt.sessionDirRetries)
+	}
+
+	if calls != 3 {
+		t.Fatalf("calls = %d, want 3", calls)
+	}
+	if want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}; !slices.Equal(delays, want) {
+		t.Fatalf("retry delays = %v, want %v", delays, want)
+	}
+	select {
+	case event := <-events:
+		if event.Type != "error" || !strings.Contains(event.Message, "main:worker") {
+			t.Fatalf("event = %#v, want session dir error for main:worker", event)
+		}
+	default:
+		t.Fatal("no error event after the last attempt")
+	}
+}
+
+func TestDispatchPendingPostsDeadLettersJournaledReplayAfterRestart(t *testing.T) {
+	sessionDir := filepath.Join(t.TempDir(), "main")
+	if err := config.CreateSessionDirs(sessionDir); err != nil {
+		t.Fatalf("config.CreateSessionDirs failed: %v", err)
+	}
+	nodes := map[string]discovery.NodeInfo{
+		"main:orchestrator": {SessionName: "main", SessionDir: sessionDir, PaneID: "%1"},
+		"main:worker":       {SessionName: "main", SessionDir: sessionDir, PaneID: "%2"},
+	}
+	cfg := &config.Config{EnterDelay: 0.1, TmuxTimeout: 1.0}
+	adjacency := map[string][]string{"orchestrator": {"worker"}}
+	content := []byte("---\nparams:\n  contextId: runtime-ctx\n  from: orchestrator\n  to: worker\n  timestamp: 2026-02-01T03:00:00Z\n---\n\nbody\n")
+	deliver := func(path string, body []byte) {
+		t.Helper()
+		if err := os.WriteFile(path, body, 0o644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		if err := message.DeliverMessage(path, "runtime-ctx", nodes, adjacency, cfg, func(string) bool { return true }, nil, idle.NewIdleTracker(), ""); err != nil {
+			t.Fatalf("DeliverMessage failed: %v", err)
+		}
+	}
+
+	// First run delivers two posts.
+	replayed := filepath.Join(sessionDir, "post", "20260201-030000-from-orchestrator-to-worker.md")
+	reused := filepath.Join(sessionDir, "post", "20260201-030001-from-orchestrator-to-worker.md")
+	deliver(replayed, content)
+	deliver(reused, content)
+
+	// Before the restart, one post reappears unchanged, one filename is
+	// reused for new content, and one post was never delivered.
+	if err := os.WriteFile(replayed, content, 0o644); err != nil {
+		t.Fatalf("WriteFile replay failed: %v", err)
+	}
+	if err := os.WriteFile(reused, append(content, "follow-up\n"...), 0o644); err != nil {
+		t.Fatalf("WriteFile reused failed: %v", err)
+	}
+	fresh := filepath.Join(sessionDir, "post", "20260201-030100-from-orchestrator-to-worker.md")
+	if err := os.WriteFile(fresh, content, 0o644); err != nil {
+		t.Fatalf("WriteFile fresh failed: %v", err)
+	}
+
+	events := make(chan tui.DaemonEvent, 4)
+	rt := &daemonRuntime{sessionDir: sessionDir, nodes: nodes, cfg: cfg, events: events}
+	rt.loadDeliveryLog(time.Now())
+	var dispatched []string
+	handle := rt.skipJournaledPosts(func(post store.PendingPost) {
+		dispatched = append(dispatched, post.Filename)
+	})
+	if _, err := rt.postReconciler().ReconcileSessionDirs([]string{sessionDir}, handle); err != nil {
+		t.Fatalf("ReconcileSessionDirs failed: %v", err)
+	}
+
+	slices.Sort(dispatched)
+	if want := []string{filepath.Base(reused), filepath.Base(fresh)}; !slices.Equal(dispatched, want) {
+		t.Fatalf("dispatched = %v, want %v", dispatched, want)
+	}
+	if _, err := os.Stat(replayed); !os.IsNotExist(err) {
+		t.Fatalf("replayed post still in post/: %v", err)
+	}
+	deadLetter := store.DeadLetterPath(sessionDir, filepath.Base(replayed), message.DlSuffixAlreadyDelivered)
+	if _, err := os.Stat(deadLetter); err != nil {
+		t.Fatalf("replayed post not dead-lettered at %s: %v", deadLetter, err)
+	}
+	select {
+	case event := <-events:
+		if event.Type != "message_received" || event.Details["failure_reason"] != "already delivered" {
+			t.Fatalf("event = %+v, want already delivered dead-letter", event)
+		}
+	default:
+		t.Fatal("no dead-letter event for the replayed post")
+	}
+}
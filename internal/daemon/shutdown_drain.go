@@ -0,0 +1,82 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/message"
+	"github.com/i9wa4/tmux-a2a-postman/internal/tui"
+)
+
+// shutdownDrainTimeout returns the shutdown_drain_timeout_seconds budget; 0
+// disables the drain.
+func shutdownDrainTimeout(cfg *config.Config) time.Duration {
+	if cfg == nil || cfg.ShutdownDrainTimeoutSeconds <= 0 {
+		return 0
+	}
+	return time.Duration(cfg.ShutdownDrainTimeoutSeconds * float64(time.Second))
+}
+
+// drainPendingPosts delivers what is still sitting in post/ for enabled
+// sessions before the daemon exits, so a restart does not strand in-flight
+// mail. Messages are delivered synchronously in filename order; the deadline
+// is checked between messages. Posts already being delivered by a worker, and
+// posts held by serialize_per_node, are left alone. Returns the number of
+// posts that left post/.
+func (rt *daemonRuntime) drainPendingPosts(timeout time.Duration) int {
+	if timeout <= 0 {
+		return 0
+	}
+	deadline := rt.now().Add(timeout)
+	drained := 0
+	remaining := 0
+	for _, sessionDir := range runtimeSessionDirs(rt.sessionDir, rt.nodes) {
+		if !rt.daemonState.IsSessionEnabled(filepath.Base(sessionDir)) {
+			continue
+		}
+		for _, postPath := range message.PendingPostPaths(sessionDir) {
+			if !rt.now().Before(deadline) {
+				remaining++
+				continue
+			}
+			if rt.drainPost(postPath) {
+				drained++
+			}
+		}
+	}
+
+	if drained > 0 || remaining > 0 {
+		log.Printf("postman: component=daemon_runtime event=drained count=%d remaining=%d timeout=%s\n", drained, remaining, timeout)
+	}
+	tui.SendEventNonBlocking(rt.events, tui.DaemonEvent{
+		Type:    "drained",
+		Message: fmt.Sprintf("Drained %d post/ message(s) before shutdown", drained),
+		Details: map[string]interface{}{
+			"count":     drained,
+			"remaining": remaining,
+		},
+	})
+	return drained
+}
+
+func (rt *daemonRuntime) drainPost(postPath string) bool {
+	if !rt.beginPostEvent(postPath) {
+		return false
+	}
+	defer rt.finishPostEvent(postPath)
+
+	filename := filepath.Base(postPath)
+	if _, err := rt.deliverPostNow(postPath, filename, rt.nodes, rt.adjacency, rt.cfg); err != nil {
+		if !errors.Is(err, message.ErrDeliveryHeld) {
+			log.Printf("postman: WARNING: shutdown drain failed for %s: %v\n", filename, err)
+		}
+		return false
+	}
+	_, statErr := os.Stat(postPath)
+	return os.IsNotExist(statErr)
+}
@@ -0,0 +1,98 @@
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/discovery"
+	"github.com/i9wa4/tmux-a2a-postman/internal/idle"
+	"github.com/i9wa4/tmux-a2a-postman/internal/tui"
+)
+
+func TestDrainPendingPostsDeliversLeftoverPostBeforeShutdown(t *testing.T) {
+	baseDir := t.TempDir()
+	sessionDir := filepath.Join(baseDir, "main")
+	pausedDir := filepath.Join(baseDir, "paused")
+	for _, dir := range []string{sessionDir, pausedDir} {
+		if err := config.CreateSessionDirs(dir); err != nil {
+			t.Fatalf("config.CreateSessionDirs failed: %v", err)
+		}
+	}
+	writePost := func(dir, filename, to string) string {
+		t.Helper()
+		content := "---\nparams:\n  contextId: runtime-ctx\n  from: orchestrator\n  to: " + to + "\n  timestamp: 2026-02-01T03:00:00Z\n---\n\nbody\n"
+		path := filepath.Join(dir, "post", filename)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		return path
+	}
+	pending := writePost(sessionDir, "20260201-030000-from-orchestrator-to-worker.md", "worker")
+	paused := writePost(pausedDir, "20260201-030100-from-orchestrator-to-critic.md", "critic")
+
+	events := make(chan tui.DaemonEvent, 8)
+	rt := &daemonRuntime{
+		contextID:  "runtime-ctx",
+		sessionDir: sessionDir,
+		nodes: map[string]discovery.NodeInfo{
+			"main:orchestrator": {SessionName: "main", SessionDir: sessionDir, PaneID: "%1"},
+			"main:worker":       {SessionName: "main", SessionDir: sessionDir, PaneID: "%2"},
+			"paused:critic":     {SessionName: "paused", SessionDir: pausedDir, PaneID: "%3"},
+		},
+		adjacency:        map[string][]string{"orchestrator": {"worker", "critic"}},
+		cfg:              &config.Config{EnterDelay: 0.1, TmuxTimeout: 1.0},
+		events:           events,
+		daemonState:      NewDaemonState(0, "runtime-ctx"),
+		idleTracker:      idle.NewIdleTracker(),
+		activePostEvents: make(map[string]bool),
+	}
+	rt.daemonState.SetSessionEnabled("main", true)
+
+	start := time.Now()
+	if got := rt.drainPendingPosts(5 * time.Second); got != 1 {
+		t.Fatalf("drainPendingPosts() = %d, want 1", got)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("drain took %v, past its 5s timeout", elapsed)
+	}
+
+	if _, err := os.Stat(pending); !os.IsNotExist(err) {
+		t.Fatalf("pending post still in post/ after drain: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(sessionDir, "inbox", "worker", filepath.Base(pending))); err != nil {
+		t.Fatalf("pending post not delivered to inbox: %v", err)
+	}
+	if _, err := os.Stat(paused); err != nil {
+		t.Fatalf("post for disabled session should stay in post/: %v", err)
+	}
+
+	// Drained mail goes through the same bookkeeping as a normal dispatch,
+	// so the TUI sees the delivery before the drained summary.
+	var types []string
+	var last tui.DaemonEvent
+	for len(events) > 0 {
+		last = <-events
+		types = append(types, last.Type)
+	}
+	if !slices.Contains(types, "message_received") {
+		t.Fatalf("events = %v, want message_received for the drained post", types)
+	}
+	if last.Type != "drained" || last.Details["count"] != 1 {
+		t.Fatalf("last event = %+v, want drained with count 1", last)
+	}
+}
+
+func TestDrainPendingPostsDisabledByZeroTimeout(t *testing.T) {
+	sessionDir := filepath.Join(t.TempDir(), "main")
+	if err := config.CreateSessionDirs(sessionDir); err != nil {
+		t.Fatalf("config.CreateSessionDirs failed: %v", err)
+	}
+	rt := &daemonRuntime{sessionDir: sessionDir, daemonState: NewDaemonState(0, "runtime-ctx")}
+	if got := rt.drainPendingPosts(shutdownDrainTimeout(&config.Config{})); got != 0 {
+		t.Fatalf("drainPendingPosts() with no timeout = %d, want 0", got)
+	}
+}
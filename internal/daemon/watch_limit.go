@@ -0,0 +1,117 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fswatcher/fswatcher"
+	"github.com/i9wa4/tmux-a2a-postman/internal/discovery"
+	"github.com/i9wa4/tmux-a2a-postman/internal/tui"
+)
+
+// watchNodeDirs adds watches for every startup node in a stable order, so the
+// same dirs land under max_watched_dirs on every start.
+func (rt *daemonRuntime) watchNodeDirs(nodes map[string]discovery.NodeInfo) {
+	nodeNames := make([]string, 0, len(nodes))
+	for nodeName := range nodes {
+		nodeNames = append(nodeNames, nodeName)
+	}
+	sort.Strings(nodeNames)
+	for _, nodeName := range nodeNames {
+		for _, dir := range nodeWatchDirs(nodes[nodeName]) {
+			rt.watchDir(dir)
+		}
+	}
+}
+
+// watchDir adds a watch for dir. Past max_watched_dirs, or when the OS
+// refuses the watch (e.g. the inotify limit), dir is polled on the scan tick
+// instead, so delivery keeps working without the watch.
+func (rt *daemonRuntime) watchDir(dir string) {
+	if rt.watcher == nil || rt.watchedDirs[dir] {
+		return
+	}
+	if _, polled := rt.polledDirs[dir]; polled {
+		return
+	}
+	var reason error
+	if limit := rt.maxWatchedDirs(); limit > 0 && len(rt.watchedDirs) >= limit {
+		reason = fmt.Errorf("max_watched_dirs=%d reached", limit)
+	} else if err := rt.watcher.Add(dir, fswatcher.All); err == nil || errors.Is(err, fswatcher.ErrAlreadyAdded) {
+		rt.watchedDirs[dir] = true
+		return
+	} else {
+		reason = err
+	}
+
+	if rt.polledDirs == nil {
+		rt.polledDirs = make(map[string]map[string]bool)
+	}
+	if len(rt.polledDirs) == 0 {
+		log.Printf("postman: WARNING: component=daemon_runtime event=watch_limit_reached watched_dir_count=%d dir=%s reason=%q; polling overflow dirs every scan\n", len(rt.watchedDirs), dir, reason)
+		tui.SendEventNonBlocking(rt.events, tui.DaemonEvent{
+			Type:    "watch_limit_reached",
+			Message: fmt.Sprintf("Watch limit reached (%v); polling overflow dirs", reason),
+			Details: map[string]interface{}{
+				"watched_dir_count": len(rt.watchedDirs),
+				"dir":               dir,
+			},
+		})
+	}
+	// Seed read/ with the current archive so only new pops are reported.
+	rt.polledDirs[dir] = listMarkdownNames(dir)
+}
+
+func (rt *daemonRuntime) maxWatchedDirs() int {
+	if rt.cfg == nil {
+		return 0
+	}
+	return rt.cfg.MaxWatchedDirs
+}
+
+// pollOverflowDirs stands in for the watcher on polled dirs. post/ and
+// daemon-submit requests are already reconciled on every scan tick, and
+// inbox/ events have no handler, so only read/ archives need a diff here.
+func (rt *daemonRuntime) pollOverflowDirs() {
+	dirs := make([]string, 0, len(rt.polledDirs))
+	for dir := range rt.polledDirs {
+		if filepath.Base(dir) == "read" {
+			dirs = append(dirs, dir)
+		}
+	}
+	sort.Strings(dirs)
+	for _, dir := range dirs {
+		seen := rt.polledDirs[dir]
+		current := listMarkdownNames(dir)
+		names := make([]string, 0, len(current))
+		for name := range current {
+			if !seen[name] {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			rt.handleReadWatcherEvent(filepath.Join(dir, name), fswatcher.Create)
+		}
+		rt.polledDirs[dir] = current
+	}
+}
+
+func listMarkdownNames(dir string) map[string]bool {
+	names := make(map[string]bool)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return names
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".md") {
+			names[entry.Name()] = true
+		}
+	}
+	return names
+}
@@ -0,0 +1,106 @@
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/discovery"
+	"github.com/i9wa4/tmux-a2a-postman/internal/idle"
+	"github.com/i9wa4/tmux-a2a-postman/internal/tui"
+)
+
+func TestWatchNodeDirs_PollsDirsBeyondMaxWatchedDirs(t *testing.T) {
+	contextDir := t.TempDir()
+	first := discovery.NodeInfo{SessionDir: filepath.Join(contextDir, "main")}
+	second := discovery.NodeInfo{SessionDir: filepath.Join(contextDir, "review")}
+
+	watcher := &recordingFilesystemWatcher{}
+	events := make(chan tui.DaemonEvent, 4)
+	rt := &daemonRuntime{
+		cfg:         &config.Config{MaxWatchedDirs: 4},
+		watcher:     watcher,
+		watchedDirs: map[string]bool{},
+		polledDirs:  map[string]map[string]bool{},
+		events:      events,
+	}
+
+	rt.watchNodeDirs(map[string]discovery.NodeInfo{
+		"main:worker":   first,
+		"review:critic": second,
+	})
+
+	if len(watcher.added) != 4 {
+		t.Fatalf("watcher.added = %v, want 4 dirs", watcher.added)
+	}
+	for _, dir := range nodeWatchDirs(first) {
+		if !rt.watchedDirs[dir] {
+			t.Fatalf("%s not watched; watchedDirs=%v", dir, rt.watchedDirs)
+		}
+	}
+	for _, dir := range nodeWatchDirs(second) {
+		if rt.watchedDirs[dir] {
+			t.Fatalf("%s watched beyond the cap", dir)
+		}
+		if _, ok := rt.polledDirs[dir]; !ok {
+			t.Fatalf("%s not polled; polledDirs=%v", dir, rt.polledDirs)
+		}
+	}
+
+	event := <-events
+	if event.Type != "watch_limit_reached" {
+		t.Fatalf("event.Type = %q, want watch_limit_reached", event.Type)
+	}
+	select {
+	case extra := <-events:
+		t.Fatalf("want a single watch_limit_reached event, got extra %#v", extra)
+	default:
+	}
+}
+
+func TestPollOverflowDirs_ReportsOnlyNewReadArchives(t *testing.T) {
+	sessionDir := filepath.Join(t.TempDir(), "main")
+	readDir := filepath.Join(sessionDir, "read")
+	if err := os.MkdirAll(readDir, 0o700); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	oldFile := "20260101-000000-s0000-from-orchestrator-to-worker.md"
+	if err := os.WriteFile(filepath.Join(readDir, oldFile), []byte("old"), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	events := make(chan tui.DaemonEvent, 4)
+	rt := &daemonRuntime{
+		cfg:         &config.Config{MaxWatchedDirs: 1},
+		watcher:     &recordingFilesystemWatcher{},
+		watchedDirs: map[string]bool{filepath.Join(sessionDir, "post"): true},
+		polledDirs:  map[string]map[string]bool{},
+		idleTracker: idle.NewIdleTracker(),
+		events:      events,
+	}
+	rt.watchDir(readDir)
+	<-events // watch_limit_reached
+
+	rt.pollOverflowDirs()
+	select {
+	case event := <-events:
+		t.Fatalf("pre-existing archive reported: %#v", event)
+	default:
+	}
+
+	newFile := "20260101-000100-s0001-from-orchestrator-to-worker.md"
+	if err := os.WriteFile(filepath.Join(readDir, newFile), []byte("new"), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	rt.pollOverflowDirs()
+	rt.waitForMailboxProjectionSyncs()
+
+	event := <-events
+	if event.Type != "node_alive" || event.Details["node"] != "main:worker" {
+		t.Fatalf("event = %#v, want node_alive for main:worker", event)
+	}
+	if !rt.polledDirs[readDir][newFile] {
+		t.Fatalf("polledDirs[%s] = %v, want %s recorded", readDir, rt.polledDirs[readDir], newFile)
+	}
+}
@@ -549,3 +549,50 @@ func canonicalTestPath(t *testing.T, path string) string {
 	}
 	return filepath.Clean(path)
 }
+
+func TestHandleWatcherEvent_NonMarkdownPostPolicy(t *testing.T) {
+	tests := []struct {
+		policy         string
+		wantEvent      bool
+		wantDeadLetter bool
+	}{
+		{policy: "", wantEvent: false, wantDeadLetter: false},
+		{policy: config.NonMDPostPolicyWarn, wantEvent: true, wantDeadLetter: false},
+		{policy: config.NonMDPostPolicyDeadLetter, wantEvent: true, wantDeadLetter: true},
+	}
+	for _, tt := range tests {
+		t.Run("policy="+tt.policy, func(t *testing.T) {
+			sessionDir := filepath.Join(t.TempDir(), "main")
+			if err := config.CreateSessionDirs(sessionDir); err != nil {
+				t.Fatalf("config.CreateSessionDirs failed: %v", err)
+			}
+			txtPath := filepath.Join(sessionDir, "post", "20260201-030000-from-orchestrator-to-worker.txt")
+			if err := os.WriteFile(txtPath, []byte("hello\n"), 0o644); err != nil {
+				t.Fatalf("WriteFile failed: %v", err)
+			}
+			events := make(chan tui.DaemonEvent, 4)
+			rt := &daemonRuntime{
+				sessionDir: sessionDir,
+				cfg:        &config.Config{NonMDPostPolicy: tt.policy},
+				events:     events,
+			}
+
+			rt.handleWatcherEvent(fswatcher.Event{Name: txtPath, Op: fswatcher.Create})
+
+			var got []tui.DaemonEvent
+			for len(events) > 0 {
+				got = append(got, <-events)
+			}
+			if gotEvent := len(got) == 1 && got[0].Type == "non_md_in_post"; gotEvent != tt.wantEvent {
+				t.Fatalf("events = %+v, want non_md_in_post = %v", got, tt.wantEvent)
+			}
+			deadPath := filepath.Join(sessionDir, "dead-letter", "20260201-030000-from-orchestrator-to-worker-dl-non-md.txt")
+			if _, err := os.Stat(deadPath); (err == nil) != tt.wantDeadLetter {
+				t.Fatalf("dead-lettered = %v, want %v", err == nil, tt.wantDeadLetter)
+			}
+			if _, err := os.Stat(txtPath); (err == nil) == tt.wantDeadLetter {
+				t.Fatalf("still in post/ = %v, want %v", err == nil, !tt.wantDeadLetter)
+			}
+		})
+	}
+}
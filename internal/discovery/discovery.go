@@ -99,25 +99,50 @@ func reduceCollisions(nodeKeyOrder []string, candidates map[string][]paneCandida
 	return nodes, collisions
 }
 
+// Settings is the part of the postman config discovery depends on;
+// *config.Config implements it. A nil Settings names nodes by pane title
+// and keeps every session's directory at baseDir/contextID/sessionName.
+type Settings interface {
+	SessionDir(baseDir, contextID, sessionName string) string
+	// PaneNameFormat is the tmux format expanding to a pane's node name.
+	PaneNameFormat() string
+}
+
+func paneNameFormatFor(settings Settings) string {
+	if settings != nil {
+		return settings.PaneNameFormat()
+	}
+	return "#{pane_title}"
+}
+
+func sessionDirFor(settings Settings, baseDir, contextID, sessionName string) string {
+	if settings != nil {
+		return settings.SessionDir(baseDir, contextID, sessionName)
+	}
+	return filepath.Join(baseDir, contextID, sessionName)
+}
+
 // DiscoverNodesWithCollisions scans tmux panes and returns nodes, collision reports, and any error.
 // For panes sharing the same sessionName:paneTitle key, the winner is the pane with the
 // highest numeric pane ID (e.g., %31 beats %26). N-1 CollisionReports are emitted per collision group.
 // Server-wide discovery: scans all sessions (-a flag).
-// SessionDir is calculated as baseDir/contextID/sessionName.
+// SessionDir is calculated as baseDir/contextID/sessionName unless
+// settings moves the session elsewhere.
 // selfSession is the daemon's own tmux session name. Unclaimed panes in foreign sessions
 // are excluded (F3: unclaimed-pane guard).
-func DiscoverNodesWithCollisions(baseDir, contextID, selfSession string) (map[string]NodeInfo, []CollisionReport, error) {
-	return discoverNodesWithCollisionsUsing(tmuxrunner.CombinedOutput, baseDir, contextID, selfSession)
+func DiscoverNodesWithCollisions(baseDir, contextID, selfSession string, settings Settings) (map[string]NodeInfo, []CollisionReport, error) {
+	return discoverNodesWithCollisionsUsing(tmuxrunner.CombinedOutput, baseDir, contextID, selfSession, settings)
 }
 
 // discoverNodesWithCollisionsUsing is the testable implementation of DiscoverNodesWithCollisions.
 // runner is called for both list-panes and show-options invocations, dispatched by args[0].
-func discoverNodesWithCollisionsUsing(runner tmuxrunner.Runner, baseDir, contextID, selfSession string) (map[string]NodeInfo, []CollisionReport, error) {
-	// Format: tab-delimited pane_id, @a2a_context_id, session_name, pane_title.
+func discoverNodesWithCollisionsUsing(runner tmuxrunner.Runner, baseDir, contextID, selfSession string, settings Settings) (map[string]NodeInfo, []CollisionReport, error) {
+	// Format: tab-delimited pane_id, @a2a_context_id, session_name, node name
+	// (pane_title, or @a2a_node under node_identity = "user_option").
 	// Tab delimiter avoids ambiguity with pane titles that contain spaces.
 	// #{@a2a_context_id} is empty when unset (unclaimed); non-empty means claimed.
 	// Batching the context_id here eliminates O(N) sequential show-options execs.
-	out, err := runner("list-panes", "-a", "-F", "#{pane_id}\t#{@a2a_context_id}\t#{session_name}\t#{pane_title}")
+	out, err := runner("list-panes", "-a", "-F", "#{pane_id}\t#{@a2a_context_id}\t#{session_name}\t"+paneNameFormatFor(settings))
 	if err != nil {
 		return nil, nil, fmt.Errorf("tmux list-panes: %w: %s", err, out)
 	}
@@ -151,8 +176,8 @@ func discoverNodesWithCollisionsUsing(runner tmuxrunner.Runner, baseDir, context
 			}
 		}
 
-		// Calculate SessionDir as baseDir/contextID/sessionName
-		sessionDir := filepath.Join(baseDir, contextID, sessionName)
+		// Calculate SessionDir as baseDir/contextID/sessionName (or per settings)
+		sessionDir := sessionDirFor(settings, baseDir, contextID, sessionName)
 		// Use session-prefixed node name to avoid collisions (Issue #33)
 		// Format: session_name:node_name
 		nodeKey := sessionName + ":" + paneTitle
@@ -178,7 +203,7 @@ func discoverNodesWithCollisionsUsing(runner tmuxrunner.Runner, baseDir, context
 	for _, nodeKey := range nodeKeyOrder {
 		var kept []paneCandidate
 		for _, c := range candidates[nodeKey] {
-			inboxDir := filepath.Join(baseDir, contextID, c.sessionName, "inbox")
+			inboxDir := filepath.Join(c.sessionDir, "inbox")
 			if _, err := os.Stat(inboxDir); err == nil {
 				// F3 fast-path: own-session panes are always included without
 				// an ownership check (selfSession fast-path).
@@ -208,12 +233,62 @@ func discoverNodesWithCollisionsUsing(runner tmuxrunner.Runner, baseDir, context
 // DiscoverNodes scans tmux panes and returns a map of node name -> NodeInfo.
 // Only panes that have a non-empty pane title are included.
 // Server-wide discovery: scans all sessions (-a flag).
-// SessionDir is calculated as baseDir/contextID/sessionName.
-func DiscoverNodes(baseDir, contextID, selfSession string) (map[string]NodeInfo, error) {
-	nodes, _, err := DiscoverNodesWithCollisions(baseDir, contextID, selfSession)
+// SessionDir is calculated as baseDir/contextID/sessionName unless
+// settings moves the session elsewhere.
+func DiscoverNodes(baseDir, contextID, selfSession string, settings Settings) (map[string]NodeInfo, error) {
+	nodes, _, err := DiscoverNodesWithCollisions(baseDir, contextID, selfSession, settings)
 	return nodes, err
 }
 
+// DiscoverNodesAcrossContexts discovers nodes for every context dir under
+// baseDir, keyed by context ID, for a supervisory view of several meshes.
+// It is read-only: tmux is listed once, nothing is claimed, and no session
+// gets the own-session fast path, so a pane counts for a context only when
+// it is unclaimed or claimed by that context. The daemon keeps using
+// DiscoverNodes for its single context.
+func DiscoverNodesAcrossContexts(baseDir string, settings Settings) (map[string]map[string]NodeInfo, error) {
+	return discoverNodesAcrossContextsUsing(tmuxrunner.CombinedOutput, baseDir, settings)
+}
+
+func discoverNodesAcrossContextsUsing(runner tmuxrunner.Runner, baseDir string, settings Settings) (map[string]map[string]NodeInfo, error) {
+	entries, err := os.ReadDir(baseDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]map[string]NodeInfo{}, nil
+		}
+		return nil, fmt.Errorf("reading base dir: %w", err)
+	}
+
+	// One list-panes for all contexts: each per-context pass filters the
+	// same snapshot.
+	var listed []byte
+	var listErr error
+	listedOnce := false
+	cached := func(args ...string) ([]byte, error) {
+		if len(args) == 0 || args[0] != "list-panes" {
+			return runner(args...)
+		}
+		if !listedOnce {
+			listed, listErr = runner(args...)
+			listedOnce = true
+		}
+		return listed, listErr
+	}
+
+	byContext := make(map[string]map[string]NodeInfo)
+	for _, entry := range entries {
+		if !entry.IsDir() || entry.Name() == "lock" {
+			continue
+		}
+		nodes, _, err := discoverNodesWithCollisionsUsing(cached, baseDir, entry.Name(), "", settings)
+		if err != nil {
+			return nil, err
+		}
+		byContext[entry.Name()] = nodes
+	}
+	return byContext, nil
+}
+
 // ResolveNodeName resolves a simple node name to a session-prefixed node name.
 // Resolution priority:
 // 1. If nodeName already contains ":", use as-is (already prefixed)
@@ -5,9 +5,11 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
 	"github.com/i9wa4/tmux-a2a-postman/internal/tmuxrunner"
 )
 
@@ -26,7 +28,7 @@ func TestDiscoverNodes_WithChildProcess(t *testing.T) {
 	tmuxCmd(t, "new-session", "-d", "-s", sessionName, "exec sleep 120")
 	tmuxCmd(t, "select-pane", "-t", sessionName+":0.0", "-T", nodeName)
 
-	nodes, err := DiscoverNodes(baseDir, contextID, sessionName)
+	nodes, err := DiscoverNodes(baseDir, contextID, sessionName, nil)
 	if err != nil {
 		t.Fatalf("DiscoverNodes: %v", err)
 	}
@@ -65,7 +67,7 @@ func TestDiscoverNodes_WithPaneTitle(t *testing.T) {
 	tmuxCmd(t, "new-session", "-d", "-s", sessionName, "exec sleep 120")
 	tmuxCmd(t, "select-pane", "-t", sessionName+":0.0", "-T", nodeName)
 
-	nodes, collisions, err := DiscoverNodesWithCollisions(baseDir, contextID, sessionName)
+	nodes, collisions, err := DiscoverNodesWithCollisions(baseDir, contextID, sessionName, nil)
 	if err != nil {
 		t.Fatalf("DiscoverNodesWithCollisions: %v", err)
 	}
@@ -360,7 +362,7 @@ func TestFilterLoop_ForeignMatchingContext(t *testing.T) {
 	mustMkdirAll(t, filepath.Join(baseDir, contextID, sessionName, "inbox"))
 
 	runner := mockTmuxRunner(tabLine("%10", contextID, sessionName, "worker"))
-	nodes, _, err := discoverNodesWithCollisionsUsing(runner, baseDir, contextID, selfSession)
+	nodes, _, err := discoverNodesWithCollisionsUsing(runner, baseDir, contextID, selfSession, nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -379,7 +381,7 @@ func TestFilterLoop_ForeignDifferentContext(t *testing.T) {
 	mustMkdirAll(t, filepath.Join(baseDir, contextID, sessionName, "inbox"))
 
 	runner := mockTmuxRunner(tabLine("%11", "ctx-other", sessionName, "orchestrator"))
-	nodes, _, err := discoverNodesWithCollisionsUsing(runner, baseDir, contextID, selfSession)
+	nodes, _, err := discoverNodesWithCollisionsUsing(runner, baseDir, contextID, selfSession, nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -399,7 +401,7 @@ func TestFilterLoop_UnclaimedPane(t *testing.T) {
 
 	// Empty claimedContext field → unclaimed pane, must be included.
 	runner := mockTmuxRunner(tabLine("%12", "", sessionName, "critic"))
-	nodes, _, err := discoverNodesWithCollisionsUsing(runner, baseDir, contextID, selfSession)
+	nodes, _, err := discoverNodesWithCollisionsUsing(runner, baseDir, contextID, selfSession, nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -418,7 +420,7 @@ func TestFilterLoop_OwnSessionFastPath(t *testing.T) {
 
 	// Own-session pane with empty claimedContext — fast-path bypasses the check.
 	runner := mockTmuxRunner(tabLine("%13", "", selfSession, "boss"))
-	nodes, _, err := discoverNodesWithCollisionsUsing(runner, baseDir, contextID, selfSession)
+	nodes, _, err := discoverNodesWithCollisionsUsing(runner, baseDir, contextID, selfSession, nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -436,6 +438,12 @@ func nodeKeys(nodes map[string]NodeInfo) []string {
 	return keys
 }
 
+func sortedNodeKeys(nodes map[string]NodeInfo) []string {
+	keys := nodeKeys(nodes)
+	slices.Sort(keys)
+	return keys
+}
+
 // TestReduceCollisions_OrderPreserved verifies that CollisionReports across different
 // NodeKeys appear in tmux list-panes traversal order (nodeKeyOrder), not map iteration
 // order. With two colliding NodeKeys, the first nodeKey's report must appear first.
@@ -467,3 +475,124 @@ func TestReduceCollisions_OrderPreserved(t *testing.T) {
 		t.Errorf("collisions[1].NodeKey: got %s, want session:beta (traversal order)", collisions[1].NodeKey)
 	}
 }
+
+// TestDiscoverNodes_UserOptionIdentity verifies that under
+// node_identity = "user_option" the @a2a_node pane option names the node and
+// panes without it fall back to their title.
+func TestDiscoverNodes_UserOptionIdentity(t *testing.T) {
+	baseDir := t.TempDir()
+	contextID := "ctx-a2a"
+	sessionName := "main"
+	mustMkdirAll(t, filepath.Join(baseDir, contextID, sessionName, "inbox"))
+
+	type pane struct{ id, title, nodeOption string }
+	panes := []pane{
+		{id: "%1", title: "claude: refactoring parser", nodeOption: "worker"},
+		{id: "%2", title: "reviewer"},
+	}
+	// Fake tmux: expand the node-name field of the list-panes format the way
+	// tmux would, including the #{?@a2a_node,...} conditional.
+	runner := func(args ...string) ([]byte, error) {
+		if len(args) != 4 || args[0] != "list-panes" {
+			return nil, fmt.Errorf("unexpected tmux call %q", args)
+		}
+		nameFormat := strings.SplitN(args[3], "\t", 4)[3]
+		var lines []string
+		for _, p := range panes {
+			name := p.title
+			if strings.Contains(nameFormat, "@a2a_node") && p.nodeOption != "" {
+				name = p.nodeOption
+			}
+			lines = append(lines, tabLine(p.id, "", sessionName, name))
+		}
+		return []byte(strings.Join(lines, "\n")), nil
+	}
+
+	cfg := &config.Config{NodeIdentity: config.NodeIdentityUserOption}
+	nodes, _, err := discoverNodesWithCollisionsUsing(runner, baseDir, contextID, sessionName, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := nodes[sessionName+":worker"].PaneID; got != "%1" {
+		t.Fatalf("main:worker pane = %q, want %%1 from @a2a_node; keys: %v", got, nodeKeys(nodes))
+	}
+	if got := nodes[sessionName+":reviewer"].PaneID; got != "%2" {
+		t.Fatalf("main:reviewer pane = %q, want %%2 from title fallback; keys: %v", got, nodeKeys(nodes))
+	}
+
+	cfg.NodeIdentity = config.NodeIdentityTitle
+	nodes, _, err = discoverNodesWithCollisionsUsing(runner, baseDir, contextID, sessionName, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := nodes[sessionName+":worker"]; ok {
+		t.Fatalf("title identity still used @a2a_node; keys: %v", nodeKeys(nodes))
+	}
+}
+
+func TestDiscoverNodes_SessionBaseDirOverride(t *testing.T) {
+	baseDir := t.TempDir()
+	fastDir := t.TempDir()
+	contextID := "ctx-a2a"
+	cfg := &config.Config{Sessions: map[string]config.SessionConfig{"fast": {BaseDir: fastDir}}}
+	mustMkdirAll(t, filepath.Join(baseDir, contextID, "main", "inbox"))
+	mustMkdirAll(t, filepath.Join(fastDir, contextID, "fast", "inbox"))
+
+	runner := mockTmuxRunner(strings.Join([]string{
+		tabLine("%1", "", "main", "orchestrator"),
+		tabLine("%2", contextID, "fast", "worker"),
+	}, "\n"))
+	nodes, _, err := discoverNodesWithCollisionsUsing(runner, baseDir, contextID, "main", cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := nodes["fast:worker"].SessionDir, filepath.Join(fastDir, contextID, "fast"); got != want {
+		t.Errorf("fast:worker SessionDir = %q, want %q; keys: %v", got, want, nodeKeys(nodes))
+	}
+	if got, want := nodes["main:orchestrator"].SessionDir, filepath.Join(baseDir, contextID, "main"); got != want {
+		t.Errorf("main:orchestrator SessionDir = %q, want %q", got, want)
+	}
+}
+
+func TestDiscoverNodesAcrossContexts_EnumeratesEveryContext(t *testing.T) {
+	baseDir := t.TempDir()
+	mustMkdirAll(t, filepath.Join(baseDir, "ctx-a", "alpha", "inbox"))
+	mustMkdirAll(t, filepath.Join(baseDir, "ctx-b", "beta", "inbox"))
+	mustMkdirAll(t, filepath.Join(baseDir, "lock"))
+
+	listPanesCalls := 0
+	runner := func(args ...string) ([]byte, error) {
+		if len(args) == 0 || args[0] != "list-panes" {
+			return nil, fmt.Errorf("unexpected tmux call %v", args)
+		}
+		listPanesCalls++
+		return []byte(strings.Join([]string{
+			tabLine("%1", "ctx-a", "alpha", "orchestrator"),
+			tabLine("%2", "", "alpha", "worker"),
+			tabLine("%3", "ctx-b", "beta", "critic"),
+			// Claimed by ctx-b but in a session only ctx-a has dirs for.
+			tabLine("%4", "ctx-b", "alpha", "stray"),
+			tabLine("%5", "", "gamma", "loner"),
+		}, "\n")), nil
+	}
+
+	byContext, err := discoverNodesAcrossContextsUsing(runner, baseDir, nil)
+	if err != nil {
+		t.Fatalf("discoverNodesAcrossContextsUsing: %v", err)
+	}
+	if listPanesCalls != 1 {
+		t.Errorf("list-panes calls = %d, want 1", listPanesCalls)
+	}
+	if len(byContext) != 2 {
+		t.Fatalf("contexts = %v, want ctx-a and ctx-b only", byContext)
+	}
+	if got, want := sortedNodeKeys(byContext["ctx-a"]), []string{"alpha:orchestrator", "alpha:worker"}; !slices.Equal(got, want) {
+		t.Errorf("ctx-a nodes = %v, want %v", got, want)
+	}
+	if got, want := sortedNodeKeys(byContext["ctx-b"]), []string{"beta:critic"}; !slices.Equal(got, want) {
+		t.Errorf("ctx-b nodes = %v, want %v", got, want)
+	}
+	if got, want := byContext["ctx-b"]["beta:critic"].SessionDir, filepath.Join(baseDir, "ctx-b", "beta"); got != want {
+		t.Errorf("beta:critic SessionDir = %q, want %q", got, want)
+	}
+}
@@ -2,7 +2,9 @@ package envelope
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -90,8 +92,8 @@ func buildEnvelope(
 
 	// Role template resolution: Nodes → CommonTemplate prepend.
 	recipientTemplate := ""
-	if nodeConfig, ok := cfg.Nodes[recipientSimple]; ok {
-		recipientTemplate = nodeConfig.Template
+	if _, ok := cfg.Nodes[recipientSimple]; ok {
+		recipientTemplate = cfg.GetNodeConfig(recipientSimple).Template
 	}
 	// Issue #49: Prepend common_template if present.
 	if cfg.CommonTemplate != "" {
@@ -162,6 +164,11 @@ func buildEnvelope(
 	}
 	sentTimestamp := ""
 	base := filepath.Base(filename)
+	// Delivery keeps the post filename, so this is where the message lands.
+	messagePath := ""
+	if filename != "" {
+		messagePath = filepath.Join(inboxPath, base)
+	}
 	if parts := strings.SplitN(base, "-", 3); len(parts) >= 2 && len(parts[0]) == 8 && len(parts[1]) == 6 && isAllDigits(parts[0]) && isAllDigits(parts[1]) {
 		sentTimestamp = parts[0] + "-" + parts[1]
 	}
@@ -175,6 +182,7 @@ func buildEnvelope(
 		"sent_timestamp":   sentTimestamp,
 		"filename":         base,
 		"inbox_path":       inboxPath,
+		"message_path":     messagePath,
 		"talks_to_line":    talksToLine,
 		"contacts_section": contactsSection,
 		"template":         recipientTemplate,
@@ -183,9 +191,29 @@ func buildEnvelope(
 		"active_nodes":     strings.Join(activeNodes, ", "),
 		"session_name":     sourceSessionName,
 	}
+	// unread_count reads the inbox, so only pay for it when the template asks.
+	if strings.Contains(tmpl, "{unread_count}") {
+		vars["unread_count"] = strconv.Itoa(countUnread(inboxPath))
+	}
 
 	timeout := time.Duration(cfg.TmuxTimeout * float64(time.Second))
-	return template.ExpandTemplate(tmpl, vars, timeout, allowShell)
+	return template.ExpandTemplate(tmpl, vars, timeout, allowShell, cfg.TemplateOptions())
+}
+
+// countUnread returns the number of message files waiting in inboxDir.
+// A missing inbox counts as empty.
+func countUnread(inboxDir string) int {
+	entries, err := os.ReadDir(inboxDir)
+	if err != nil {
+		return 0
+	}
+	count := 0
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".md") {
+			count++
+		}
+	}
+	return count
 }
 
 // ContactSection renders adjacent node names with concise role summaries.
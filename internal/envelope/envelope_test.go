@@ -1,6 +1,8 @@
 package envelope
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -176,6 +178,43 @@ func TestBuildEnvelope_InboxPath(t *testing.T) {
 	}
 }
 
+func TestBuildEnvelope_MessagePath(t *testing.T) {
+	cfg := &config.Config{TmuxTimeout: 5.0}
+	nodes := map[string]discovery.NodeInfo{
+		"main:worker": {SessionName: "main", SessionDir: "/my/session"},
+	}
+
+	result := BuildEnvelope(cfg, "{message_path}", "worker", "postman", "ctx", "/my/session/post/file.md", nil, map[string][]string{}, nodes, "main", map[string]bool{})
+	if want := filepath.Join("/my/session", "inbox", "worker", "file.md"); result != want {
+		t.Errorf("message_path = %q, want %q", result, want)
+	}
+}
+
+func TestBuildEnvelope_UnreadCountReflectsInbox(t *testing.T) {
+	cfg := &config.Config{TmuxTimeout: 5.0}
+	sessionDir := t.TempDir()
+	inboxDir := filepath.Join(sessionDir, "inbox", "worker")
+	if err := os.MkdirAll(filepath.Join(inboxDir, "nested"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"a.md", "b.md", "c.md", "note.txt"} {
+		if err := os.WriteFile(filepath.Join(inboxDir, name), []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	filename := filepath.Join(sessionDir, "post", "file.md")
+
+	result := BuildEnvelope(cfg, "{node}: you have {unread_count} unread messages", "worker", "postman", "ctx", filename, nil, map[string][]string{}, map[string]discovery.NodeInfo{}, "", map[string]bool{})
+	if want := "worker: you have 3 unread messages"; result != want {
+		t.Fatalf("BuildEnvelope = %q, want %q", result, want)
+	}
+
+	result = BuildEnvelope(cfg, "{unread_count}", "critic", "postman", "ctx", filename, nil, map[string][]string{}, map[string]discovery.NodeInfo{}, "", map[string]bool{})
+	if result != "0" {
+		t.Fatalf("BuildEnvelope for missing inbox = %q, want 0", result)
+	}
+}
+
 func TestBuildEnvelope_SentTimestamp(t *testing.T) {
 	cfg := &config.Config{TmuxTimeout: 5.0}
 	adjacency := map[string][]string{}
@@ -14,6 +14,7 @@ type Metadata struct {
 	ReplyPolicy              string
 	ReplyTo                  string
 	MessageType              string
+	Method                   string
 	Timestamp                string
 	ThreadID                 string
 	TaskID                   string
@@ -33,6 +34,7 @@ type Metadata struct {
 	BlockedScope             string
 	BlockedScopeID           string
 	BlockedReason            string
+	Confirm                  string
 	Body                     string
 }
 
@@ -175,6 +177,8 @@ func DecodeEnvelopeMetadata(frontmatter, body string) (Metadata, error) {
 				metadata.ReplyTo = value
 			case "messageType", "message_type":
 				metadata.MessageType = value
+			case "method":
+				metadata.Method = value
 			case "timestamp":
 				metadata.Timestamp = value
 			case "thread_id":
@@ -213,6 +217,8 @@ func DecodeEnvelopeMetadata(frontmatter, body string) (Metadata, error) {
 				metadata.BlockedScopeID = value
 			case "blocked_reason":
 				metadata.BlockedReason = value
+			case "confirm":
+				metadata.Confirm = value
 			}
 		}
 	}
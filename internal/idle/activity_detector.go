@@ -0,0 +1,126 @@
+package idle
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/discovery"
+	"github.com/i9wa4/tmux-a2a-postman/internal/paneutil"
+)
+
+// heartbeatDirName is the session subdirectory HeartbeatDetector watches.
+const heartbeatDirName = "heartbeat"
+
+// activitySignalTimeout bounds one activity_signal_command run.
+const activitySignalTimeout = 5 * time.Second
+
+// ActivityDetector produces one observation of a node pane per capture tick.
+// The tracker only compares observations: a different value from the last
+// tick is a change, and two changes within activity_window_seconds mark the
+// node active. An error means the pane could not be measured this tick and
+// leaves its state as it was.
+type ActivityDetector interface {
+	Observe(nodeKey string, nodeInfo discovery.NodeInfo) (string, error)
+}
+
+// CaptureDetector observes the visible pane contents. It is the default, and
+// the only detector whose observations feed compaction detection and
+// pane_capture_diff_log.
+type CaptureDetector struct{}
+
+// Observe captures the node's pane.
+func (CaptureDetector) Observe(_ string, nodeInfo discovery.NodeInfo) (string, error) {
+	return paneutil.CaptureContent(nodeInfo.PaneID)
+}
+
+// HeartbeatDetector observes the mtime of heartbeat/<node> in the node's
+// session dir, so an agent that touches the file while it works reads as
+// active even when its pane output is steady.
+type HeartbeatDetector struct{}
+
+// Observe stats the node's heartbeat file.
+func (HeartbeatDetector) Observe(nodeKey string, nodeInfo discovery.NodeInfo) (string, error) {
+	_, nodeName, found := strings.Cut(nodeKey, ":")
+	if !found {
+		nodeName = nodeKey
+	}
+	info, err := os.Stat(filepath.Join(nodeInfo.SessionDir, heartbeatDirName, nodeName))
+	if err != nil {
+		return "", err
+	}
+	return info.ModTime().UTC().Format(time.RFC3339Nano), nil
+}
+
+// SignalDetector observes the stdout of an external shell command, run once
+// per node pane with POSTMAN_NODE and POSTMAN_PANE_ID set.
+type SignalDetector struct {
+	Command string
+}
+
+// Observe runs the signal command for the node.
+func (d SignalDetector) Observe(nodeKey string, nodeInfo discovery.NodeInfo) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), activitySignalTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", d.Command)
+	cmd.Env = append(os.Environ(), "POSTMAN_NODE="+nodeKey, "POSTMAN_PANE_ID="+nodeInfo.PaneID)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+	cmd.WaitDelay = 500 * time.Millisecond
+
+	out, err := cmd.Output()
+	if ctx.Err() == context.DeadlineExceeded {
+		return "", fmt.Errorf("activity_signal_command timed out after %s", activitySignalTimeout)
+	}
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("activity_signal_command: %w: %s", err, msg)
+		}
+		return "", fmt.Errorf("activity_signal_command: %w", err)
+	}
+	return string(out), nil
+}
+
+// NewActivityDetector returns the detector selected by activity_detector.
+// Unknown values fall back to CaptureDetector; validation reports them.
+func NewActivityDetector(cfg *config.Config) ActivityDetector {
+	if cfg == nil {
+		return CaptureDetector{}
+	}
+	switch cfg.ActivityDetector {
+	case config.ActivityDetectorHeartbeat:
+		return HeartbeatDetector{}
+	case config.ActivityDetectorSignal:
+		return SignalDetector{Command: cfg.ActivitySignalCommand}
+	default:
+		return CaptureDetector{}
+	}
+}
+
+// SetActivityDetector replaces the detector chosen from config. nil restores
+// the config selection.
+func (t *IdleTracker) SetActivityDetector(detector ActivityDetector) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.detector = detector
+}
+
+// activityDetector returns the detector for this tick. Caller must hold t.mu.
+func (t *IdleTracker) activityDetector(cfg *config.Config) ActivityDetector {
+	if t.detector != nil {
+		return t.detector
+	}
+	return NewActivityDetector(cfg)
+}
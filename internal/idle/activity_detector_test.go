@@ -0,0 +1,142 @@
+package idle
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/discovery"
+	"github.com/i9wa4/tmux-a2a-postman/internal/tmuxtest"
+)
+
+// fakeDetector returns the scripted observation for each node; a node
+// without one is unmeasurable.
+type fakeDetector struct {
+	observations map[string]string
+}
+
+func (d *fakeDetector) Observe(nodeKey string, _ discovery.NodeInfo) (string, error) {
+	observation, ok := d.observations[nodeKey]
+	if !ok {
+		return "", errors.New("no observation")
+	}
+	return observation, nil
+}
+
+func TestCheckPaneCapture_FakeDetectorDrivesActivityTransitions(t *testing.T) {
+	fake := tmuxtest.Install(t, tmuxtest.WithPane(tmuxtest.Pane{ID: "%11", SessionName: "review", Title: "worker", CurrentCommand: "codex"}))
+
+	now := time.Date(2026, time.October, 16, 9, 0, 0, 0, time.UTC)
+	tracker := newIdleTrackerWithClock(func() time.Time { return now })
+	detector := &fakeDetector{observations: map[string]string{}}
+	tracker.SetActivityDetector(detector)
+	cfg := &config.Config{
+		NodeActiveSeconds:     60,
+		NodeStaleSeconds:      600,
+		ActivityWindowSeconds: 120,
+	}
+	nodes := map[string]discovery.NodeInfo{
+		"review:worker": {PaneID: "%11", SessionName: "review"},
+	}
+
+	tick := func(observation string, advance time.Duration) {
+		t.Helper()
+		now = now.Add(advance)
+		if observation == "" {
+			delete(detector.observations, "review:worker")
+		} else {
+			detector.observations["review:worker"] = observation
+		}
+		tracker.checkPaneCapture(cfg, nodes)
+	}
+
+	tick("", 0)
+	if got := tracker.GetPaneActivityStatus(cfg)["%11"]; got != "" {
+		t.Fatalf("status before any observation = %q, want none", got)
+	}
+
+	tick("beat-1", 0)
+	tick("beat-2", 10*time.Second)
+	tick("beat-3", 10*time.Second)
+	if got := tracker.GetPaneActivityStatus(cfg)["%11"]; got != "active" {
+		t.Fatalf("status after changing observations = %q, want active", got)
+	}
+	if got := tracker.GetNodeStates()["review:worker"].LastScreenChange; !got.Equal(now) {
+		t.Fatalf("LastScreenChange = %v, want %v", got, now)
+	}
+
+	tick("beat-3", 90*time.Second)
+	if got := tracker.GetPaneActivityStatus(cfg)["%11"]; got != "idle" {
+		t.Fatalf("status after unchanged observation = %q, want idle", got)
+	}
+
+	tick("", 30*time.Second)
+	if got := tracker.GetPaneActivityStatus(cfg)["%11"]; got != "idle" {
+		t.Fatalf("status after failed observation = %q, want idle kept", got)
+	}
+
+	for _, invocation := range fake.Invocations() {
+		if strings.HasPrefix(invocation, "capture-pane") {
+			t.Fatalf("pane captured with a non-capture detector: %v", invocation)
+		}
+	}
+}
+
+func TestHeartbeatDetector_ObservesHeartbeatFileMtime(t *testing.T) {
+	sessionDir := t.TempDir()
+	nodeInfo := discovery.NodeInfo{PaneID: "%11", SessionName: "review", SessionDir: sessionDir}
+	detector := NewActivityDetector(&config.Config{ActivityDetector: config.ActivityDetectorHeartbeat})
+
+	if _, err := detector.Observe("review:worker", nodeInfo); err == nil {
+		t.Fatal("Observe() without heartbeat file: want error")
+	}
+
+	heartbeat := filepath.Join(sessionDir, heartbeatDirName, "worker")
+	if err := os.MkdirAll(filepath.Dir(heartbeat), 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	if err := os.WriteFile(heartbeat, nil, 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	first := time.Date(2026, time.October, 16, 9, 0, 0, 0, time.UTC)
+	if err := os.Chtimes(heartbeat, first, first); err != nil {
+		t.Fatalf("Chtimes: %v", err)
+	}
+	before, err := detector.Observe("review:worker", nodeInfo)
+	if err != nil {
+		t.Fatalf("Observe(): %v", err)
+	}
+	if err := os.Chtimes(heartbeat, first.Add(time.Second), first.Add(time.Second)); err != nil {
+		t.Fatalf("Chtimes: %v", err)
+	}
+	after, err := detector.Observe("review:worker", nodeInfo)
+	if err != nil {
+		t.Fatalf("Observe(): %v", err)
+	}
+	if before == after {
+		t.Fatalf("touching the heartbeat file did not change the observation (%q)", before)
+	}
+}
+
+func TestSignalDetector_ObservesCommandStdout(t *testing.T) {
+	detector := NewActivityDetector(&config.Config{
+		ActivityDetector:      config.ActivityDetectorSignal,
+		ActivitySignalCommand: `printf '%s %s' "$POSTMAN_NODE" "$POSTMAN_PANE_ID"`,
+	})
+	got, err := detector.Observe("review:worker", discovery.NodeInfo{PaneID: "%11"})
+	if err != nil {
+		t.Fatalf("Observe(): %v", err)
+	}
+	if got != "review:worker %11" {
+		t.Fatalf("Observe() = %q, want %q", got, "review:worker %11")
+	}
+
+	failing := SignalDetector{Command: "exit 3"}
+	if _, err := failing.Observe("review:worker", discovery.NodeInfo{PaneID: "%11"}); err == nil {
+		t.Fatal("Observe() with failing command: want error")
+	}
+}
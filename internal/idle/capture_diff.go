@@ -0,0 +1,79 @@
+package idle
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+)
+
+// captureDiffDirName holds pane_capture_diff_log output under the context dir.
+const captureDiffDirName = "pane-capture-diffs"
+
+// recordCaptureDiff keeps the pane's latest capture under
+// pane_capture_diff_log and, when changed, writes the diff against the one
+// before it. Callers hold t.mu.
+func (t *IdleTracker) recordCaptureDiff(cfg *config.Config, paneID, nodeKey, content string, changed bool, now time.Time) {
+	if !config.BoolVal(cfg.PaneCaptureDiffLog, false) || t.captureDiffDir == "" {
+		return
+	}
+	if t.lastCapture == nil {
+		t.lastCapture = make(map[string]string)
+	}
+	if previous, ok := t.lastCapture[paneID]; ok && changed {
+		if err := writeCaptureDiff(t.captureDiffDir, paneID, nodeKey, previous, content, now); err != nil {
+			log.Printf("postman: WARNING: pane capture diff for %s: %v\n", paneID, err)
+		}
+	}
+	t.lastCapture[paneID] = content
+}
+
+// captureDiffPath returns the diff artifact for paneID ("%11" -> pane-11.diff).
+func captureDiffPath(dir, paneID string) string {
+	return filepath.Join(dir, "pane-"+strings.TrimPrefix(paneID, "%")+".diff")
+}
+
+// writeCaptureDiff replaces the pane's diff artifact with the lines that
+// changed between the previous and current capture. Only the latest change is
+// kept, so the directory stays one small file per pane.
+func writeCaptureDiff(dir, paneID, nodeKey, previous, current string, at time.Time) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("creating capture diff dir: %w", err)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "# pane=%s node=%s at=%s\n", paneID, nodeKey, at.UTC().Format(time.RFC3339))
+	b.WriteString(captureLineDiff(previous, current))
+	return os.WriteFile(captureDiffPath(dir, paneID), []byte(b.String()), 0o600)
+}
+
+// captureLineDiff trims the lines both captures share at the start and end
+// and renders the rest as "-" (previous) and "+" (current) lines, preceded by
+// the 1-based line where they begin. Pane captures are short, so this is
+// enough to see what moved without a full diff algorithm.
+func captureLineDiff(previous, current string) string {
+	before := strings.Split(previous, "\n")
+	after := strings.Split(current, "\n")
+	prefix := 0
+	for prefix < len(before) && prefix < len(after) && before[prefix] == after[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(before)-prefix && suffix < len(after)-prefix &&
+		before[len(before)-1-suffix] == after[len(after)-1-suffix] {
+		suffix++
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "@@ line %d @@\n", prefix+1)
+	for _, line := range before[prefix : len(before)-suffix] {
+		b.WriteString("-" + line + "\n")
+	}
+	for _, line := range after[prefix : len(after)-suffix] {
+		b.WriteString("+" + line + "\n")
+	}
+	return b.String()
+}
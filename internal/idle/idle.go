@@ -12,6 +12,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 	"unicode"
 
@@ -73,6 +74,7 @@ type PaneCaptureState struct {
 	LastChangeAt              time.Time // Last time content change was detected
 	ChangeCount               int       // Consecutive change count (2 = active)
 	LastCaptureAt             time.Time // Last capture time
+	LastStatus                string    // Last reported activity status; drives activity hysteresis
 	LastCompactionPingAt      time.Time // Last compaction-triggered PING for this pane
 	LastCompactionTrigger     string    // Non-empty while a compaction marker remains in scanned content
 	LastCompactionHash        uint32    // Scanned compaction content hash for the most recent compaction marker state
@@ -89,8 +91,12 @@ type IdleTracker struct {
 	nodeActivity         map[string]NodeActivity
 	paneCaptureState     map[string]PaneCaptureState // paneKey -> PaneCaptureState
 	nodeCompactionMemory map[string]PaneCaptureState // nodeKey -> last handled compaction state
+	captureDiffDir       string                      // pane_capture_diff_log output dir; "" = off
+	lastCapture          map[string]string           // paneID -> previous capture, kept only for diffs
+	detector             ActivityDetector            // nil = chosen from activity_detector each tick
 	mu                   sync.Mutex
 	clock                func() time.Time
+	captureStride        atomic.Int32 // capture on every Nth tick; <= 1 = every tick (adaptive_scan)
 }
 
 // NewIdleTracker creates a new IdleTracker instance (Issue #71).
@@ -98,6 +104,12 @@ func NewIdleTracker() *IdleTracker {
 	return newIdleTrackerWithClock(time.Now)
 }
 
+// NewIdleTrackerWithClock creates an IdleTracker that reads the time from
+// clock, so replayed activity carries the recorded timestamps.
+func NewIdleTrackerWithClock(clock func() time.Time) *IdleTracker {
+	return newIdleTrackerWithClock(clock)
+}
+
 func newIdleTrackerWithClock(clock func() time.Time) *IdleTracker {
 	if clock == nil {
 		clock = time.Now
@@ -106,6 +118,7 @@ func newIdleTrackerWithClock(clock func() time.Time) *IdleTracker {
 		nodeActivity:         make(map[string]NodeActivity),
 		paneCaptureState:     make(map[string]PaneCaptureState),
 		nodeCompactionMemory: make(map[string]PaneCaptureState),
+		lastCapture:          make(map[string]string),
 		clock:                clock,
 	}
 }
@@ -147,6 +160,27 @@ func (t *IdleTracker) MarkNodeAlive(nodeKey string) {
 	t.nodeActivity[nodeKey] = activity
 }
 
+// RecordAck records that a node acknowledged its inbox mail by moving it to
+// read/. The ack counts as liveness and as a response, so LastSent catches up
+// with LastReceived and the node no longer reads as sitting on mail.
+func (t *IdleTracker) RecordAck(nodeKey string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	activity := t.nodeActivity[nodeKey]
+	activity.LivenessConfirmed = true
+	activity.LastSent = t.now()
+	t.nodeActivity[nodeKey] = activity
+}
+
+// HasUnackedReceipt reports whether a node received mail after its last send
+// or ack.
+func (t *IdleTracker) HasUnackedReceipt(nodeKey string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	activity := t.nodeActivity[nodeKey]
+	return !activity.LastReceived.IsZero() && activity.LastReceived.After(activity.LastSent)
+}
+
 // GetNodeStates returns a copy of all node activity states (Issue #55).
 func (t *IdleTracker) GetNodeStates() map[string]NodeActivity {
 	t.mu.Lock()
@@ -179,12 +213,29 @@ func statusForState(state PaneCaptureState, now time.Time, cfg *config.Config) s
 	if state.LastChangeAt.IsZero() {
 		return "stale"
 	}
-	if now.Sub(state.LastChangeAt) <= time.Duration(cfg.NodeActiveSeconds)*time.Second {
+	threshold := time.Duration(cfg.NodeActiveSeconds) * time.Second
+	if state.LastStatus == "active" {
+		// Hysteresis: an active pane must stay quiet for the extra grace before
+		// it reports idle, while a single change still flips idle back to active.
+		threshold += time.Duration(cfg.ActivityHysteresisSeconds * float64(time.Second))
+	}
+	if now.Sub(state.LastChangeAt) <= threshold {
 		return "active"
 	}
 	return "idle"
 }
 
+// advanceActivityStatus records each pane's current status as its
+// LastStatus, which is what moves a pane in and out of the hysteresis
+// grace. Only the capture tick calls it, so status reads stay free of side
+// effects. Lock-free — caller must hold t.mu.
+func (t *IdleTracker) advanceActivityStatus(cfg *config.Config, now time.Time) {
+	for paneID, state := range t.paneCaptureState {
+		state.LastStatus = statusForState(state, now, cfg)
+		t.paneCaptureState[paneID] = state
+	}
+}
+
 // GetPaneActivityStatus returns pane activity status based on idle.go logic.
 // Returns map of paneID -> status ("active"/"idle"/"stale").
 // Issue #120: Expose paneCaptureState for get-status-oneline.
@@ -490,6 +541,20 @@ func refreshSameCompactionMarker(state *PaneCaptureState, scan compactionMarkerS
 	}
 }
 
+// markCompactionActivity counts a newly detected compaction as pane activity
+// and liveness when compaction_counts_as_activity is set.
+// Lock-free — caller must hold t.mu.
+func (t *IdleTracker) markCompactionActivity(cfg *config.Config, state *PaneCaptureState, nodeKey string, now time.Time) {
+	if !config.BoolVal(cfg.CompactionCountsAsActivity, false) {
+		return
+	}
+	state.LastChangeAt = now
+	activity := t.nodeActivity[nodeKey]
+	activity.LastScreenChange = now
+	activity.LivenessConfirmed = true
+	t.nodeActivity[nodeKey] = activity
+}
+
 func applyCompactionMemory(state *PaneCaptureState, memory PaneCaptureState) {
 	state.LastCompactionPingAt = memory.LastCompactionPingAt
 	state.LastCompactionTrigger = memory.LastCompactionTrigger
@@ -537,7 +602,8 @@ func compactionAbsenceAuthoritative(state PaneCaptureState, scope compactionCapt
 		state.LastCompactionScope != compactionScopeHistory
 }
 
-// checkPaneCapture performs pane content capture and updates NodeActivity on consecutive changes.
+// checkPaneCapture samples each node pane with the activity detector and
+// updates NodeActivity on consecutive changes.
 func (t *IdleTracker) checkPaneCapture(cfg *config.Config, nodes map[string]discovery.NodeInfo) []CompactionPingTarget {
 	if !config.BoolVal(cfg.PaneCaptureEnabled, true) {
 		return nil
@@ -559,6 +625,9 @@ func (t *IdleTracker) checkPaneCapture(cfg *config.Config, nodes map[string]disc
 	for nodeName, nodeInfo := range nodes {
 		paneToNode[nodeInfo.PaneID] = nodeName
 	}
+	detector := t.activityDetector(cfg)
+	// Compaction markers and capture diffs only make sense for pane contents.
+	_, observesPane := detector.(CaptureDetector)
 
 	// Parse pane IDs and filter to node panes only (MUST 3: node panes first)
 	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
@@ -589,10 +658,10 @@ func (t *IdleTracker) checkPaneCapture(cfg *config.Config, nodes map[string]disc
 	compactionTargets := make(map[string]CompactionPingTarget)
 
 	for _, paneID := range nodePaneIDs {
-		// Capture pane content
-		content, err := paneutil.CaptureContent(paneID)
+		// Observe the pane
+		content, err := detector.Observe(paneToNode[paneID], nodes[paneToNode[paneID]])
 		if err != nil {
-			// MUST 2: Capture failed - treat as "unmeasurable", skip but keep state
+			// MUST 2: Observation failed - treat as "unmeasurable", skip but keep state
 			// Do NOT delete state - carry forward to next poll
 			continue
 		}
@@ -603,8 +672,12 @@ func (t *IdleTracker) checkPaneCapture(cfg *config.Config, nodes map[string]disc
 
 		// Get previous state
 		state, exists := t.paneCaptureState[paneID]
-		allowFullHistory := supportsCompactionRuntime(runtime) && (!exists || currentHash != state.LastHash)
-		compactionContent, compactionHash, compactionScope := captureCompactionContent(paneID, runtime, content, currentHash, cfg.PaneCaptureTailLines, allowFullHistory)
+		compactionContent, compactionHash, compactionScope := "", uint32(0), compactionScopeVisible
+		if observesPane {
+			t.recordCaptureDiff(cfg, paneID, paneToNode[paneID], content, exists && currentHash != state.LastHash, now)
+			allowFullHistory := supportsCompactionRuntime(runtime) && (!exists || currentHash != state.LastHash)
+			compactionContent, compactionHash, compactionScope = captureCompactionContent(paneID, runtime, content, currentHash, cfg.PaneCaptureTailLines, allowFullHistory)
+		}
 		if !exists {
 			// First time seeing this pane - initialize state
 			state = PaneCaptureState{
@@ -620,6 +693,7 @@ func (t *IdleTracker) checkPaneCapture(cfg *config.Config, nodes map[string]disc
 					}
 					if shouldPingCompaction(state, scan, compactionHash, compactionScope, now) {
 						recordCompactionPing(&state, scan, compactionHash, compactionScope, now)
+						t.markCompactionActivity(cfg, &state, nodeKey, now)
 						compactionTargets[nodeKey] = CompactionPingTarget{
 							NodeKey: nodeKey,
 							Runtime: runtime,
@@ -642,6 +716,7 @@ func (t *IdleTracker) checkPaneCapture(cfg *config.Config, nodes map[string]disc
 			if scan := compactionTriggerScan(runtime, compactionContent); scan.Trigger != "" {
 				if shouldPingCompaction(state, scan, compactionHash, compactionScope, now) {
 					recordCompactionPing(&state, scan, compactionHash, compactionScope, now)
+					t.markCompactionActivity(cfg, &state, nodeKey, now)
 					compactionTargets[nodeKey] = CompactionPingTarget{
 						NodeKey: nodeKey,
 						Runtime: runtime,
@@ -709,9 +784,11 @@ func (t *IdleTracker) checkPaneCapture(cfg *config.Config, nodes map[string]disc
 	for paneID, state := range t.paneCaptureState {
 		if !state.LastCaptureAt.IsZero() && now.Sub(state.LastCaptureAt) > staleThreshold {
 			delete(t.paneCaptureState, paneID)
+			delete(t.lastCapture, paneID)
 		}
 	}
 	t.pruneNodeCompactionMemory(now)
+	t.advanceActivityStatus(cfg, now)
 
 	targetNodeKeys := make([]string, 0, len(compactionTargets))
 	for nodeKey := range compactionTargets {
@@ -725,6 +802,13 @@ func (t *IdleTracker) checkPaneCapture(cfg *config.Config, nodes map[string]disc
 	return targets
 }
 
+// SetCaptureStride makes the capture loop run on every stride-th tick, so
+// adaptive_scan can slow capture while the mesh is quiet. 1 restores every
+// tick.
+func (t *IdleTracker) SetCaptureStride(stride int) {
+	t.captureStride.Store(int32(stride))
+}
+
 // StartPaneCaptureCheck starts a goroutine that periodically captures pane content.
 func (t *IdleTracker) StartPaneCaptureCheck(ctx context.Context, cfg *config.Config, baseDir string, contextID string, selfSession string, onCompactionPing func(map[string]discovery.NodeInfo, []CompactionPingTarget)) {
 	if !config.BoolVal(cfg.PaneCaptureEnabled, true) || cfg.PaneCaptureIntervalSeconds <= 0 {
@@ -733,16 +817,24 @@ func (t *IdleTracker) StartPaneCaptureCheck(ctx context.Context, cfg *config.Con
 
 	interval := time.Duration(cfg.PaneCaptureIntervalSeconds * float64(time.Second))
 	ticker := time.NewTicker(interval)
+	t.mu.Lock()
+	t.captureDiffDir = filepath.Join(baseDir, contextID, captureDiffDirName)
+	t.mu.Unlock()
 
 	go func() {
 		defer ticker.Stop()
+		skipped := 0
 		for {
 			select {
 			case <-ctx.Done():
 				return
 			case <-ticker.C:
+				if skipped++; skipped < int(t.captureStride.Load()) {
+					continue
+				}
+				skipped = 0
 				// Discover nodes (edge-filtered)
-				nodes, _, err := discovery.DiscoverNodesWithCollisions(baseDir, contextID, selfSession)
+				nodes, _, err := discovery.DiscoverNodesWithCollisions(baseDir, contextID, selfSession, cfg)
 				if err != nil {
 					continue
 				}
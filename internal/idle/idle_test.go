@@ -207,6 +207,83 @@ func TestGetPaneActivityStatus_LongUnchangedLivePaneStaysIdle(t *testing.T) {
 	}
 }
 
+func TestAdvanceActivityStatus_HysteresisHoldsActiveThroughBriefDip(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tracker := newIdleTrackerWithClock(func() time.Time { return now })
+	cfg := &config.Config{
+		NodeActiveSeconds:         60.0,
+		ActivityHysteresisSeconds: 30.0,
+	}
+	// tick stands in for the capture loop, the only place statuses advance.
+	tick := func() map[string]string {
+		tracker.mu.Lock()
+		tracker.advanceActivityStatus(cfg, now)
+		tracker.mu.Unlock()
+		return tracker.GetPaneActivityStatus(cfg)
+	}
+	lastChange := now
+	tracker.mu.Lock()
+	tracker.paneCaptureState["%14"] = PaneCaptureState{LastHash: 1, LastChangeAt: lastChange, LastCaptureAt: now}
+	tracker.mu.Unlock()
+
+	if got := tick()["%14"]; got != "active" {
+		t.Fatalf("status right after change = %q, want active", got)
+	}
+	now = lastChange.Add(75 * time.Second) // past node_active_seconds, inside grace
+	if got := tick()["%14"]; got != "active" {
+		t.Fatalf("status within hysteresis grace = %q, want active", got)
+	}
+	now = lastChange.Add(91 * time.Second)
+	if got := tick()["%14"]; got != "idle" {
+		t.Fatalf("status after grace = %q, want idle", got)
+	}
+
+	// Once idle, the plain threshold applies: a single change returns to
+	// active, and no grace is granted to a pane that was never seen active.
+	lastChange = now
+	tracker.mu.Lock()
+	state := tracker.paneCaptureState["%14"]
+	state.LastChangeAt = lastChange
+	tracker.paneCaptureState["%14"] = state
+	tracker.mu.Unlock()
+	if got := tick()["%14"]; got != "active" {
+		t.Fatalf("status after a single change = %q, want active", got)
+	}
+
+	tracker.mu.Lock()
+	tracker.paneCaptureState["%15"] = PaneCaptureState{LastHash: 1, LastChangeAt: now.Add(-75 * time.Second), LastCaptureAt: now}
+	tracker.mu.Unlock()
+	if got := tick()["%15"]; got != "idle" {
+		t.Fatalf("status for pane first seen past threshold = %q, want idle", got)
+	}
+}
+
+func TestGetPaneActivityStatus_DoesNotAdvanceHysteresis(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tracker := newIdleTrackerWithClock(func() time.Time { return now })
+	cfg := &config.Config{
+		NodeActiveSeconds:         60.0,
+		ActivityHysteresisSeconds: 30.0,
+	}
+	tracker.mu.Lock()
+	tracker.paneCaptureState["%14"] = PaneCaptureState{LastHash: 1, LastChangeAt: now, LastCaptureAt: now, LastStatus: "active"}
+	tracker.mu.Unlock()
+
+	// Reading past the grace reports idle but must not record it: back inside
+	// the grace the pane still counts as active.
+	now = now.Add(91 * time.Second)
+	if got := tracker.GetPaneActivityStatus(cfg)["%14"]; got != "idle" {
+		t.Fatalf("status after grace = %q, want idle", got)
+	}
+	if err := tracker.ExportPaneActivityToFile(cfg, filepath.Join(t.TempDir(), "pane-activity.json")); err != nil {
+		t.Fatalf("ExportPaneActivityToFile: %v", err)
+	}
+	now = now.Add(-16 * time.Second)
+	if got := tracker.GetPaneActivityStatus(cfg)["%14"]; got != "active" {
+		t.Fatalf("status back inside grace = %q, want active: a read changed the hysteresis state", got)
+	}
+}
+
 func TestGetPaneActivityStatus_EmptyState(t *testing.T) {
 	// No pane capture state -> empty result.
 	tracker := NewIdleTracker()
@@ -245,6 +322,31 @@ func TestGetLivenessMap(t *testing.T) {
 	}
 }
 
+func TestRecordAckClearsUnackedReceipt(t *testing.T) {
+	now := time.Date(2026, time.May, 21, 1, 2, 3, 0, time.UTC)
+	tracker := newIdleTrackerWithClock(func() time.Time { return now })
+	nodeKey := "session1:worker"
+
+	if tracker.HasUnackedReceipt(nodeKey) {
+		t.Fatal("HasUnackedReceipt() = true before any activity")
+	}
+	tracker.UpdateSendActivity(nodeKey)
+	now = now.Add(time.Second)
+	tracker.UpdateReceiveActivity(nodeKey)
+	if !tracker.HasUnackedReceipt(nodeKey) {
+		t.Fatal("HasUnackedReceipt() = false after receive, want true")
+	}
+
+	now = now.Add(time.Second)
+	tracker.RecordAck(nodeKey)
+	if tracker.HasUnackedReceipt(nodeKey) {
+		t.Fatal("HasUnackedReceipt() = true after ack, want false")
+	}
+	if !tracker.GetLivenessMap()[nodeKey] {
+		t.Fatal("ack did not confirm liveness")
+	}
+}
+
 func TestContainsCompactionTrigger(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -624,6 +726,65 @@ func TestCheckPaneCapture_CompactionTriggerReturnsDetectedNodeAfterInitialCaptur
 	}
 }
 
+func TestCheckPaneCapture_CompactionCountsAsActivity(t *testing.T) {
+	scriptDir := t.TempDir()
+	capturePath := filepath.Join(scriptDir, "capture.txt")
+	scriptPath := filepath.Join(scriptDir, "tmux")
+	script := "#!/bin/sh\n" +
+		"if [ \"$1\" = 'list-panes' ] && [ \"$2\" = '-a' ] && [ \"$3\" = '-F' ] && [ \"$4\" = '#{pane_id}\t#{pane_current_command}' ]; then\n" +
+		"  printf '%s\\n' '%11\tclaude'\n" +
+		"  exit 0\n" +
+		"fi\n" +
+		"if [ \"$1\" = 'capture-pane' ] && [ \"$2\" = '-p' ] && [ \"$3\" = '-t' ] && [ \"$4\" = '%11' ]; then\n" +
+		"  cat \"$TMUX_A2A_TEST_CAPTURE\"\n" +
+		"  exit 0\n" +
+		"fi\n" +
+		"exit 1\n"
+	if err := os.WriteFile(scriptPath, []byte(script), 0o755); err != nil {
+		t.Fatalf("WriteFile(fake tmux): %v", err)
+	}
+	t.Setenv("PATH", scriptDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("TMUX_A2A_TEST_CAPTURE", capturePath)
+
+	nodes := map[string]discovery.NodeInfo{
+		"review:worker": {PaneID: "%11", SessionName: "review"},
+	}
+	for _, enabled := range []bool{false, true} {
+		start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+		now := start
+		tracker := newIdleTrackerWithClock(func() time.Time { return now })
+		cfg := &config.Config{
+			ActivityWindowSeconds:      120,
+			NodeStaleSeconds:           3600,
+			CompactionCountsAsActivity: &enabled,
+		}
+
+		if err := os.WriteFile(capturePath, []byte("ready"), 0o644); err != nil {
+			t.Fatalf("WriteFile(capture ready): %v", err)
+		}
+		tracker.checkPaneCapture(cfg, nodes)
+
+		// A single screen change after a long quiet spell is not enough on
+		// its own to refresh the node's activity.
+		now = start.Add(10 * time.Minute)
+		if err := os.WriteFile(capturePath, []byte("✻ Conversation compacted (ctrl+o for history)"), 0o644); err != nil {
+			t.Fatalf("WriteFile(capture marker): %v", err)
+		}
+		if targets := tracker.checkPaneCapture(cfg, nodes); len(targets) != 1 {
+			t.Fatalf("enabled=%v: checkPaneCapture() returned %d targets, want 1", enabled, len(targets))
+		}
+
+		activity := tracker.GetNodeStates()["review:worker"]
+		if enabled {
+			if !activity.LastScreenChange.Equal(now) || !activity.LivenessConfirmed {
+				t.Fatalf("enabled: activity = %+v, want LastScreenChange=%v and liveness confirmed", activity, now)
+			}
+		} else if !activity.LastScreenChange.IsZero() || activity.LivenessConfirmed {
+			t.Fatalf("disabled: activity = %+v, want compaction ignored", activity)
+		}
+	}
+}
+
 func TestCheckPaneCapture_CompactionTriggerUsesRecentHistory(t *testing.T) {
 	scriptDir := t.TempDir()
 	visiblePath := filepath.Join(scriptDir, "visible.txt")
@@ -2339,3 +2500,66 @@ func TestCheckPaneCapture_CompactionTriggerDoesNotRepeatSameCaptureAfterMarkerCl
 		t.Fatalf("repeated checkPaneCapture() returned %d targets, want 0 for the same compaction capture", len(repeatedTargets))
 	}
 }
+
+func TestCheckPaneCapture_DiffLogWritesChangedLines(t *testing.T) {
+	scriptDir := t.TempDir()
+	capturePath := filepath.Join(scriptDir, "capture.txt")
+	scriptPath := filepath.Join(scriptDir, "tmux")
+	script := "#!/bin/sh\n" +
+		"if [ \"$1\" = 'list-panes' ] && [ \"$2\" = '-a' ] && [ \"$3\" = '-F' ] && [ \"$4\" = '#{pane_id}\t#{pane_current_command}' ]; then\n" +
+		"  printf '%s\\n' '%11\tzsh'\n" +
+		"  exit 0\n" +
+		"fi\n" +
+		"if [ \"$1\" = 'capture-pane' ] && [ \"$2\" = '-p' ] && [ \"$3\" = '-t' ] && [ \"$4\" = '%11' ]; then\n" +
+		"  cat \"$TMUX_A2A_TEST_CAPTURE\"\n" +
+		"  exit 0\n" +
+		"fi\n" +
+		"exit 1\n"
+	if err := os.WriteFile(scriptPath, []byte(script), 0o755); err != nil {
+		t.Fatalf("WriteFile(fake tmux): %v", err)
+	}
+	t.Setenv("PATH", scriptDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("TMUX_A2A_TEST_CAPTURE", capturePath)
+
+	nodes := map[string]discovery.NodeInfo{
+		"review:worker": {PaneID: "%11", SessionName: "review"},
+	}
+	for _, enabled := range []bool{false, true} {
+		now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+		tracker := newIdleTrackerWithClock(func() time.Time { return now })
+		tracker.captureDiffDir = t.TempDir()
+		cfg := &config.Config{
+			ActivityWindowSeconds: 120,
+			NodeStaleSeconds:      3600,
+			PaneCaptureDiffLog:    &enabled,
+		}
+
+		for _, capture := range []string{"$ make test\nrunning\n$", "$ make test\nPASS\n$"} {
+			if err := os.WriteFile(capturePath, []byte(capture), 0o644); err != nil {
+				t.Fatalf("WriteFile(capture): %v", err)
+			}
+			tracker.checkPaneCapture(cfg, nodes)
+			now = now.Add(5 * time.Second)
+		}
+
+		data, err := os.ReadFile(captureDiffPath(tracker.captureDiffDir, "%11"))
+		if !enabled {
+			if !os.IsNotExist(err) {
+				t.Fatalf("diff artifact written with pane_capture_diff_log off: err=%v", err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("ReadFile(diff artifact): %v", err)
+		}
+		got := string(data)
+		for _, want := range []string{"node=review:worker", "@@ line 2 @@", "-running\n", "+PASS\n"} {
+			if !strings.Contains(got, want) {
+				t.Fatalf("diff artifact missing %q:\n%s", want, got)
+			}
+		}
+		if strings.Contains(got, "make test") {
+			t.Fatalf("diff artifact includes unchanged lines:\n%s", got)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package journal
+
+const MessageRecalledEventType = "message_recalled"
+
+// MessageRecalledPayload records a sender withdrawing a message before the
+// recipient read it. Stage is "post" (never delivered) or "inbox" (delivered
+// but unread and moved to dead-letter).
+type MessageRecalledPayload struct {
+	MessageID      string `json:"message_id"`
+	From           string `json:"from"`
+	To             string `json:"to"`
+	Stage          string `json:"stage"`
+	DeadLetterPath string `json:"dead_letter_path,omitempty"`
+}
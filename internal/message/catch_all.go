@@ -0,0 +1,37 @@
+package message
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/discovery"
+	"github.com/i9wa4/tmux-a2a-postman/internal/envelope"
+	"github.com/i9wa4/tmux-a2a-postman/internal/router"
+)
+
+// catchAllRecipient resolves catch_all_node for mail whose recipient is
+// unknown. It reports false when no catch-all is configured or the catch-all
+// is not a running node, in which case the mail is dead-lettered as before.
+func catchAllRecipient(cfg *config.Config, sourceSessionName string, knownNodes map[string]discovery.NodeInfo) (router.Resolution, bool) {
+	if cfg == nil || strings.TrimSpace(cfg.CatchAllNode) == "" {
+		return router.Resolution{}, false
+	}
+	resolution := resolveRuntimeNode(strings.TrimSpace(cfg.CatchAllNode), sourceSessionName, knownNodes)
+	return resolution, resolution.Found
+}
+
+// annotateCatchAll prefixes the message body with a note naming the
+// recipient the sender intended. The frontmatter is left as sent, and a post
+// retried after a hold is not annotated twice.
+func annotateCatchAll(content, intendedRecipient string) string {
+	note := fmt.Sprintf("> postman: delivered via catch_all_node; the intended recipient %q is unknown.", intendedRecipient)
+	if strings.Contains(content, note) {
+		return content
+	}
+	if _, body, ok, err := envelope.ScanFrontmatter(content); ok && err == nil {
+		prefix := content[:len(content)-len(body)]
+		return prefix + "\n\n" + note + "\n\n" + strings.TrimLeft(body, "\n")
+	}
+	return note + "\n\n" + content
+}
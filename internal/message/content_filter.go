@@ -0,0 +1,76 @@
+package message
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"os/exec"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/envelope"
+)
+
+// defaultContentFilterTimeout applies when content_filter_timeout_seconds is
+// unset.
+const defaultContentFilterTimeout = 5 * time.Second
+
+// applyContentFilter pipes the message body through content_filter_command
+// and returns the content to deliver. Only the body after the frontmatter is
+// filtered, so the filter cannot rewrite routing metadata. Any failure keeps
+// the original content.
+func applyContentFilter(cfg *config.Config, filename, content string) string {
+	if cfg == nil || strings.TrimSpace(cfg.ContentFilterCommand) == "" {
+		return content
+	}
+	prefix, body := "", content
+	if _, scannedBody, ok, err := envelope.ScanFrontmatter(content); ok && err == nil {
+		prefix, body = content[:len(content)-len(scannedBody)], scannedBody
+	}
+
+	filtered, err := runContentFilter(cfg, body)
+	if err != nil {
+		log.Printf("postman: WARNING: content_filter_command failed for %s, delivering original: %v\n", filename, err)
+		return content
+	}
+	return prefix + filtered
+}
+
+func runContentFilter(cfg *config.Config, body string) (string, error) {
+	timeout := defaultContentFilterTimeout
+	if cfg.ContentFilterTimeoutSeconds > 0 {
+		timeout = time.Duration(cfg.ContentFilterTimeoutSeconds * float64(time.Second))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", cfg.ContentFilterCommand)
+	cmd.Stdin = strings.NewReader(body)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	// Kill the whole group on timeout so a backgrounded child cannot hold
+	// stdout open past the deadline.
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+	cmd.WaitDelay = 500 * time.Millisecond
+
+	out, err := cmd.Output()
+	if ctx.Err() == context.DeadlineExceeded {
+		return "", fmt.Errorf("timed out after %s", timeout)
+	}
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%w: %s", err, msg)
+		}
+		return "", err
+	}
+	if len(bytes.TrimSpace(out)) == 0 {
+		return "", fmt.Errorf("filter produced no output")
+	}
+	return string(out), nil
+}
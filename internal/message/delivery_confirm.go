@@ -0,0 +1,95 @@
+package message
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/paneutil"
+)
+
+// deliveryConfirmCaptureLines is how much scrollback the echo check reads, so
+// a notification the agent already scrolled past still counts.
+const deliveryConfirmCaptureLines = 100
+
+// deliveryConfirmWait is how long the pane gets to redraw before it is
+// captured.
+const deliveryConfirmWait = 500 * time.Millisecond
+
+// envelopeRequestsConfirm reports whether the envelope carries confirm: true.
+func envelopeRequestsConfirm(content string) bool {
+	metadata, err := ParseEnvelopeMetadata(content)
+	if err != nil {
+		return false
+	}
+	confirm, err := strconv.ParseBool(metadata.Confirm)
+	return err == nil && confirm
+}
+
+// deliveryEchoMarker picks the text the pane must show: the message filename
+// when the notification names it, otherwise the notification's first
+// non-blank line.
+func deliveryEchoMarker(notificationMsg, filename string) string {
+	if strings.Contains(notificationMsg, filename) {
+		return filename
+	}
+	for _, line := range strings.Split(notificationMsg, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return filename
+}
+
+// deliveryConfirmation checks, off the delivery path, that a confirm: true
+// notification showed up in the recipient pane. A missing marker gets one
+// resend; if the pane still does not show it, unconfirmed runs. Mail that
+// has left the inbox in the meantime was picked up and needs no check.
+type deliveryConfirmation struct {
+	paneID      string
+	inboxPath   string
+	resend      func() string
+	schedule    func(delay time.Duration, check func())
+	unconfirmed func()
+}
+
+func (c deliveryConfirmation) start(marker string) {
+	c.schedule(deliveryConfirmWait, func() { c.check(marker, 1) })
+}
+
+func (c deliveryConfirmation) check(marker string, attempt int) {
+	if _, err := os.Stat(c.inboxPath); err != nil {
+		return
+	}
+	captured, err := paneutil.CaptureRecentContent(c.paneID, deliveryConfirmCaptureLines)
+	switch {
+	case err != nil:
+		log.Printf("postman: WARNING: delivery confirmation could not capture pane %s: %v\n", c.paneID, err)
+	case strings.Contains(captured, marker):
+		return
+	default:
+		log.Printf("postman: delivery confirmation: %s not visible in pane %s (attempt %d)\n", marker, c.paneID, attempt)
+	}
+	if attempt == 1 {
+		next := c.resend()
+		c.schedule(deliveryConfirmWait, func() { c.check(next, 2) })
+		return
+	}
+	c.unconfirmed()
+}
+
+func (o DeliverOptions) scheduleConfirm(delay time.Duration, check func()) {
+	if o.ScheduleConfirm != nil {
+		o.ScheduleConfirm(delay, check)
+		return
+	}
+	time.AfterFunc(delay, check)
+}
+
+func (o DeliverOptions) correct(event DaemonEvent) {
+	if o.Corrections != nil {
+		o.Corrections(event)
+	}
+}
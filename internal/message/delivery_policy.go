@@ -30,16 +30,32 @@ type deliveryPolicyInput struct {
 	EnvelopeChecked  bool
 	EnvelopeMismatch bool
 
+	EmptyBody bool
+	TooLarge  bool
+
 	RecipientResolved   bool
 	RecipientResolution router.Resolution
 	RecipientForeign    bool
+	RecipientPaneDead   bool
 
 	SenderResolved   bool
 	SenderResolution router.Resolution
 
+	SenderVerified bool
+	SenderMismatch bool
+
+	RateLimitChecked bool
+	RateLimited      bool
+
+	SessionQuotaChecked  bool
+	SessionQuotaExceeded bool
+
 	RoutingChecked bool
 	RoutingAllowed bool
 
+	MethodChecked bool
+	MethodAllowed bool
+
 	SenderSessionChecked    bool
 	SenderSessionEnabled    bool
 	RecipientSessionChecked bool
@@ -48,6 +64,14 @@ type deliveryPolicyInput struct {
 	QueueChecked bool
 	QueueCount   int
 	QueueCap     int
+
+	// DeliveryUnconfirmed is set after the fact, when a confirm: true
+	// message reached the inbox but its pane never echoed the notification.
+	DeliveryUnconfirmed bool
+
+	// RecipientFifoNoReader is also set after the fact, when the message
+	// reached the inbox but nothing was reading the recipient's FIFO.
+	RecipientFifoNoReader bool
 }
 
 func planDeliveryPolicy(input deliveryPolicyInput) deliveryDecision {
@@ -66,6 +90,16 @@ func planDeliveryPolicy(input deliveryPolicyInput) deliveryDecision {
 		return forgedSenderDecision()
 	}
 
+	if input.TooLarge {
+		return deliveryDecision{
+			Action:                     deliveryActionDeadLetter,
+			DeadLetterSuffix:           dlSuffixTooLarge,
+			DeadLetterReason:           deadLetterReasonTooLarge,
+			EventReason:                deadLetterReasonTooLarge,
+			SendDeadLetterNotification: true,
+		}
+	}
+
 	if input.EnvelopeChecked && input.EnvelopeMismatch {
 		return deliveryDecision{
 			Action:                     deliveryActionDeadLetter,
@@ -76,7 +110,26 @@ func planDeliveryPolicy(input deliveryPolicyInput) deliveryDecision {
 		}
 	}
 
+	if input.EmptyBody {
+		return deliveryDecision{
+			Action:                     deliveryActionDeadLetter,
+			DeadLetterSuffix:           dlSuffixEmptyBody,
+			DeadLetterReason:           deadLetterReasonEmptyBody,
+			EventReason:                deadLetterReasonEmptyBody,
+			SendDeadLetterNotification: true,
+		}
+	}
+
 	if input.RecipientResolved {
+		if input.RecipientPaneDead {
+			return deliveryDecision{
+				Action:                     deliveryActionDeadLetter,
+				DeadLetterSuffix:           dlSuffixPaneNotRunning,
+				DeadLetterReason:           deadLetterReasonPaneNotRunning,
+				EventReason:                deadLetterReasonPaneNotRunning,
+				SendDeadLetterNotification: true,
+			}
+		}
 		if !input.RecipientResolution.Found {
 			if input.RecipientResolution.FailureReason == router.FailureUnknownSession {
 				return deliveryDecision{
@@ -114,6 +167,39 @@ func planDeliveryPolicy(input deliveryPolicyInput) deliveryDecision {
 		}
 	}
 
+	if input.SenderVerified && input.SenderMismatch {
+		// No sender notification: the claimed sender is the node being
+		// impersonated, and the real one is not to be trusted with feedback.
+		return deliveryDecision{
+			Action:           deliveryActionDeadLetter,
+			DeadLetterSuffix: dlSuffixSenderMismatch,
+			DeadLetterReason: deadLetterReasonSenderMismatch,
+			EventReason:      deadLetterReasonSenderMismatch,
+		}
+	}
+
+	if input.RateLimitChecked && input.RateLimited {
+		// No sender notification: notifying every excess message would
+		// amplify the flood. The daemon emits one rate_limited event per window.
+		return deliveryDecision{
+			Action:           deliveryActionDeadLetter,
+			DeadLetterSuffix: dlSuffixRateLimited,
+			DeadLetterReason: deadLetterReasonRateLimited,
+			EventReason:      deadLetterReasonRateLimited,
+		}
+	}
+
+	if input.SessionQuotaChecked && input.SessionQuotaExceeded {
+		// Like rate limiting, the daemon emits one session_quota event per
+		// hour instead of notifying each sender.
+		return deliveryDecision{
+			Action:           deliveryActionDeadLetter,
+			DeadLetterSuffix: dlSuffixSessionQuota,
+			DeadLetterReason: deadLetterReasonSessionQuota,
+			EventReason:      deadLetterReasonSessionQuota,
+		}
+	}
+
 	if input.RoutingChecked && input.Info.From != "daemon" && !input.RoutingAllowed {
 		return deliveryDecision{
 			Action:             deliveryActionDeadLetter,
@@ -123,6 +209,16 @@ func planDeliveryPolicy(input deliveryPolicyInput) deliveryDecision {
 		}
 	}
 
+	if input.MethodChecked && input.Info.From != "daemon" && !input.MethodAllowed {
+		return deliveryDecision{
+			Action:                     deliveryActionDeadLetter,
+			DeadLetterSuffix:           dlSuffixMethodDenied,
+			DeadLetterReason:           deadLetterReasonMethodDenied,
+			EventReason:                deadLetterReasonMethodDenied,
+			SendDeadLetterNotification: true,
+		}
+	}
+
 	if input.SenderSessionChecked && input.Info.From != "daemon" && !input.SenderSessionEnabled {
 		return deliveryDecision{
 			Action:                     deliveryActionDeadLetter,
@@ -143,6 +239,26 @@ func planDeliveryPolicy(input deliveryPolicyInput) deliveryDecision {
 		}
 	}
 
+	if input.DeliveryUnconfirmed {
+		return deliveryDecision{
+			Action:                     deliveryActionDeadLetter,
+			DeadLetterSuffix:           dlSuffixUnconfirmed,
+			DeadLetterReason:           deadLetterReasonDeliveryUnconfirmed,
+			EventReason:                deadLetterReasonDeliveryUnconfirmed,
+			SendDeadLetterNotification: true,
+		}
+	}
+
+	if input.RecipientFifoNoReader {
+		return deliveryDecision{
+			Action:                     deliveryActionDeadLetter,
+			DeadLetterSuffix:           dlSuffixFifoNoReader,
+			DeadLetterReason:           deadLetterReasonFifoNoReader,
+			EventReason:                deadLetterReasonFifoNoReader,
+			SendDeadLetterNotification: true,
+		}
+	}
+
 	if input.QueueChecked {
 		if input.QueueCount >= input.QueueCap {
 			return deliveryDecision{
@@ -134,6 +134,60 @@ func TestPlanDeliveryPolicy(t *testing.T) {
 				EventReason:      "unknown sender",
 			},
 		},
+		{
+			name: "sender mismatch",
+			in: deliveryPolicyInput{
+				Info:                baseInfo,
+				RecipientResolved:   true,
+				RecipientResolution: foundRecipient,
+				SenderResolved:      true,
+				SenderResolution:    foundSender,
+				SenderVerified:      true,
+				SenderMismatch:      true,
+			},
+			want: deliveryDecision{
+				Action:           deliveryActionDeadLetter,
+				DeadLetterSuffix: dlSuffixSenderMismatch,
+				DeadLetterReason: deadLetterReasonSenderMismatch,
+				EventReason:      deadLetterReasonSenderMismatch,
+			},
+		},
+		{
+			name: "rate limited",
+			in: deliveryPolicyInput{
+				Info:                baseInfo,
+				RecipientResolved:   true,
+				RecipientResolution: foundRecipient,
+				SenderResolved:      true,
+				SenderResolution:    foundSender,
+				RateLimitChecked:    true,
+				RateLimited:         true,
+			},
+			want: deliveryDecision{
+				Action:           deliveryActionDeadLetter,
+				DeadLetterSuffix: dlSuffixRateLimited,
+				DeadLetterReason: deadLetterReasonRateLimited,
+				EventReason:      deadLetterReasonRateLimited,
+			},
+		},
+		{
+			name: "session quota exceeded",
+			in: deliveryPolicyInput{
+				Info:                 baseInfo,
+				RecipientResolved:    true,
+				RecipientResolution:  foundRecipient,
+				SenderResolved:       true,
+				SenderResolution:     foundSender,
+				SessionQuotaChecked:  true,
+				SessionQuotaExceeded: true,
+			},
+			want: deliveryDecision{
+				Action:           deliveryActionDeadLetter,
+				DeadLetterSuffix: dlSuffixSessionQuota,
+				DeadLetterReason: deadLetterReasonSessionQuota,
+				EventReason:      deadLetterReasonSessionQuota,
+			},
+		},
 		{
 			name: "route denial",
 			in: deliveryPolicyInput{
@@ -0,0 +1,66 @@
+package message
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// EventDuplicateMessageID is the event type for a post whose message id was
+// already delivered to the same recipient.
+const EventDuplicateMessageID = "duplicate_message_id"
+
+// duplicateReadSuffix marks suppressed duplicates parked in read/, so they
+// are not taken for the recipient having read its mail.
+const duplicateReadSuffix = "-dup-message-id"
+
+// IsDuplicateReadFile reports whether a read/ filename is a suppressed
+// duplicate rather than mail a node has read.
+func IsDuplicateReadFile(filename string) bool {
+	return strings.HasSuffix(filename, duplicateReadSuffix+".md")
+}
+
+// envelopeMessageID returns the envelope messageId, or "" when absent.
+func envelopeMessageID(content string) string {
+	metadata, err := ParseEnvelopeMetadata(content)
+	if err != nil {
+		return ""
+	}
+	return metadata.MessageID
+}
+
+// parkDuplicateMessageID moves a post whose message id already reached its
+// recipient to read/ and emits a duplicate_message_id event. The caller must
+// not deliver it.
+func parkDuplicateMessageID(sourceSessionDir, postPath, filename, messageID string, info *MessageInfo, events chan<- DaemonEvent) error {
+	readDir := filepath.Join(sourceSessionDir, "read")
+	if err := os.MkdirAll(readDir, 0o700); err != nil {
+		return fmt.Errorf("creating read dir: %w", err)
+	}
+	dst := filepath.Join(readDir, strings.TrimSuffix(filename, ".md")+duplicateReadSuffix+".md")
+	if err := os.Rename(postPath, dst); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("moving duplicate to read/: %w", err)
+	}
+	log.Printf("postman: %s repeats message id %q already delivered to %s; moved to read/ without delivery\n", filename, messageID, info.To)
+	if events != nil {
+		select {
+		case events <- DaemonEvent{
+			Type:    EventDuplicateMessageID,
+			Message: fmt.Sprintf("Duplicate message id %s: %s -> %s not delivered again", messageID, info.From, info.To),
+			Details: map[string]interface{}{
+				"filename":   filename,
+				"message_id": messageID,
+				"from":       info.From,
+				"to":         info.To,
+			},
+		}:
+		default:
+		}
+	}
+	return nil
+}
@@ -3,11 +3,13 @@ package message
 import (
 	"crypto/rand"
 	"crypto/sha256"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -22,6 +24,7 @@ import (
 	"github.com/i9wa4/tmux-a2a-postman/internal/notification"
 	"github.com/i9wa4/tmux-a2a-postman/internal/projection"
 	"github.com/i9wa4/tmux-a2a-postman/internal/router"
+	"github.com/i9wa4/tmux-a2a-postman/internal/runtimecontext"
 	"github.com/i9wa4/tmux-a2a-postman/internal/store"
 	"github.com/i9wa4/tmux-a2a-postman/internal/template"
 )
@@ -34,6 +37,16 @@ const (
 	deadLetterReasonSenderSessionDisabled    = "sender session disabled"
 	deadLetterReasonRecipientSessionDisabled = "recipient session disabled"
 	deadLetterReasonForeignSession           = "foreign session"
+	deadLetterReasonSenderMismatch           = "sender mismatch"
+	deadLetterReasonRateLimited              = "rate limited"
+	deadLetterReasonTTLExpired               = "ttl expired"
+	deadLetterReasonMethodDenied             = "method not permitted"
+	deadLetterReasonPaneNotRunning           = "pane not running"
+	deadLetterReasonEmptyBody                = "empty body"
+	deadLetterReasonTooLarge                 = "too large"
+	deadLetterReasonDeliveryUnconfirmed      = "delivery unconfirmed"
+	deadLetterReasonFifoNoReader             = "fifo has no reader"
+	deadLetterReasonSessionQuota             = "session quota exceeded"
 )
 
 // Dead-letter filename suffixes appended before .md extension (Issue #206).
@@ -48,8 +61,27 @@ const (
 	DlSuffixTTLExpired       = "-dl-ttl-expired"
 	dlSuffixForeignSession   = "-dl-foreign-session"
 	dlSuffixForgedSender     = "-dl-forged-sender"
+	dlSuffixSenderMismatch   = "-dl-sender-mismatch"
+	dlSuffixRateLimited      = "-dl-rate-limited"
+	DlSuffixRecalled         = "-dl-recalled"
+	DlSuffixAlreadyDelivered = "-dl-already-delivered"
+	dlSuffixMethodDenied     = "-dl-method-denied"
+	dlSuffixPaneNotRunning   = "-dl-pane-not-running"
+	dlSuffixEmptyBody        = "-dl-empty-body"
+	dlSuffixTooLarge         = "-dl-too-large"
+	dlSuffixUnconfirmed      = "-dl-unconfirmed"
+	dlSuffixFifoNoReader     = "-dl-fifo-no-reader"
+	dlSuffixSessionQuota     = "-dl-session-quota"
 )
 
+// DefaultMessageMethod is assumed for mail whose envelope has no method field.
+const DefaultMessageMethod = "message/send"
+
+// ErrDeliveryHeld reports that the message was left in post/ for a later
+// retry: serialize_per_node is waiting for the recipient to read its current
+// mail, or dead_pane_policy = "hold" is waiting for its pane to return.
+var ErrDeliveryHeld = errors.New("delivery held for recipient")
+
 // inboxQueueCap is the maximum number of messages allowed in a recipient inbox
 // before overflow messages are sent to dead-letter (agent-session queue guard).
 const inboxQueueCap = 20
@@ -369,6 +401,28 @@ func resolveRuntimeNode(address, sourceSessionName string, knownNodes map[string
 	})
 }
 
+// originatingPaneNode resolves the node whose pane authored a message. The
+// pane ID comes from the sender runtime-context snapshot referenced by the
+// envelope; the node is the discovered pane title that owns that pane ID.
+// Returns false when either link is missing, so verification stays advisory
+// for messages written without send-heredoc.
+func originatingPaneNode(sessionDir, content string, knownNodes map[string]discovery.NodeInfo) (string, bool) {
+	metadata, err := envelope.ParseMetadata(content)
+	if err != nil || metadata.RuntimeContextID == "" {
+		return "", false
+	}
+	summary, err := runtimecontext.LoadSummary(sessionDir, metadata.RuntimeContextID, time.Now())
+	if err != nil || summary.Fields.Tmux == nil || summary.Fields.Tmux.PaneID == "" {
+		return "", false
+	}
+	for nodeKey, nodeInfo := range knownNodes {
+		if nodeInfo.PaneID == summary.Fields.Tmux.PaneID {
+			return nodeKey, true
+		}
+	}
+	return "", false
+}
+
 // StripDeadLetterSuffix removes the -dl-{reason} suffix from a dead-letter filename.
 // Transforms "msg-dl-routing-denied.md" → "msg.md".
 func StripDeadLetterSuffix(filename string) string {
@@ -390,11 +444,15 @@ func emitDeliveryDecisionEvent(events chan<- DaemonEvent, decision deliveryDecis
 	if events == nil || decision.EventReason == "" {
 		return
 	}
+	events <- deliveryDecisionEvent(decision, info, filename)
+}
+
+func deliveryDecisionEvent(decision deliveryDecision, info *MessageInfo, filename string) DaemonEvent {
 	message := fmt.Sprintf("Dead-letter: %s (%s)", filename, decision.EventReason)
 	if info != nil {
 		message = fmt.Sprintf("Dead-letter: %s -> %s (%s)", info.From, info.To, decision.EventReason)
 	}
-	events <- DaemonEvent{
+	return DaemonEvent{
 		Type:    "message_received",
 		Message: message,
 		Details: map[string]interface{}{
@@ -403,6 +461,24 @@ func emitDeliveryDecisionEvent(events chan<- DaemonEvent, decision deliveryDecis
 	}
 }
 
+func emitClockSkewEvent(events chan<- DaemonEvent, info *MessageInfo, filename string, skew time.Duration) {
+	if events == nil {
+		return
+	}
+	select {
+	case events <- DaemonEvent{
+		Type:    "clock_skew",
+		Message: fmt.Sprintf("Clock skew: %s -> %s is %s ahead of the daemon clock", info.From, info.To, skew.Truncate(time.Second)),
+		Details: map[string]interface{}{
+			"filename":     filename,
+			"from":         info.From,
+			"skew_seconds": skew.Seconds(),
+		},
+	}:
+	default:
+	}
+}
+
 func deadLetterDecisionDestination(sessionDir, filename string, decision deliveryDecision) string {
 	return deadLetterDst(sessionDir, filename, decision.DeadLetterSuffix)
 }
@@ -427,6 +503,55 @@ type MessageInfo struct {
 
 type EnvelopeMetadata = envelope.Metadata
 
+// ParseMessageTime parses info.Timestamp with filename_timestamp_format,
+// falling back to the default layout so files written before the format
+// changed still parse. Layouts without a zone are read in local time, which
+// is how postman writes them.
+func ParseMessageTime(info *MessageInfo, cfg *config.Config) (time.Time, error) {
+	layout := cfg.EffectiveFilenameTimestampFormat()
+	sent, err := parseMessageTimeLayout(info.Timestamp, layout)
+	if err != nil && layout != config.DefaultFilenameTimestampFormat {
+		if fallback, fallbackErr := parseMessageTimeLayout(info.Timestamp, config.DefaultFilenameTimestampFormat); fallbackErr == nil {
+			return fallback, nil
+		}
+	}
+	return sent, err
+}
+
+func parseMessageTimeLayout(timestamp, layout string) (time.Time, error) {
+	if layout == "unix" {
+		seconds, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("parsing epoch timestamp %q: %w", timestamp, err)
+		}
+		return time.Unix(seconds, 0), nil
+	}
+	return time.ParseInLocation(layout, timestamp, time.Local)
+}
+
+// messageAge reports how old a message is by its filename timestamp.
+func messageAge(info *MessageInfo, cfg *config.Config, now time.Time) (time.Duration, bool) {
+	sent, err := ParseMessageTime(info, cfg)
+	if err != nil {
+		return 0, false
+	}
+	return now.Sub(sent), true
+}
+
+// clockSkew reports how far info's filename timestamp runs ahead of now, and
+// whether that exceeds max_clock_skew_seconds.
+func clockSkew(info *MessageInfo, cfg *config.Config, now time.Time) (time.Duration, bool) {
+	if cfg == nil || cfg.MaxClockSkewSeconds <= 0 {
+		return 0, false
+	}
+	sent, err := ParseMessageTime(info, cfg)
+	if err != nil {
+		return 0, false
+	}
+	skew := sent.Sub(now)
+	return skew, skew > time.Duration(cfg.MaxClockSkewSeconds*float64(time.Second))
+}
+
 // SessionHash returns a 4-character hex hash of the tmux session name (#198).
 // Returns empty string if sessionName is empty.
 func SessionHash(sessionName string) string {
@@ -579,7 +704,7 @@ func writeRoutingDeniedWarning(sourceSessionDir, contextID string, info *Message
 	}
 
 	timeout := time.Duration(cfg.TmuxTimeout * float64(time.Second))
-	warnContent := template.ExpandTemplate(cfg.EdgeViolationWarningTemplate, vars, timeout, cfg.AllowShellForEdgeViolationWarningTemplate())
+	warnContent := template.ExpandTemplate(cfg.EdgeViolationWarningTemplate, vars, timeout, cfg.AllowShellForEdgeViolationWarningTemplate(), cfg.TemplateOptions())
 	mode := cfg.EdgeViolationWarningMode
 	if mode == "" {
 		mode = "compact"
@@ -595,6 +720,85 @@ func writeRoutingDeniedWarning(sourceSessionDir, contextID string, info *Message
 	_ = os.WriteFile(warnPath, []byte(warnContent), 0o600)
 }
 
+// isEmptyMessageBody reports whether content carries no sender text: nothing
+// after the frontmatter (and any generated send envelope) but whitespace or a
+// bare "## Content" heading.
+func isEmptyMessageBody(content string) bool {
+	if content == "" {
+		return false
+	}
+	body, _ := envelope.SenderBodyFromContent(content)
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && line != "## Content" {
+			return false
+		}
+	}
+	return true
+}
+
+// writeEmptyBodyWarning tells the sender its message went out with an empty
+// body, under empty_body_policy = "warn_sender".
+func writeEmptyBodyWarning(sessionDir, contextID, senderSimpleName, originalFilename, recipient string) {
+	senderInbox := filepath.Join(sessionDir, "inbox", senderSimpleName)
+	if err := os.MkdirAll(senderInbox, 0o700); err != nil {
+		log.Printf("postman: WARNING: failed to create empty-body warning inbox for %s: %v\n", senderSimpleName, err)
+		return
+	}
+	now := time.Now()
+	filename := fmt.Sprintf("%s-from-postman-to-%s.md", now.Format("20060102-150405"), senderSimpleName)
+	content := fmt.Sprintf(
+		"---\nparams:\n  contextId: %s\n  from: postman\n  to: %s\n  timestamp: %s\n  messageType: empty_body_warning\n---\n\n## Empty Message Warning\n\nYour message %q to %s had an empty body; it was delivered anyway.\nSend the intended text with:\ntmux-a2a-postman send-heredoc --to %s <<'POSTMAN_BODY'\n<your message>\nPOSTMAN_BODY\n",
+		contextID,
+		senderSimpleName,
+		now.Format(time.RFC3339),
+		originalFilename,
+		recipient,
+		nodeaddr.Simple(recipient),
+	)
+	if err := os.WriteFile(filepath.Join(senderInbox, filename), []byte(content), 0o600); err != nil {
+		log.Printf("postman: WARNING: failed to write empty-body warning for %s: %v\n", senderSimpleName, err)
+	}
+}
+
+// DeliverOptions carries optional daemon-side delivery hooks.
+type DeliverOptions struct {
+	// RateLimited reserves one message of the sender's (session-prefixed
+	// node key) budget and reports whether the budget was already spent, in
+	// which case nothing is reserved. nil disables rate limiting.
+	RateLimited func(senderKey string) bool
+	// ReleaseRateLimit hands back a slot reserved by RateLimited when the
+	// message ends up not delivered.
+	ReleaseRateLimit func(senderKey string)
+	// SessionQuotaExceeded reserves one message of the sender's session
+	// max_messages_per_hour quota and reports whether the quota was already
+	// used up, in which case nothing is reserved. nil disables session
+	// quotas.
+	SessionQuotaExceeded func(sessionName string) bool
+	// ReleaseSessionQuota hands back a slot reserved by SessionQuotaExceeded
+	// when the message ends up not delivered.
+	ReleaseSessionQuota func(sessionName string)
+	// Muted reports whether the recipient (session-prefixed node key) has
+	// pane notifications muted; its mail still lands in the inbox. nil mutes
+	// nothing.
+	Muted func(recipientKey string) bool
+	// PaneDead reports whether the recipient (session-prefixed node key) is
+	// known to have lost its pane; dead_pane_policy decides what happens to
+	// its mail. nil treats every pane as running.
+	PaneDead func(recipientKey string) bool
+	// DeliveredIDs indexes the message ids already delivered per recipient;
+	// a repeated id is parked in read/ instead of delivered. nil disables
+	// duplicate suppression.
+	DeliveredIDs *store.DeliveredMessageIDs
+	// ScheduleConfirm runs a confirm: true pane echo check after delay, off
+	// the delivery path. nil uses time.AfterFunc.
+	ScheduleConfirm func(delay time.Duration, check func())
+	// Corrections receives events that revise an earlier delivery, such as
+	// a confirm: true message pulled back out of the inbox after its pane
+	// never showed the notification. nil only logs them.
+	Corrections func(event DaemonEvent)
+}
+
 // DeliverMessage moves a message from post/ to the recipient's inbox/ or dead-letter/.
 // Multi-session support: postPath is the full path to the message file in post/ directory.
 // The message will be delivered to the recipient's session directory based on NodeInfo.SessionDir.
@@ -605,6 +809,11 @@ func writeRoutingDeniedWarning(sourceSessionDir, contextID string, info *Message
 // Issue #53: Added events channel parameter for dead-letter notifications
 // Issue #71: Added idleTracker parameter for activity tracking
 func DeliverMessage(postPath string, contextID string, knownNodes map[string]discovery.NodeInfo, adjacency map[string][]string, cfg *config.Config, isSessionEnabled func(string) bool, events chan<- DaemonEvent, idleTracker *idle.IdleTracker, daemonSession string) error {
+	return DeliverMessageWithOptions(postPath, contextID, knownNodes, adjacency, cfg, isSessionEnabled, events, idleTracker, daemonSession, DeliverOptions{})
+}
+
+// DeliverMessageWithOptions is DeliverMessage with optional daemon hooks.
+func DeliverMessageWithOptions(postPath string, contextID string, knownNodes map[string]discovery.NodeInfo, adjacency map[string][]string, cfg *config.Config, isSessionEnabled func(string) bool, events chan<- DaemonEvent, idleTracker *idle.IdleTracker, daemonSession string, opts DeliverOptions) error {
 	// Extract filename from postPath
 	filename := filepath.Base(postPath)
 
@@ -642,6 +851,11 @@ func DeliverMessage(postPath string, contextID string, knownNodes map[string]dis
 		return moveToDeadLetterForDecision(sourceSessionDir, sourceSessionName, postPath, dst, filename, nil, messageContent)
 	}
 	policyInput.Info = *info
+	receivedAt := time.Now()
+	skew, skewed := clockSkew(info, cfg, receivedAt)
+	if skewed {
+		log.Printf("postman: WARNING: clock_skew: %s is timestamped %s ahead of the daemon clock; measuring its age from receipt\n", filename, skew.Truncate(time.Second))
+	}
 	senderSimpleName := nodeaddr.Simple(info.From)
 	recipientSimpleName := nodeaddr.Simple(info.To)
 
@@ -667,6 +881,20 @@ func DeliverMessage(postPath string, contextID string, knownNodes map[string]dis
 		return moveToDeadLetterForDecision(sourceSessionDir, sourceSessionName, postPath, dst, filename, info, messageContent)
 	}
 
+	// max_message_bytes: check the size before the body is read, so an
+	// oversized post never reaches memory, the journal, or a pane.
+	if info.From != "daemon" && cfg.MaxMessageBytes > 0 {
+		if stat, statErr := os.Stat(postPath); statErr == nil && stat.Size() > cfg.MaxMessageBytes {
+			policyInput.TooLarge = true
+			decision := planDeliveryPolicy(policyInput)
+			dst := deadLetterDecisionDestination(sourceSessionDir, filename, decision)
+			notifySenderOfDeadLetter(cfg, decision, sourceSessionDir, contextID, senderSimpleName, filename, filepath.Base(dst))
+			log.Printf("📨 postman: %s is %d bytes, over max_message_bytes=%d (moved to dead-letter/)\n", filename, stat.Size(), cfg.MaxMessageBytes)
+			emitDeliveryDecisionEvent(events, decision, info, filename)
+			return moveToDeadLetterForDecision(sourceSessionDir, sourceSessionName, postPath, dst, filename, info, "")
+		}
+	}
+
 	// Issue #161: Validate frontmatter envelope (skip only for daemon-origin messages)
 	if info.From != "daemon" {
 		rawBytes, readErr := os.ReadFile(postPath)
@@ -682,9 +910,7 @@ func DeliverMessage(postPath string, contextID string, knownNodes map[string]dis
 			policyInput.EnvelopeMismatch = parseErr != nil || envFrom != info.From || envTo != info.To
 			if decision := planDeliveryPolicy(policyInput); decision.Action == deliveryActionDeadLetter {
 				dst := deadLetterDecisionDestination(sourceSessionDir, filename, decision)
-				if decision.SendDeadLetterNotification {
-					sendDeadLetterNotification(sourceSessionDir, contextID, senderSimpleName, decision.DeadLetterReason, filename, filepath.Base(dst))
-				}
+				notifySenderOfDeadLetter(cfg, decision, sourceSessionDir, contextID, senderSimpleName, filename, filepath.Base(dst))
 				emitDeliveryDecisionEvent(events, decision, info, filename)
 				return moveToDeadLetterForDecision(sourceSessionDir, sourceSessionName, postPath, dst, filename, info, messageContent)
 			}
@@ -699,17 +925,88 @@ func DeliverMessage(postPath string, contextID string, knownNodes map[string]dis
 			log.Printf("postman: WARNING: failed to read message content %s: %v\n", filename, readErr)
 		}
 	}
+	// Hash the post as written, before catch-all or content filter rewrites,
+	// so a replay of the same file matches its delivery log entry.
+	contentHash := store.PostContentHash([]byte(messageContent))
+
+	// Mail to the reserved name postman with a postman/* method is a query
+	// the daemon answers itself; bare mail to postman stays unroutable. Only
+	// a discovered node in an enabled session gets an answer.
+	if _, senderKnown := knownNodes[nodeaddr.Full(info.From, sourceSessionName)]; recipientSimpleName == "postman" && senderKnown && (sourceSessionName == daemonSession || isSessionEnabled(sourceSessionName)) {
+		if method := postmanQueryMethod(messageContent); method != "" {
+			var livenessMap map[string]bool
+			if idleTracker != nil {
+				livenessMap = idleTracker.GetLivenessMap()
+			}
+			return answerPostmanQuery(method, postPath, sourceSessionDir, sourceSessionName, contextID, senderSimpleName, messageContent, knownNodes, adjacency, livenessMap)
+		}
+	}
+
+	// empty_body_policy: frontmatter-only mail would only notify a pane with
+	// nothing to read.
+	if info.From != "daemon" && isEmptyMessageBody(messageContent) {
+		switch cfg.EffectiveEmptyBodyPolicy() {
+		case config.EmptyBodyPolicyDeadLetter:
+			policyInput.EmptyBody = true
+			decision := planDeliveryPolicy(policyInput)
+			dst := deadLetterDecisionDestination(sourceSessionDir, filename, decision)
+			notifySenderOfDeadLetter(cfg, decision, sourceSessionDir, contextID, senderSimpleName, filename, filepath.Base(dst))
+			log.Printf("📨 postman: %s has an empty body (moved to dead-letter/)\n", filename)
+			emitDeliveryDecisionEvent(events, decision, info, filename)
+			return moveToDeadLetterForDecision(sourceSessionDir, sourceSessionName, postPath, dst, filename, info, messageContent)
+		case config.EmptyBodyPolicyWarnSender:
+			log.Printf("postman: %s has an empty body; delivering and warning %s\n", filename, senderSimpleName)
+			writeEmptyBodyWarning(sourceSessionDir, contextID, senderSimpleName, filename, info.To)
+		}
+	}
+
+	// Node aliases: mail for an alias goes to the node that declares it, and
+	// the edge check below sees the canonical name.
+	if canonical := cfg.CanonicalNodeName(info.To); canonical != info.To {
+		log.Printf("postman: %s addressed to alias %q; delivering to %s\n", filename, info.To, canonical)
+		info.To = canonical
+		policyInput.Info.To = canonical
+		recipientSimpleName = nodeaddr.Simple(canonical)
+	}
 
 	// Resolve recipient name (Issue #33: session-aware adjacency)
 	recipientResolution := resolveRuntimeNode(info.To, sourceSessionName, knownNodes)
+	// dead_pane_policy: a node whose pane disappeared drops out of knownNodes,
+	// so decide here, before its mail falls to the unknown-recipient path.
+	if info.From != "daemon" && opts.PaneDead != nil && opts.PaneDead(recipientResolution.Address) {
+		switch cfg.EffectiveDeadPanePolicy() {
+		case config.DeadPanePolicyHold:
+			log.Printf("postman: holding %s for %s until its pane returns (dead_pane_policy)\n", filename, recipientResolution.Address)
+			return ErrDeliveryHeld
+		case config.DeadPanePolicyDeadLetter:
+			policyInput.RecipientPaneDead = true
+		}
+	}
+	// catch_all_node: hand unknown-recipient mail to a configured node rather
+	// than stranding it in dead-letter/. The edge check is skipped for it.
+	catchAll := false
+	if !recipientResolution.Found && info.From != "daemon" && !policyInput.RecipientPaneDead {
+		if fallback, ok := catchAllRecipient(cfg, sourceSessionName, knownNodes); ok {
+			annotated := annotateCatchAll(messageContent, info.To)
+			if err := os.WriteFile(postPath, []byte(annotated), 0o600); err != nil {
+				return fmt.Errorf("writing catch-all post: %w", err)
+			}
+			log.Printf("postman: unknown recipient %q for %s; delivering to catch_all_node %s\n", info.To, filename, fallback.Address)
+			messageContent = annotated
+			rerouted := *info
+			rerouted.To = fallback.Address
+			info = &rerouted
+			recipientSimpleName = nodeaddr.Simple(info.To)
+			recipientResolution = fallback
+			catchAll = true
+		}
+	}
 	policyInput.RecipientResolved = true
 	policyInput.RecipientResolution = recipientResolution
 	recipientFullName := recipientResolution.Address
 	if decision := planDeliveryPolicy(policyInput); decision.Action == deliveryActionDeadLetter {
 		dst := deadLetterDecisionDestination(sourceSessionDir, filename, decision)
-		if decision.SendDeadLetterNotification {
-			sendDeadLetterNotification(sourceSessionDir, contextID, senderSimpleName, decision.DeadLetterReason, filename, filepath.Base(dst))
-		}
+		notifySenderOfDeadLetter(cfg, decision, sourceSessionDir, contextID, senderSimpleName, filename, filepath.Base(dst))
 		// Issue #53: Notify dead-letter event
 		emitDeliveryDecisionEvent(events, decision, info, filename)
 		return moveToDeadLetterForDecision(sourceSessionDir, sourceSessionName, postPath, dst, filename, info, messageContent)
@@ -722,9 +1019,7 @@ func DeliverMessage(postPath string, contextID string, knownNodes map[string]dis
 	policyInput.RecipientForeign = daemonSession != "" && nodeInfo.SessionName != daemonSession && !isSessionEnabled(nodeInfo.SessionName)
 	if decision := planDeliveryPolicy(policyInput); decision.Action == deliveryActionDeadLetter {
 		dst := deadLetterDecisionDestination(sourceSessionDir, filename, decision)
-		if decision.SendDeadLetterNotification {
-			sendDeadLetterNotification(sourceSessionDir, contextID, senderSimpleName, decision.DeadLetterReason, filename, filepath.Base(dst))
-		}
+		notifySenderOfDeadLetter(cfg, decision, sourceSessionDir, contextID, senderSimpleName, filename, filepath.Base(dst))
 		log.Printf("postman: F4: dead-lettering %s — recipient session %q is foreign (daemon session: %q)\n", filename, nodeInfo.SessionName, daemonSession)
 		emitDeliveryDecisionEvent(events, decision, info, filename)
 		return moveToDeadLetterForDecision(sourceSessionDir, sourceSessionName, postPath, dst, filename, info, messageContent)
@@ -737,14 +1032,86 @@ func DeliverMessage(postPath string, contextID string, knownNodes map[string]dis
 	senderFullName := senderResolution.Address
 	if decision := planDeliveryPolicy(policyInput); decision.Action == deliveryActionDeadLetter {
 		dst := deadLetterDecisionDestination(sourceSessionDir, filename, decision)
+		notifySenderOfDeadLetter(cfg, decision, sourceSessionDir, contextID, senderSimpleName, filename, filepath.Base(dst))
 		// Issue #53: Notify dead-letter event
 		emitDeliveryDecisionEvent(events, decision, info, filename)
 		return moveToDeadLetterForDecision(sourceSessionDir, sourceSessionName, postPath, dst, filename, info, messageContent)
 	}
 
+	// Opt-in sender verification: when the originating pane can be determined,
+	// the filename sender must match the node that owns that pane.
+	if config.BoolVal(cfg.VerifySender, false) && info.From != "daemon" {
+		paneNode, ok := originatingPaneNode(sourceSessionDir, messageContent, knownNodes)
+		if ok {
+			policyInput.SenderVerified = true
+			policyInput.SenderMismatch = nodeaddr.Simple(paneNode) != senderSimpleName
+		}
+		if decision := planDeliveryPolicy(policyInput); decision.Action == deliveryActionDeadLetter {
+			dst := deadLetterDecisionDestination(sourceSessionDir, filename, decision)
+			notifySenderOfDeadLetter(cfg, decision, sourceSessionDir, contextID, senderSimpleName, filename, filepath.Base(dst))
+			log.Printf("postman: SECURITY: sender %q does not match originating pane node %q — dead-lettering %s\n", info.From, paneNode, filename)
+			emitDeliveryDecisionEvent(events, decision, info, filename)
+			return moveToDeadLetterForDecision(sourceSessionDir, sourceSessionName, postPath, dst, filename, info, messageContent)
+		}
+	}
+
+	// One-at-a-time handoff: leave the post for a later retry while the
+	// recipient still has unread mail. Checked before the flood guard so
+	// retries of a held message do not spend the sender's budget.
+	if cfg != nil && config.BoolVal(cfg.SerializePerNode, false) && info.From != "daemon" &&
+		holdForUnreadMail(filepath.Join(nodeInfo.SessionDir, "inbox", recipientSimpleName), cfg.SerializePerNodeTimeoutSeconds, time.Now()) {
+		log.Printf("postman: holding %s for %s until its inbox is read (serialize_per_node)\n", filename, info.To)
+		return ErrDeliveryHeld
+	}
+
+	// delivered is set once the post reaches the inbox; until then every
+	// budget slot or message id claimed below is released again, so mail
+	// that is rejected or held spends nothing and a later retry can deliver.
+	delivered := false
+
+	// Per-sender flood guard: dead-letter mail beyond the sender's budget.
+	if opts.RateLimited != nil && info.From != "daemon" {
+		policyInput.RateLimitChecked = true
+		policyInput.RateLimited = opts.RateLimited(senderFullName)
+		if !policyInput.RateLimited && opts.ReleaseRateLimit != nil {
+			defer func() {
+				if !delivered {
+					opts.ReleaseRateLimit(senderFullName)
+				}
+			}()
+		}
+		if decision := planDeliveryPolicy(policyInput); decision.Action == deliveryActionDeadLetter {
+			dst := deadLetterDecisionDestination(sourceSessionDir, filename, decision)
+			notifySenderOfDeadLetter(cfg, decision, sourceSessionDir, contextID, senderSimpleName, filename, filepath.Base(dst))
+			log.Printf("📨 postman: rate limited %s -> %s (moved to dead-letter/)\n", info.From, info.To)
+			emitDeliveryDecisionEvent(events, decision, info, filename)
+			return moveToDeadLetterForDecision(sourceSessionDir, sourceSessionName, postPath, dst, filename, info, messageContent)
+		}
+	}
+
+	// Per-session quota: dead-lettered while the session already had its
+	// quota of deliveries in the last hour.
+	if opts.SessionQuotaExceeded != nil && info.From != "daemon" {
+		policyInput.SessionQuotaChecked = true
+		policyInput.SessionQuotaExceeded = opts.SessionQuotaExceeded(sourceSessionName)
+		if !policyInput.SessionQuotaExceeded && opts.ReleaseSessionQuota != nil {
+			defer func() {
+				if !delivered {
+					opts.ReleaseSessionQuota(sourceSessionName)
+				}
+			}()
+		}
+		if decision := planDeliveryPolicy(policyInput); decision.Action == deliveryActionDeadLetter {
+			dst := deadLetterDecisionDestination(sourceSessionDir, filename, decision)
+			log.Printf("📨 postman: session quota exceeded in %s: %s -> %s (moved to dead-letter/)\n", sourceSessionName, info.From, info.To)
+			emitDeliveryDecisionEvent(events, decision, info, filename)
+			return moveToDeadLetterForDecision(sourceSessionDir, sourceSessionName, postPath, dst, filename, info, messageContent)
+		}
+	}
+
 	// Check routing permissions (DEFAULT DENY)
 	// IMPORTANT: sender="daemon" is always allowed (#172)
-	if info.From != "daemon" {
+	if info.From != "daemon" && !catchAll {
 		allowed := false
 		// Try adjacency lookup with both simple name and full name
 		// This supports both old-style (simple names) and new-style (session:node) adjacency configs
@@ -765,19 +1132,43 @@ func DeliverMessage(postPath string, contextID string, knownNodes map[string]dis
 		}
 		policyInput.RoutingChecked = true
 		policyInput.RoutingAllowed = allowed
+		edgeViolationMode := cfg.EffectiveEdgeViolationMode()
+		if !allowed && edgeViolationMode == config.EdgeViolationModeWarnOnly {
+			// edge_violation_mode = "warn_only": tell the sender, deliver anyway.
+			writeRoutingDeniedWarning(sourceSessionDir, contextID, info, senderSimpleName, senderFullName, adjacency, cfg)
+			log.Printf("📨 postman: edge violation %s -> %s delivered anyway (edge_violation_mode = warn_only)\n", info.From, info.To)
+			policyInput.RoutingAllowed = true
+		}
 		if decision := planDeliveryPolicy(policyInput); decision.Action == deliveryActionDeadLetter {
 			// Issue #80: Send warning message back to sender
-			if decision.SendRoutingWarning {
+			if decision.SendRoutingWarning && edgeViolationMode != config.EdgeViolationModeSilent {
 				writeRoutingDeniedWarning(sourceSessionDir, contextID, info, senderSimpleName, senderFullName, adjacency, cfg)
 			}
 
 			// Routing denied: move to dead-letter/ in source session
 			dst := deadLetterDecisionDestination(sourceSessionDir, filename, decision)
+			notifySenderOfDeadLetter(cfg, decision, sourceSessionDir, contextID, senderSimpleName, filename, filepath.Base(dst))
 			log.Printf("📨 postman: routing denied %s -> %s (moved to dead-letter/)\n", info.From, info.To)
 			// Issue #53: Notify dead-letter event
 			emitDeliveryDecisionEvent(events, decision, info, filename)
 			return moveToDeadLetterForDecision(sourceSessionDir, sourceSessionName, postPath, dst, filename, info, messageContent)
 		}
+
+		// Method-scoped edges: "@methods=" limits which envelope methods a hop carries.
+		if cfg != nil {
+			if methods, err := config.ParseEdgeMethods(cfg.Edges); err == nil && len(methods) > 0 {
+				method := messageMethod(messageContent)
+				policyInput.MethodChecked = true
+				policyInput.MethodAllowed = config.EdgePermitsMethod(methods, senderSimpleName, recipientSimpleName, method)
+				if decision := planDeliveryPolicy(policyInput); decision.Action == deliveryActionDeadLetter {
+					dst := deadLetterDecisionDestination(sourceSessionDir, filename, decision)
+					notifySenderOfDeadLetter(cfg, decision, sourceSessionDir, contextID, senderSimpleName, filename, filepath.Base(dst))
+					log.Printf("📨 postman: method %q not permitted on %s -> %s (moved to dead-letter/)\n", method, info.From, info.To)
+					emitDeliveryDecisionEvent(events, decision, info, filename)
+					return moveToDeadLetterForDecision(sourceSessionDir, sourceSessionName, postPath, dst, filename, info, messageContent)
+				}
+			}
+		}
 	}
 
 	// Check session enabled/disabled state
@@ -792,9 +1183,7 @@ func DeliverMessage(postPath string, contextID string, knownNodes map[string]dis
 		if decision := planDeliveryPolicy(policyInput); decision.Action == deliveryActionDeadLetter {
 			dst := deadLetterDecisionDestination(sourceSessionDir, filename, decision)
 			log.Printf("📨 postman: sender session %s disabled (moved to dead-letter/)\n", senderSessionName)
-			if decision.SendDeadLetterNotification {
-				sendDeadLetterNotification(sourceSessionDir, contextID, senderSimpleName, decision.DeadLetterReason, filename, filepath.Base(dst))
-			}
+			notifySenderOfDeadLetter(cfg, decision, sourceSessionDir, contextID, senderSimpleName, filename, filepath.Base(dst))
 			// Issue #53: Notify dead-letter event
 			emitDeliveryDecisionEvent(events, decision, info, filename)
 			return moveToDeadLetterForDecision(sourceSessionDir, sourceSessionName, postPath, dst, filename, info, messageContent)
@@ -806,9 +1195,7 @@ func DeliverMessage(postPath string, contextID string, knownNodes map[string]dis
 		if decision := planDeliveryPolicy(policyInput); decision.Action == deliveryActionDeadLetter {
 			dst := deadLetterDecisionDestination(sourceSessionDir, filename, decision)
 			log.Printf("📨 postman: recipient session %s disabled (moved to dead-letter/)\n", recipientSessionName)
-			if decision.SendDeadLetterNotification {
-				sendDeadLetterNotification(sourceSessionDir, contextID, senderSimpleName, decision.DeadLetterReason, filename, filepath.Base(dst))
-			}
+			notifySenderOfDeadLetter(cfg, decision, sourceSessionDir, contextID, senderSimpleName, filename, filepath.Base(dst))
 			// Issue #53: Notify dead-letter event
 			emitDeliveryDecisionEvent(events, decision, info, filename)
 			return moveToDeadLetterForDecision(sourceSessionDir, sourceSessionName, postPath, dst, filename, info, messageContent)
@@ -819,6 +1206,29 @@ func DeliverMessage(postPath string, contextID string, knownNodes map[string]dis
 	recipientSessionDir := nodeInfo.SessionDir
 	recipientInbox := filepath.Join(recipientSessionDir, "inbox", recipientSimpleName)
 
+	// Idempotency: a message id already delivered to this recipient, e.g. a
+	// producer retry or a re-posted file, is parked in read/ instead.
+	messageID := ""
+	if info.From != "daemon" {
+		messageID = envelopeMessageID(messageContent)
+	}
+	if messageID != "" && opts.DeliveredIDs != nil {
+		recipient := info.To
+		claimed, err := opts.DeliveredIDs.Claim(sourceSessionDir, messageID, recipient)
+		switch {
+		case err != nil:
+			log.Printf("postman: WARNING: duplicate check skipped for %s: %v\n", filename, err)
+		case !claimed:
+			return parkDuplicateMessageID(sourceSessionDir, postPath, filename, messageID, info, events)
+		default:
+			defer func() {
+				if !delivered {
+					opts.DeliveredIDs.Release(sourceSessionDir, messageID, recipient)
+				}
+			}()
+		}
+	}
+
 	// Enforce inbox queue cap: dead-letter overflow beyond inboxQueueCap.
 	// Protects agent-session nodes from unbounded queue growth (#agent-session).
 	if count, countErr := countInboxMessages(recipientInbox); countErr == nil {
@@ -826,19 +1236,49 @@ func DeliverMessage(postPath string, contextID string, knownNodes map[string]dis
 		policyInput.QueueCount = count
 		if decision := planDeliveryPolicy(policyInput); decision.Action == deliveryActionDeadLetter {
 			dst := deadLetterDecisionDestination(sourceSessionDir, filename, decision)
-			if decision.SendDeadLetterNotification {
-				sendDeadLetterNotification(sourceSessionDir, contextID, senderSimpleName, decision.DeadLetterReason, filename, filepath.Base(dst))
-			}
+			notifySenderOfDeadLetter(cfg, decision, sourceSessionDir, contextID, senderSimpleName, filename, filepath.Base(dst))
 			log.Printf("postman: inbox queue full for %s (cap=%d, current=%d): dead-lettering %s\n", info.To, inboxQueueCap, count, filename)
 			emitDeliveryDecisionEvent(events, decision, info, filename)
 			return moveToDeadLetterForDecision(sourceSessionDir, sourceSessionName, postPath, dst, filename, info, messageContent)
 		}
 	}
 
+	if cfg.KeepsPostArchive() {
+		// Archive first so the audit copy exists before the post leaves post/.
+		if _, err := store.ArchivePostCopy(postPath, []byte(messageContent)); err != nil {
+			return err
+		}
+	}
+	if info.From != "daemon" {
+		if filtered := applyContentFilter(cfg, filename, messageContent); filtered != messageContent {
+			if err := os.WriteFile(postPath, []byte(filtered), 0o600); err != nil {
+				return fmt.Errorf("writing filtered post: %w", err)
+			}
+			messageContent = filtered
+		}
+	}
 	dst, err := store.DeliverPostToInbox(postPath, recipientInbox, filename)
 	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			// The sender recalled the post after we read it; nothing to deliver.
+			log.Printf("postman: %s left post/ before delivery (recalled); skipping\n", filename)
+			return nil
+		}
 		return err
 	}
+	delivered = true
+	// Journal the delivery with the content it carried: a post/ file that
+	// later reappears with the same name and content is a replay.
+	if err := store.AppendDeliveryLog(sourceSessionDir, store.DeliveryLogEntry{
+		Filename:    filename,
+		Recipient:   info.To,
+		MessageID:   messageID,
+		ContentHash: contentHash,
+		Outcome:     store.DeliveryOutcomeDelivered,
+		At:          time.Now(),
+	}); err != nil {
+		log.Printf("postman: WARNING: delivery log append failed for %s: %v\n", filename, err)
+	}
 	resultFields := deliveryTraceFieldsFromContent(filename, shadowRelativePath(recipientSessionDir, dst), recipientSessionName, contextID, messageContent, info)
 	resultFields.DeliveryAttempt = 1
 	resultFields.Result = "delivered"
@@ -883,9 +1323,90 @@ func DeliverMessage(postPath string, contextID string, knownNodes map[string]dis
 	// Send tmux notification to the recipient pane
 	// Issue #84: Get liveness map for talks_to_line filtering
 	livenessMap := idleTracker.GetLivenessMap()
-	sendDeliveryNotification(controlplane.TargetForNode(info.To, nodeInfo), cfg, adjacency, knownNodes, contextID, info.To, info.From, sourceSessionName, postPath, livenessMap)
-	// NOTE: Error already logged by SendToPane (WARNING level)
-	// Continue with delivery (notification failure does not fail delivery)
+	if opts.Muted != nil && opts.Muted(recipientFullName) {
+		log.Printf("postman: %s is muted; delivered %s without a pane notification\n", recipientFullName, filename)
+	} else if target := controlplane.TargetForNode(info.To, nodeInfo); envelopeRequestsConfirm(messageContent) && target.Hand.Kind == controlplane.HandKindTmux && cfg.GetNodeConfig(nodeaddr.Simple(info.To)).Transport != config.NodeTransportFifo {
+		// confirm: true — the pane must echo the notification. The check runs
+		// later, off the delivery path; an unconfirmed message is pulled back
+		// out of the inbox then and reported as a correction.
+		recipient := *info
+		unconfirmedInput := policyInput
+		resend := func() string {
+			notificationMsg, _ := sendDeliveryNotification(target, cfg, adjacency, knownNodes, contextID, recipient.To, recipient.From, sourceSessionName, postPath, livenessMap, nil)
+			return deliveryEchoMarker(notificationMsg, filename)
+		}
+		notificationMsg, _ := sendDeliveryNotification(target, cfg, adjacency, knownNodes, contextID, info.To, info.From, sourceSessionName, postPath, livenessMap, events)
+		marker := deliveryEchoMarker(notificationMsg, filename)
+		deliveryConfirmation{
+			paneID:    target.Hand.Address,
+			inboxPath: dst,
+			resend:    resend,
+			schedule:  opts.scheduleConfirm,
+			unconfirmed: func() {
+				unconfirmedInput.DeliveryUnconfirmed = true
+				decision := planDeliveryPolicy(unconfirmedInput)
+				deadDst := deadLetterDecisionDestination(sourceSessionDir, filename, decision)
+				log.Printf("postman: pane %s never showed the notification for %s after a resend: dead-lettering %s\n", target.Hand.Address, recipient.To, filename)
+				if err := moveToDeadLetterForDecision(sourceSessionDir, sourceSessionName, dst, deadDst, filename, &recipient, messageContent); err != nil {
+					if !errors.Is(err, os.ErrNotExist) {
+						log.Printf("postman: WARNING: failed to dead-letter unconfirmed %s: %v\n", filename, err)
+					}
+					return
+				}
+				notifySenderOfDeadLetter(cfg, decision, sourceSessionDir, contextID, senderSimpleName, filename, filepath.Base(deadDst))
+				if err := store.AppendDeliveryLog(sourceSessionDir, store.DeliveryLogEntry{
+					Filename:    filename,
+					Recipient:   recipient.To,
+					MessageID:   messageID,
+					ContentHash: contentHash,
+					Outcome:     store.DeliveryOutcomeUnconfirmed,
+					At:          time.Now(),
+				}); err != nil {
+					log.Printf("postman: WARNING: delivery log append failed for %s: %v\n", filename, err)
+				}
+				if opts.DeliveredIDs != nil {
+					opts.DeliveredIDs.Release(sourceSessionDir, messageID, recipient.To)
+				}
+				opts.correct(deliveryDecisionEvent(decision, &recipient, filename))
+			},
+		}.start(marker)
+	} else if _, err := sendDeliveryNotification(target, cfg, adjacency, knownNodes, contextID, info.To, info.From, sourceSessionName, postPath, livenessMap, events); errors.Is(err, controlplane.ErrFifoNoReader) {
+		// Nothing is reading the recipient's FIFO, so nobody will ever be
+		// told about the message: pull it back out of the inbox.
+		fifoInput := policyInput
+		fifoInput.RecipientFifoNoReader = true
+		decision := planDeliveryPolicy(fifoInput)
+		deadDst := deadLetterDecisionDestination(sourceSessionDir, filename, decision)
+		log.Printf("postman: no reader on the FIFO for %s: dead-lettering %s\n", info.To, filename)
+		if err := moveToDeadLetterForDecision(sourceSessionDir, sourceSessionName, dst, deadDst, filename, info, messageContent); err != nil {
+			return fmt.Errorf("dead-lettering %s: %w", filename, err)
+		}
+		notifySenderOfDeadLetter(cfg, decision, sourceSessionDir, contextID, senderSimpleName, filename, filepath.Base(deadDst))
+		if err := store.AppendDeliveryLog(sourceSessionDir, store.DeliveryLogEntry{
+			Filename:    filename,
+			Recipient:   info.To,
+			MessageID:   messageID,
+			ContentHash: contentHash,
+			Outcome:     store.DeliveryOutcomeUnconfirmed,
+			At:          time.Now(),
+		}); err != nil {
+			log.Printf("postman: WARNING: delivery log append failed for %s: %v\n", filename, err)
+		}
+		// Not delivered after all: the deferred releases hand back the
+		// message id and budget slots.
+		delivered = false
+		// A circuit-open event may already hold the daemon's one slot for
+		// this message; the correction hook still gets the dead-letter then.
+		event := deliveryDecisionEvent(decision, info, filename)
+		select {
+		case events <- event:
+		default:
+			opts.correct(event)
+		}
+		return nil
+	}
+	// NOTE: Other notification errors are already logged (WARNING level);
+	// they do not fail the delivery.
 
 	// Update activity timestamps for idle detection (Issue #55)
 	// NOTE: Exclude daemon system messages from activity tracking.
@@ -900,8 +1421,14 @@ func DeliverMessage(postPath string, contextID string, knownNodes map[string]dis
 	}
 
 	// Delivery latency logging (#179): parse message timestamp and log age.
-	if msgTime, err := time.Parse("20060102-150405", info.Timestamp); err == nil {
-		age := time.Since(msgTime)
+	// A skewed sender clock would make the age negative, so fall back to
+	// the time the daemon picked the message up.
+	if skewed {
+		log.Printf("📬 postman: delivered %s -> %s (age: %s since receipt)\n", filename, info.To, time.Since(receivedAt).Truncate(time.Millisecond))
+		// Sent only after delivery: the daemon buffers one event per
+		// message, and dead-letter paths need that slot.
+		emitClockSkewEvent(events, info, filename, skew)
+	} else if age, ok := messageAge(info, cfg, time.Now()); ok {
 		log.Printf("📬 postman: delivered %s -> %s (age: %s)\n", filename, info.To, age.Truncate(time.Second))
 	} else {
 		log.Printf("📬 postman: delivered %s -> %s\n", filename, info.To)
@@ -909,7 +1436,10 @@ func DeliverMessage(postPath string, contextID string, knownNodes map[string]dis
 	return nil
 }
 
-func sendDeliveryNotification(target controlplane.Target, cfg *config.Config, adjacency map[string][]string, knownNodes map[string]discovery.NodeInfo, contextID, recipient, sender, sourceSessionName, notificationPath string, livenessMap map[string]bool) {
+// sendDeliveryNotification notifies the recipient's hand and returns the
+// notification text, or "" when no adapter could be selected, together with
+// the hand's delivery error. A circuit-open skip is not an error.
+func sendDeliveryNotification(target controlplane.Target, cfg *config.Config, adjacency map[string][]string, knownNodes map[string]discovery.NodeInfo, contextID, recipient, sender, sourceSessionName, notificationPath string, livenessMap map[string]bool, events chan<- DaemonEvent) (string, error) {
 	recipientSimpleName := nodeaddr.Simple(recipient)
 	notificationMsg := notification.BuildNotification(cfg, adjacency, knownNodes, contextID, recipient, sender, sourceSessionName, notificationPath, livenessMap)
 	nodeEnterDelay := cfg.GetNodeConfig(recipientSimpleName).EnterDelay
@@ -917,15 +1447,20 @@ func sendDeliveryNotification(target controlplane.Target, cfg *config.Config, ad
 	if nodeEnterDelay != 0 {
 		enterDelay = time.Duration(nodeEnterDelay * float64(time.Second))
 	}
+	preEnterDelay := time.Duration(cfg.GetNodeConfig(recipientSimpleName).PreEnterDelay * float64(time.Second))
 	tmuxTimeout := time.Duration(cfg.TmuxTimeout * float64(time.Second))
 	verifyDelay := time.Duration(cfg.EnterVerifyDelay * float64(time.Second))
+	if nodeConfig := cfg.GetNodeConfig(recipientSimpleName); nodeConfig.Transport == config.NodeTransportFifo {
+		target.Hand = controlplane.HandAttachment{Kind: controlplane.HandKindFifo, Address: nodeConfig.FifoPath}
+	}
 	adapter, err := controlplane.DefaultHandAdapter(target)
 	if err != nil {
 		log.Printf("postman: WARNING: failed to select hand adapter for %s: %v\n", target.RunID, err)
-		return
+		return "", err
 	}
 	delivery := controlplane.PaneDelivery{
 		Content:        notificationMsg,
+		PreEnterDelay:  preEnterDelay,
 		EnterDelay:     enterDelay,
 		TmuxTimeout:    tmuxTimeout,
 		EnterCount:     cfg.GetNodeConfig(recipientSimpleName).EnterCount,
@@ -933,15 +1468,28 @@ func sendDeliveryNotification(target controlplane.Target, cfg *config.Config, ad
 		VerifyDelay:    verifyDelay,
 		MaxRetries:     cfg.EnterRetryMax,
 	}
-	log.Printf("postman: notification: attempting pane delivery to %s (pane=%s session=%s msg=%s)\n", recipient, target.Hand.Address, target.SessionName, filepath.Base(notificationPath))
-	deliverNotificationWithRetry(adapter, target, delivery, recipient, knownNodes, filepath.Base(notificationPath))
+	cooldown := time.Duration(cfg.NotificationCircuitCooldownSeconds * float64(time.Second))
+	var notifyErr error
+	defaultNotificationBreaker.notify(target.RunID, cfg.NotificationFailureThreshold, cooldown, time.Now(), events, func() bool {
+		log.Printf("postman: notification: attempting pane delivery to %s (pane=%s session=%s msg=%s)\n", recipient, target.Hand.Address, target.SessionName, filepath.Base(notificationPath))
+		notifyErr = deliverNotificationWithRetry(adapter, target, delivery, recipient, knownNodes, filepath.Base(notificationPath))
+		return notifyErr == nil
+	})
+	return notificationMsg, notifyErr
 }
 
 // deliverNotificationWithRetry attempts adapter.Deliver and, on failure, retries
 // once using a refreshed pane ID from knownNodes when available. Extracted for
 // testability: callers can inject a TmuxHandAdapter with a mock SendToPane.
-func deliverNotificationWithRetry(adapter controlplane.HandAdapter, target controlplane.Target, delivery controlplane.PaneDelivery, recipient string, knownNodes map[string]discovery.NodeInfo, filename string) {
+// It returns the last delivery error, or nil once the notification landed.
+func deliverNotificationWithRetry(adapter controlplane.HandAdapter, target controlplane.Target, delivery controlplane.PaneDelivery, recipient string, knownNodes map[string]discovery.NodeInfo, filename string) error {
 	if err := adapter.Deliver(target, delivery); err != nil {
+		if target.Hand.Kind != controlplane.HandKindTmux {
+			// Non-pane hands already waited out their own timeout; a pane
+			// refresh cannot help them.
+			log.Printf("postman: WARNING: %s notification failed: node=%s address=%s session=%s msg=%s err=%v\n", target.Hand.Kind, recipient, target.Hand.Address, target.SessionName, filename, err)
+			return err
+		}
 		// Retry once: look up a potentially refreshed PaneID from knownNodes (the
 		// daemon's discovery loop may have updated it since goroutine launch).
 		retryTarget := target
@@ -952,10 +1500,11 @@ func deliverNotificationWithRetry(adapter controlplane.HandAdapter, target contr
 		}
 		if retryErr := adapter.Deliver(retryTarget, delivery); retryErr != nil {
 			log.Printf("postman: WARNING: pane notification failed: node=%s pane=%s session=%s msg=%s err=%v\n", recipient, retryTarget.Hand.Address, retryTarget.SessionName, filename, retryErr)
-			return
+			return retryErr
 		}
 	}
 	log.Printf("postman: notification: pane delivery succeeded for %s (pane=%s msg=%s)\n", recipient, target.Hand.Address, filename)
+	return nil
 }
 
 func DeliverSystemMessageDirect(filename string, nodeInfo discovery.NodeInfo, recipient, sender, contextID, content string, cfg *config.Config, adjacency map[string][]string, knownNodes map[string]discovery.NodeInfo, livenessMap map[string]bool) error {
@@ -996,11 +1545,37 @@ func DeliverSystemMessageDirectResultToTarget(filename string, target controlpla
 	}
 
 	notificationPath := target.PostPath(filename)
-	sendDeliveryNotification(target, cfg, adjacency, knownNodes, contextID, target.ActorID, sender, target.SessionName, notificationPath, livenessMap)
+	sendDeliveryNotification(target, cfg, adjacency, knownNodes, contextID, target.ActorID, sender, target.SessionName, notificationPath, livenessMap, nil)
 	log.Printf("📬 postman: delivered %s -> %s\n", filename, target.ActorID)
 	return result, nil
 }
 
+// holdForUnreadMail reports whether the inbox still has unread mail younger
+// than timeoutSeconds; timeoutSeconds <= 0 holds for as long as mail is unread.
+func holdForUnreadMail(inboxDir string, timeoutSeconds float64, now time.Time) bool {
+	entries, err := os.ReadDir(inboxDir)
+	if err != nil {
+		return false
+	}
+	var oldest time.Time
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+			continue
+		}
+		fi, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if oldest.IsZero() || fi.ModTime().Before(oldest) {
+			oldest = fi.ModTime()
+		}
+	}
+	if oldest.IsZero() {
+		return false
+	}
+	return timeoutSeconds <= 0 || now.Sub(oldest) < time.Duration(timeoutSeconds*float64(time.Second))
+}
+
 // countInboxMessages returns the number of .md files in an inbox directory.
 // Returns 0, nil if the directory does not exist (empty inbox is not an error).
 func countInboxMessages(inboxDir string) (int, error) {
@@ -1011,12 +1586,40 @@ func shadowRelativePath(sessionDir, fullPath string) string {
 	return store.ShadowRelativePath(sessionDir, fullPath)
 }
 
+// notifySenderOfDeadLetter writes the sender note for a dead-letter decision.
+// Decisions flagged SendDeadLetterNotification always notify. With
+// dead_letter_feedback the other reasons notify too, except where the sender
+// is untrusted (parse error, forged sender), a note would amplify a flood
+// (rate limited), or the routing warning already told the sender.
+func notifySenderOfDeadLetter(cfg *config.Config, decision deliveryDecision, sessionDir, contextID, senderNode, originalFilename, deadLetterBasename string) {
+	if !decision.SendDeadLetterNotification {
+		if cfg == nil || !config.BoolVal(cfg.DeadLetterFeedback, false) || decision.SendRoutingWarning {
+			return
+		}
+		switch decision.DeadLetterSuffix {
+		case dlSuffixParseError, dlSuffixForgedSender, dlSuffixSenderMismatch, dlSuffixRateLimited, dlSuffixSessionQuota:
+			return
+		}
+	}
+	reason := decision.DeadLetterReason
+	if reason == "" {
+		reason = decision.EventReason
+	}
+	writeDeadLetterNotification(cfg, sessionDir, contextID, senderNode, reason, originalFilename, deadLetterBasename)
+}
+
 // sendDeadLetterNotification writes a dead-letter notification directly to the
 // sender's inbox. Bypasses post/ to avoid re-triggering the daemon delivery loop.
 // Pattern follows the routing-denied notification at DeliverMessage:162-175.
 // Issue #208: Extended with dead-letter path and recovery guidance.
 // deadLetterBasename is the actual basename of the dead-letter file (after rename).
 func sendDeadLetterNotification(sessionDir, contextID, senderNode, reason, originalFilename, deadLetterBasename string) {
+	writeDeadLetterNotification(nil, sessionDir, contextID, senderNode, reason, originalFilename, deadLetterBasename)
+}
+
+// writeDeadLetterNotification renders dead_letter_feedback_template when set,
+// otherwise the built-in notification.
+func writeDeadLetterNotification(cfg *config.Config, sessionDir, contextID, senderNode, reason, originalFilename, deadLetterBasename string) {
 	senderSimpleName := nodeaddr.Simple(senderNode)
 	senderInbox := filepath.Join(sessionDir, "inbox", senderSimpleName)
 	if mkErr := os.MkdirAll(senderInbox, 0o700); mkErr != nil {
@@ -1030,6 +1633,26 @@ func sendDeadLetterNotification(sessionDir, contextID, senderNode, reason, origi
 	// Build dead-letter file path for reference
 	deadLetterPath := filepath.Join(sessionDir, "dead-letter", deadLetterBasename)
 
+	if cfg != nil && cfg.DeadLetterFeedbackTemplate != "" {
+		vars := map[string]string{
+			"context_id":        contextID,
+			"node":              senderSimpleName,
+			"iso_timestamp":     now.Format(time.RFC3339),
+			"timestamp":         now.Format(time.RFC3339),
+			"reason":            reason,
+			"original_filename": originalFilename,
+			"dead_letter_path":  deadLetterPath,
+			"session_dir":       sessionDir,
+			"filename":          filename,
+		}
+		timeout := time.Duration(cfg.TmuxTimeout * float64(time.Second))
+		content := template.ExpandTemplate(cfg.DeadLetterFeedbackTemplate, vars, timeout, cfg.AllowShellForDeadLetterFeedbackTemplate(), cfg.TemplateOptions())
+		if writeErr := os.WriteFile(filepath.Join(senderInbox, filename), []byte(content), 0o600); writeErr != nil {
+			log.Printf("postman: WARNING: failed to write dead-letter notification for %s: %v\n", senderNode, writeErr)
+		}
+		return
+	}
+
 	content := fmt.Sprintf(
 		"---\nparams:\n  contextId: %s\n  from: postman\n  to: %s\n  timestamp: %s\n  messageType: dead_letter_notification\n---\n\n## Dead-letter Notification\n\nYour message %q was not delivered.\nReason: %s\n\nDead-letter path: %s\n\nRecovery: inspect the dead-letter file above, then send a corrected message with the heredoc-explicit command and quoted delimiter:\ntmux-a2a-postman send-heredoc --to <node> <<'POSTMAN_BODY'\n<corrected message>\nPOSTMAN_BODY\n",
 		contextID,
@@ -1057,6 +1680,15 @@ func parseEnvelopeFromTo(content string) (from, to string, err error) {
 	return metadata.From, metadata.To, nil
 }
 
+// messageMethod returns the envelope method of a message, defaulting to
+// DefaultMessageMethod when the envelope omits it or cannot be parsed.
+func messageMethod(content string) string {
+	if metadata, err := ParseEnvelopeMetadata(content); err == nil && metadata.Method != "" {
+		return metadata.Method
+	}
+	return DefaultMessageMethod
+}
+
 // ParseEnvelopeMetadata extracts selected fields from the params block inside
 // a message frontmatter envelope.
 func ParseEnvelopeMetadata(content string) (EnvelopeMetadata, error) {
@@ -1064,9 +1696,10 @@ func ParseEnvelopeMetadata(content string) (EnvelopeMetadata, error) {
 }
 
 // DrainStalePost moves stale messages from post/ to dead-letter/ with ttl-expired suffix.
-// A message is stale if its file modification time exceeds ttlSeconds.
-// Returns the number of drained messages. Skips if ttlSeconds <= 0.
-func DrainStalePost(sessionDir string, ttlSeconds float64) int {
+// A message is stale if its file modification time exceeds message_ttl_seconds.
+// Returns the number of drained messages. Skips if the TTL is <= 0.
+func DrainStalePost(sessionDir string, cfg *config.Config) int {
+	ttlSeconds := cfg.MessageTTLSeconds
 	if ttlSeconds <= 0 {
 		return 0
 	}
@@ -1106,6 +1739,12 @@ func DrainStalePost(sessionDir string, ttlSeconds float64) int {
 			}
 			if err := moveToDeadLetterWithProjection(sessionDir, filepath.Base(sessionDir), src, dst, entry.Name(), from, to, string(content)); err == nil {
 				log.Printf("postman: drained stale post/ message: %s (TTL expired)\n", entry.Name())
+				if from != "" && from != "postman" && from != "daemon" {
+					notifySenderOfDeadLetter(cfg, deliveryDecision{
+						DeadLetterSuffix: DlSuffixTTLExpired,
+						DeadLetterReason: deadLetterReasonTTLExpired,
+					}, sessionDir, filepath.Base(filepath.Dir(sessionDir)), from, entry.Name(), filepath.Base(dst))
+				}
 				count++
 			}
 		}
@@ -1113,6 +1752,24 @@ func DrainStalePost(sessionDir string, ttlSeconds float64) int {
 	return count
 }
 
+// PendingPostPaths lists the .md files in sessionDir/post in filename
+// order, the order the daemon delivers them in.
+func PendingPostPaths(sessionDir string) []string {
+	postDir := filepath.Join(sessionDir, "post")
+	entries, err := os.ReadDir(postDir)
+	if err != nil {
+		return nil
+	}
+	var paths []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+			continue
+		}
+		paths = append(paths, filepath.Join(postDir, entry.Name()))
+	}
+	return paths
+}
+
 // ScanInboxMessages scans the inbox directory and returns a list of MessageInfo.
 func ScanInboxMessages(inboxPath string) []MessageInfo {
 	var messages []MessageInfo
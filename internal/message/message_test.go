@@ -3,11 +3,13 @@ package message
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 
@@ -17,6 +19,9 @@ import (
 	"github.com/i9wa4/tmux-a2a-postman/internal/idle"
 	"github.com/i9wa4/tmux-a2a-postman/internal/journal"
 	"github.com/i9wa4/tmux-a2a-postman/internal/projection"
+	"github.com/i9wa4/tmux-a2a-postman/internal/runtimecontext"
+	"github.com/i9wa4/tmux-a2a-postman/internal/store"
+	"github.com/i9wa4/tmux-a2a-postman/internal/tmuxtest"
 )
 
 func TestParseMessageFilename(t *testing.T) {
@@ -83,6 +88,46 @@ func TestParseMessageFilename(t *testing.T) {
 	}
 }
 
+func TestParseMessageTime_CustomFilenameTimestampFormat(t *testing.T) {
+	now := time.Date(2026, 2, 1, 5, 10, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		filename string
+		format   string
+		wantAge  time.Duration
+	}{
+		{name: "rfc3339", filename: "2026-02-01T05:00:00Z-from-orchestrator-to-worker.md", format: time.RFC3339, wantAge: 10 * time.Minute},
+		{name: "unix", filename: fmt.Sprintf("%d-from-orchestrator-to-worker.md", now.Add(-5*time.Minute).Unix()), format: "unix", wantAge: 5 * time.Minute},
+		{name: "default", filename: now.Add(-time.Hour).In(time.Local).Format("20060102-150405") + "-from-orchestrator-to-worker.md", wantAge: time.Hour},
+		{name: "default layout under custom format", filename: now.Add(-2*time.Hour).In(time.Local).Format("20060102-150405") + "-from-orchestrator-to-worker.md", format: time.RFC3339, wantAge: 2 * time.Hour},
+		{name: "default layout under unix format", filename: now.Add(-3*time.Hour).In(time.Local).Format("20060102-150405") + "-from-orchestrator-to-worker.md", format: "unix", wantAge: 3 * time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := ParseMessageFilename(tt.filename)
+			if err != nil {
+				t.Fatalf("ParseMessageFilename(%q): %v", tt.filename, err)
+			}
+			cfg := &config.Config{FilenameTimestampFormat: tt.format}
+			age, ok := messageAge(info, cfg, now)
+			if !ok {
+				t.Fatalf("messageAge(%q) not ok", info.Timestamp)
+			}
+			if age != tt.wantAge {
+				t.Fatalf("messageAge(%q) = %s, want %s", info.Timestamp, age, tt.wantAge)
+			}
+		})
+	}
+
+	info, err := ParseMessageFilename("2026-02-01T05:00:00Z-from-orchestrator-to-worker.md")
+	if err != nil {
+		t.Fatalf("ParseMessageFilename: %v", err)
+	}
+	if _, ok := messageAge(info, &config.Config{}, now); ok {
+		t.Fatal("default layout parsed an RFC3339 timestamp, want failure")
+	}
+}
+
 func TestParseMessageFilename_Invalid(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -240,6 +285,253 @@ func TestDeliverMessage(t *testing.T) {
 	}
 }
 
+func TestDeliverMessage_FifoWithoutReaderDeadLetters(t *testing.T) {
+	sessionDir := filepath.Join(t.TempDir(), "test")
+	if err := config.CreateSessionDirs(sessionDir); err != nil {
+		t.Fatalf("config.CreateSessionDirs failed: %v", err)
+	}
+	recipientInbox := filepath.Join(sessionDir, "inbox", "worker")
+	if err := os.MkdirAll(recipientInbox, 0o755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	fifoPath := filepath.Join(t.TempDir(), "worker.fifo")
+	if err := syscall.Mkfifo(fifoPath, 0o600); err != nil {
+		t.Fatalf("Mkfifo failed: %v", err)
+	}
+
+	filename := "20260201-030000-from-orchestrator-to-worker.md"
+	postPath := filepath.Join(sessionDir, "post", filename)
+	content := "---\nparams:\n  contextId: test-ctx\n  from: orchestrator\n  to: worker\n  timestamp: 2026-02-01T03:00:00Z\n---\n\ntest message\n"
+	if err := os.WriteFile(postPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	nodes := map[string]discovery.NodeInfo{
+		"test:worker":       {PaneID: "%1", SessionName: "test", SessionDir: sessionDir},
+		"test:orchestrator": {PaneID: "%2", SessionName: "test", SessionDir: sessionDir},
+	}
+	adjacency := map[string][]string{
+		"orchestrator": {"worker"},
+		"worker":       {"orchestrator"},
+	}
+	cfg := &config.Config{
+		TmuxTimeout: 0.1,
+		Nodes: map[string]config.NodeConfig{
+			"worker": {Transport: config.NodeTransportFifo, FifoPath: fifoPath},
+		},
+	}
+	events := make(chan DaemonEvent, 1)
+	if err := DeliverMessage(postPath, "test-ctx", nodes, adjacency, cfg, func(string) bool { return true }, events, idle.NewIdleTracker(), ""); err != nil {
+		t.Fatalf("DeliverMessage failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(recipientInbox, filename)); !os.IsNotExist(err) {
+		t.Fatalf("message left in inbox with no FIFO reader: %v", err)
+	}
+	deadPath := filepath.Join(sessionDir, "dead-letter", "20260201-030000-from-orchestrator-to-worker-dl-fifo-no-reader.md")
+	if _, err := os.Stat(deadPath); err != nil {
+		t.Fatalf("message not dead-lettered: %v", err)
+	}
+	select {
+	case event := <-events:
+		if got := event.Details["failure_reason"]; got != deadLetterReasonFifoNoReader {
+			t.Fatalf("failure_reason = %v, want %q", got, deadLetterReasonFifoNoReader)
+		}
+	default:
+		t.Fatal("no dead-letter event emitted")
+	}
+}
+
+func TestDeliverMessage_PostRetentionCopyArchivesPost(t *testing.T) {
+	sessionDir := filepath.Join(t.TempDir(), "test")
+	if err := config.CreateSessionDirs(sessionDir); err != nil {
+		t.Fatalf("config.CreateSessionDirs failed: %v", err)
+	}
+	recipientInbox := filepath.Join(sessionDir, "inbox", "worker")
+	filename := "20260201-030000-from-orchestrator-to-worker.md"
+	postPath := filepath.Join(sessionDir, "post", filename)
+	content := "---\nparams:\n  contextId: test-ctx\n  from: orchestrator\n  to: worker\n  timestamp: 2026-02-01T03:00:00Z\n---\n\naudited message\n"
+	if err := os.WriteFile(postPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	nodes := map[string]discovery.NodeInfo{
+		"test:worker":       {PaneID: "%1", SessionName: "test", SessionDir: sessionDir},
+		"test:orchestrator": {PaneID: "%2", SessionName: "test", SessionDir: sessionDir},
+	}
+	adjacency := map[string][]string{
+		"orchestrator": {"worker"},
+		"worker":       {"orchestrator"},
+	}
+	cfg := &config.Config{
+		EnterDelay:    0.1,
+		TmuxTimeout:   1.0,
+		PostRetention: config.PostRetentionCopy,
+	}
+	if err := DeliverMessage(postPath, "test-ctx", nodes, adjacency, cfg, func(string) bool { return true }, nil, idle.NewIdleTracker(), ""); err != nil {
+		t.Fatalf("DeliverMessage failed: %v", err)
+	}
+
+	if got, err := os.ReadFile(filepath.Join(recipientInbox, filename)); err != nil || string(got) != content {
+		t.Errorf("inbox copy = %q, %v; want delivered content", got, err)
+	}
+	if got, err := os.ReadFile(filepath.Join(sessionDir, "post", "archive", filename)); err != nil || string(got) != content {
+		t.Errorf("post/archive copy = %q, %v; want original content", got, err)
+	}
+	pending, err := store.ListPendingPosts([]string{sessionDir})
+	if err != nil {
+		t.Fatalf("ListPendingPosts: %v", err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("pending posts after copy delivery = %+v, want none", pending)
+	}
+	if _, ok := store.PostFromPath(filepath.Join(sessionDir, "post", "archive", filename)); ok {
+		t.Error("archived copy is treated as a live post")
+	}
+
+	// A duplicate watcher event for the original path must not re-deliver.
+	if err := os.Remove(filepath.Join(recipientInbox, filename)); err != nil {
+		t.Fatalf("Remove inbox copy: %v", err)
+	}
+	if err := DeliverMessage(postPath, "test-ctx", nodes, adjacency, cfg, func(string) bool { return true }, nil, idle.NewIdleTracker(), ""); err != nil {
+		t.Fatalf("second DeliverMessage failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(recipientInbox, filename)); !os.IsNotExist(err) {
+		t.Errorf("message re-delivered from archive: stat err = %v", err)
+	}
+}
+
+func TestDeliverMessage_MethodScopedEdge(t *testing.T) {
+	sessionDir := filepath.Join(t.TempDir(), "test")
+	if err := config.CreateSessionDirs(sessionDir); err != nil {
+		t.Fatalf("config.CreateSessionDirs failed: %v", err)
+	}
+	nodes := map[string]discovery.NodeInfo{
+		"test:worker":       {PaneID: "%1", SessionName: "test", SessionDir: sessionDir},
+		"test:orchestrator": {PaneID: "%2", SessionName: "test", SessionDir: sessionDir},
+	}
+	cfg := &config.Config{
+		EnterDelay:  0.1,
+		TmuxTimeout: 1.0,
+		Edges:       []string{"orchestrator --- worker @methods=task/assign"},
+	}
+	adjacency, err := config.ParseEdges(cfg.Edges)
+	if err != nil {
+		t.Fatalf("ParseEdges: %v", err)
+	}
+
+	deliver := func(filename, method string) {
+		t.Helper()
+		content := "---\nparams:\n  contextId: test-ctx\n  from: orchestrator\n  to: worker\n  method: " + method + "\n  timestamp: 2026-02-01T03:00:00Z\n---\n\nbody\n"
+		postPath := filepath.Join(sessionDir, "post", filename)
+		if err := os.WriteFile(postPath, []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		if err := DeliverMessage(postPath, "test-ctx", nodes, adjacency, cfg, func(string) bool { return true }, nil, idle.NewIdleTracker(), ""); err != nil {
+			t.Fatalf("DeliverMessage(%s) failed: %v", method, err)
+		}
+	}
+
+	permitted := "20260201-030000-from-orchestrator-to-worker.md"
+	deliver(permitted, "task/assign")
+	if _, err := os.Stat(filepath.Join(sessionDir, "inbox", "worker", permitted)); err != nil {
+		t.Errorf("task/assign not delivered: %v", err)
+	}
+
+	blocked := "20260201-030100-from-orchestrator-to-worker.md"
+	deliver(blocked, "chat/message")
+	if _, err := os.Stat(filepath.Join(sessionDir, "inbox", "worker", blocked)); !os.IsNotExist(err) {
+		t.Errorf("chat/message delivered despite edge method filter: %v", err)
+	}
+	deadPath := filepath.Join(sessionDir, "dead-letter", "20260201-030100-from-orchestrator-to-worker-dl-method-denied.md")
+	if _, err := os.Stat(deadPath); err != nil {
+		t.Errorf("chat/message not dead-lettered as method denied: %v", err)
+	}
+}
+
+func TestDeliverMessage_SerializePerNodeHoldsUntilRead(t *testing.T) {
+	sessionDir := filepath.Join(t.TempDir(), "test")
+	if err := config.CreateSessionDirs(sessionDir); err != nil {
+		t.Fatalf("config.CreateSessionDirs failed: %v", err)
+	}
+	recipientInbox := filepath.Join(sessionDir, "inbox", "worker")
+	nodes := map[string]discovery.NodeInfo{
+		"test:worker":       {PaneID: "%1", SessionName: "test", SessionDir: sessionDir},
+		"test:orchestrator": {PaneID: "%2", SessionName: "test", SessionDir: sessionDir},
+	}
+	adjacency := map[string][]string{
+		"orchestrator": {"worker"},
+		"worker":       {"orchestrator"},
+	}
+	cfg := &config.Config{
+		EnterDelay:                     0.1,
+		TmuxTimeout:                    1.0,
+		SerializePerNode:               new(true),
+		SerializePerNodeTimeoutSeconds: 300,
+	}
+	post := func(filename string) string {
+		t.Helper()
+		content := "---\nparams:\n  contextId: test-ctx\n  from: orchestrator\n  to: worker\n  timestamp: 2026-02-01T03:00:00Z\n---\n\nbody\n"
+		postPath := filepath.Join(sessionDir, "post", filename)
+		if err := os.WriteFile(postPath, []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		return postPath
+	}
+	deliver := func(postPath string) error {
+		return DeliverMessage(postPath, "test-ctx", nodes, adjacency, cfg, func(string) bool { return true }, nil, idle.NewIdleTracker(), "")
+	}
+
+	first := "20260201-030000-from-orchestrator-to-worker.md"
+	if err := deliver(post(first)); err != nil {
+		t.Fatalf("first DeliverMessage failed: %v", err)
+	}
+
+	second := "20260201-030100-from-orchestrator-to-worker.md"
+	secondPost := post(second)
+	if err := deliver(secondPost); !errors.Is(err, ErrDeliveryHeld) {
+		t.Fatalf("second DeliverMessage err = %v, want ErrDeliveryHeld", err)
+	}
+	if _, err := os.Stat(secondPost); err != nil {
+		t.Fatalf("held message left post/: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(recipientInbox, second)); !os.IsNotExist(err) {
+		t.Fatalf("held message reached inbox: %v", err)
+	}
+
+	// The worker reads the first message (inbox -> read).
+	if err := os.Rename(filepath.Join(recipientInbox, first), filepath.Join(sessionDir, "read", first)); err != nil {
+		t.Fatalf("Rename to read/: %v", err)
+	}
+	if err := deliver(secondPost); err != nil {
+		t.Fatalf("second DeliverMessage after read failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(recipientInbox, second)); err != nil {
+		t.Fatalf("second message not delivered after read: %v", err)
+	}
+}
+
+func TestHoldForUnreadMail_TimeoutReleases(t *testing.T) {
+	inbox := t.TempDir()
+	path := filepath.Join(inbox, "20260201-030000-from-orchestrator-to-worker.md")
+	if err := os.WriteFile(path, []byte("x"), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	now := time.Now()
+	if err := os.Chtimes(path, now.Add(-10*time.Minute), now.Add(-10*time.Minute)); err != nil {
+		t.Fatalf("Chtimes: %v", err)
+	}
+	if holdForUnreadMail(inbox, 300, now) {
+		t.Error("held past serialize_per_node_timeout_seconds")
+	}
+	if !holdForUnreadMail(inbox, 0, now) {
+		t.Error("timeout 0 should hold while mail is unread")
+	}
+	if holdForUnreadMail(t.TempDir(), 300, now) {
+		t.Error("held for an empty inbox")
+	}
+}
+
 func TestDeliverMessage_InvalidRecipient(t *testing.T) {
 	sessionDir := t.TempDir()
 	if err := config.CreateSessionDirs(sessionDir); err != nil {
@@ -428,6 +720,108 @@ func TestDeliverMessage_ExplicitUnknownRecipientSession(t *testing.T) {
 	}
 }
 
+func TestDeliverMessage_UnknownRecipientGoesToCatchAllNode(t *testing.T) {
+	filename := "20260201-030000-from-worker-to-ghost.md"
+	content := "---\nparams:\n  contextId: test-ctx\n  from: worker\n  to: ghost\n  timestamp: 2026-02-01T03:00:00Z\n---\n\ntest message\n"
+	deliver := func(t *testing.T, catchAllNode string) string {
+		t.Helper()
+		sessionDir := filepath.Join(t.TempDir(), "test")
+		if err := config.CreateSessionDirs(sessionDir); err != nil {
+			t.Fatalf("config.CreateSessionDirs failed: %v", err)
+		}
+		postPath := filepath.Join(sessionDir, "post", filename)
+		if err := os.WriteFile(postPath, []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		nodes := map[string]discovery.NodeInfo{
+			"test:orchestrator": {PaneID: "%2", SessionName: "test", SessionDir: sessionDir},
+			"test:worker":       {PaneID: "%1", SessionName: "test", SessionDir: sessionDir},
+		}
+		// No worker -> orchestrator edge: the catch-all needs none.
+		adjacency := map[string][]string{"worker": {"ghost"}}
+		cfg := &config.Config{EnterDelay: 0.1, TmuxTimeout: 1.0, CatchAllNode: catchAllNode}
+		if err := DeliverMessage(postPath, "test-ctx", nodes, adjacency, cfg, func(string) bool { return true }, nil, idle.NewIdleTracker(), ""); err != nil {
+			t.Fatalf("DeliverMessage failed: %v", err)
+		}
+		return sessionDir
+	}
+
+	t.Run("catch_all_node set", func(t *testing.T) {
+		sessionDir := deliver(t, "orchestrator")
+		data, err := os.ReadFile(filepath.Join(sessionDir, "inbox", "orchestrator", filename))
+		if err != nil {
+			t.Fatalf("message not delivered to catch-all inbox: %v", err)
+		}
+		got := string(data)
+		if !strings.Contains(got, `intended recipient "ghost" is unknown`) {
+			t.Fatalf("catch-all delivery missing intended-recipient note:\n%s", got)
+		}
+		if !strings.HasPrefix(got, "---\nparams:") || !strings.Contains(got, "test message") {
+			t.Fatalf("catch-all delivery lost the envelope or body:\n%s", got)
+		}
+		if entries, _ := os.ReadDir(filepath.Join(sessionDir, "dead-letter")); len(entries) != 0 {
+			t.Fatalf("dead-letter/ has %d entries, want 0", len(entries))
+		}
+	})
+
+	t.Run("catch_all_node unset", func(t *testing.T) {
+		sessionDir := deliver(t, "")
+		deadPath := filepath.Join(sessionDir, "dead-letter", "20260201-030000-from-worker-to-ghost-dl-unknown-recipient.md")
+		if _, err := os.Stat(deadPath); err != nil {
+			t.Fatalf("message not in unknown-recipient dead-letter: %v", err)
+		}
+	})
+}
+
+func TestDeliverMessage_DeadPanePolicy(t *testing.T) {
+	filename := "20260201-030000-from-orchestrator-to-worker.md"
+	content := "---\nparams:\n  contextId: test-ctx\n  from: orchestrator\n  to: worker\n  timestamp: 2026-02-01T03:00:00Z\n---\n\ntest message\n"
+	deliver := func(t *testing.T, policy string) (string, error) {
+		t.Helper()
+		sessionDir := filepath.Join(t.TempDir(), "test")
+		if err := config.CreateSessionDirs(sessionDir); err != nil {
+			t.Fatalf("config.CreateSessionDirs failed: %v", err)
+		}
+		postPath := filepath.Join(sessionDir, "post", filename)
+		if err := os.WriteFile(postPath, []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		// worker's pane is gone, so discovery no longer lists it.
+		nodes := map[string]discovery.NodeInfo{
+			"test:orchestrator": {PaneID: "%1", SessionName: "test", SessionDir: sessionDir},
+		}
+		adjacency := map[string][]string{"orchestrator": {"worker"}}
+		cfg := &config.Config{EnterDelay: 0.1, TmuxTimeout: 1.0, DeadPanePolicy: policy}
+		opts := DeliverOptions{PaneDead: func(key string) bool { return key == "test:worker" }}
+		err := DeliverMessageWithOptions(postPath, "test-ctx", nodes, adjacency, cfg, func(string) bool { return true }, nil, idle.NewIdleTracker(), "", opts)
+		return sessionDir, err
+	}
+
+	t.Run("hold", func(t *testing.T) {
+		sessionDir, err := deliver(t, config.DeadPanePolicyHold)
+		if !errors.Is(err, ErrDeliveryHeld) {
+			t.Fatalf("DeliverMessageWithOptions error = %v, want ErrDeliveryHeld", err)
+		}
+		if _, err := os.Stat(filepath.Join(sessionDir, "post", filename)); err != nil {
+			t.Fatalf("held message left post/: %v", err)
+		}
+		if entries, _ := os.ReadDir(filepath.Join(sessionDir, "dead-letter")); len(entries) != 0 {
+			t.Fatalf("dead-letter/ has %d entries, want 0", len(entries))
+		}
+	})
+
+	t.Run("dead_letter", func(t *testing.T) {
+		sessionDir, err := deliver(t, config.DeadPanePolicyDeadLetter)
+		if err != nil {
+			t.Fatalf("DeliverMessageWithOptions failed: %v", err)
+		}
+		deadPath := filepath.Join(sessionDir, "dead-letter", "20260201-030000-from-orchestrator-to-worker-dl-pane-not-running.md")
+		if _, err := os.Stat(deadPath); err != nil {
+			t.Fatalf("message not in pane-not-running dead-letter: %v", err)
+		}
+	})
+}
+
 func TestDeliverMessage_CrossSessionExplicitRecipient(t *testing.T) {
 	sourceSessionDir := filepath.Join(t.TempDir(), "sender-session")
 	if err := config.CreateSessionDirs(sourceSessionDir); err != nil {
@@ -627,6 +1021,388 @@ func TestRouting_Denied(t *testing.T) {
 	}
 }
 
+func writeSenderVerificationMessage(t *testing.T, sessionDir, filename, from, to, paneNode, paneID string) string {
+	t.Helper()
+	saved, err := runtimecontext.SaveSnapshot(sessionDir, runtimecontext.BuildSnapshot(runtimecontext.BuildOptions{
+		Now:                        time.Date(2026, 2, 1, 4, 0, 0, 0, time.UTC),
+		ContextID:                  "test-ctx",
+		MessageID:                  filename,
+		TmuxSession:                "test",
+		Node:                       paneNode,
+		PaneID:                     paneID,
+		CWD:                        t.TempDir(),
+		SuppressRuntimeAutoCollect: true,
+	}))
+	if err != nil {
+		t.Fatalf("SaveSnapshot: %v", err)
+	}
+	postPath := filepath.Join(sessionDir, "post", filename)
+	content := fmt.Sprintf("---\nparams:\n  contextId: test-ctx\n  from: %s\n  to: %s\n  timestamp: 2026-02-01T04:00:00Z\n  runtimeContextId: %s\n---\n\ntest message\n", from, to, saved.Snapshot.SnapshotID)
+	if err := os.WriteFile(postPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	return postPath
+}
+
+func TestDeliverMessage_VerifySender(t *testing.T) {
+	tests := []struct {
+		name         string
+		verify       bool
+		paneNode     string
+		paneID       string
+		wantDelivery bool
+	}{
+		{name: "spoofed sender dead-lettered", verify: true, paneNode: "critic", paneID: "%3", wantDelivery: false},
+		{name: "matching sender delivered", verify: true, paneNode: "orchestrator", paneID: "%2", wantDelivery: true},
+		{name: "unknown pane delivered", verify: true, paneNode: "critic", paneID: "%99", wantDelivery: true},
+		{name: "verification off delivers spoofed sender", verify: false, paneNode: "critic", paneID: "%3", wantDelivery: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sessionDir := filepath.Join(t.TempDir(), "test")
+			if err := config.CreateSessionDirs(sessionDir); err != nil {
+				t.Fatalf("config.CreateSessionDirs failed: %v", err)
+			}
+			recipientInbox := filepath.Join(sessionDir, "inbox", "worker")
+			if err := os.MkdirAll(recipientInbox, 0o755); err != nil {
+				t.Fatalf("MkdirAll failed: %v", err)
+			}
+
+			filename := "20260201-040000-from-orchestrator-to-worker.md"
+			postPath := writeSenderVerificationMessage(t, sessionDir, filename, "orchestrator", "worker", tt.paneNode, tt.paneID)
+
+			nodes := map[string]discovery.NodeInfo{
+				"test:worker":       {PaneID: "%1", SessionName: "test", SessionDir: sessionDir},
+				"test:orchestrator": {PaneID: "%2", SessionName: "test", SessionDir: sessionDir},
+				"test:critic":       {PaneID: "%3", SessionName: "test", SessionDir: sessionDir},
+			}
+			adjacency := map[string][]string{
+				"orchestrator": {"worker"},
+				"worker":       {"orchestrator"},
+			}
+			cfg := &config.Config{
+				EnterDelay:         0.1,
+				TmuxTimeout:        1.0,
+				VerifySender:       new(tt.verify),
+				DeadLetterFeedback: new(true),
+			}
+
+			if err := DeliverMessage(postPath, "test-ctx", nodes, adjacency, cfg, func(string) bool { return true }, nil, idle.NewIdleTracker(), ""); err != nil {
+				t.Fatalf("DeliverMessage failed: %v", err)
+			}
+
+			_, inboxErr := os.Stat(filepath.Join(recipientInbox, filename))
+			deadPath := filepath.Join(sessionDir, "dead-letter", "20260201-040000-from-orchestrator-to-worker-dl-sender-mismatch.md")
+			_, deadErr := os.Stat(deadPath)
+			if tt.wantDelivery {
+				if inboxErr != nil {
+					t.Fatalf("message not delivered to inbox: %v", inboxErr)
+				}
+				if deadErr == nil {
+					t.Fatalf("message unexpectedly dead-lettered: %s", deadPath)
+				}
+				return
+			}
+			if inboxErr == nil {
+				t.Fatal("spoofed message reached recipient inbox")
+			}
+			if deadErr != nil {
+				t.Fatalf("spoofed message not dead-lettered as sender mismatch: %v", deadErr)
+			}
+			// The claimed sender never sent this mail, so it must not be
+			// told about it, even with dead_letter_feedback on.
+			if got := ScanInboxMessages(filepath.Join(sessionDir, "inbox", "orchestrator")); len(got) != 0 {
+				t.Fatalf("claimed sender inbox = %v, want empty", got)
+			}
+		})
+	}
+}
+
+func TestDeliverMessageWithOptions_RateLimitedSenderDeadLettered(t *testing.T) {
+	sessionDir := filepath.Join(t.TempDir(), "test")
+	if err := config.CreateSessionDirs(sessionDir); err != nil {
+		t.Fatalf("config.CreateSessionDirs failed: %v", err)
+	}
+	recipientInbox := filepath.Join(sessionDir, "inbox", "worker")
+	if err := os.MkdirAll(recipientInbox, 0o755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	nodes := map[string]discovery.NodeInfo{
+		"test:worker":       {PaneID: "%1", SessionName: "test", SessionDir: sessionDir},
+		"test:orchestrator": {PaneID: "%2", SessionName: "test", SessionDir: sessionDir},
+	}
+	adjacency := map[string][]string{
+		"orchestrator": {"worker"},
+		"worker":       {"orchestrator"},
+	}
+	cfg := &config.Config{EnterDelay: 0.1, TmuxTimeout: 1.0}
+
+	var checked []string
+	charged := 0
+	limit := 2
+	opts := DeliverOptions{
+		RateLimited: func(sender string) bool {
+			checked = append(checked, sender)
+			if charged >= limit {
+				return true
+			}
+			charged++
+			return false
+		},
+		ReleaseRateLimit: func(string) { charged-- },
+	}
+
+	// Mail to an unknown recipient is dead-lettered before delivery and
+	// spends none of the sender's budget.
+	ghostPath := filepath.Join(sessionDir, "post", "20260201-035959-from-orchestrator-to-ghost.md")
+	if err := os.WriteFile(ghostPath, []byte("---\nparams:\n  from: orchestrator\n  to: ghost\n  timestamp: 2026-02-01T03:59:59Z\n---\n\nlost\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if err := DeliverMessageWithOptions(ghostPath, "test-ctx", nodes, adjacency, cfg, func(string) bool { return true }, nil, idle.NewIdleTracker(), "", opts); err != nil {
+		t.Fatalf("DeliverMessageWithOptions(ghost) failed: %v", err)
+	}
+	if charged != 0 {
+		t.Fatalf("charged = %d after unroutable mail, want 0", charged)
+	}
+
+	for i := 0; i < limit+1; i++ {
+		filename := fmt.Sprintf("20260201-04000%d-from-orchestrator-to-worker.md", i)
+		postPath := filepath.Join(sessionDir, "post", filename)
+		content := "---\nparams:\n  from: orchestrator\n  to: worker\n  timestamp: 2026-02-01T04:00:00Z\n---\n\nflood\n"
+		if err := os.WriteFile(postPath, []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		if err := DeliverMessageWithOptions(postPath, "test-ctx", nodes, adjacency, cfg, func(string) bool { return true }, nil, idle.NewIdleTracker(), "", opts); err != nil {
+			t.Fatalf("DeliverMessageWithOptions(%d) failed: %v", i, err)
+		}
+	}
+
+	for _, sender := range checked {
+		if sender != "test:orchestrator" {
+			t.Fatalf("RateLimited called with %q, want session-prefixed sender", sender)
+		}
+	}
+	entries, err := os.ReadDir(recipientInbox)
+	if err != nil {
+		t.Fatalf("ReadDir failed: %v", err)
+	}
+	if len(entries) != limit {
+		t.Fatalf("inbox count = %d, want %d", len(entries), limit)
+	}
+	if charged != limit {
+		t.Fatalf("charged = %d, want %d delivered messages", charged, limit)
+	}
+	deadPath := filepath.Join(sessionDir, "dead-letter", "20260201-040002-from-orchestrator-to-worker-dl-rate-limited.md")
+	if _, err := os.Stat(deadPath); err != nil {
+		t.Fatalf("message over the limit not dead-lettered as rate limited: %v", err)
+	}
+}
+
+func TestDeliverMessageWithOptions_MutedRecipientSkipsNotification(t *testing.T) {
+	sessionDir := filepath.Join(t.TempDir(), "test")
+	if err := config.CreateSessionDirs(sessionDir); err != nil {
+		t.Fatalf("config.CreateSessionDirs failed: %v", err)
+	}
+	recipientInbox := filepath.Join(sessionDir, "inbox", "worker")
+	if err := os.MkdirAll(recipientInbox, 0o755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	nodes := map[string]discovery.NodeInfo{
+		"test:worker":       {PaneID: "%1", SessionName: "test", SessionDir: sessionDir},
+		"test:orchestrator": {PaneID: "%2", SessionName: "test", SessionDir: sessionDir},
+	}
+	adjacency := map[string][]string{
+		"orchestrator": {"worker"},
+		"worker":       {"orchestrator"},
+	}
+	cfg := &config.Config{EnterDelay: 0.1, TmuxTimeout: 1.0}
+
+	var buf bytes.Buffer
+	originalOutput := log.Writer()
+	originalFlags := log.Flags()
+	log.SetOutput(&buf)
+	log.SetFlags(0)
+	t.Cleanup(func() {
+		log.SetOutput(originalOutput)
+		log.SetFlags(originalFlags)
+	})
+
+	filename := "20260201-050000-from-orchestrator-to-worker.md"
+	postPath := filepath.Join(sessionDir, "post", filename)
+	content := "---\nparams:\n  from: orchestrator\n  to: worker\n  timestamp: 2026-02-01T05:00:00Z\n---\n\nquiet please\n"
+	if err := os.WriteFile(postPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	var checked []string
+	opts := DeliverOptions{Muted: func(recipient string) bool {
+		checked = append(checked, recipient)
+		return true
+	}}
+	if err := DeliverMessageWithOptions(postPath, "test-ctx", nodes, adjacency, cfg, func(string) bool { return true }, nil, idle.NewIdleTracker(), "", opts); err != nil {
+		t.Fatalf("DeliverMessageWithOptions failed: %v", err)
+	}
+
+	if len(checked) != 1 || checked[0] != "test:worker" {
+		t.Fatalf("Muted called with %v, want [test:worker]", checked)
+	}
+	if _, err := os.Stat(filepath.Join(recipientInbox, filename)); err != nil {
+		t.Fatalf("muted recipient did not receive the message: %v", err)
+	}
+	logs := buf.String()
+	if strings.Contains(logs, "attempting pane delivery") {
+		t.Fatalf("muted recipient was notified; logs:\n%s", logs)
+	}
+	if !strings.Contains(logs, "is muted") {
+		t.Fatalf("logs missing mute notice:\n%s", logs)
+	}
+}
+
+func TestDeliverMessage_RepeatedMessageIDIsSuppressed(t *testing.T) {
+	sessionDir := filepath.Join(t.TempDir(), "test")
+	if err := config.CreateSessionDirs(sessionDir); err != nil {
+		t.Fatalf("config.CreateSessionDirs failed: %v", err)
+	}
+	recipientInbox := filepath.Join(sessionDir, "inbox", "worker")
+	nodes := map[string]discovery.NodeInfo{
+		"test:worker":       {PaneID: "%1", SessionName: "test", SessionDir: sessionDir},
+		"test:orchestrator": {PaneID: "%2", SessionName: "test", SessionDir: sessionDir},
+	}
+	adjacency := map[string][]string{
+		"orchestrator": {"worker"},
+		"worker":       {"orchestrator"},
+	}
+	cfg := &config.Config{EnterDelay: 0.1, TmuxTimeout: 1.0}
+	opts := DeliverOptions{DeliveredIDs: store.NewDeliveredMessageIDs()}
+
+	post := func(filename, messageID string) []DaemonEvent {
+		t.Helper()
+		postPath := filepath.Join(sessionDir, "post", filename)
+		content := "---\nparams:\n  from: orchestrator\n  to: worker\n  messageId: " + messageID + "\n  timestamp: 2026-02-01T06:00:00Z\n---\n\nhello\n"
+		if err := os.WriteFile(postPath, []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		events := make(chan DaemonEvent, 1)
+		if err := DeliverMessageWithOptions(postPath, "test-ctx", nodes, adjacency, cfg, func(string) bool { return true }, events, idle.NewIdleTracker(), "", opts); err != nil {
+			t.Fatalf("DeliverMessage(%s) failed: %v", filename, err)
+		}
+		if _, err := os.Stat(postPath); !os.IsNotExist(err) {
+			t.Fatalf("%s still in post/: %v", filename, err)
+		}
+		close(events)
+		var got []DaemonEvent
+		for event := range events {
+			got = append(got, event)
+		}
+		return got
+	}
+
+	first := "20260201-060000-from-orchestrator-to-worker.md"
+	post(first, "req-42")
+	if _, err := os.Stat(filepath.Join(recipientInbox, first)); err != nil {
+		t.Fatalf("first post not delivered: %v", err)
+	}
+
+	retry := "20260201-060005-from-orchestrator-to-worker.md"
+	events := post(retry, "req-42")
+	if _, err := os.Stat(filepath.Join(recipientInbox, retry)); !os.IsNotExist(err) {
+		t.Fatalf("repeated message id delivered again: %v", err)
+	}
+	parked := filepath.Join(sessionDir, "read", "20260201-060005-from-orchestrator-to-worker-dup-message-id.md")
+	if _, err := os.Stat(parked); err != nil {
+		t.Fatalf("repeated message id not moved to read/: %v", err)
+	}
+	if len(events) != 1 || events[0].Type != EventDuplicateMessageID || events[0].Details["message_id"] != "req-42" {
+		t.Fatalf("events = %+v, want one duplicate_message_id for req-42", events)
+	}
+
+	fresh := "20260201-060010-from-orchestrator-to-worker.md"
+	post(fresh, "req-43")
+	if _, err := os.Stat(filepath.Join(recipientInbox, fresh)); err != nil {
+		t.Fatalf("new message id not delivered: %v", err)
+	}
+
+	// A restarted daemon loads the delivered ids from the delivery log.
+	opts.DeliveredIDs = store.NewDeliveredMessageIDs()
+	afterRestart := "20260201-060015-from-orchestrator-to-worker.md"
+	post(afterRestart, "req-43")
+	if _, err := os.Stat(filepath.Join(recipientInbox, afterRestart)); !os.IsNotExist(err) {
+		t.Fatalf("message id delivered again after restart: %v", err)
+	}
+}
+
+func TestDeliverMessage_FutureTimestampFlagsClockSkew(t *testing.T) {
+	sessionDir := filepath.Join(t.TempDir(), "test")
+	if err := config.CreateSessionDirs(sessionDir); err != nil {
+		t.Fatalf("config.CreateSessionDirs failed: %v", err)
+	}
+	recipientInbox := filepath.Join(sessionDir, "inbox", "worker")
+	if err := os.MkdirAll(recipientInbox, 0o755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	nodes := map[string]discovery.NodeInfo{
+		"test:worker":       {PaneID: "%1", SessionName: "test", SessionDir: sessionDir},
+		"test:orchestrator": {PaneID: "%2", SessionName: "test", SessionDir: sessionDir},
+	}
+	adjacency := map[string][]string{
+		"orchestrator": {"worker"},
+		"worker":       {"orchestrator"},
+	}
+	cfg := &config.Config{EnterDelay: 0.1, TmuxTimeout: 1.0, MaxClockSkewSeconds: 60}
+
+	var buf bytes.Buffer
+	originalOutput := log.Writer()
+	originalFlags := log.Flags()
+	log.SetOutput(&buf)
+	log.SetFlags(0)
+	t.Cleanup(func() {
+		log.SetOutput(originalOutput)
+		log.SetFlags(originalFlags)
+	})
+
+	deliver := func(sent time.Time) (string, []DaemonEvent) {
+		t.Helper()
+		buf.Reset()
+		filename := sent.Format("20060102-150405") + "-from-orchestrator-to-worker.md"
+		postPath := filepath.Join(sessionDir, "post", filename)
+		content := "---\nparams:\n  from: orchestrator\n  to: worker\n  timestamp: 2026-02-01T06:00:00Z\n---\n\nhello\n"
+		if err := os.WriteFile(postPath, []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		events := make(chan DaemonEvent, 1)
+		if err := DeliverMessage(postPath, "test-ctx", nodes, adjacency, cfg, func(string) bool { return true }, events, idle.NewIdleTracker(), ""); err != nil {
+			t.Fatalf("DeliverMessage failed: %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(recipientInbox, filename)); err != nil {
+			t.Fatalf("message not delivered: %v", err)
+		}
+		close(events)
+		var got []DaemonEvent
+		for event := range events {
+			got = append(got, event)
+		}
+		return buf.String(), got
+	}
+
+	logs, events := deliver(time.Now().Add(2 * time.Hour))
+	if !strings.Contains(logs, "WARNING: clock_skew") {
+		t.Fatalf("logs missing clock_skew warning:\n%s", logs)
+	}
+	if !strings.Contains(logs, "since receipt)") || strings.Contains(logs, "age: -") {
+		t.Fatalf("age not measured from receipt:\n%s", logs)
+	}
+	if len(events) != 1 || events[0].Type != "clock_skew" {
+		t.Fatalf("events = %#v, want one clock_skew event", events)
+	}
+	if skew, _ := events[0].Details["skew_seconds"].(float64); skew < 3600 {
+		t.Fatalf("skew_seconds = %v, want about 7200", events[0].Details["skew_seconds"])
+	}
+
+	logs, events = deliver(time.Now().Add(-time.Minute))
+	if strings.Contains(logs, "clock_skew") || len(events) != 0 {
+		t.Fatalf("past-dated message flagged as skewed; events=%#v logs:\n%s", events, logs)
+	}
+}
+
 func TestDeliverMessage_PostmanGenericPathDeadLettered(t *testing.T) {
 	sessionDir := filepath.Join(t.TempDir(), "test") // basename must match session name in nodes map
 	if err := config.CreateSessionDirs(sessionDir); err != nil {
@@ -711,6 +1487,72 @@ func TestPONG_Handling(t *testing.T) {
 	}
 }
 
+func TestDeliverMessage_PostmanStatusQueryGetsReply(t *testing.T) {
+	sessionDir := filepath.Join(t.TempDir(), "test")
+	if err := config.CreateSessionDirs(sessionDir); err != nil {
+		t.Fatalf("config.CreateSessionDirs failed: %v", err)
+	}
+	nodes := map[string]discovery.NodeInfo{
+		"test:worker": {PaneID: "%1", SessionName: "test", SessionDir: sessionDir},
+		"test:critic": {PaneID: "%2", SessionName: "test", SessionDir: sessionDir},
+	}
+	adjacency := map[string][]string{"worker": {"critic", "orchestrator"}, "critic": {"worker"}, "orchestrator": {"worker"}}
+	cfg := &config.Config{EnterDelay: 0.1, TmuxTimeout: 1.0}
+	tracker := idle.NewIdleTracker()
+	tracker.MarkNodeAlive("test:critic")
+
+	queryName := "20260201-050000-from-worker-to-postman.md"
+	queryPath := filepath.Join(sessionDir, "post", queryName)
+	query := "---\nparams:\n  contextId: test-ctx\n  from: worker\n  to: postman\n  method: postman/status\n  timestamp: 2026-02-01T05:00:00Z\n---\n\nstatus please\n"
+	if err := os.WriteFile(queryPath, []byte(query), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if err := DeliverMessage(queryPath, "test-ctx", nodes, adjacency, cfg, func(string) bool { return true }, nil, tracker, ""); err != nil {
+		t.Fatalf("DeliverMessage(query) failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(sessionDir, "read", queryName)); err != nil {
+		t.Fatalf("answered query not archived in read/: %v", err)
+	}
+	inbox := filepath.Join(sessionDir, "inbox", "worker")
+	replies := ScanInboxMessages(inbox)
+	if len(replies) != 1 || replies[0].From != "postman" || replies[0].To != "worker" {
+		t.Fatalf("worker inbox = %+v, want one reply from postman", replies)
+	}
+	data, err := os.ReadFile(filepath.Join(inbox, replies[0].Filename))
+	if err != nil {
+		t.Fatalf("ReadFile(reply): %v", err)
+	}
+	reply := string(data)
+	for _, want := range []string{"method: postman/status", "replyTo: " + queryName, "- critic (pane %2, live)", "- orchestrator (no pane)", "Nodes with a pane in test: critic, worker"} {
+		if !strings.Contains(reply, want) {
+			t.Errorf("reply missing %q:\n%s", want, reply)
+		}
+	}
+
+	// Without a postman method the mail is not a query and stays unroutable.
+	plainName := "20260201-050100-from-worker-to-postman.md"
+	plainPath := filepath.Join(sessionDir, "post", plainName)
+	plain := "---\nparams:\n  contextId: test-ctx\n  from: worker\n  to: postman\n  timestamp: 2026-02-01T05:01:00Z\n---\n\nPONG\n"
+	if err := os.WriteFile(plainPath, []byte(plain), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if err := DeliverMessage(plainPath, "test-ctx", nodes, adjacency, cfg, func(string) bool { return true }, nil, tracker, ""); err != nil {
+		t.Fatalf("DeliverMessage(plain) failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(sessionDir, "dead-letter", "20260201-050100-from-worker-to-postman-dl-unknown-recipient.md")); err != nil {
+		t.Fatalf("plain mail to postman not dead-lettered: %v", err)
+	}
+	for _, msg := range ScanInboxMessages(inbox) {
+		if msg.Filename == replies[0].Filename {
+			continue
+		}
+		if data, err := os.ReadFile(filepath.Join(inbox, msg.Filename)); err == nil && strings.Contains(string(data), "## Postman Status") {
+			t.Fatalf("plain mail to postman was answered as a query: %s", msg.Filename)
+		}
+	}
+}
+
 func TestScanInboxMessages(t *testing.T) {
 	t.Run("valid messages returned", func(t *testing.T) {
 		dir := t.TempDir()
@@ -1351,6 +2193,366 @@ func TestDeliverMessage_RoutingDeniedWarningIncludesReplyCommand(t *testing.T) {
 	}
 }
 
+func TestDeliverMessage_EdgeViolationMode(t *testing.T) {
+	tests := []struct {
+		mode          string
+		wantDelivered bool
+		wantWarning   bool
+	}{
+		{mode: "", wantDelivered: false, wantWarning: true},
+		{mode: config.EdgeViolationModeBlock, wantDelivered: false, wantWarning: true},
+		{mode: config.EdgeViolationModeWarnOnly, wantDelivered: true, wantWarning: true},
+		{mode: config.EdgeViolationModeSilent, wantDelivered: false, wantWarning: false},
+	}
+	for _, tt := range tests {
+		t.Run("mode="+tt.mode, func(t *testing.T) {
+			sessionDir := filepath.Join(t.TempDir(), "test")
+			if err := config.CreateSessionDirs(sessionDir); err != nil {
+				t.Fatalf("config.CreateSessionDirs failed: %v", err)
+			}
+
+			filename := "20260201-040000-from-orchestrator-to-worker.md"
+			postPath := filepath.Join(sessionDir, "post", filename)
+			content := "---\nparams:\n  contextId: test-ctx\n  from: orchestrator\n  to: worker\n  timestamp: 2026-02-01T04:00:00Z\n---\n\ntest message\n"
+			if err := os.WriteFile(postPath, []byte(content), 0o644); err != nil {
+				t.Fatalf("WriteFile failed: %v", err)
+			}
+
+			nodes := map[string]discovery.NodeInfo{
+				"test:worker":       {PaneID: "%1", SessionName: "test", SessionDir: sessionDir},
+				"test:orchestrator": {PaneID: "%2", SessionName: "test", SessionDir: sessionDir},
+			}
+			cfg := &config.Config{
+				EnterDelay:                   0.1,
+				TmuxTimeout:                  1.0,
+				EdgeViolationWarningTemplate: "edge violation",
+				EdgeViolationMode:            tt.mode,
+			}
+
+			if err := DeliverMessage(postPath, "test-ctx", nodes, map[string][]string{}, cfg, func(string) bool { return true }, nil, idle.NewIdleTracker(), ""); err != nil {
+				t.Fatalf("DeliverMessage failed: %v", err)
+			}
+
+			_, inboxErr := os.Stat(filepath.Join(sessionDir, "inbox", "worker", filename))
+			if delivered := inboxErr == nil; delivered != tt.wantDelivered {
+				t.Errorf("delivered to worker inbox = %v, want %v", delivered, tt.wantDelivered)
+			}
+			deadPath := filepath.Join(sessionDir, "dead-letter", "20260201-040000-from-orchestrator-to-worker-dl-routing-denied.md")
+			if _, err := os.Stat(deadPath); (err == nil) == tt.wantDelivered {
+				t.Errorf("dead-lettered = %v, want %v", err == nil, !tt.wantDelivered)
+			}
+			warnings, _ := os.ReadDir(filepath.Join(sessionDir, "inbox", "orchestrator"))
+			if gotWarning := len(warnings) > 0; gotWarning != tt.wantWarning {
+				t.Errorf("warning in sender inbox = %v, want %v", gotWarning, tt.wantWarning)
+			}
+		})
+	}
+}
+
+func TestDeliverMessage_EmptyBodyPolicy(t *testing.T) {
+	tests := []struct {
+		policy        string
+		wantDelivered bool
+		wantNote      bool
+	}{
+		{policy: "", wantDelivered: true, wantNote: false},
+		{policy: config.EmptyBodyPolicyDeliver, wantDelivered: true, wantNote: false},
+		{policy: config.EmptyBodyPolicyDeadLetter, wantDelivered: false, wantNote: true},
+		{policy: config.EmptyBodyPolicyWarnSender, wantDelivered: true, wantNote: true},
+	}
+	for _, tt := range tests {
+		t.Run("policy="+tt.policy, func(t *testing.T) {
+			sessionDir := filepath.Join(t.TempDir(), "test")
+			if err := config.CreateSessionDirs(sessionDir); err != nil {
+				t.Fatalf("config.CreateSessionDirs failed: %v", err)
+			}
+
+			filename := "20260201-040000-from-orchestrator-to-worker.md"
+			postPath := filepath.Join(sessionDir, "post", filename)
+			content := "---\nparams:\n  contextId: test-ctx\n  from: orchestrator\n  to: worker\n  timestamp: 2026-02-01T04:00:00Z\n---\n\n## Content\n\n"
+			if err := os.WriteFile(postPath, []byte(content), 0o644); err != nil {
+				t.Fatalf("WriteFile failed: %v", err)
+			}
+
+			nodes := map[string]discovery.NodeInfo{
+				"test:worker":       {PaneID: "%1", SessionName: "test", SessionDir: sessionDir},
+				"test:orchestrator": {PaneID: "%2", SessionName: "test", SessionDir: sessionDir},
+			}
+			adjacency := map[string][]string{
+				"orchestrator": {"worker"},
+				"worker":       {"orchestrator"},
+			}
+			cfg := &config.Config{
+				EnterDelay:      0.1,
+				TmuxTimeout:     1.0,
+				EmptyBodyPolicy: tt.policy,
+			}
+
+			if err := DeliverMessage(postPath, "test-ctx", nodes, adjacency, cfg, func(string) bool { return true }, nil, idle.NewIdleTracker(), ""); err != nil {
+				t.Fatalf("DeliverMessage failed: %v", err)
+			}
+
+			_, inboxErr := os.Stat(filepath.Join(sessionDir, "inbox", "worker", filename))
+			if delivered := inboxErr == nil; delivered != tt.wantDelivered {
+				t.Errorf("delivered to worker inbox = %v, want %v", delivered, tt.wantDelivered)
+			}
+			deadPath := filepath.Join(sessionDir, "dead-letter", "20260201-040000-from-orchestrator-to-worker-dl-empty-body.md")
+			if _, err := os.Stat(deadPath); (err == nil) == tt.wantDelivered {
+				t.Errorf("dead-lettered = %v, want %v", err == nil, !tt.wantDelivered)
+			}
+			notes, _ := os.ReadDir(filepath.Join(sessionDir, "inbox", "orchestrator"))
+			if gotNote := len(notes) > 0; gotNote != tt.wantNote {
+				t.Errorf("note in sender inbox = %v, want %v", gotNote, tt.wantNote)
+			}
+		})
+	}
+}
+
+func TestIsEmptyMessageBody(t *testing.T) {
+	header := "---\nparams:\n  from: a\n  to: b\n---\n"
+	tests := []struct {
+		content string
+		want    bool
+	}{
+		{content: header, want: true},
+		{content: header + "\n  \n", want: true},
+		{content: header + "\n## Content\n\n", want: true},
+		{content: header + "\nhello\n", want: false},
+		{content: "", want: false},
+	}
+	for _, tt := range tests {
+		if got := isEmptyMessageBody(tt.content); got != tt.want {
+			t.Errorf("isEmptyMessageBody(%q) = %v, want %v", tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestDeliverMessage_MaxMessageBytes(t *testing.T) {
+	header := "---\nparams:\n  contextId: test-ctx\n  from: orchestrator\n  to: worker\n  timestamp: 2026-02-01T04:00:00Z\n---\n\n"
+	tests := []struct {
+		name          string
+		body          string
+		wantDelivered bool
+	}{
+		{name: "normal", body: "short report\n", wantDelivered: true},
+		{name: "oversized", body: strings.Repeat("x", 512) + "\n", wantDelivered: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sessionDir := filepath.Join(t.TempDir(), "test")
+			if err := config.CreateSessionDirs(sessionDir); err != nil {
+				t.Fatalf("config.CreateSessionDirs failed: %v", err)
+			}
+			filename := "20260201-040000-from-orchestrator-to-worker.md"
+			postPath := filepath.Join(sessionDir, "post", filename)
+			if err := os.WriteFile(postPath, []byte(header+tt.body), 0o644); err != nil {
+				t.Fatalf("WriteFile failed: %v", err)
+			}
+			nodes := map[string]discovery.NodeInfo{
+				"test:worker":       {PaneID: "%1", SessionName: "test", SessionDir: sessionDir},
+				"test:orchestrator": {PaneID: "%2", SessionName: "test", SessionDir: sessionDir},
+			}
+			adjacency := map[string][]string{
+				"orchestrator": {"worker"},
+				"worker":       {"orchestrator"},
+			}
+			cfg := &config.Config{
+				EnterDelay:      0.1,
+				TmuxTimeout:     1.0,
+				MaxMessageBytes: 256,
+			}
+			events := make(chan DaemonEvent, 4)
+
+			if err := DeliverMessage(postPath, "test-ctx", nodes, adjacency, cfg, func(string) bool { return true }, events, idle.NewIdleTracker(), ""); err != nil {
+				t.Fatalf("DeliverMessage failed: %v", err)
+			}
+
+			_, inboxErr := os.Stat(filepath.Join(sessionDir, "inbox", "worker", filename))
+			if delivered := inboxErr == nil; delivered != tt.wantDelivered {
+				t.Errorf("delivered to worker inbox = %v, want %v", delivered, tt.wantDelivered)
+			}
+			deadPath := filepath.Join(sessionDir, "dead-letter", "20260201-040000-from-orchestrator-to-worker-dl-too-large.md")
+			if _, err := os.Stat(deadPath); (err == nil) == tt.wantDelivered {
+				t.Errorf("dead-lettered = %v, want %v", err == nil, !tt.wantDelivered)
+			}
+			if tt.wantDelivered {
+				return
+			}
+			notes, _ := os.ReadDir(filepath.Join(sessionDir, "inbox", "orchestrator"))
+			if len(notes) != 1 {
+				t.Errorf("sender inbox notes = %d, want 1 dead-letter note", len(notes))
+			}
+			select {
+			case event := <-events:
+				if !strings.Contains(event.Message, deadLetterReasonTooLarge) {
+					t.Errorf("event message = %q, want reason %q", event.Message, deadLetterReasonTooLarge)
+				}
+			default:
+				t.Error("no dead-letter event emitted")
+			}
+		})
+	}
+}
+
+func TestDeliverMessage_ConfirmRequiresPaneEcho(t *testing.T) {
+	filename := "20260201-040000-from-orchestrator-to-worker.md"
+	content := "---\nparams:\n  contextId: test-ctx\n  from: orchestrator\n  to: worker\n  timestamp: 2026-02-01T04:00:00Z\n  confirm: true\n---\n\nplease ack\n"
+	tests := []struct {
+		name          string
+		capture       string
+		wantDelivered bool
+	}{
+		{name: "echoed", capture: "You've got mail: " + filename + ".\n", wantDelivered: true},
+		{name: "not echoed", capture: "$ \n", wantDelivered: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := tmuxtest.Install(t, tmuxtest.WithPane(tmuxtest.Pane{ID: "%1", SessionName: "test", Title: "worker", Capture: tt.capture}))
+
+			sessionDir := filepath.Join(t.TempDir(), "test")
+			if err := config.CreateSessionDirs(sessionDir); err != nil {
+				t.Fatalf("config.CreateSessionDirs failed: %v", err)
+			}
+			postPath := filepath.Join(sessionDir, "post", filename)
+			if err := os.WriteFile(postPath, []byte(content), 0o644); err != nil {
+				t.Fatalf("WriteFile failed: %v", err)
+			}
+			nodes := map[string]discovery.NodeInfo{
+				"test:worker":       {PaneID: "%1", SessionName: "test", SessionDir: sessionDir},
+				"test:orchestrator": {PaneID: "%2", SessionName: "test", SessionDir: sessionDir},
+			}
+			adjacency := map[string][]string{
+				"orchestrator": {"worker"},
+				"worker":       {"orchestrator"},
+			}
+			cfg := &config.Config{
+				TmuxTimeout:          1.0,
+				NotificationTemplate: "You've got mail: {filename}.",
+			}
+			var scheduled []func()
+			var corrections []DaemonEvent
+			opts := DeliverOptions{
+				ScheduleConfirm: func(delay time.Duration, check func()) {
+					scheduled = append(scheduled, check)
+				},
+				Corrections: func(event DaemonEvent) {
+					corrections = append(corrections, event)
+				},
+			}
+			countPastes := func() int {
+				pastes := 0
+				for _, line := range fake.Invocations() {
+					if strings.HasPrefix(line, "paste-buffer") && strings.Contains(line, "-t %1") {
+						pastes++
+					}
+				}
+				return pastes
+			}
+
+			if err := DeliverMessageWithOptions(postPath, "test-ctx", nodes, adjacency, cfg, func(string) bool { return true }, nil, idle.NewIdleTracker(), "", opts); err != nil {
+				t.Fatalf("DeliverMessageWithOptions failed: %v", err)
+			}
+
+			// Delivery returns without waiting on the pane: the message is in
+			// the inbox and the echo check is only scheduled.
+			inboxPath := filepath.Join(sessionDir, "inbox", "worker", filename)
+			if _, err := os.Stat(inboxPath); err != nil {
+				t.Fatalf("message not in inbox when delivery returned: %v", err)
+			}
+			if len(scheduled) != 1 || countPastes() != 1 {
+				t.Fatalf("scheduled checks = %d, pastes = %d; want 1 and 1", len(scheduled), countPastes())
+			}
+			for len(scheduled) > 0 {
+				check := scheduled[0]
+				scheduled = scheduled[1:]
+				check()
+			}
+
+			// One paste when the echo shows up; a paste and one resend otherwise.
+			wantPastes := 1
+			if !tt.wantDelivered {
+				wantPastes = 2
+			}
+			if pastes := countPastes(); pastes != wantPastes {
+				t.Errorf("pastes to %%1 = %d, want %d\n%s", pastes, wantPastes, strings.Join(fake.Invocations(), "\n"))
+			}
+
+			_, inboxErr := os.Stat(inboxPath)
+			if delivered := inboxErr == nil; delivered != tt.wantDelivered {
+				t.Errorf("left in worker inbox = %v, want %v", delivered, tt.wantDelivered)
+			}
+			deadPath := filepath.Join(sessionDir, "dead-letter", "20260201-040000-from-orchestrator-to-worker-dl-unconfirmed.md")
+			if _, err := os.Stat(deadPath); (err == nil) == tt.wantDelivered {
+				t.Errorf("dead-lettered = %v, want %v", err == nil, !tt.wantDelivered)
+			}
+			if tt.wantDelivered {
+				if len(corrections) != 0 {
+					t.Errorf("corrections = %+v, want none", corrections)
+				}
+				return
+			}
+			if len(corrections) != 1 || !strings.Contains(corrections[0].Message, deadLetterReasonDeliveryUnconfirmed) {
+				t.Errorf("corrections = %+v, want one with reason %q", corrections, deadLetterReasonDeliveryUnconfirmed)
+			}
+			entries, err := store.ReadDeliveryLog(sessionDir)
+			if err != nil || len(entries) != 2 || entries[1].Outcome != store.DeliveryOutcomeUnconfirmed {
+				t.Errorf("delivery log = %+v, %v; want delivered then unconfirmed", entries, err)
+			}
+		})
+	}
+}
+
+func TestDeliverMessage_AliasedRecipientUsesCanonicalNode(t *testing.T) {
+	sessionDir := filepath.Join(t.TempDir(), "test")
+	if err := config.CreateSessionDirs(sessionDir); err != nil {
+		t.Fatalf("config.CreateSessionDirs failed: %v", err)
+	}
+	nodes := map[string]discovery.NodeInfo{
+		"test:worker":       {PaneID: "%1", SessionName: "test", SessionDir: sessionDir},
+		"test:orchestrator": {PaneID: "%2", SessionName: "test", SessionDir: sessionDir},
+		"test:critic":       {PaneID: "%3", SessionName: "test", SessionDir: sessionDir},
+	}
+	cfg := &config.Config{
+		EnterDelay:  0.1,
+		TmuxTimeout: 1.0,
+		Nodes: map[string]config.NodeConfig{
+			"worker":       {},
+			"orchestrator": {Aliases: []string{"orch"}},
+			"critic":       {Aliases: []string{"crit"}},
+		},
+	}
+	// Edges name only the canonical nodes; critic is not a neighbor of worker.
+	adjacency := map[string][]string{
+		"worker":       {"orchestrator"},
+		"orchestrator": {"worker", "critic"},
+		"critic":       {"orchestrator"},
+	}
+	deliver := func(filename, to string) {
+		t.Helper()
+		postPath := filepath.Join(sessionDir, "post", filename)
+		content := "---\nparams:\n  contextId: test-ctx\n  from: worker\n  to: " + to + "\n  timestamp: 2026-02-01T04:00:00Z\n---\n\nhello\n"
+		if err := os.WriteFile(postPath, []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		if err := DeliverMessage(postPath, "test-ctx", nodes, adjacency, cfg, func(string) bool { return true }, nil, idle.NewIdleTracker(), ""); err != nil {
+			t.Fatalf("DeliverMessage(%s) failed: %v", filename, err)
+		}
+	}
+
+	deliver("20260201-040000-from-worker-to-orch.md", "orch")
+	if _, err := os.Stat(filepath.Join(sessionDir, "inbox", "orchestrator", "20260201-040000-from-worker-to-orch.md")); err != nil {
+		t.Fatalf("mail to alias orch not in orchestrator inbox: %v", err)
+	}
+
+	deliver("20260201-040100-from-worker-to-crit.md", "crit")
+	if _, err := os.Stat(filepath.Join(sessionDir, "inbox", "critic", "20260201-040100-from-worker-to-crit.md")); !os.IsNotExist(err) {
+		t.Fatalf("mail to alias crit delivered despite no worker--critic edge (err = %v)", err)
+	}
+	if _, err := os.Stat(filepath.Join(sessionDir, "dead-letter", "20260201-040100-from-worker-to-crit-dl-routing-denied.md")); err != nil {
+		t.Fatalf("mail to alias crit not dead-lettered as routing denied: %v", err)
+	}
+}
+
 func TestDeliverMessage_AppendsShadowJournalDeliveredEvent(t *testing.T) {
 	sessionDir := filepath.Join(t.TempDir(), "review-session")
 	if err := config.CreateSessionDirs(sessionDir); err != nil {
@@ -1771,7 +2973,7 @@ func TestDeliverNotificationWithRetry_RetryUsesRefreshedPaneID(t *testing.T) {
 
 	adapter := controlplane.TmuxHandAdapter{
 		ProbeRuntime: func(string) (string, error) { return "bash", nil },
-		SendToPane: func(paneID string, _ string, _ time.Duration, _ time.Duration, _ int, _ bool, _ time.Duration, _ int) error {
+		SendToPane: func(paneID string, _ string, _ time.Duration, _ time.Duration, _ time.Duration, _ int, _ bool, _ time.Duration, _ int) error {
 			callCount++
 			gotPaneIDs = append(gotPaneIDs, paneID)
 			if callCount == 1 {
@@ -1823,7 +3025,7 @@ func TestDeliverNotificationWithRetry_BothAttemptsFail_LogsWarning(t *testing.T)
 
 	adapter := controlplane.TmuxHandAdapter{
 		ProbeRuntime: func(string) (string, error) { return "bash", nil },
-		SendToPane: func(_ string, _ string, _ time.Duration, _ time.Duration, _ int, _ bool, _ time.Duration, _ int) error {
+		SendToPane: func(_ string, _ string, _ time.Duration, _ time.Duration, _ time.Duration, _ int, _ bool, _ time.Duration, _ int) error {
 			callCount++
 			return fmt.Errorf("pane not found")
 		},
@@ -1858,3 +3060,223 @@ func TestDeliverNotificationWithRetry_BothAttemptsFail_LogsWarning(t *testing.T)
 		t.Errorf("expected msg= in WARNING, got: %s", logOut)
 	}
 }
+
+func TestNotificationBreaker_SkipsAfterThresholdAndProbesAfterCooldown(t *testing.T) {
+	breaker := newNotificationBreaker()
+	events := make(chan DaemonEvent, 4)
+	start := time.Date(2026, time.May, 2, 9, 0, 0, 0, time.UTC)
+	attempts := 0
+	succeed := false
+	send := func() bool {
+		attempts++
+		return succeed
+	}
+	notify := func(at time.Time) {
+		breaker.notify("review:worker", 3, time.Minute, at, events, send)
+	}
+
+	for i := range 3 {
+		notify(start.Add(time.Duration(i) * time.Second))
+	}
+	if attempts != 3 {
+		t.Fatalf("attempts before threshold = %d, want 3", attempts)
+	}
+	select {
+	case event := <-events:
+		if event.Type != EventNotificationCircuitOpen || event.Details["node"] != "review:worker" {
+			t.Fatalf("event = %#v, want notification_circuit_open for review:worker", event)
+		}
+	default:
+		t.Fatal("no notification_circuit_open event after the threshold")
+	}
+
+	notify(start.Add(30 * time.Second))
+	if attempts != 3 {
+		t.Fatalf("attempts while open = %d, want the send skipped", attempts)
+	}
+
+	// The probe after the cooldown fails and reopens the breaker.
+	notify(start.Add(2*time.Second + time.Minute))
+	notify(start.Add(time.Minute + 30*time.Second))
+	if attempts != 4 {
+		t.Fatalf("attempts after failed probe = %d, want one probe then skipped", attempts)
+	}
+
+	succeed = true
+	notify(start.Add(3 * time.Minute))
+	notify(start.Add(3*time.Minute + time.Second))
+	if attempts != 6 {
+		t.Fatalf("attempts after successful probe = %d, want sends re-enabled", attempts)
+	}
+}
+
+func TestDeliverMessage_DeadLetterFeedbackCoversEveryReason(t *testing.T) {
+	const feedbackTemplate = "note: {reason} ({original_filename})"
+	tests := []struct {
+		name        string
+		from        string
+		to          string
+		sessionOn   func(string) bool
+		wantReason  string
+		rateLimited bool
+	}{
+		{name: "unknown sender", from: "ghost", to: "worker", wantReason: "unknown sender"},
+		{name: "unknown recipient", from: "orchestrator", to: "nobody", wantReason: deadLetterReasonUnknownRecipient},
+		{name: "routing denied", from: "worker", to: "critic", wantReason: ""},
+		{name: "sender session disabled", from: "orchestrator", to: "worker", sessionOn: func(string) bool { return false }, wantReason: deadLetterReasonSenderSessionDisabled},
+		{name: "rate limited", from: "orchestrator", to: "worker", rateLimited: true, wantReason: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sessionDir := filepath.Join(t.TempDir(), "test")
+			if err := config.CreateSessionDirs(sessionDir); err != nil {
+				t.Fatalf("config.CreateSessionDirs failed: %v", err)
+			}
+			nodes := map[string]discovery.NodeInfo{
+				"test:worker":       {PaneID: "%1", SessionName: "test", SessionDir: sessionDir},
+				"test:orchestrator": {PaneID: "%2", SessionName: "test", SessionDir: sessionDir},
+				"test:critic":       {PaneID: "%3", SessionName: "test", SessionDir: sessionDir},
+			}
+			adjacency := map[string][]string{"orchestrator": {"worker"}, "worker": {"orchestrator"}}
+			cfg := &config.Config{EnterDelay: 0.1, TmuxTimeout: 1.0, DeadLetterFeedback: new(true), DeadLetterFeedbackTemplate: feedbackTemplate}
+			sessionOn := tt.sessionOn
+			if sessionOn == nil {
+				sessionOn = func(string) bool { return true }
+			}
+			opts := DeliverOptions{RateLimited: func(string) bool { return tt.rateLimited }}
+
+			filename := fmt.Sprintf("20260201-050000-from-%s-to-%s.md", tt.from, tt.to)
+			postPath := filepath.Join(sessionDir, "post", filename)
+			content := fmt.Sprintf("---\nparams:\n  from: %s\n  to: %s\n  timestamp: 2026-02-01T05:00:00Z\n---\n\nbody\n", tt.from, tt.to)
+			if err := os.WriteFile(postPath, []byte(content), 0o644); err != nil {
+				t.Fatalf("WriteFile failed: %v", err)
+			}
+			if err := DeliverMessageWithOptions(postPath, "test-ctx", nodes, adjacency, cfg, sessionOn, nil, idle.NewIdleTracker(), "", opts); err != nil {
+				t.Fatalf("DeliverMessageWithOptions failed: %v", err)
+			}
+
+			notes := readFeedbackNotes(t, filepath.Join(sessionDir, "inbox", tt.from), "note: ")
+			if tt.wantReason == "" {
+				if len(notes) != 0 {
+					t.Fatalf("feedback notes = %q, want none", notes)
+				}
+				return
+			}
+			want := fmt.Sprintf("note: %s (%s)", tt.wantReason, filename)
+			if len(notes) != 1 || notes[0] != want {
+				t.Fatalf("feedback notes = %q, want [%q]", notes, want)
+			}
+		})
+	}
+}
+
+func TestDeliverMessage_DeadLetterFeedbackDisabledKeepsUnknownSenderSilent(t *testing.T) {
+	sessionDir := filepath.Join(t.TempDir(), "test")
+	if err := config.CreateSessionDirs(sessionDir); err != nil {
+		t.Fatalf("config.CreateSessionDirs failed: %v", err)
+	}
+	nodes := map[string]discovery.NodeInfo{
+		"test:worker": {PaneID: "%1", SessionName: "test", SessionDir: sessionDir},
+	}
+	filename := "20260201-050000-from-ghost-to-worker.md"
+	postPath := filepath.Join(sessionDir, "post", filename)
+	content := "---\nparams:\n  from: ghost\n  to: worker\n  timestamp: 2026-02-01T05:00:00Z\n---\n\nbody\n"
+	if err := os.WriteFile(postPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	cfg := &config.Config{EnterDelay: 0.1, TmuxTimeout: 1.0}
+	if err := DeliverMessage(postPath, "test-ctx", nodes, map[string][]string{}, cfg, func(string) bool { return true }, nil, idle.NewIdleTracker(), ""); err != nil {
+		t.Fatalf("DeliverMessage failed: %v", err)
+	}
+	if entries, _ := os.ReadDir(filepath.Join(sessionDir, "inbox", "ghost")); len(entries) != 0 {
+		t.Fatalf("unknown sender got %d note(s) with dead_letter_feedback off, want 0", len(entries))
+	}
+}
+
+func TestDrainStalePost_DeadLetterFeedbackNotifiesSender(t *testing.T) {
+	sessionDir := filepath.Join(t.TempDir(), "ctx", "test")
+	if err := config.CreateSessionDirs(sessionDir); err != nil {
+		t.Fatalf("config.CreateSessionDirs failed: %v", err)
+	}
+	filename := "20260201-050000-from-orchestrator-to-worker.md"
+	postPath := filepath.Join(sessionDir, "post", filename)
+	if err := os.WriteFile(postPath, []byte("stale"), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(postPath, old, old); err != nil {
+		t.Fatalf("Chtimes failed: %v", err)
+	}
+	cfg := &config.Config{MessageTTLSeconds: 60, DeadLetterFeedback: new(true), DeadLetterFeedbackTemplate: "note: {reason} ({original_filename})"}
+
+	if drained := DrainStalePost(sessionDir, cfg); drained != 1 {
+		t.Fatalf("DrainStalePost() = %d, want 1", drained)
+	}
+	notes := readFeedbackNotes(t, filepath.Join(sessionDir, "inbox", "orchestrator"), "note: ")
+	want := "note: ttl expired (" + filename + ")"
+	if len(notes) != 1 || notes[0] != want {
+		t.Fatalf("feedback notes = %q, want [%q]", notes, want)
+	}
+}
+
+func readFeedbackNotes(t *testing.T, inboxDir, prefix string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(inboxDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		t.Fatalf("ReadDir(%s): %v", inboxDir, err)
+	}
+	var notes []string
+	for _, entry := range entries {
+		data, err := os.ReadFile(filepath.Join(inboxDir, entry.Name()))
+		if err != nil {
+			t.Fatalf("ReadFile: %v", err)
+		}
+		if strings.HasPrefix(string(data), prefix) {
+			notes = append(notes, string(data))
+		}
+	}
+	return notes
+}
+
+func TestDeliverMessage_ContentFilterTransformsBody(t *testing.T) {
+	sessionDir := filepath.Join(t.TempDir(), "test")
+	if err := config.CreateSessionDirs(sessionDir); err != nil {
+		t.Fatalf("config.CreateSessionDirs failed: %v", err)
+	}
+	nodes := map[string]discovery.NodeInfo{
+		"test:worker":       {PaneID: "%1", SessionName: "test", SessionDir: sessionDir},
+		"test:orchestrator": {PaneID: "%2", SessionName: "test", SessionDir: sessionDir},
+	}
+	adjacency := map[string][]string{"orchestrator": {"worker"}}
+	frontmatter := "---\nparams:\n  contextId: test-ctx\n  from: orchestrator\n  to: worker\n  timestamp: 2026-02-01T03:00:00Z\n---\n"
+
+	deliver := func(command, filename string) string {
+		t.Helper()
+		cfg := &config.Config{EnterDelay: 0.1, TmuxTimeout: 1.0, ContentFilterCommand: command}
+		postPath := filepath.Join(sessionDir, "post", filename)
+		if err := os.WriteFile(postPath, []byte(frontmatter+"\napi key: secret\n"), 0o644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		if err := DeliverMessage(postPath, "test-ctx", nodes, adjacency, cfg, func(string) bool { return true }, nil, idle.NewIdleTracker(), ""); err != nil {
+			t.Fatalf("DeliverMessage failed: %v", err)
+		}
+		delivered, err := os.ReadFile(filepath.Join(sessionDir, "inbox", "worker", filename))
+		if err != nil {
+			t.Fatalf("ReadFile inbox failed: %v", err)
+		}
+		return string(delivered)
+	}
+
+	got := deliver("tr a-z A-Z", "20260201-030000-from-orchestrator-to-worker.md")
+	if want := frontmatter + "\nAPI KEY: SECRET\n"; got != want {
+		t.Fatalf("filtered delivery = %q, want %q", got, want)
+	}
+
+	got = deliver("exit 3", "20260201-030100-from-orchestrator-to-worker.md")
+	if want := frontmatter + "\napi key: secret\n"; got != want {
+		t.Fatalf("failed filter delivery = %q, want original %q", got, want)
+	}
+}
@@ -0,0 +1,82 @@
+package message
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// EventNotificationCircuitOpen reports a node whose pane notifications kept
+// failing, so they are skipped for a cooldown while mail still lands in the
+// inbox.
+const EventNotificationCircuitOpen = "notification_circuit_open"
+
+// notificationBreaker counts consecutive pane notification failures per node
+// and, once a node reaches the threshold, skips its notifications until the
+// cooldown ends. The first notification after the cooldown is a probe: a
+// success closes the breaker, a failure reopens it.
+type notificationBreaker struct {
+	mu        sync.Mutex
+	failures  map[string]int
+	openUntil map[string]time.Time
+}
+
+var defaultNotificationBreaker = newNotificationBreaker()
+
+func newNotificationBreaker() *notificationBreaker {
+	return &notificationBreaker{
+		failures:  make(map[string]int),
+		openUntil: make(map[string]time.Time),
+	}
+}
+
+// notify calls send unless node's breaker is open, records the outcome, and
+// emits notification_circuit_open when this failure opens it. A threshold
+// or cooldown <= 0 disables the breaker.
+func (b *notificationBreaker) notify(node string, threshold int, cooldown time.Duration, now time.Time, events chan<- DaemonEvent, send func() bool) {
+	if threshold <= 0 || cooldown <= 0 {
+		send()
+		return
+	}
+
+	b.mu.Lock()
+	until, open := b.openUntil[node]
+	b.mu.Unlock()
+	if open && now.Before(until) {
+		log.Printf("postman: notification circuit open for %s until %s; skipping pane notification\n", node, until.Format(time.RFC3339))
+		return
+	}
+
+	ok := send()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if ok {
+		delete(b.failures, node)
+		delete(b.openUntil, node)
+		return
+	}
+	b.failures[node]++
+	failures := b.failures[node]
+	if failures < threshold {
+		return
+	}
+	b.openUntil[node] = now.Add(cooldown)
+	log.Printf("postman: WARNING: %d consecutive pane notification failures for %s; skipping notifications for %s\n", failures, node, cooldown)
+	if events == nil {
+		return
+	}
+	select {
+	case events <- DaemonEvent{
+		Type:    EventNotificationCircuitOpen,
+		Message: fmt.Sprintf("Notification circuit open: %s after %d failures (retry in %s)", node, failures, cooldown),
+		Details: map[string]interface{}{
+			"node":             node,
+			"failures":         failures,
+			"cooldown_seconds": cooldown.Seconds(),
+		},
+	}:
+	default:
+	}
+}
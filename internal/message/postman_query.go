@@ -0,0 +1,118 @@
+package message
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/discovery"
+	"github.com/i9wa4/tmux-a2a-postman/internal/nodeaddr"
+)
+
+// Methods postman answers when mail is addressed to it. Mail to postman
+// with any other method keeps the unknown-recipient handling.
+const (
+	PostmanMethodStatus = "postman/status"
+	PostmanMethodHelp   = "postman/help"
+)
+
+// postmanQueryMethod returns the postman method a message asks for, or "".
+func postmanQueryMethod(content string) string {
+	metadata, err := ParseEnvelopeMetadata(content)
+	if err != nil {
+		return ""
+	}
+	switch method := strings.TrimSpace(metadata.Method); method {
+	case PostmanMethodStatus, PostmanMethodHelp:
+		return method
+	}
+	return ""
+}
+
+// answerPostmanQuery writes postman's reply to method into the sender's
+// inbox and archives the request in read/.
+func answerPostmanQuery(method, postPath, sourceSessionDir, sourceSessionName, contextID, senderSimpleName string, content string, knownNodes map[string]discovery.NodeInfo, adjacency map[string][]string, livenessMap map[string]bool) error {
+	filename := filepath.Base(postPath)
+	var body string
+	switch method {
+	case PostmanMethodStatus:
+		body = postmanStatusBody(sourceSessionDir, sourceSessionName, contextID, senderSimpleName, knownNodes, adjacency, livenessMap)
+	default:
+		body = postmanHelpBody()
+	}
+
+	replyTo := filename
+	if metadata, err := ParseEnvelopeMetadata(content); err == nil && metadata.MessageID != "" {
+		replyTo = metadata.MessageID
+	}
+	now := time.Now()
+	replyName, err := GenerateFilename(now.Format("20060102-150405"), "postman", senderSimpleName, sourceSessionName)
+	if err != nil {
+		return fmt.Errorf("naming postman reply: %w", err)
+	}
+	reply := fmt.Sprintf("---\nparams:\n  contextId: %s\n  from: postman\n  to: %s\n  timestamp: %s\n  method: %s\n  replyTo: %s\n  messageType: status_update\n---\n\n%s",
+		contextID, senderSimpleName, now.Format(time.RFC3339), method, replyTo, body)
+	senderInbox := filepath.Join(sourceSessionDir, "inbox", senderSimpleName)
+	if err := os.MkdirAll(senderInbox, 0o700); err != nil {
+		return fmt.Errorf("creating inbox for postman reply: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(senderInbox, replyName), []byte(reply), 0o600); err != nil {
+		return fmt.Errorf("writing postman reply: %w", err)
+	}
+	if err := os.Rename(postPath, filepath.Join(sourceSessionDir, "read", filename)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("archiving postman query: %w", err)
+	}
+	log.Printf("📨 postman: answered %s from %s with %s\n", method, senderSimpleName, replyName)
+	return nil
+}
+
+func postmanStatusBody(sessionDir, sessionName, contextID, sender string, knownNodes map[string]discovery.NodeInfo, adjacency map[string][]string, livenessMap map[string]bool) string {
+	var b strings.Builder
+	b.WriteString("## Postman Status\n\n")
+	fmt.Fprintf(&b, "- context: %s\n", contextID)
+	fmt.Fprintf(&b, "- session: %s\n", sessionName)
+	fmt.Fprintf(&b, "- you: %s\n", sender)
+	fmt.Fprintf(&b, "- unread in your inbox: %d\n", len(ScanInboxMessages(filepath.Join(sessionDir, "inbox", sender))))
+
+	b.WriteString("\nYou can talk to:\n")
+	neighbors := config.GetTalksTo(adjacency, sender)
+	slices.Sort(neighbors)
+	if len(neighbors) == 0 {
+		b.WriteString("- none\n")
+	}
+	for _, neighbor := range neighbors {
+		key := nodeaddr.Full(neighbor, sessionName)
+		switch info, ok := knownNodes[key]; {
+		case !ok:
+			fmt.Fprintf(&b, "- %s (no pane)\n", neighbor)
+		case livenessMap[key]:
+			fmt.Fprintf(&b, "- %s (pane %s, live)\n", neighbor, info.PaneID)
+		default:
+			fmt.Fprintf(&b, "- %s (pane %s, not yet confirmed live)\n", neighbor, info.PaneID)
+		}
+	}
+
+	var sessionNodes []string
+	for key := range knownNodes {
+		if session, name, ok := nodeaddr.Split(key); ok && session == sessionName {
+			sessionNodes = append(sessionNodes, name)
+		}
+	}
+	slices.Sort(sessionNodes)
+	fmt.Fprintf(&b, "\nNodes with a pane in %s: %s\n", sessionName, strings.Join(sessionNodes, ", "))
+	return b.String()
+}
+
+func postmanHelpBody() string {
+	return "## Postman Help\n\n" +
+		"Mail addressed to postman is answered when its envelope params carry one of these methods:\n\n" +
+		"- " + PostmanMethodStatus + ": your inbox count, the nodes you can talk to, and which have panes\n" +
+		"- " + PostmanMethodHelp + ": this list\n\n" +
+		"The reply lands in your inbox; claim it with `tmux-a2a-postman pop`.\n" +
+		"Send mail to other nodes with `tmux-a2a-postman send-heredoc --to <node>`.\n"
+}
@@ -0,0 +1,45 @@
+package message
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/controlplane"
+	"github.com/i9wa4/tmux-a2a-postman/internal/discovery"
+)
+
+// RenotifyUnreadInbox re-sends the pane notification for every message still
+// in the node's inbox, oldest first, and returns the filenames it notified.
+// Read mail has already left inbox/ and is not repeated.
+func RenotifyUnreadInbox(nodeKey string, nodeInfo discovery.NodeInfo, cfg *config.Config, adjacency map[string][]string, knownNodes map[string]discovery.NodeInfo, contextID string, livenessMap map[string]bool) []string {
+	target := controlplane.TargetForNode(nodeKey, nodeInfo)
+	inboxDir := target.InboxDir()
+	entries, err := os.ReadDir(inboxDir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("postman: WARNING: renotify: reading %s: %v\n", inboxDir, err)
+		}
+		return nil
+	}
+	var unread []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+			continue
+		}
+		unread = append(unread, entry.Name())
+	}
+	// Filenames lead with their timestamp, so name order is delivery order.
+	sort.Strings(unread)
+	for _, filename := range unread {
+		sender := ""
+		if info, err := ParseMessageFilename(filename); err == nil {
+			sender = info.From
+		}
+		sendDeliveryNotification(target, cfg, adjacency, knownNodes, contextID, target.ActorID, sender, nodeInfo.SessionName, filepath.Join(inboxDir, filename), livenessMap, nil)
+	}
+	return unread
+}
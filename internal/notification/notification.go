@@ -14,6 +14,7 @@ import (
 	"github.com/i9wa4/tmux-a2a-postman/internal/config"
 	"github.com/i9wa4/tmux-a2a-postman/internal/discovery"
 	"github.com/i9wa4/tmux-a2a-postman/internal/envelope"
+	"github.com/i9wa4/tmux-a2a-postman/internal/nodeaddr"
 	"github.com/i9wa4/tmux-a2a-postman/internal/paneutil"
 )
 
@@ -61,28 +62,43 @@ func (n *PaneNotifier) InitCooldown(d time.Duration) {
 	n.cooldown = d
 }
 
-// BuildNotification builds a notification message using notification_template.
+// BuildNotification builds a notification message using the recipient's
+// [<node>] notification_template, falling back to the global one.
 // Variables available: from_node, node, timestamp, filename, inbox_path,
-// talks_to_line, template, reply_command, context_id.
+// message_path, talks_to_line, template, reply_command, context_id.
+// notification_include_path appends the delivered file's path unless the
+// template already shows {message_path}.
+// The recipient's notification_prefix and notification_suffix wrap the result
+// verbatim.
 // recipient and sender are simple node names (not session-prefixed).
 // sourceSessionName is the session name where the message originated.
 func BuildNotification(cfg *config.Config, adjacency map[string][]string, nodes map[string]discovery.NodeInfo, contextID, recipient, sender, sourceSessionName, filename string, livenessMap map[string]bool) string {
-	return envelope.BuildNotificationEnvelope(cfg, cfg.NotificationTemplate, recipient, sender, contextID, filename, nil, adjacency, nodes, sourceSessionName, livenessMap)
+	nodeCfg := cfg.GetNodeConfig(nodeaddr.Simple(recipient))
+	tmpl := cfg.NotificationTemplate
+	if nodeCfg.NotificationTemplate != "" {
+		tmpl = nodeCfg.NotificationTemplate
+	}
+	if config.BoolVal(cfg.NotificationIncludePath, false) && !strings.Contains(tmpl, "{message_path}") {
+		tmpl += "\n\nInbox file: {message_path}"
+	}
+	body := envelope.BuildNotificationEnvelope(cfg, tmpl, recipient, sender, contextID, filename, nil, adjacency, nodes, sourceSessionName, livenessMap)
+	return nodeCfg.NotificationPrefix + body + nodeCfg.NotificationSuffix
 }
 
 // SendToPane sends a message to a tmux pane using set-buffer + paste-buffer.
 // Security: Sanitizes message before passing to tmux set-buffer.
 // Error handling: Logs errors but does not fail (graceful degradation).
+// preEnterDelay is waited before the text is pasted; enterDelay between the text and C-m.
 // enterCount controls how many C-m keystrokes to send; 0 or 1 sends one, N>=2 sends N total.
 // bypassCooldown skips the per-pane rate limit; direct message delivery passes true.
 // verifyDelay > 0 enables post-Enter capture comparison: after C-m, waits verifyDelay,
 // captures pane, waits again, captures again; if identical, retries C-m up to maxRetries.
-func SendToPane(paneID string, message string, enterDelay time.Duration, tmuxTimeout time.Duration, enterCount int, bypassCooldown bool, verifyDelay time.Duration, maxRetries int) error {
-	return defaultPaneNotifier.SendToPane(paneID, message, enterDelay, tmuxTimeout, enterCount, bypassCooldown, verifyDelay, maxRetries)
+func SendToPane(paneID string, message string, preEnterDelay time.Duration, enterDelay time.Duration, tmuxTimeout time.Duration, enterCount int, bypassCooldown bool, verifyDelay time.Duration, maxRetries int) error {
+	return defaultPaneNotifier.SendToPane(paneID, message, preEnterDelay, enterDelay, tmuxTimeout, enterCount, bypassCooldown, verifyDelay, maxRetries)
 }
 
 // SendToPane sends a message to a tmux pane using this notifier's dependencies and cooldown state.
-func (n *PaneNotifier) SendToPane(paneID string, message string, enterDelay time.Duration, tmuxTimeout time.Duration, enterCount int, bypassCooldown bool, verifyDelay time.Duration, maxRetries int) error {
+func (n *PaneNotifier) SendToPane(paneID string, message string, preEnterDelay time.Duration, enterDelay time.Duration, tmuxTimeout time.Duration, enterCount int, bypassCooldown bool, verifyDelay time.Duration, maxRetries int) error {
 	if n == nil {
 		n = defaultPaneNotifier
 	}
@@ -117,6 +133,12 @@ func (n *PaneNotifier) SendToPane(paneID string, message string, enterDelay time
 		return err
 	}
 
+	// 0. Wait pre_enter_delay so a momentarily busy agent TUI can settle
+	// before the text arrives.
+	if preEnterDelay > 0 {
+		n.sleepFor(preEnterDelay)
+	}
+
 	// 1-2. Set buffer + paste buffer (serialized via bufferMu to prevent
 	// global tmux paste-buffer race when deliveries run concurrently).
 	bufferMu.Lock()
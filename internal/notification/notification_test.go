@@ -5,6 +5,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -122,6 +123,49 @@ func TestBuildNotification(t *testing.T) {
 	}
 }
 
+func TestBuildNotification_PerNodeTemplateOverridesGlobal(t *testing.T) {
+	cfg := &config.Config{
+		NotificationTemplate: "Global: mail from {from_node}",
+		TmuxTimeout:          5.0,
+		Nodes: map[string]config.NodeConfig{
+			"observer": {NotificationTemplate: "Observer: {from_node} wrote to you"},
+			"worker":   {Role: "implementation"},
+		},
+	}
+	filename := "/path/to/session/post/20260204-120000-from-orchestrator-to-observer.md"
+
+	observer := BuildNotification(cfg, map[string][]string{}, map[string]discovery.NodeInfo{}, "ctx", "test:observer", "orchestrator", "test", filename, nil)
+	if !strings.Contains(observer, "Observer: orchestrator wrote to you") || strings.Contains(observer, "Global:") {
+		t.Fatalf("observer notification = %q, want its own template", observer)
+	}
+
+	worker := BuildNotification(cfg, map[string][]string{}, map[string]discovery.NodeInfo{}, "ctx", "worker", "orchestrator", "test", filename, nil)
+	if !strings.Contains(worker, "Global: mail from orchestrator") {
+		t.Fatalf("worker notification = %q, want the global template", worker)
+	}
+}
+
+func TestBuildNotification_PrefixAndSuffixWrapText(t *testing.T) {
+	cfg := &config.Config{
+		NotificationTemplate: "Mail from {from_node}",
+		TmuxTimeout:          5.0,
+		Nodes: map[string]config.NodeConfig{
+			"repl":   {NotificationPrefix: "/inbox ", NotificationSuffix: " <submit>"},
+			"worker": {Role: "implementation"},
+		},
+	}
+	filename := "/path/to/session/post/20260204-120000-from-orchestrator-to-repl.md"
+
+	plain := BuildNotification(cfg, map[string][]string{}, map[string]discovery.NodeInfo{}, "ctx", "worker", "orchestrator", "test", filename, nil)
+	wrapped := BuildNotification(cfg, map[string][]string{}, map[string]discovery.NodeInfo{}, "ctx", "test:repl", "orchestrator", "test", filename, nil)
+	if want := "/inbox " + plain + " <submit>"; wrapped != want {
+		t.Fatalf("repl notification = %q, want %q", wrapped, want)
+	}
+	if plain != "Mail from orchestrator" {
+		t.Fatalf("worker notification = %q, want the unwrapped template", plain)
+	}
+}
+
 func TestBuildNotification_ReplyCommandExpandsConcreteRecipient(t *testing.T) {
 	cfg := &config.Config{
 		NotificationTemplate: "Reply: {reply_command}",
@@ -394,7 +438,7 @@ func TestStripVT(t *testing.T) {
 
 func TestSendToPane_InvalidPane(t *testing.T) {
 	// Test that SendToPane gracefully handles invalid pane
-	err := SendToPane("invalid-pane", "test message", 100*time.Millisecond, 1*time.Second, 1, true, 0, 0)
+	err := SendToPane("invalid-pane", "test message", 0, 100*time.Millisecond, 1*time.Second, 1, true, 0, 0)
 	if err == nil {
 		t.Error("SendToPane() with invalid pane should return error")
 	}
@@ -411,7 +455,7 @@ func TestSendToPane_RejectsEmptyNotificationBody(t *testing.T) {
 	origPath := os.Getenv("PATH")
 	t.Setenv("PATH", tmpDir+":"+origPath)
 
-	err := SendToPane("%99", " \n\t ", 1*time.Millisecond, 1*time.Second, 1, true, 0, 0)
+	err := SendToPane("%99", " \n\t ", 0, 1*time.Millisecond, 1*time.Second, 1, true, 0, 0)
 	if err == nil {
 		t.Fatal("SendToPane(empty) error = nil, want error")
 	}
@@ -426,10 +470,10 @@ func TestSendToPane_RejectsEmptyNotificationBody(t *testing.T) {
 func TestPaneNotifierCooldownIsInstanceScoped(t *testing.T) {
 	now := time.Date(2026, time.June, 1, 1, 0, 0, 0, time.UTC)
 	notifierA, callsA := paneNotifierForTest(now, time.Minute)
-	if err := notifierA.SendToPane("%1", "hello", 0, 0, 1, false, 0, 0); err != nil {
+	if err := notifierA.SendToPane("%1", "hello", 0, 0, 0, 1, false, 0, 0); err != nil {
 		t.Fatalf("notifierA first SendToPane: %v", err)
 	}
-	if err := notifierA.SendToPane("%1", "hello again", 0, 0, 1, false, 0, 0); err != nil {
+	if err := notifierA.SendToPane("%1", "hello again", 0, 0, 0, 1, false, 0, 0); err != nil {
 		t.Fatalf("notifierA second SendToPane: %v", err)
 	}
 	if *callsA != 3 {
@@ -437,7 +481,7 @@ func TestPaneNotifierCooldownIsInstanceScoped(t *testing.T) {
 	}
 
 	notifierB, callsB := paneNotifierForTest(now, time.Minute)
-	if err := notifierB.SendToPane("%1", "independent hello", 0, 0, 1, false, 0, 0); err != nil {
+	if err := notifierB.SendToPane("%1", "independent hello", 0, 0, 0, 1, false, 0, 0); err != nil {
 		t.Fatalf("notifierB SendToPane: %v", err)
 	}
 	if *callsB != 3 {
@@ -448,10 +492,10 @@ func TestPaneNotifierCooldownIsInstanceScoped(t *testing.T) {
 func TestPaneNotifierBypassCooldown(t *testing.T) {
 	now := time.Date(2026, time.June, 1, 1, 0, 0, 0, time.UTC)
 	notifier, calls := paneNotifierForTest(now, time.Minute)
-	if err := notifier.SendToPane("%1", "hello", 0, 0, 1, true, 0, 0); err != nil {
+	if err := notifier.SendToPane("%1", "hello", 0, 0, 0, 1, true, 0, 0); err != nil {
 		t.Fatalf("first SendToPane: %v", err)
 	}
-	if err := notifier.SendToPane("%1", "hello again", 0, 0, 1, true, 0, 0); err != nil {
+	if err := notifier.SendToPane("%1", "hello again", 0, 0, 0, 1, true, 0, 0); err != nil {
 		t.Fatalf("second SendToPane: %v", err)
 	}
 	if *calls != 6 {
@@ -459,6 +503,26 @@ func TestPaneNotifierBypassCooldown(t *testing.T) {
 	}
 }
 
+func TestPaneNotifierPreEnterDelayPrecedesTextAndEnterDelayPrecedesSubmit(t *testing.T) {
+	now := time.Date(2026, time.June, 1, 1, 0, 0, 0, time.UTC)
+	notifier, _ := paneNotifierForTest(now, 0)
+	var sequence []string
+	notifier.sleep = func(d time.Duration) {
+		sequence = append(sequence, "sleep "+d.String())
+	}
+	notifier.runTmux = func(args ...string) error {
+		sequence = append(sequence, args[0])
+		return nil
+	}
+	if err := notifier.SendToPane("%1", "hello", 2*time.Second, 3*time.Second, 0, 1, true, 0, 0); err != nil {
+		t.Fatalf("SendToPane: %v", err)
+	}
+	want := []string{"sleep 2s", "set-buffer", "paste-buffer", "sleep 3s", "send-keys"}
+	if !reflect.DeepEqual(sequence, want) {
+		t.Fatalf("send sequence = %v, want %v", sequence, want)
+	}
+}
+
 func TestPaneNotifierEnterVerifyRetryUsesInjectedDependencies(t *testing.T) {
 	now := time.Date(2026, time.June, 1, 1, 0, 0, 0, time.UTC)
 	notifier, _ := paneNotifierForTest(now, 0)
@@ -472,7 +536,7 @@ func TestPaneNotifierEnterVerifyRetryUsesInjectedDependencies(t *testing.T) {
 	notifier.capture = func(paneID string) (string, error) {
 		return "unchanged", nil
 	}
-	if err := notifier.SendToPane("%1", "hello", 0, 0, 1, true, 1*time.Millisecond, 2); err != nil {
+	if err := notifier.SendToPane("%1", "hello", 0, 0, 0, 1, true, 1*time.Millisecond, 2); err != nil {
 		t.Fatalf("SendToPane: %v", err)
 	}
 	if sendKeys != 3 {
@@ -492,7 +556,7 @@ func TestSendToPane_EnterCount2(t *testing.T) {
 	origPath := os.Getenv("PATH")
 	t.Setenv("PATH", tmpDir+":"+origPath)
 
-	err := SendToPane("%99", "hello", 1*time.Millisecond, 1*time.Second, 2, true, 0, 0)
+	err := SendToPane("%99", "hello", 0, 1*time.Millisecond, 1*time.Second, 2, true, 0, 0)
 	if err != nil {
 		t.Fatalf("SendToPane failed: %v", err)
 	}
@@ -531,7 +595,7 @@ func TestSendToPane_EnterCount3(t *testing.T) {
 	origPath := os.Getenv("PATH")
 	t.Setenv("PATH", tmpDir+":"+origPath)
 
-	err := SendToPane("%99", "hello", 1*time.Millisecond, 1*time.Second, 3, true, 0, 0)
+	err := SendToPane("%99", "hello", 0, 1*time.Millisecond, 1*time.Second, 3, true, 0, 0)
 	if err != nil {
 		t.Fatalf("SendToPane failed: %v", err)
 	}
@@ -569,7 +633,7 @@ func TestSendToPane_EnterCount1(t *testing.T) {
 	origPath := os.Getenv("PATH")
 	t.Setenv("PATH", tmpDir+":"+origPath)
 
-	err := SendToPane("%99", "hello", 1*time.Millisecond, 1*time.Second, 1, true, 0, 0)
+	err := SendToPane("%99", "hello", 0, 1*time.Millisecond, 1*time.Second, 1, true, 0, 0)
 	if err != nil {
 		t.Fatalf("SendToPane failed: %v", err)
 	}
@@ -607,7 +671,7 @@ func TestSendToPane_EnterCount0(t *testing.T) {
 	origPath := os.Getenv("PATH")
 	t.Setenv("PATH", tmpDir+":"+origPath)
 
-	err := SendToPane("%99", "hello", 1*time.Millisecond, 1*time.Second, 0, true, 0, 0)
+	err := SendToPane("%99", "hello", 0, 1*time.Millisecond, 1*time.Second, 0, true, 0, 0)
 	if err != nil {
 		t.Fatalf("SendToPane failed: %v", err)
 	}
@@ -646,7 +710,7 @@ func TestSendToPane_EnterCountNegative(t *testing.T) {
 	t.Setenv("PATH", tmpDir+":"+origPath)
 
 	// Negative enterCount: loop does not execute, no panic; sends exactly 1 Enter
-	err := SendToPane("%99", "hello", 1*time.Millisecond, 1*time.Second, -1, true, 0, 0)
+	err := SendToPane("%99", "hello", 0, 1*time.Millisecond, 1*time.Second, -1, true, 0, 0)
 	if err != nil {
 		t.Fatalf("SendToPane with negative enterCount should not error: %v", err)
 	}
@@ -696,7 +760,7 @@ esac
 		os.Stderr = origStderr
 	}()
 
-	err = SendToPane("%99", "hello", 1*time.Millisecond, 1*time.Second, 1, true, 1*time.Millisecond, 2)
+	err = SendToPane("%99", "hello", 0, 1*time.Millisecond, 1*time.Second, 1, true, 1*time.Millisecond, 2)
 	if err != nil {
 		t.Fatalf("SendToPane failed: %v", err)
 	}
@@ -726,3 +790,31 @@ func paneNotifierForTest(now time.Time, cooldown time.Duration) (*PaneNotifier,
 	}
 	return notifier, &calls
 }
+
+func TestBuildNotification_IncludePath(t *testing.T) {
+	sessionDir := filepath.Join(t.TempDir(), "ctx", "review")
+	postPath := filepath.Join(t.TempDir(), "post", "20260501-101500-s0001-from-orchestrator-to-worker.md")
+	nodes := map[string]discovery.NodeInfo{
+		"review:worker": {PaneID: "%1", SessionName: "review", SessionDir: sessionDir},
+	}
+	wantPath := filepath.Join(sessionDir, "inbox", "worker", "20260501-101500-s0001-from-orchestrator-to-worker.md")
+	build := func(tmpl string, includePath bool) string {
+		cfg := &config.Config{
+			NotificationTemplate:    tmpl,
+			NotificationIncludePath: &includePath,
+			TmuxTimeout:             5.0,
+		}
+		return BuildNotification(cfg, map[string][]string{}, nodes, "ctx", "worker", "orchestrator", "review", postPath, nil)
+	}
+
+	if got := build("Mail from {from_node}", false); strings.Contains(got, "Inbox file:") {
+		t.Fatalf("notification without notification_include_path = %q, want no path", got)
+	}
+	if got, want := build("Mail from {from_node}", true), "Mail from orchestrator\n\nInbox file: "+wantPath; got != want {
+		t.Fatalf("notification = %q, want %q", got, want)
+	}
+	// A template that already shows the path is not given a second copy.
+	if got, want := build("Ack {message_path}", true), "Ack "+wantPath; got != want {
+		t.Fatalf("notification = %q, want %q", got, want)
+	}
+}
@@ -74,13 +74,31 @@ func SendPingToNodeWithOptions(nodeInfo discovery.NodeInfo, contextID, nodeName,
 	content = template.ExpandVariables(content, map[string]string{
 		"message_type": "ping",
 		"heading":      "Ping",
-		"message":      "PING from postman daemon. Do NOT reply to this message.",
+		"message":      pingMessage(cfg, simpleName, contextID, postPath, activeNodes, adjacency, nodes, sourceSessionName),
 		"role_content": roleContent,
 	})
 
 	return message.DeliverSystemMessageDirectResultToTarget(filename, target, "postman", contextID, content, cfg, adjacency, nodes, livenessMap)
 }
 
+// defaultPingMessage is the PING line for nodes without a ping_template.
+const defaultPingMessage = "PING from postman daemon. Do NOT reply to this message."
+
+// pingMessage renders the node's ping_template with the same variables as
+// the envelope around it, so each agent can get role-specific onboarding.
+func pingMessage(cfg *config.Config, simpleName, contextID, postPath string, activeNodes []string, adjacency map[string][]string, nodes map[string]discovery.NodeInfo, sourceSessionName string) string {
+	if cfg == nil {
+		return defaultPingMessage
+	}
+	tmpl := cfg.GetNodeConfig(simpleName).PingTemplate
+	if tmpl == "" {
+		return defaultPingMessage
+	}
+	// Keep user content from closing the envelope early.
+	tmpl = strings.ReplaceAll(tmpl, "<!-- end of message -->", "<!-- end of msg -->")
+	return envelope.BuildEnvelope(cfg, tmpl, simpleName, "postman", contextID, postPath, activeNodes, adjacency, nodes, sourceSessionName, nil)
+}
+
 func joinSkillCatalogs(catalogs []string) string {
 	var parts []string
 	for _, catalog := range catalogs {
@@ -179,6 +179,56 @@ func TestSendPingToNode_ReplyCommandExpandsConcreteRecipient(t *testing.T) {
 	}
 }
 
+func TestSendPingToNode_NodePingTemplateExpandsRoleSpecificContent(t *testing.T) {
+	tmpDir := t.TempDir()
+	sessionDir := filepath.Join(tmpDir, "test-session")
+	if err := config.CreateSessionDirs(sessionDir); err != nil {
+		t.Fatalf("CreateSessionDirs: %v", err)
+	}
+
+	nodes := map[string]discovery.NodeInfo{
+		"test-session:worker":       {PaneID: "%100", SessionName: "test-session", SessionDir: sessionDir},
+		"test-session:orchestrator": {PaneID: "%101", SessionName: "test-session", SessionDir: sessionDir},
+	}
+	adjacency := map[string][]string{
+		"worker":       {"orchestrator"},
+		"orchestrator": {"worker"},
+	}
+	cfg := &config.Config{
+		TmuxTimeout:  5.0,
+		ReplyCommand: "tmux-a2a-postman send-heredoc --to <recipient>",
+		Nodes: map[string]config.NodeConfig{
+			"worker": {PingTemplate: "Welcome, {node}. {talks_to_line}. Report with: {reply_command}"},
+		},
+	}
+	tmpl := "# {heading}\n\n{message}\n"
+
+	if err := SendPingToNode(nodes["test-session:worker"], "ctx-ping", "test-session:worker", tmpl, cfg, []string{"worker", "orchestrator"}, nil, adjacency, nodes); err != nil {
+		t.Fatalf("SendPingToNode(worker) error = %v", err)
+	}
+	_, body := readSingleInboxMessage(t, sessionDir, "worker")
+	for _, want := range []string{
+		"Welcome, worker.",
+		"Can talk to: orchestrator.",
+		"Report with: tmux-a2a-postman send-heredoc --to worker",
+	} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("worker PING missing %q: %q", want, body)
+		}
+	}
+	if strings.Contains(body, "PING from postman daemon") {
+		t.Fatalf("worker PING kept the built-in line: %q", body)
+	}
+
+	if err := SendPingToNode(nodes["test-session:orchestrator"], "ctx-ping", "test-session:orchestrator", tmpl, cfg, []string{"worker", "orchestrator"}, nil, adjacency, nodes); err != nil {
+		t.Fatalf("SendPingToNode(orchestrator) error = %v", err)
+	}
+	_, body = readSingleInboxMessage(t, sessionDir, "orchestrator")
+	if !strings.Contains(body, "PING from postman daemon. Do NOT reply to this message.") {
+		t.Fatalf("orchestrator PING = %q, want the built-in line", body)
+	}
+}
+
 func TestSendPingToNode_DefaultDaemonTemplateShowsContactRoles(t *testing.T) {
 	tmpDir := t.TempDir()
 	t.Setenv("HOME", tmpDir)
@@ -25,6 +25,7 @@ type DaemonSubmitCommand string
 const (
 	DaemonSubmitSend               DaemonSubmitCommand = "send"
 	DaemonSubmitPop                DaemonSubmitCommand = "pop"
+	DaemonSubmitAck                DaemonSubmitCommand = "ack"
 	DaemonSubmitRuntimeDiagnostics DaemonSubmitCommand = "runtime-diagnostics"
 	DaemonSubmitRuntimeProfile     DaemonSubmitCommand = "runtime-profile"
 )
@@ -38,10 +38,14 @@ const (
 	MailboxProjectionDeliveredEventType    = "mailbox_projection_delivered"
 	MailboxProjectionReadEventType         = "mailbox_projection_read"
 	MailboxProjectionDeadLetteredEventType = "mailbox_projection_dead_lettered"
+	// A read/ message packed into read/archive/ by read_archival_seconds.
+	MailboxProjectionReadArchivedEventType = "mailbox_projection_read_archived"
 )
 
 var mailboxProjectionRoots = []string{"post", "inbox", "read", "dead-letter"}
 
+var readArchiveRel = filepath.Join("read", "archive")
+
 func ProjectMailboxProjection(sessionDir string) (MailboxProjection, bool, error) {
 	state, ok := loadCurrentSessionState(sessionDir)
 	if !ok {
@@ -150,6 +154,9 @@ func ProjectMailboxProjection(sessionDir string) (MailboxProjection, bool, error
 			if !setProjectedFile(projected.Read, payload.Path, payload.Content) {
 				return MailboxProjection{}, false, fmt.Errorf("invalid read path %q", payload.Path)
 			}
+		case MailboxProjectionReadArchivedEventType:
+			delete(projected.Read, pathKey(payload.Path))
+			delete(projected.tombstonedRead, pathKey(payload.Path))
 		case MailboxProjectionDeadLetteredEventType:
 			rememberManagedPost(projected.managedPost, payload.SourcePath)
 			delete(projected.Post, pathKey(payload.SourcePath))
@@ -310,14 +317,18 @@ func syncDesiredMailboxFiles(sessionDir string, desired map[string]string, manag
 			if err != nil {
 				return err
 			}
-			if d.IsDir() {
-				return nil
-			}
 			rel, err := filepath.Rel(sessionDir, path)
 			if err != nil {
 				return err
 			}
 			rel = pathKey(rel)
+			if d.IsDir() {
+				// read/archive/ holds tarballs the journal does not track.
+				if rel == readArchiveRel {
+					return filepath.SkipDir
+				}
+				return nil
+			}
 			if _, ok := desired[rel]; ok {
 				return nil
 			}
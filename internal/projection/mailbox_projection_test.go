@@ -246,6 +246,56 @@ func TestSyncMailboxProjection_RemovesConsumedProjectedPostFiles(t *testing.T) {
 	}
 }
 
+func TestSyncMailboxProjection_ReadArchivedStaysArchived(t *testing.T) {
+	sessionDir := t.TempDir()
+	now := time.Date(2026, time.April, 14, 6, 0, 0, 0, time.UTC)
+
+	writer, err := journal.OpenShadowWriter(sessionDir, "ctx-main", "review", 101, now)
+	if err != nil {
+		t.Fatalf("OpenShadowWriter() error = %v", err)
+	}
+	name := "20260414-060001-r1111-from-orchestrator-to-worker.md"
+	readRel := filepath.Join("read", name)
+	appendMailboxEventForTest(t, writer, MailboxProjectionReadEventType, journal.VisibilityOperatorVisible, journal.MailboxEventPayload{
+		MessageID: name,
+		From:      "orchestrator",
+		To:        "worker",
+		Path:      readRel,
+		Content:   "read body",
+	}, now.Add(time.Second))
+	if err := SyncMailboxProjection(sessionDir); err != nil {
+		t.Fatalf("SyncMailboxProjection(initial) error = %v", err)
+	}
+
+	// Archival removes the read/ file, leaves a tarball, and journals it.
+	if err := os.Remove(filepath.Join(sessionDir, readRel)); err != nil {
+		t.Fatalf("Remove(read file): %v", err)
+	}
+	tarball := filepath.Join(sessionDir, "read", "archive", "read-20260414-060002.tar.gz")
+	if err := os.MkdirAll(filepath.Dir(tarball), 0o700); err != nil {
+		t.Fatalf("MkdirAll(archive): %v", err)
+	}
+	if err := os.WriteFile(tarball, []byte("tarball"), 0o600); err != nil {
+		t.Fatalf("WriteFile(tarball): %v", err)
+	}
+	appendMailboxEventForTest(t, writer, MailboxProjectionReadArchivedEventType, journal.VisibilityOperatorVisible, journal.MailboxEventPayload{
+		MessageID: name,
+		From:      "orchestrator",
+		To:        "worker",
+		Path:      readRel,
+	}, now.Add(2*time.Second))
+
+	if err := SyncMailboxProjection(sessionDir); err != nil {
+		t.Fatalf("SyncMailboxProjection(archived) error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(sessionDir, readRel)); !os.IsNotExist(err) {
+		t.Fatalf("archived read file was written back or wrong error: %v", err)
+	}
+	if _, err := os.Stat(tarball); err != nil {
+		t.Fatalf("read archive tarball removed by sync: %v", err)
+	}
+}
+
 // TestProjectMailboxProjection_IgnoresEmptyContentReadEvent reproduces the
 // #633 root cause: a burst of racy mailbox_projection_read events for the
 // same message_id, where a later event's payload carries empty content
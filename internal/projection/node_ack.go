@@ -0,0 +1,11 @@
+package projection
+
+// NodeAckedEventType records an inbox message a node acknowledged with the
+// ack command. The move into read/ is journaled as a mailbox read event like
+// any pop; this event is what counts as the node answering its mail.
+const NodeAckedEventType = "node_acked"
+
+type NodeAckedEventPayload struct {
+	NodeKey  string `json:"node_key"`
+	Filename string `json:"filename"`
+}
@@ -0,0 +1,128 @@
+package projection
+
+import (
+	"time"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/journal"
+	"github.com/i9wa4/tmux-a2a-postman/internal/nodeaddr"
+)
+
+// NodeStats summarizes one node's mailbox activity in the journal.
+type NodeStats struct {
+	Node         string
+	Received     int
+	Sent         int
+	DeadLettered int
+	// Read counts received messages with a read event, the sample behind
+	// AverageReadLatency (delivery to read).
+	Read               int
+	AverageReadLatency time.Duration
+	// DroppedBalls counts received messages left unread for longer than the
+	// dropped-ball threshold, whether read late or not at all.
+	DroppedBalls int
+}
+
+// NodeStatsRange bounds which events count. Zero Since or Until leaves that
+// side open; Now closes the window for messages that were never read.
+type NodeStatsRange struct {
+	Since        time.Time
+	Until        time.Time
+	Now          time.Time
+	DroppedAfter time.Duration
+}
+
+func (r NodeStatsRange) contains(at time.Time) bool {
+	if !r.Since.IsZero() && at.Before(r.Since) {
+		return false
+	}
+	if !r.Until.IsZero() && !at.Before(r.Until) {
+		return false
+	}
+	return true
+}
+
+// ProjectNodeStats replays every journal generation in sessionDir and
+// summarizes node: messages delivered to it, messages it sent (left post/
+// delivered or dead-lettered), its dead-lettered mail, mean delivery-to-read
+// latency, and dropped balls. Receipts are bucketed by delivery time and
+// sends by consume time.
+func ProjectNodeStats(sessionDir, sessionName, node string, window NodeStatsRange) (NodeStats, error) {
+	target := nodeaddr.Full(node, sessionName)
+	stats := NodeStats{Node: node}
+	delivered := make(map[string]time.Time)
+	readAt := make(map[string]time.Time)
+	sent := make(map[string]bool)
+	deadLettered := make(map[string]bool)
+
+	err := journal.ReplayEach(sessionDir, func(event journal.Event) error {
+		switch event.Type {
+		case MailboxProjectionDeliveredEventType, MailboxProjectionReadEventType,
+			MailboxProjectionPostConsumedEventType, MailboxProjectionDeadLetteredEventType:
+		default:
+			return nil
+		}
+		payload, ok := decodeMailboxEventPayload(event.Payload)
+		if !ok || payload.MessageID == "" {
+			return nil
+		}
+		at, err := time.Parse(time.RFC3339, event.OccurredAt)
+		if err != nil {
+			return nil
+		}
+
+		switch event.Type {
+		case MailboxProjectionDeliveredEventType:
+			if nodeaddr.Full(payload.To, sessionName) != target || !window.contains(at) {
+				return nil
+			}
+			if _, seen := delivered[payload.MessageID]; !seen {
+				delivered[payload.MessageID] = at
+			}
+		case MailboxProjectionReadEventType:
+			if _, seen := readAt[payload.MessageID]; !seen {
+				readAt[payload.MessageID] = at
+			}
+		case MailboxProjectionPostConsumedEventType:
+			if nodeaddr.Full(payload.From, sessionName) == target && window.contains(at) {
+				sent[payload.MessageID] = true
+			}
+		case MailboxProjectionDeadLetteredEventType:
+			if nodeaddr.Full(payload.From, sessionName) == target && window.contains(at) {
+				sent[payload.MessageID] = true
+				deadLettered[payload.MessageID] = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return NodeStats{}, err
+	}
+
+	end := window.Now
+	if !window.Until.IsZero() && (end.IsZero() || window.Until.Before(end)) {
+		end = window.Until
+	}
+	var totalLatency time.Duration
+	for messageID, deliveredAt := range delivered {
+		stats.Received++
+		read, ok := readAt[messageID]
+		if ok && !read.Before(deliveredAt) {
+			latency := read.Sub(deliveredAt)
+			stats.Read++
+			totalLatency += latency
+			if window.DroppedAfter > 0 && latency > window.DroppedAfter {
+				stats.DroppedBalls++
+			}
+			continue
+		}
+		if window.DroppedAfter > 0 && !end.IsZero() && end.Sub(deliveredAt) > window.DroppedAfter {
+			stats.DroppedBalls++
+		}
+	}
+	if stats.Read > 0 {
+		stats.AverageReadLatency = totalLatency / time.Duration(stats.Read)
+	}
+	stats.Sent = len(sent)
+	stats.DeadLettered = len(deadLettered)
+	return stats, nil
+}
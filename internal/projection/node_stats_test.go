@@ -0,0 +1,73 @@
+package projection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/i9wa4/tmux-a2a-postman/internal/journal"
+)
+
+func TestProjectNodeStats_SummarizesSyntheticJournal(t *testing.T) {
+	sessionDir := t.TempDir()
+	start := time.Date(2026, time.May, 2, 9, 0, 0, 0, time.UTC)
+
+	writer, err := journal.OpenShadowWriter(sessionDir, "ctx-main", "review", 101, start)
+	if err != nil {
+		t.Fatalf("OpenShadowWriter() error = %v", err)
+	}
+	appendEvent := func(eventType, messageID, from, to string, at time.Time) {
+		t.Helper()
+		if _, err := writer.AppendEvent(eventType, journal.VisibilityMailboxProjection, journal.MailboxEventPayload{
+			MessageID: messageID,
+			From:      from,
+			To:        to,
+		}, at); err != nil {
+			t.Fatalf("AppendEvent(%s %s): %v", eventType, messageID, err)
+		}
+	}
+
+	// Before the range: ignored.
+	appendEvent(MailboxProjectionDeliveredEventType, "m0.md", "boss", "worker", start.Add(-time.Hour))
+	// Read after 1m and 3m.
+	appendEvent(MailboxProjectionDeliveredEventType, "m1.md", "boss", "worker", start.Add(time.Minute))
+	appendEvent(MailboxProjectionReadEventType, "m1.md", "boss", "worker", start.Add(2*time.Minute))
+	appendEvent(MailboxProjectionDeliveredEventType, "m2.md", "boss", "review:worker", start.Add(3*time.Minute))
+	appendEvent(MailboxProjectionReadEventType, "m2.md", "boss", "worker", start.Add(6*time.Minute))
+	// Read after 20 minutes: a dropped ball at a 10-minute threshold.
+	appendEvent(MailboxProjectionDeliveredEventType, "m3.md", "critic", "worker", start.Add(10*time.Minute))
+	appendEvent(MailboxProjectionReadEventType, "m3.md", "critic", "worker", start.Add(30*time.Minute))
+	// Never read and older than the threshold at the range end.
+	appendEvent(MailboxProjectionDeliveredEventType, "m4.md", "critic", "worker", start.Add(20*time.Minute))
+	// Never read but still recent: not dropped.
+	appendEvent(MailboxProjectionDeliveredEventType, "m5.md", "critic", "worker", start.Add(55*time.Minute))
+	// Another node's mail.
+	appendEvent(MailboxProjectionDeliveredEventType, "m6.md", "worker", "critic", start.Add(4*time.Minute))
+	// Sent by worker: two delivered, one dead-lettered.
+	appendEvent(MailboxProjectionPostConsumedEventType, "m6.md", "worker", "critic", start.Add(4*time.Minute))
+	appendEvent(MailboxProjectionPostConsumedEventType, "m7.md", "worker", "boss", start.Add(5*time.Minute))
+	appendEvent(MailboxProjectionDeadLetteredEventType, "m8.md", "worker", "ghost", start.Add(7*time.Minute))
+	// After the range: ignored.
+	appendEvent(MailboxProjectionPostConsumedEventType, "m9.md", "worker", "boss", start.Add(2*time.Hour))
+
+	got, err := ProjectNodeStats(sessionDir, "review", "worker", NodeStatsRange{
+		Since:        start,
+		Until:        start.Add(time.Hour),
+		Now:          start.Add(3 * time.Hour),
+		DroppedAfter: 10 * time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("ProjectNodeStats() error = %v", err)
+	}
+	want := NodeStats{
+		Node:               "worker",
+		Received:           5,
+		Sent:               3,
+		DeadLettered:       1,
+		Read:               3,
+		AverageReadLatency: (time.Minute + 3*time.Minute + 20*time.Minute) / 3,
+		DroppedBalls:       2,
+	}
+	if got != want {
+		t.Fatalf("ProjectNodeStats() = %+v, want %+v", got, want)
+	}
+}
@@ -314,6 +314,36 @@ type RuntimeDiagnostics struct {
 	NonDaemonDelivery NonDaemonDeliveryRuntimeDiagnostics `json:"non_daemon_delivery"`
 }
 
+// DeadLetterReasonCounts breaks dead-letters down by cause since the daemon
+// started. Causes without their own counter land in Other.
+type DeadLetterReasonCounts struct {
+	ParseError       int `json:"parse_error"`
+	UnknownRecipient int `json:"unknown_recipient"`
+	RoutingDenied    int `json:"routing_denied"`
+	SessionDisabled  int `json:"session_disabled"`
+	Expired          int `json:"expired"`
+	Other            int `json:"other"`
+}
+
+// MeshSummary is the daemon's periodic health rollup across all nodes.
+// DroppedBalls counts nodes holding unacknowledged mail while not active;
+// EmptySessions counts enabled sessions that have had no node for longer
+// than empty_session_alert_seconds.
+type MeshSummary struct {
+	ObservedAt        string `json:"observed_at"`
+	WindowSeconds     int    `json:"window_seconds"`
+	NodeCount         int    `json:"node_count"`
+	ActiveNodes       int    `json:"active_nodes"`
+	IdleNodes         int    `json:"idle_nodes"`
+	StaleNodes        int    `json:"stale_nodes"`
+	PendingInbox      int    `json:"pending_inbox"`
+	DroppedBalls      int    `json:"dropped_balls"`
+	RecentDeadLetters int    `json:"recent_dead_letters"`
+	EmptySessions     int    `json:"empty_sessions"`
+
+	DeadLettersByReason DeadLetterReasonCounts `json:"dead_letters_by_reason"`
+}
+
 type AllSessionStatus struct {
 	SchemaVersion int             `json:"schema_version"`
 	ContextID     string          `json:"context_id"`
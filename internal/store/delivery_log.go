@@ -0,0 +1,205 @@
+package store
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Delivery log outcomes. A post is journaled as delivered when it reaches
+// the recipient inbox; a later unconfirmed entry records that it was pulled
+// back out because the pane never showed the notification.
+const (
+	DeliveryOutcomeDelivered   = "delivered"
+	DeliveryOutcomeUnconfirmed = "unconfirmed"
+)
+
+// deliveryLogName is the append-only delivery log kept in each session dir.
+const deliveryLogName = "delivery-log.jsonl"
+
+// DeliveryLogEntry records one completed delivery of a post/ file.
+// ContentHash identifies the post content that was delivered, so a later
+// post that merely reuses the filename is not mistaken for a replay.
+type DeliveryLogEntry struct {
+	Filename    string    `json:"filename"`
+	Recipient   string    `json:"recipient"`
+	MessageID   string    `json:"message_id,omitempty"`
+	ContentHash string    `json:"content_hash,omitempty"`
+	Outcome     string    `json:"outcome"`
+	At          time.Time `json:"at"`
+}
+
+// PostContentHash returns the delivery log content hash for a post body.
+func PostContentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// DeliveryLogPath returns the delivery log path for sessionDir.
+func DeliveryLogPath(sessionDir string) string {
+	return filepath.Join(sessionDir, deliveryLogName)
+}
+
+// AppendDeliveryLog appends entry to the session's delivery log.
+func AppendDeliveryLog(sessionDir string, entry DeliveryLogEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encoding delivery log entry: %w", err)
+	}
+	f, err := os.OpenFile(DeliveryLogPath(sessionDir), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("opening delivery log: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("appending delivery log: %w", err)
+	}
+	return f.Close()
+}
+
+// ReadDeliveryLog returns the session's delivery log entries in append order.
+// A missing log is empty; malformed lines, such as one torn by a crash, are
+// skipped.
+func ReadDeliveryLog(sessionDir string) ([]DeliveryLogEntry, error) {
+	f, err := os.Open(DeliveryLogPath(sessionDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("opening delivery log: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var entries []DeliveryLogEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry DeliveryLogEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil || entry.Filename == "" {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading delivery log: %w", err)
+	}
+	return entries, nil
+}
+
+// DeliveredMessageIDs indexes the message ids each session's delivery log
+// records as delivered, per recipient. A session's log is read once, on
+// first use; after that the index is kept current by Claim. It is safe for
+// concurrent use.
+type DeliveredMessageIDs struct {
+	mu       sync.Mutex
+	sessions map[string]map[string]bool
+}
+
+// NewDeliveredMessageIDs returns an empty index.
+func NewDeliveredMessageIDs() *DeliveredMessageIDs {
+	return &DeliveredMessageIDs{sessions: make(map[string]map[string]bool)}
+}
+
+func deliveredMessageIDKey(messageID, recipient string) string {
+	return recipient + "\x00" + messageID
+}
+
+// Claim records messageID as delivered to recipient and reports true, or
+// reports false when it already was. The check and the insert happen under
+// one lock, so concurrent deliveries of the same id claim it once. A caller
+// whose delivery then does not happen must Release the claim.
+func (d *DeliveredMessageIDs) Claim(sessionDir, messageID, recipient string) (bool, error) {
+	if messageID == "" {
+		return true, nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	ids, ok := d.sessions[sessionDir]
+	if !ok {
+		entries, err := ReadDeliveryLog(sessionDir)
+		if err != nil {
+			return false, err
+		}
+		ids = make(map[string]bool)
+		for _, entry := range entries {
+			if entry.MessageID == "" {
+				continue
+			}
+			switch entry.Outcome {
+			case DeliveryOutcomeDelivered:
+				ids[deliveredMessageIDKey(entry.MessageID, entry.Recipient)] = true
+			case DeliveryOutcomeUnconfirmed:
+				delete(ids, deliveredMessageIDKey(entry.MessageID, entry.Recipient))
+			}
+		}
+		d.sessions[sessionDir] = ids
+	}
+	key := deliveredMessageIDKey(messageID, recipient)
+	if ids[key] {
+		return false, nil
+	}
+	ids[key] = true
+	return true, nil
+}
+
+// Release drops a claim whose delivery did not happen.
+func (d *DeliveredMessageIDs) Release(sessionDir, messageID, recipient string) {
+	if messageID == "" {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.sessions[sessionDir], deliveredMessageIDKey(messageID, recipient))
+}
+
+// PruneDeliveryLog drops entries older than maxAge and returns how many were
+// removed. The log is rewritten through a temp file and rename.
+func PruneDeliveryLog(sessionDir string, maxAge time.Duration, now time.Time) (int, error) {
+	entries, err := ReadDeliveryLog(sessionDir)
+	if err != nil || len(entries) == 0 {
+		return 0, err
+	}
+	cutoff := now.Add(-maxAge)
+	kept := entries[:0]
+	for _, entry := range entries {
+		if entry.At.After(cutoff) {
+			kept = append(kept, entry)
+		}
+	}
+	removed := len(entries) - len(kept)
+	if removed == 0 {
+		return 0, nil
+	}
+
+	tmp, err := os.CreateTemp(sessionDir, deliveryLogName+".tmp-*")
+	if err != nil {
+		return 0, fmt.Errorf("creating delivery log temp file: %w", err)
+	}
+	writer := bufio.NewWriter(tmp)
+	for _, entry := range kept {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			continue
+		}
+		_, _ = writer.Write(append(line, '\n'))
+	}
+	if err := writer.Flush(); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return 0, fmt.Errorf("writing delivery log: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return 0, fmt.Errorf("writing delivery log: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), DeliveryLogPath(sessionDir)); err != nil {
+		_ = os.Remove(tmp.Name())
+		return 0, fmt.Errorf("replacing delivery log: %w", err)
+	}
+	return removed, nil
+}
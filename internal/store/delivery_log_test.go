@@ -0,0 +1,79 @@
+package store
+
+import (
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDeliveryLogAppendReadPrune(t *testing.T) {
+	sessionDir := t.TempDir()
+	now := time.Date(2026, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	if entries, err := ReadDeliveryLog(sessionDir); err != nil || len(entries) != 0 {
+		t.Fatalf("ReadDeliveryLog(missing) = %v, %v; want empty", entries, err)
+	}
+	for _, entry := range []DeliveryLogEntry{
+		{Filename: "old.md", Recipient: "worker", Outcome: DeliveryOutcomeDelivered, At: now.Add(-48 * time.Hour)},
+		{Filename: "new.md", Recipient: "worker", Outcome: DeliveryOutcomeDelivered, At: now.Add(-time.Hour)},
+	} {
+		if err := AppendDeliveryLog(sessionDir, entry); err != nil {
+			t.Fatalf("AppendDeliveryLog: %v", err)
+		}
+	}
+	// A line torn by a crash mid-append must not hide the others.
+	f, err := os.OpenFile(DeliveryLogPath(sessionDir), os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatalf("OpenFile: %v", err)
+	}
+	_, _ = f.WriteString(`{"filename":"torn`)
+	_ = f.Close()
+
+	entries, err := ReadDeliveryLog(sessionDir)
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("ReadDeliveryLog() = %v, %v; want 2 entries", entries, err)
+	}
+
+	removed, err := PruneDeliveryLog(sessionDir, 24*time.Hour, now)
+	if err != nil || removed != 1 {
+		t.Fatalf("PruneDeliveryLog() = %d, %v; want 1 removed", removed, err)
+	}
+	entries, err = ReadDeliveryLog(sessionDir)
+	if err != nil || len(entries) != 1 || entries[0].Filename != "new.md" {
+		t.Fatalf("after prune ReadDeliveryLog() = %v, %v; want only new.md", entries, err)
+	}
+}
+
+func TestDeliveredMessageIDsClaimOnceAcrossGoroutines(t *testing.T) {
+	sessionDir := t.TempDir()
+	if err := AppendDeliveryLog(sessionDir, DeliveryLogEntry{Filename: "old.md", Recipient: "worker", MessageID: "req-1", Outcome: DeliveryOutcomeDelivered, At: time.Now()}); err != nil {
+		t.Fatalf("AppendDeliveryLog: %v", err)
+	}
+	ids := NewDeliveredMessageIDs()
+	if claimed, err := ids.Claim(sessionDir, "req-1", "worker"); err != nil || claimed {
+		t.Fatalf("Claim(logged id) = %v, %v; want false", claimed, err)
+	}
+
+	var wg sync.WaitGroup
+	var wins atomic.Int32
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if claimed, err := ids.Claim(sessionDir, "req-2", "worker"); err == nil && claimed {
+				wins.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	if wins.Load() != 1 {
+		t.Fatalf("concurrent claims won %d times, want 1", wins.Load())
+	}
+
+	ids.Release(sessionDir, "req-2", "worker")
+	if claimed, err := ids.Claim(sessionDir, "req-2", "worker"); err != nil || !claimed {
+		t.Fatalf("Claim after Release = %v, %v; want true", claimed, err)
+	}
+}
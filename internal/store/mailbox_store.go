@@ -109,6 +109,21 @@ func DeliverPostToInbox(postPath, recipientInbox, filename string) (string, erro
 	return dst, nil
 }
 
+// ArchivePostCopy writes the bytes of a live post file to post/archive/ so
+// they survive delivery. The archive is a subdirectory, so pending-post scans
+// never pick it up again.
+func ArchivePostCopy(postPath string, content []byte) (string, error) {
+	archiveDir := filepath.Join(filepath.Dir(postPath), "archive")
+	if err := os.MkdirAll(archiveDir, 0o700); err != nil {
+		return "", fmt.Errorf("creating post archive: %w", err)
+	}
+	dst := filepath.Join(archiveDir, filepath.Base(postPath))
+	if err := os.WriteFile(dst, content, 0o600); err != nil {
+		return "", fmt.Errorf("archiving post: %w", err)
+	}
+	return dst, nil
+}
+
 // ConsumePost removes a post file after another delivery backend consumed it.
 func ConsumePost(postPath string) error {
 	return os.Remove(postPath)
@@ -0,0 +1,162 @@
+package store
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ReadArchiveDir is where ArchiveOldReadMessages parks its tarballs. Scans of
+// read/ treat it as out of bounds.
+func ReadArchiveDir(sessionDir string) string {
+	return filepath.Join(sessionDir, "read", "archive")
+}
+
+// PostArchiveDir holds the post_retention = "copy" audit copies. Old copies
+// are packed into tarballs in the same directory.
+func PostArchiveDir(sessionDir string) string {
+	return filepath.Join(sessionDir, "post", "archive")
+}
+
+// ArchiveOldReadMessages packs every file directly under read/ last modified
+// more than maxAge before now into one read/archive/read-<timestamp>.tar.gz,
+// then removes the originals. It returns the paths it archived; nothing is
+// removed unless the tarball was written completely.
+func ArchiveOldReadMessages(sessionDir string, maxAge time.Duration, now time.Time) ([]string, error) {
+	return archiveOldFiles(filepath.Join(sessionDir, "read"), ReadArchiveDir(sessionDir), "read", maxAge, now)
+}
+
+// ArchiveOldPostCopies packs post/archive/ audit copies older than maxAge
+// into post/archive/post-<timestamp>.tar.gz the same way.
+func ArchiveOldPostCopies(sessionDir string, maxAge time.Duration, now time.Time) ([]string, error) {
+	archiveDir := PostArchiveDir(sessionDir)
+	return archiveOldFiles(archiveDir, archiveDir, "post", maxAge, now)
+}
+
+// RemovePostArchiveCopy deletes the post/archive/ audit copy of filename,
+// if there is one.
+func RemovePostArchiveCopy(sessionDir, filename string) error {
+	if err := os.Remove(filepath.Join(PostArchiveDir(sessionDir), filename)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// archiveOldFiles packs the old regular files of dir into one
+// archiveDir/<kind>-<timestamp>.tar.gz. Earlier tarballs and in-flight
+// temporaries are skipped, so archiveDir may be dir itself.
+func archiveOldFiles(dir, archiveDir, kind string, maxAge time.Duration, now time.Time) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading %s directory: %w", kind, err)
+	}
+	cutoff := now.Add(-maxAge)
+	var old []string
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || strings.HasPrefix(entry.Name(), ".") || strings.HasSuffix(entry.Name(), ".tar.gz") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if info.ModTime().Before(cutoff) {
+			old = append(old, filepath.Join(dir, entry.Name()))
+		}
+	}
+	if len(old) == 0 {
+		return nil, nil
+	}
+	sort.Strings(old)
+
+	if err := os.MkdirAll(archiveDir, 0o700); err != nil {
+		return nil, fmt.Errorf("creating %s archive directory: %w", kind, err)
+	}
+	tmp, err := os.CreateTemp(archiveDir, "."+kind+"-*.tar.gz.tmp")
+	if err != nil {
+		return nil, fmt.Errorf("creating %s archive: %w", kind, err)
+	}
+	if err := writeArchiveTarball(tmp, old); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return nil, err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return nil, fmt.Errorf("closing %s archive: %w", kind, err)
+	}
+	dst := uniqueArchivePath(archiveDir, kind, now)
+	if err := os.Rename(tmp.Name(), dst); err != nil {
+		_ = os.Remove(tmp.Name())
+		return nil, fmt.Errorf("finishing %s archive: %w", kind, err)
+	}
+
+	archived := make([]string, 0, len(old))
+	for _, path := range old {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return archived, fmt.Errorf("removing archived %s message: %w", kind, err)
+		}
+		archived = append(archived, path)
+	}
+	return archived, nil
+}
+
+func writeArchiveTarball(w io.Writer, paths []string) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	for _, path := range paths {
+		if err := addArchiveTarEntry(tw, path); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("writing archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("writing archive: %w", err)
+	}
+	return nil
+}
+
+func addArchiveTarEntry(tw *tar.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("opening message: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("stat message: %w", err)
+	}
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return fmt.Errorf("archiving %s: %w", info.Name(), err)
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("archiving %s: %w", info.Name(), err)
+	}
+	if _, err := io.Copy(tw, f); err != nil {
+		return fmt.Errorf("archiving %s: %w", info.Name(), err)
+	}
+	return nil
+}
+
+func uniqueArchivePath(archiveDir, kind string, now time.Time) string {
+	base := kind + "-" + now.Format("20060102-150405")
+	path := filepath.Join(archiveDir, base+".tar.gz")
+	for i := 2; ; i++ {
+		if _, err := os.Stat(path); err != nil {
+			return path
+		}
+		path = filepath.Join(archiveDir, fmt.Sprintf("%s-%d.tar.gz", base, i))
+	}
+}
@@ -0,0 +1,134 @@
+package store
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestArchiveOldReadMessages(t *testing.T) {
+	sessionDir := t.TempDir()
+	readDir := filepath.Join(sessionDir, "read")
+	if err := os.MkdirAll(readDir, 0o700); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	now := time.Date(2026, time.June, 1, 12, 0, 0, 0, time.UTC)
+	files := map[string]time.Time{
+		"20260501-090000-from-boss-to-worker.md":       now.Add(-30 * 24 * time.Hour),
+		"20260501-090000-from-boss-to-worker.pop.json": now.Add(-30 * 24 * time.Hour),
+		"20260601-110000-from-boss-to-worker.md":       now.Add(-time.Hour),
+	}
+	for name, mtime := range files {
+		path := filepath.Join(readDir, name)
+		if err := os.WriteFile(path, []byte("body of "+name), 0o600); err != nil {
+			t.Fatalf("WriteFile(%s): %v", name, err)
+		}
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatalf("Chtimes(%s): %v", name, err)
+		}
+	}
+
+	archived, err := ArchiveOldReadMessages(sessionDir, 7*24*time.Hour, now)
+	if err != nil {
+		t.Fatalf("ArchiveOldReadMessages: %v", err)
+	}
+	if len(archived) != 2 {
+		t.Fatalf("archived = %v, want the two old files", archived)
+	}
+
+	// Only the recent message is left among the live read/ files.
+	entries, err := os.ReadDir(readDir)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	var live []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			live = append(live, entry.Name())
+		}
+	}
+	if len(live) != 1 || live[0] != "20260601-110000-from-boss-to-worker.md" {
+		t.Fatalf("live read files = %v, want only the recent message", live)
+	}
+
+	tarball := filepath.Join(ReadArchiveDir(sessionDir), "read-20260601-120000.tar.gz")
+	f, err := os.Open(tarball)
+	if err != nil {
+		t.Fatalf("Open(tarball): %v", err)
+	}
+	defer func() { _ = f.Close() }()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("gzip.NewReader: %v", err)
+	}
+	tr := tar.NewReader(gz)
+	got := map[string]string{}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("tar Next: %v", err)
+		}
+		body, _ := io.ReadAll(tr)
+		got[header.Name] = string(body)
+	}
+	for _, name := range []string{"20260501-090000-from-boss-to-worker.md", "20260501-090000-from-boss-to-worker.pop.json"} {
+		if got[name] != "body of "+name {
+			t.Fatalf("tarball entry %s = %q", name, got[name])
+		}
+	}
+
+	// A second pass has nothing old left and writes no new tarball.
+	if archived, err := ArchiveOldReadMessages(sessionDir, 7*24*time.Hour, now); err != nil || len(archived) != 0 {
+		t.Fatalf("second ArchiveOldReadMessages = %v, %v; want nothing", archived, err)
+	}
+}
+
+func TestArchiveOldPostCopies(t *testing.T) {
+	sessionDir := t.TempDir()
+	archiveDir := PostArchiveDir(sessionDir)
+	if err := os.MkdirAll(archiveDir, 0o700); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	now := time.Date(2026, time.June, 1, 12, 0, 0, 0, time.UTC)
+	files := map[string]time.Time{
+		"20260501-090000-from-boss-to-worker.md": now.Add(-30 * 24 * time.Hour),
+		"20260601-110000-from-boss-to-worker.md": now.Add(-time.Hour),
+	}
+	for name, mtime := range files {
+		path := filepath.Join(archiveDir, name)
+		if err := os.WriteFile(path, []byte("body of "+name), 0o600); err != nil {
+			t.Fatalf("WriteFile(%s): %v", name, err)
+		}
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatalf("Chtimes(%s): %v", name, err)
+		}
+	}
+
+	archived, err := ArchiveOldPostCopies(sessionDir, 7*24*time.Hour, now)
+	if err != nil {
+		t.Fatalf("ArchiveOldPostCopies: %v", err)
+	}
+	if len(archived) != 1 || filepath.Base(archived[0]) != "20260501-090000-from-boss-to-worker.md" {
+		t.Fatalf("archived = %v, want only the old copy", archived)
+	}
+	tarball := filepath.Join(archiveDir, "post-20260601-120000.tar.gz")
+	if _, err := os.Stat(tarball); err != nil {
+		t.Fatalf("post tarball missing: %v", err)
+	}
+
+	// The tarball sits beside the copies; a later pass must not pack it.
+	old := now.Add(-30 * 24 * time.Hour)
+	if err := os.Chtimes(tarball, old, old); err != nil {
+		t.Fatalf("Chtimes(tarball): %v", err)
+	}
+	if archived, err := ArchiveOldPostCopies(sessionDir, 7*24*time.Hour, now); err != nil || len(archived) != 0 {
+		t.Fatalf("second ArchiveOldPostCopies = %v, %v; want nothing", archived, err)
+	}
+}
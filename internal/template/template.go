@@ -2,9 +2,12 @@ package template
 
 import (
 	"context"
+	"log"
 	"os/exec"
 	"regexp"
 	"strings"
+	"sync/atomic"
+	"syscall"
 	"time"
 )
 
@@ -21,8 +24,82 @@ var (
 	shellCommandPattern = regexp.MustCompile(`\$\(([^)]+)\)`)
 )
 
+// secretVarPrefix namespaces secrets_file keys among template variables.
+const secretVarPrefix = "secret:"
+
+// Options carries the loaded-config settings an expansion needs beyond the
+// template and its variables; the zero value expands with none of them.
+type Options struct {
+	// Secrets are the secrets_file values exposed as {secret:NAME}.
+	Secrets map[string]string
+	// Shell caps concurrent $(...) commands; nil is unlimited.
+	Shell *ShellLimiter
+}
+
+// ShellLimiter caps how many $(...) commands run at once across every
+// expansion that shares it, so a burst of deliveries cannot fork one command
+// per message at once. A nil *ShellLimiter is unlimited.
+type ShellLimiter struct {
+	slots chan struct{}
+}
+
+// NewShellLimiter returns a limiter allowing n concurrent commands, or nil
+// (unlimited) when n <= 0.
+func NewShellLimiter(n int) *ShellLimiter {
+	if n <= 0 {
+		return nil
+	}
+	return &ShellLimiter{slots: make(chan struct{}, n)}
+}
+
+// acquire waits up to wait for a free slot and returns its release func, or
+// false when none freed up in time.
+func (l *ShellLimiter) acquire(wait time.Duration) (func(), bool) {
+	if l == nil {
+		return func() {}, true
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case l.slots <- struct{}{}:
+		return func() { <-l.slots }, true
+	case <-timer.C:
+		return nil, false
+	}
+}
+
 type shellExecutor func(ctx context.Context, command string) ([]byte, error)
 
+// Shell expansion runs inside message delivery, so one command may never
+// hold it longer than maxShellTimeout whatever the caller passes; a
+// non-positive timeout falls back to defaultShellTimeout. A capped timeout
+// is logged once per requested value, so a large tmux_timeout_seconds does
+// not shorten shell commands without a trace.
+const (
+	defaultShellTimeout = 5 * time.Second
+	maxShellTimeout     = 10 * time.Second
+	// shellWaitDelay bounds how long Output waits for pipes to close after
+	// the process group is killed.
+	shellWaitDelay = 500 * time.Millisecond
+)
+
+// lastClampedShellTimeout remembers the last timeout reported as capped.
+var lastClampedShellTimeout atomic.Int64
+
+func clampShellTimeout(timeout time.Duration) time.Duration {
+	switch {
+	case timeout <= 0:
+		return defaultShellTimeout
+	case timeout > maxShellTimeout:
+		if lastClampedShellTimeout.Swap(int64(timeout)) != int64(timeout) {
+			log.Printf("postman: template shell timeout %s exceeds the %s cap; $(...) commands are killed after %s\n", timeout, maxShellTimeout, maxShellTimeout)
+		}
+		return maxShellTimeout
+	default:
+		return timeout
+	}
+}
+
 // ExpandVariables replaces {variable} patterns with values from the vars map.
 // Undefined variables remain as-is in the output.
 func ExpandVariables(template string, vars map[string]string) string {
@@ -38,15 +115,31 @@ func ExpandVariables(template string, vars map[string]string) string {
 }
 
 func defaultShellExecutor(ctx context.Context, command string) ([]byte, error) {
-	return exec.CommandContext(ctx, "sh", "-c", command).Output()
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	// Own process group: cancellation kills background children too, which
+	// would otherwise keep stdout open and Output blocked past the timeout.
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+	cmd.WaitDelay = shellWaitDelay
+	return cmd.Output()
 }
 
-func expandShellCommandsWithExecutor(template string, timeout time.Duration, executor shellExecutor) string {
+func expandShellCommandsWithExecutor(template string, timeout time.Duration, shell *ShellLimiter, executor shellExecutor) string {
 	return shellCommandPattern.ReplaceAllStringFunc(template, func(match string) string {
 		// Extract command (without $(...))
 		cmd := match[2 : len(match)-1]
 
-		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		// Queue for a slot no longer than the command itself may run, so a
+		// burst delays delivery by at most one more timeout.
+		release, ok := shell.acquire(clampShellTimeout(timeout))
+		if !ok {
+			return ""
+		}
+		defer release()
+
+		ctx, cancel := context.WithTimeout(context.Background(), clampShellTimeout(timeout))
 		defer cancel()
 
 		out, err := executor(ctx, cmd)
@@ -61,18 +154,27 @@ func expandShellCommandsWithExecutor(template string, timeout time.Duration, exe
 }
 
 // ExpandTemplate performs full template expansion:
-// 1. Execute shell commands $(...) — only when allowShell is true
-// 2. Expand variables {variable}
-func ExpandTemplate(tmpl string, vars map[string]string, timeout time.Duration, allowShell bool) string {
-	return expandTemplateWithExecutor(tmpl, vars, timeout, allowShell, defaultShellExecutor)
+//  1. Execute shell commands $(...) — only when allowShell is true; otherwise
+//     the $(...) token is left literal. Each command is killed, with its
+//     children, after timeout (capped at maxShellTimeout). opts.Shell caps
+//     how many commands run at once; a command that waits longer than its
+//     timeout for a slot expands empty without running.
+//  2. Expand variables {variable}, plus {secret:NAME} from opts.Secrets.
+//     Secrets are substituted after shell expansion, so they never reach a
+//     command line.
+func ExpandTemplate(tmpl string, vars map[string]string, timeout time.Duration, allowShell bool, opts Options) string {
+	return expandTemplateWithExecutor(tmpl, vars, timeout, allowShell, opts, defaultShellExecutor)
 }
 
-func expandTemplateWithExecutor(tmpl string, vars map[string]string, timeout time.Duration, allowShell bool, executor shellExecutor) string {
+func expandTemplateWithExecutor(tmpl string, vars map[string]string, timeout time.Duration, allowShell bool, opts Options, executor shellExecutor) string {
 	expanded := tmpl
 	if allowShell {
-		expanded = expandShellCommandsWithExecutor(tmpl, timeout, executor)
+		expanded = expandShellCommandsWithExecutor(tmpl, timeout, opts.Shell, executor)
+	}
+	sanitizedVars := make(map[string]string, len(vars)+len(opts.Secrets))
+	for k, v := range opts.Secrets {
+		sanitizedVars[secretVarPrefix+k] = v
 	}
-	sanitizedVars := make(map[string]string, len(vars))
 	for k, v := range vars {
 		sanitizedVars[k] = shellCommandPattern.ReplaceAllString(v, "")
 	}
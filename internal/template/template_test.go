@@ -1,8 +1,14 @@
 package template
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -59,6 +65,7 @@ func TestExpandShellCommandsWithExecutor_ReplacesCommandsInOrder(t *testing.T) {
 	got := expandShellCommandsWithExecutor(
 		"First: $(one), Second: $(two)",
 		5*time.Second,
+		nil,
 		executor,
 	)
 	want := "First: one, Second: two"
@@ -76,7 +83,7 @@ func TestExpandShellCommandsWithExecutor_TrimTrailingNewlines(t *testing.T) {
 		return []byte("value\n\n"), nil
 	}
 
-	got := expandShellCommandsWithExecutor("Result: $(echo value)", 5*time.Second, executor)
+	got := expandShellCommandsWithExecutor("Result: $(echo value)", 5*time.Second, nil, executor)
 	want := "Result: value"
 
 	if got != want {
@@ -89,7 +96,7 @@ func TestExpandShellCommandsWithExecutor_FailureExpandsEmpty(t *testing.T) {
 		return nil, errors.New("command failed")
 	}
 
-	got := expandShellCommandsWithExecutor("Result: $(exit 1)", 5*time.Second, executor)
+	got := expandShellCommandsWithExecutor("Result: $(exit 1)", 5*time.Second, nil, executor)
 	want := "Result: "
 
 	if got != want {
@@ -110,7 +117,7 @@ func TestExpandShellCommandsWithExecutor_TimeoutExpandsEmpty(t *testing.T) {
 		return nil, context.DeadlineExceeded
 	}
 
-	got := expandShellCommandsWithExecutor("Result: $(slow)", timeout, executor)
+	got := expandShellCommandsWithExecutor("Result: $(slow)", timeout, nil, executor)
 	want := "Result: "
 
 	if got != want {
@@ -118,6 +125,66 @@ func TestExpandShellCommandsWithExecutor_TimeoutExpandsEmpty(t *testing.T) {
 	}
 }
 
+func TestExpandShellCommandsWithExecutor_CapsConcurrentCommands(t *testing.T) {
+	shell := NewShellLimiter(2)
+
+	var running, peak atomic.Int32
+	executor := func(ctx context.Context, command string) ([]byte, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			old := peak.Load()
+			if n <= old || peak.CompareAndSwap(old, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		return []byte(command), nil
+	}
+
+	const expansions = 8
+	results := make([]string, expansions)
+	var wg sync.WaitGroup
+	for i := range expansions {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = expandShellCommandsWithExecutor("v=$(value)", 5*time.Second, shell, executor)
+		}()
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got > 2 {
+		t.Fatalf("peak concurrent commands = %d, want at most 2", got)
+	}
+	for i, got := range results {
+		if got != "v=value" {
+			t.Fatalf("results[%d] = %q, want every queued command to run", i, got)
+		}
+	}
+}
+
+func TestExpandShellCommandsWithExecutor_SlotWaitTimesOut(t *testing.T) {
+	shell := NewShellLimiter(1)
+	release, ok := shell.acquire(time.Second)
+	if !ok {
+		t.Fatal("acquire() on an idle cap failed")
+	}
+	defer release()
+
+	ran := false
+	executor := func(ctx context.Context, command string) ([]byte, error) {
+		ran = true
+		return []byte("value"), nil
+	}
+	if got := expandShellCommandsWithExecutor("v=$(value)", 50*time.Millisecond, shell, executor); got != "v=" {
+		t.Fatalf("expansion without a free slot = %q, want %q", got, "v=")
+	}
+	if ran {
+		t.Fatal("command ran without a slot")
+	}
+}
+
 func TestExpandTemplateWithExecutor_ShellDisabledDoesNotExecute(t *testing.T) {
 	executor := func(ctx context.Context, command string) ([]byte, error) {
 		t.Fatalf("executor called with %q while shell expansion is disabled", command)
@@ -130,6 +197,7 @@ func TestExpandTemplateWithExecutor_ShellDisabledDoesNotExecute(t *testing.T) {
 		vars,
 		5*time.Second,
 		false,
+		Options{},
 		executor,
 	)
 	want := "Command: $(echo no), Node: worker"
@@ -145,7 +213,7 @@ func TestExpandTemplate_SanitizesInjection(t *testing.T) {
 		"node": "evil$(rm -rf /)",
 	}
 
-	got := ExpandTemplate(tmpl, vars, 5*time.Second, false)
+	got := ExpandTemplate(tmpl, vars, 5*time.Second, false, Options{})
 	want := "Node: evil"
 
 	if got != want {
@@ -162,7 +230,7 @@ func TestExpandTemplateWithExecutor_SanitizesInjection(t *testing.T) {
 		"node": "evil$(rm -rf /)",
 	}
 
-	got := expandTemplateWithExecutor(tmpl, vars, 5*time.Second, true, executor)
+	got := expandTemplateWithExecutor(tmpl, vars, 5*time.Second, true, Options{}, executor)
 	want := "Node: evil"
 
 	if got != want {
@@ -183,10 +251,70 @@ func TestExpandTemplateWithExecutor_ExpandsShellAndVariables(t *testing.T) {
 		"recipient": "worker",
 	}
 
-	got := expandTemplateWithExecutor(template, vars, 5*time.Second, true, executor)
+	got := expandTemplateWithExecutor(template, vars, 5*time.Second, true, Options{}, executor)
 	want := "User: current-user, Sender: orchestrator, Recipient: worker"
 
 	if got != want {
 		t.Errorf("expandTemplateWithExecutor() = %q, want %q", got, want)
 	}
 }
+
+func TestExpandTemplate_SecretsComeFromOptions(t *testing.T) {
+	tmpl := "token={secret:API_TOKEN}"
+	withSecrets := Options{Secrets: map[string]string{"API_TOKEN": "s3cret"}}
+	if got := ExpandTemplate(tmpl, nil, time.Second, false, withSecrets); got != "token=s3cret" {
+		t.Errorf("ExpandTemplate(with secrets) = %q, want %q", got, "token=s3cret")
+	}
+	// Another config's expansion does not see them.
+	if got := ExpandTemplate(tmpl, nil, time.Second, false, Options{}); got != tmpl {
+		t.Errorf("ExpandTemplate(without secrets) = %q, want %q left as-is", got, tmpl)
+	}
+}
+
+func TestExpandTemplate_SlowCommandCancelledAtTimeout(t *testing.T) {
+	start := time.Now()
+	// The background sleep inherits stdout; only a process-group kill
+	// releases Output before the sleep finishes.
+	got := ExpandTemplate("before $(sleep 5 & wait) after", nil, 200*time.Millisecond, true, Options{})
+	elapsed := time.Since(start)
+
+	if got != "before  after" {
+		t.Errorf("ExpandTemplate() = %q, want the timed-out command expanded empty", got)
+	}
+	if elapsed > 2*time.Second {
+		t.Fatalf("ExpandTemplate() took %v, want cancellation near the 200ms timeout", elapsed)
+	}
+}
+
+func TestClampShellTimeout(t *testing.T) {
+	for _, tt := range []struct {
+		in, want time.Duration
+	}{
+		{0, defaultShellTimeout},
+		{-time.Second, defaultShellTimeout},
+		{time.Second, time.Second},
+		{time.Hour, maxShellTimeout},
+	} {
+		if got := clampShellTimeout(tt.in); got != tt.want {
+			t.Errorf("clampShellTimeout(%v) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestClampShellTimeout_LogsCapOncePerValue(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+	lastClampedShellTimeout.Store(0)
+
+	clampShellTimeout(30 * time.Second)
+	clampShellTimeout(30 * time.Second)
+	clampShellTimeout(time.Second)
+	if got := strings.Count(buf.String(), "exceeds the 10s cap"); got != 1 {
+		t.Fatalf("cap logged %d times, want once:\n%s", got, buf.String())
+	}
+	clampShellTimeout(time.Minute)
+	if got := strings.Count(buf.String(), "exceeds the 10s cap"); got != 2 {
+		t.Fatalf("new capped value logged %d times in total, want 2:\n%s", got, buf.String())
+	}
+}
@@ -0,0 +1,91 @@
+package tui
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync/atomic"
+)
+
+// criticalEventReserve is how many buffer slots routine events leave free
+// so errors and dead-letters still get through while the TUI is behind.
+// Small buffers reserve at most a quarter of their capacity.
+const criticalEventReserve = 8
+
+// droppedRoutineEvents counts routine events dropped since the last
+// "events_dropped" summary made it onto the channel.
+var droppedRoutineEvents atomic.Int64
+
+// SendEventNonBlocking attempts to deliver event on events without blocking.
+// If the channel is nil, full, or has no ready receiver, the event is
+// dropped rather than stalling the caller (Issue #572 B1: a blocking send
+// here previously let a stalled TUI relay wedge budget-gated delivery
+// goroutines forever, leaking their concurrency slot).
+//
+// Routine events also stay out of the last few buffer slots, which are kept
+// for critical events (see IsCriticalEvent). Dropped routine events are
+// counted and reported in one "events_dropped" event once there is room.
+func SendEventNonBlocking(events chan<- DaemonEvent, event DaemonEvent) {
+	if events == nil {
+		return
+	}
+	if IsCriticalEvent(event) {
+		select {
+		case events <- event:
+		default:
+			log.Printf("postman: WARNING: TUI event channel full; dropped %s event: %s\n", event.Type, event.Message)
+		}
+		return
+	}
+
+	reserve := min(criticalEventReserve, cap(events)/4)
+	if cap(events) > 0 && len(events) >= cap(events)-reserve {
+		droppedRoutineEvents.Add(1)
+		return
+	}
+	select {
+	case events <- event:
+	default:
+		droppedRoutineEvents.Add(1)
+		return
+	}
+	flushDroppedEventSummary(events, reserve)
+}
+
+// flushDroppedEventSummary reports routine events dropped so far, if the
+// routine share of the buffer has room for the summary.
+func flushDroppedEventSummary(events chan<- DaemonEvent, reserve int) {
+	if droppedRoutineEvents.Load() == 0 || len(events) >= cap(events)-reserve {
+		return
+	}
+	dropped := droppedRoutineEvents.Swap(0)
+	if dropped == 0 {
+		return
+	}
+	summary := DaemonEvent{
+		Type:    "events_dropped",
+		Message: fmt.Sprintf("Dropped %d low-priority event(s) while the TUI was behind", dropped),
+		Details: map[string]interface{}{"count": dropped},
+	}
+	select {
+	case events <- summary:
+		log.Printf("postman: WARNING: TUI event channel was full; dropped %d low-priority event(s)\n", dropped)
+	default:
+		droppedRoutineEvents.Add(dropped)
+	}
+}
+
+// IsCriticalEvent reports whether event may use the buffer slots reserved
+// for errors, dead-letter notices, and silent critical edges.
+func IsCriticalEvent(event DaemonEvent) bool {
+	if event.Type == "error" || event.Type == "critical_edge_silent" {
+		return true
+	}
+	if event.Type != "message_received" {
+		return false
+	}
+	if reason, _ := event.Details["failure_reason"].(string); reason != "" {
+		return true
+	}
+	return strings.HasPrefix(event.Message, "Dead-letter:")
+}
@@ -0,0 +1,53 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSendEventNonBlocking_ReservesSlotsForCriticalEvents(t *testing.T) {
+	droppedRoutineEvents.Store(0)
+	t.Cleanup(func() { droppedRoutineEvents.Store(0) })
+
+	events := make(chan DaemonEvent, 20) // reserve = min(8, 20/4) = 5
+	for i := 0; i < 20; i++ {
+		SendEventNonBlocking(events, DaemonEvent{Type: "status_update", Message: "routine"})
+	}
+	if len(events) != 15 {
+		t.Fatalf("routine events buffered = %d, want 15 with 5 slots reserved", len(events))
+	}
+	if got := droppedRoutineEvents.Load(); got != 5 {
+		t.Fatalf("dropped routine events = %d, want 5", got)
+	}
+
+	SendEventNonBlocking(events, DaemonEvent{Type: "error", Message: "deliver failed"})
+	SendEventNonBlocking(events, DaemonEvent{
+		Type:    "message_received",
+		Message: "Dead-letter: a -> b (routing denied)",
+		Details: map[string]interface{}{"failure_reason": "routing denied"},
+	})
+	if len(events) != 17 {
+		t.Fatalf("buffered = %d after critical events, want 17", len(events))
+	}
+
+	// Drain the routine backlog; the next routine send is followed by a
+	// summary of what was dropped.
+	var critical []DaemonEvent
+	for len(events) > 0 {
+		if event := <-events; IsCriticalEvent(event) {
+			critical = append(critical, event)
+		}
+	}
+	if len(critical) != 2 {
+		t.Fatalf("critical events received = %d, want 2", len(critical))
+	}
+	SendEventNonBlocking(events, DaemonEvent{Type: "status_update", Message: "routine"})
+	<-events
+	summary := <-events
+	if summary.Type != "events_dropped" || !strings.Contains(summary.Message, "Dropped 5 ") {
+		t.Fatalf("summary = %#v, want events_dropped reporting 5", summary)
+	}
+	if got := droppedRoutineEvents.Load(); got != 0 {
+		t.Fatalf("dropped counter = %d after summary, want 0", got)
+	}
+}
@@ -3,6 +3,7 @@ package tui
 import (
 	"fmt"
 	"log"
+	"slices"
 	"strings"
 	"time"
 
@@ -40,6 +41,10 @@ type SessionInfo struct {
 	Name      string
 	NodeCount int
 	Enabled   bool // Issue #35: Requirement 4 - enable/disable toggle
+
+	// Group is the tui_groups label the row belongs to. A row with a Group
+	// and no Name stands for a collapsed group.
+	Group string
 }
 
 // EventEntry holds event information with session context (Issue #59).
@@ -61,21 +66,6 @@ type DaemonEvent struct {
 // DaemonEventMsg wraps DaemonEvent for tea.Msg interface.
 type DaemonEventMsg DaemonEvent
 
-// SendEventNonBlocking attempts to deliver event on events without blocking.
-// If the channel is nil, full, or has no ready receiver, the event is
-// dropped rather than stalling the caller (Issue #572 B1: a blocking send
-// here previously let a stalled TUI relay wedge budget-gated delivery
-// goroutines forever, leaking their concurrency slot).
-func SendEventNonBlocking(events chan<- DaemonEvent, event DaemonEvent) {
-	if events == nil {
-		return
-	}
-	select {
-	case events <- event:
-	default:
-	}
-}
-
 type startupReadinessTickMsg time.Time
 
 // TUICommand represents a command from TUI to the daemon.
@@ -99,10 +89,13 @@ type Model struct {
 	selectedSession  int
 	sessionNodes     map[string][]string // Issue #59: session name -> simple node names
 	sessionSnapshots map[string]status.SessionStatus
+	collapsedGroups  map[string]bool // tui_groups labels c has collapsed
 
 	// Node state tracking (Issue #55)
-	nodeStates        map[string]string // "active" / "idle" / "stale"
-	unreadInboxCounts map[string]int    // live unread inbox depth per node
+	nodeStates        map[string]string    // "active" / "idle" / "stale"
+	unreadInboxCounts map[string]int       // live unread inbox depth per node
+	ballHeldSince     map[string]time.Time // LastReceived for nodes that have not replied since
+	meshSummary       *status.MeshSummary
 
 	// Shared state
 	daemonEvents  <-chan DaemonEvent
@@ -114,6 +107,21 @@ type Model struct {
 	lastEvent     string
 	quitting      bool
 
+	// pendingDisable names the session awaiting a second press to disable,
+	// so a stray space/enter does not halt delivery.
+	pendingDisable string
+
+	// nodeFilter narrows the nodes section to names containing it; "/"
+	// starts typing it (filtering), enter keeps it, esc clears it.
+	nodeFilter string
+	filtering  bool
+
+	// selectedNode is the node J/K picked in the selected session ("" =
+	// none); m toggles its mute. mutedNodes mirrors the daemon's mute set,
+	// keyed "session:node".
+	selectedNode string
+	mutedNodes   map[string]bool
+
 	startupPingReadyAt  time.Time
 	startupReadinessNow time.Time
 
@@ -121,6 +129,8 @@ type Model struct {
 	config *config.Config
 
 	ownContextID string
+
+	nowFunc func() time.Time // test hook; nil means time.Now
 }
 
 // Quitting returns true if the TUI is in quitting state (Issue #57).
@@ -136,6 +146,86 @@ func (m Model) getSelectedSessionName() string {
 	return m.sessions[m.selectedSession].Name
 }
 
+// moveSelectedNode steps the node cursor through the nodes shown for the
+// selected session, starting from the first or last node.
+func (m *Model) moveSelectedNode(delta int) {
+	names := m.visibleNodeNames()
+	if len(names) == 0 {
+		m.selectedNode = ""
+		return
+	}
+	idx := slices.Index(names, m.selectedNode)
+	switch {
+	case idx < 0 && delta > 0:
+		idx = 0
+	case idx < 0:
+		idx = len(names) - 1
+	default:
+		idx = (idx + delta + len(names)) % len(names)
+	}
+	m.selectedNode = names[idx]
+}
+
+// toggleSelectedNodeMute asks the daemon to mute or unmute the selected
+// node's pane notifications; mail keeps landing in its inbox.
+func (m *Model) toggleSelectedNodeMute() {
+	sessionName := m.getSelectedSessionName()
+	if sessionName == "" || m.selectedNode == "" {
+		return
+	}
+	nodeKey := sessionName + ":" + m.selectedNode
+	if m.tuiCommands == nil {
+		m.sessionStatus[sessionName] = "Mute: daemon unavailable"
+		return
+	}
+	muted := !m.mutedNodes[nodeKey]
+	value, label := "off", "Unmuted "+m.selectedNode
+	if muted {
+		value, label = "on", "Muted "+m.selectedNode+" (inbox only, no pane notifications)"
+	}
+	m.tuiCommands <- TUICommand{
+		Type:   "node_mute",
+		Target: nodeKey,
+		Value:  value,
+	}
+	if m.mutedNodes == nil {
+		m.mutedNodes = make(map[string]bool)
+	}
+	if muted {
+		m.mutedNodes[nodeKey] = true
+	} else {
+		delete(m.mutedNodes, nodeKey)
+	}
+	m.sessionStatus[sessionName] = label
+}
+
+// toggleSession asks the daemon to enable or disable sessionName and mirrors
+// the new state locally until the next session list arrives.
+func (m *Model) toggleSession(sessionName string, enabled bool) {
+	m.pendingDisable = ""
+	if m.tuiCommands == nil {
+		m.sessionStatus[sessionName] = "Toggle: daemon unavailable"
+		return
+	}
+	value, label := "off", "Disabling session..."
+	if enabled {
+		value, label = "on", "Enabling session..."
+	}
+	m.tuiCommands <- TUICommand{
+		Type:   "session_toggle",
+		Target: sessionName,
+		Value:  value,
+	}
+	m.sessionStatus[sessionName] = label
+	for _, sessions := range [][]SessionInfo{m.knownSessions, m.sessions} {
+		for i := range sessions {
+			if sessions[i].Name == sessionName {
+				sessions[i].Enabled = enabled
+			}
+		}
+	}
+}
+
 func clampSelectedSession(sessions []SessionInfo, selected int) int {
 	if len(sessions) == 0 {
 		return 0
@@ -233,12 +323,78 @@ func (m Model) startupReadinessNotice() string {
 }
 
 func (m *Model) refreshVisibleSessions() {
-	// Default TUI session rows mirror tmux list-sessions order exactly.
-	m.sessions = append([]SessionInfo(nil), m.knownSessions...)
+	// Default TUI session rows mirror tmux list-sessions order exactly, except
+	// that a tui_groups group is listed together where its first session sits.
+	m.sessions = m.groupedSessionRows()
 	m.selectedSession = clampSelectedSession(m.sessions, m.selectedSession)
 	m.pruneSessionSnapshots()
 }
 
+// groupedSessionRows builds the session rows from knownSessions, pulling each
+// group's sessions together and folding a collapsed group into one row.
+func (m Model) groupedSessionRows() []SessionInfo {
+	labels := m.config.TUISessionGroupLabels()
+	if len(labels) == 0 {
+		return append([]SessionInfo(nil), m.knownSessions...)
+	}
+	rows := make([]SessionInfo, 0, len(m.knownSessions))
+	seen := make(map[string]bool)
+	for _, session := range m.knownSessions {
+		label := labels[session.Name]
+		if label == "" {
+			rows = append(rows, session)
+			continue
+		}
+		if seen[label] {
+			continue
+		}
+		seen[label] = true
+		if m.collapsedGroups[label] {
+			rows = append(rows, SessionInfo{Group: label})
+			continue
+		}
+		rows = append(rows, m.groupMembers(label)...)
+	}
+	return rows
+}
+
+// groupMembers lists the live sessions under label, in tmux order.
+func (m Model) groupMembers(label string) []SessionInfo {
+	labels := m.config.TUISessionGroupLabels()
+	var members []SessionInfo
+	for _, session := range m.knownSessions {
+		if labels[session.Name] == label {
+			session.Group = label
+			members = append(members, session)
+		}
+	}
+	return members
+}
+
+// toggleSelectedGroup collapses or expands the group of the selected row and
+// keeps the cursor on that group.
+func (m *Model) toggleSelectedGroup() {
+	if m.selectedSession < 0 || m.selectedSession >= len(m.sessions) {
+		return
+	}
+	label := m.sessions[m.selectedSession].Group
+	if label == "" {
+		return
+	}
+	if m.collapsedGroups == nil {
+		m.collapsedGroups = make(map[string]bool)
+	}
+	if m.collapsedGroups[label] {
+		delete(m.collapsedGroups, label)
+	} else {
+		m.collapsedGroups[label] = true
+	}
+	m.pendingDisable = ""
+	m.selectedNode = ""
+	m.sessions = m.groupedSessionRows()
+	m.selectedSession = slices.IndexFunc(m.sessions, func(row SessionInfo) bool { return row.Group == label })
+}
+
 func (m *Model) pruneSessionSnapshots() {
 	if len(m.sessionSnapshots) == 0 || len(m.knownSessions) == 0 {
 		return
@@ -283,6 +439,12 @@ func (m *Model) updateNodeStatesFromActivity(nodeStatesRaw interface{}) {
 	}
 
 	for nodeKey, activity := range nodeActivities {
+		if activity.LastReceived.After(activity.LastSent) && !activity.LastReceived.IsZero() {
+			m.ballHeldSince[nodeKey] = activity.LastReceived
+		} else {
+			delete(m.ballHeldSince, nodeKey)
+		}
+
 		// Extract simple name for the session-node filter.
 		simpleName := nodeKey
 		if parts := strings.SplitN(nodeKey, ":", 2); len(parts) == 2 {
@@ -332,6 +494,7 @@ func InitialModel(daemonEvents <-chan DaemonEvent, tuiCommands chan<- TUICommand
 		sessionSnapshots:    make(map[string]status.SessionStatus),
 		nodeStates:          make(map[string]string), // Issue #55: Node state tracking
 		unreadInboxCounts:   make(map[string]int),
+		ballHeldSince:       make(map[string]time.Time),
 		config:              cfg,
 		daemonEvents:        daemonEvents,
 		tuiCommands:         tuiCommands,    // Issue #47: Command channel
@@ -378,18 +541,77 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, m.startupReadinessTickCmd()
 
 	case tea.KeyPressMsg:
+		if m.filtering {
+			return m.updateNodeFilter(msg)
+		}
 		switch msg.String() {
+		case "/":
+			m.filtering = true
+			m.pendingDisable = ""
+			return m, nil
 		case "q", "ctrl+c":
 			m.quitting = true
 			return m, tea.Quit
 		case "j", "down":
 			m.selectedSession = moveSelectedSession(m.sessions, m.selectedSession, 1)
+			m.pendingDisable = ""
+			m.selectedNode = ""
 			return m, nil
 		case "k", "up":
 			m.selectedSession = moveSelectedSession(m.sessions, m.selectedSession, -1)
+			m.pendingDisable = ""
+			m.selectedNode = ""
+			return m, nil
+		case "!":
+			if worst, ok := m.mostProblematicSession(); ok {
+				m.selectedSession = worst
+				m.pendingDisable = ""
+				m.selectedNode = ""
+			}
+			return m, nil
+		case "J":
+			m.moveSelectedNode(1)
+			return m, nil
+		case "K":
+			m.moveSelectedNode(-1)
+			return m, nil
+		case "m":
+			m.toggleSelectedNodeMute()
+			return m, nil
+		case "c":
+			m.toggleSelectedGroup()
+			return m, nil
+		case "space", "enter":
+			if m.selectedSession < 0 || m.selectedSession >= len(m.sessions) {
+				return m, nil
+			}
+			sess := m.sessions[m.selectedSession]
+			switch {
+			case sess.Name == "":
+				// A collapsed group row: space/enter opens it.
+				m.toggleSelectedGroup()
+			case !sess.Enabled:
+				m.toggleSession(sess.Name, true)
+			case m.pendingDisable == sess.Name:
+				m.toggleSession(sess.Name, false)
+			default:
+				m.pendingDisable = sess.Name
+			}
+			return m, nil
+		case "y":
+			if m.pendingDisable != "" {
+				m.toggleSession(m.pendingDisable, false)
+			}
+			return m, nil
+		case "n", "esc":
+			m.pendingDisable = ""
+			if msg.String() == "esc" {
+				m.nodeFilter = ""
+				m.selectedNode = ""
+			}
 			return m, nil
 		case "p":
-			if m.selectedSession >= 0 && m.selectedSession < len(m.sessions) {
+			if m.selectedSession >= 0 && m.selectedSession < len(m.sessions) && m.sessions[m.selectedSession].Name != "" {
 				sess := m.sessions[m.selectedSession]
 				if m.startupPingLocked() {
 					remaining := formatStartupPingRemaining(m.startupPingRemaining())
@@ -481,6 +703,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// We need to extract state from each NodeActivity
 				m.updateNodeStatesFromActivity(nodeStatesRaw)
 			}
+		case "mesh_summary":
+			if summary, ok := msg.Details["summary"].(status.MeshSummary); ok {
+				m.meshSummary = &summary
+			}
 		case "inbox_unread_count_update":
 			if counts, ok := msg.Details["unread_counts"].(map[string]int); ok {
 				m.unreadInboxCounts = counts
@@ -528,6 +754,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if len(m.events) > 10 {
 				m.events = m.events[len(m.events)-10:]
 			}
+		case "watch_limit_reached", "clock_skew", "events_dropped", "duplicate_message_id", "notification_circuit_open", "non_md_in_post", "empty_session", "session_quota":
+			m.events = append(m.events, EventEntry{
+				Message:   msg.Message,
+				Timestamp: time.Now(),
+				Severity:  SeverityWarning,
+			})
+			if len(m.events) > 10 {
+				m.events = m.events[len(m.events)-10:]
+			}
 		case "pane_restart":
 			sessionName := m.resolveSessionFromDetails(msg.Details)
 			if sessionName != "" {
@@ -542,6 +777,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if len(m.events) > 10 {
 				m.events = m.events[len(m.events)-10:]
 			}
+		case "critical_edge_silent":
+			m.events = append(m.events, EventEntry{
+				Message:   msg.Message,
+				Timestamp: time.Now(),
+				Severity:  SeverityCritical,
+			})
+			if len(m.events) > 10 {
+				m.events = m.events[len(m.events)-10:]
+			}
 		case "session_collapsed":
 			sessionName := m.resolveSessionFromDetails(msg.Details)
 			if sessionName != "" {
@@ -617,22 +861,45 @@ func (m Model) resolveSessionFromDetails(details map[string]interface{}) string
 	return match
 }
 
-func sessionIndicator(state string, enabled bool) string {
+// defaultIndicatorSymbols are used for states tui_symbols leaves unset.
+var defaultIndicatorSymbols = map[string]string{
+	"inactive": "⚫",
+	"waiting":  "🟡",
+	"pending":  "🔷",
+	"stale":    "🔴",
+	"ready":    "🟢",
+}
+
+// indicatorState maps a visible state to its tui_symbols/tui_colors key.
+func indicatorState(state string, enabled bool) string {
 	if !enabled {
-		return "⚫"
+		return "inactive"
 	}
 	switch state {
 	case "", "initial", "unavailable", "unowned":
-		return "⚫"
-	case "waiting":
-		return "🟡"
-	case "pending":
-		return "🔷"
-	case "stale":
-		return "🔴"
+		return "inactive"
+	case "waiting", "pending", "stale":
+		return state
 	default:
-		return "🟢"
+		return "ready"
+	}
+}
+
+// sessionIndicator renders the status symbol for state, honoring the
+// tui_symbols and tui_colors overrides.
+func (m Model) sessionIndicator(state string, enabled bool) string {
+	key := indicatorState(state, enabled)
+	symbol := defaultIndicatorSymbols[key]
+	if m.config == nil {
+		return symbol
+	}
+	if override := m.config.TUISymbols[key]; override != "" {
+		symbol = override
+	}
+	if color := m.config.TUIColors[key]; color != "" {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render(symbol)
 	}
+	return symbol
 }
 
 func sessionStatusUnavailable(snapshot status.SessionStatus) bool {
@@ -640,23 +907,75 @@ func sessionStatusUnavailable(snapshot status.SessionStatus) bool {
 }
 
 func (m Model) defaultSessionIndicator(session SessionInfo) string {
-	snapshot, ok := m.sessionStatusFor(session.Name)
-	if !ok {
-		// Session exists in tmux, but canonical status has not arrived yet.
-		return "⚫"
+	state := m.sessionWorstState(session.Name)
+	if state == "" {
+		// No canonical status yet, or no panes to classify.
+		return m.sessionIndicator("", false)
 	}
-	if sessionStatusUnavailable(snapshot) {
-		return "⚫"
+	return m.sessionIndicator(state, true)
+}
+
+// sessionWorstState returns the aggregate state the session indicator
+// shows, or "" while the session's canonical status has not arrived or it
+// has no classifiable panes.
+func (m Model) sessionWorstState(name string) string {
+	snapshot, ok := m.sessionStatusFor(name)
+	if !ok || sessionStatusUnavailable(snapshot) {
+		return ""
 	}
-	state := snapshot.VisibleState
-	if state == "" {
-		state = status.SessionVisibleState(snapshot.Nodes)
+	if snapshot.VisibleState != "" {
+		return snapshot.VisibleState
 	}
-	if state == "" {
-		// Session exists, but there are no canonical panes to classify yet.
-		return "⚫"
+	return status.SessionVisibleState(snapshot.Nodes)
+}
+
+// groupWorstState returns the worst aggregate state across the sessions of
+// a tui_groups group, or "" when none of them is classified yet.
+func (m Model) groupWorstState(label string) string {
+	worst := ""
+	for _, session := range m.groupMembers(label) {
+		state := m.sessionWorstState(session.Name)
+		if state != "" && (worst == "" || status.StateRank(state) > status.StateRank(worst)) {
+			worst = state
+		}
+	}
+	return worst
+}
+
+// rowWorstState is sessionWorstState for a session row, or groupWorstState
+// for a collapsed group row.
+func (m Model) rowWorstState(row SessionInfo) string {
+	if row.Name == "" && row.Group != "" {
+		return m.groupWorstState(row.Group)
+	}
+	return m.sessionWorstState(row.Name)
+}
+
+// mostProblematicSession picks the session with the worst aggregate node
+// state for the "!" key, breaking ties by the most recent warning-or-worse
+// event. It reports false when every session is ready or unclassified.
+func (m Model) mostProblematicSession() (int, bool) {
+	lastAlert := make(map[string]time.Time)
+	for _, event := range m.events {
+		if event.Severity == "" || event.Severity == SeverityInfo {
+			continue
+		}
+		if event.Timestamp.After(lastAlert[event.SessionName]) {
+			lastAlert[event.SessionName] = event.Timestamp
+		}
+	}
+	best, bestRank := -1, status.StateRank("ready")
+	for i, session := range m.sessions {
+		rank := status.StateRank(m.rowWorstState(session))
+		switch {
+		case rank < bestRank:
+			continue
+		case rank == bestRank && (best < 0 || !lastAlert[session.Name].After(lastAlert[m.sessions[best].Name])):
+			continue
+		}
+		best, bestRank = i, rank
 	}
-	return sessionIndicator(state, true)
+	return best, best >= 0
 }
 
 func nodeStateLabel(state string) string {
@@ -681,22 +1000,73 @@ func (m Model) renderSessionsSection() string {
 		return b.String()
 	}
 
+	group := ""
 	for i, session := range m.sessions {
 		cursor := "  "
 		if i == m.selectedSession {
 			cursor = "> "
 		}
+		if session.Name == "" {
+			state := m.groupWorstState(session.Group)
+			indicator := m.sessionIndicator(state, state != "")
+			fmt.Fprintf(&b, "%s%s [%d] + %s (%d sessions, %s) [c:expand]\n", cursor, indicator, i, session.Group, len(m.groupMembers(session.Group)), nodeStateLabel(state))
+			group = ""
+			continue
+		}
+		if session.Group != group {
+			group = session.Group
+			if group != "" {
+				fmt.Fprintf(&b, "  - %s [c:collapse]\n", group)
+			}
+		}
 		indicator := m.defaultSessionIndicator(session)
+		if group != "" {
+			indicator = "  " + indicator
+		}
 		fmt.Fprintf(&b, "%s%s [%d] %s\n", cursor, indicator, i, session.Name)
+		if session.Name == m.pendingDisable {
+			fmt.Fprintf(&b, "    disable %s? [space/y:confirm] [n:cancel]\n", session.Name)
+		}
 	}
 
 	return b.String()
 }
 
+// updateNodeFilter edits the node filter while "/" filter mode is active.
+func (m Model) updateNodeFilter(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		m.quitting = true
+		return m, tea.Quit
+	case "esc":
+		m.filtering = false
+		m.nodeFilter = ""
+	case "enter":
+		m.filtering = false
+	case "backspace":
+		if runes := []rune(m.nodeFilter); len(runes) > 0 {
+			m.nodeFilter = string(runes[:len(runes)-1])
+		}
+	default:
+		m.nodeFilter += msg.Text
+	}
+	return m, nil
+}
+
 func (m Model) renderNodesSection() string {
 	var b strings.Builder
 
-	b.WriteString("[nodes]\n")
+	b.WriteString("[nodes]")
+	switch {
+	case m.filtering:
+		b.WriteString(" /" + m.nodeFilter + "_")
+	case m.nodeFilter != "":
+		b.WriteString(" filter: " + m.nodeFilter + " [esc:clear]")
+	}
+	if m.selectedNode != "" {
+		b.WriteString(" [J/K:node m:mute]")
+	}
+	b.WriteString("\n")
 	selectedSession := m.getSelectedSessionName()
 	if selectedSession == "" {
 		b.WriteString("(no nodes)\n")
@@ -728,6 +1098,19 @@ func (m Model) renderSelectedSessionStatus() string {
 	return "\n[status]\n" + message + "\n"
 }
 
+func (m Model) renderMeshSummary() string {
+	if m.meshSummary == nil {
+		return ""
+	}
+	s := m.meshSummary
+	line := fmt.Sprintf("nodes %d active / %d idle / %d stale  inbox %d  dropped %d  dead-letters %d",
+		s.ActiveNodes, s.IdleNodes, s.StaleNodes, s.PendingInbox, s.DroppedBalls, s.RecentDeadLetters)
+	if s.EmptySessions > 0 {
+		line += fmt.Sprintf("  empty sessions %d", s.EmptySessions)
+	}
+	return "\n[mesh]\n" + line + "\n"
+}
+
 func visibleStateLabel(node status.NodeStatus) string {
 	if node.VisibleState != "" {
 		return node.VisibleState
@@ -757,21 +1140,54 @@ func orderedStatusNodeNames(snapshot status.SessionStatus) []string {
 	return ordered
 }
 
+// filterNodeNames keeps the names containing the node filter.
+func (m Model) filterNodeNames(nodeNames []string) []string {
+	if m.nodeFilter == "" {
+		return nodeNames
+	}
+	filtered := nodeNames[:0:0]
+	for _, nodeName := range nodeNames {
+		if strings.Contains(nodeName, m.nodeFilter) {
+			filtered = append(filtered, nodeName)
+		}
+	}
+	return filtered
+}
+
+// visibleNodeNames lists the nodes the nodes section shows for the selected
+// session, in display order.
+func (m Model) visibleNodeNames() []string {
+	snapshot, ok := m.sessionStatusFor(m.getSelectedSessionName())
+	if !ok || sessionStatusUnavailable(snapshot) {
+		return nil
+	}
+	return m.filterNodeNames(orderedStatusNodeNames(snapshot))
+}
+
 func (m Model) renderNodesSectionFromStatus(snapshot status.SessionStatus) string {
 	nodeByName := make(map[string]status.NodeStatus, len(snapshot.Nodes))
 	for _, node := range snapshot.Nodes {
 		nodeByName[node.Name] = node
 	}
 
-	nodeNames := orderedStatusNodeNames(snapshot)
-	if len(nodeNames) == 0 {
+	if len(orderedStatusNodeNames(snapshot)) == 0 {
 		return "(non-AI or unknown session)\n"
 	}
+	nodeNames := m.filterNodeNames(orderedStatusNodeNames(snapshot))
+	if len(nodeNames) == 0 {
+		return fmt.Sprintf("(no nodes match %q)\n", m.nodeFilter)
+	}
 
+	displayNames := make(map[string]string, len(nodeNames))
 	nameWidth := 0
 	for _, nodeName := range nodeNames {
-		if len(nodeName) > nameWidth {
-			nameWidth = len(nodeName)
+		displayName := nodeName
+		if held := m.ballHeldFor(snapshot.SessionName, nodeByName[nodeName]); held > 0 {
+			displayName = fmt.Sprintf("%s(%s)", nodeName, formatHeldDuration(held))
+		}
+		displayNames[nodeName] = displayName
+		if len(displayName) > nameWidth {
+			nameWidth = len(displayName)
 		}
 	}
 
@@ -779,14 +1195,59 @@ func (m Model) renderNodesSectionFromStatus(snapshot status.SessionStatus) strin
 	for _, nodeName := range nodeNames {
 		node := nodeByName[nodeName]
 		visibleState := visibleStateLabel(node)
-		indicator := sessionIndicator(visibleState, true)
+		indicator := m.sessionIndicator(visibleState, true)
 		label := nodeStateLabel(visibleState)
-		fmt.Fprintf(&b, "%-*s  %s  %s\n", nameWidth, nodeName, indicator, label)
+		line := fmt.Sprintf("%-*s  %s  %s", nameWidth, displayNames[nodeName], indicator, label)
+		if unread := m.unreadInboxCounts[snapshot.SessionName+":"+nodeName]; unread > 0 {
+			line += fmt.Sprintf("  inbox:%d", unread)
+		}
+		if m.mutedNodes[snapshot.SessionName+":"+nodeName] {
+			line += "  muted"
+		}
+		if m.selectedNode != "" {
+			cursor := "  "
+			if nodeName == m.selectedNode {
+				cursor = "> "
+			}
+			line = cursor + line
+		}
+		b.WriteString(line + "\n")
 	}
 
 	return b.String()
 }
 
+// ballHeldFor reports how long an idle, stale, or pending node has held
+// unanswered mail; active nodes and nodes that replied report 0.
+func (m Model) ballHeldFor(sessionName string, node status.NodeStatus) time.Duration {
+	switch visibleStateLabel(node) {
+	case "idle", "stale", "pending":
+	default:
+		return 0
+	}
+	since, ok := m.ballHeldSince[sessionName+":"+node.Name]
+	if !ok {
+		return 0
+	}
+	now := time.Now()
+	if m.nowFunc != nil {
+		now = m.nowFunc()
+	}
+	return now.Sub(since)
+}
+
+// formatHeldDuration keeps the inline hold time to one unit: 45s, 3m, 2h.
+func formatHeldDuration(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d/time.Second))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	default:
+		return fmt.Sprintf("%dh", int(d/time.Hour))
+	}
+}
+
 // View renders the simplified default operator surface for #363.
 func (m Model) View() tea.View {
 	view := tea.View{AltScreen: true}
@@ -817,6 +1278,7 @@ func (m Model) View() tea.View {
 	b.WriteString(m.renderSessionsSection())
 	b.WriteString("\n")
 	b.WriteString(m.renderNodesSection())
+	b.WriteString(m.renderMeshSummary())
 	b.WriteString(m.renderSelectedSessionStatus())
 	view.Content = b.String()
 	return view
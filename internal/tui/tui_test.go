@@ -6,7 +6,9 @@ import (
 	"time"
 
 	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
 	"github.com/i9wa4/tmux-a2a-postman/internal/config"
+	"github.com/i9wa4/tmux-a2a-postman/internal/idle"
 	"github.com/i9wa4/tmux-a2a-postman/internal/status"
 	"github.com/i9wa4/tmux-a2a-postman/internal/version"
 )
@@ -308,6 +310,59 @@ func TestTUI_View_DefaultSurfaceNavigationCanSelectVisibleDisabledSession(t *tes
 	}
 }
 
+func TestTUI_Update_BangSelectsMostProblematicSession(t *testing.T) {
+	ch := make(chan DaemonEvent, 10)
+	defer close(ch)
+
+	m := InitialModel(ch, nil, config.DefaultConfig(), "")
+	m.sessions = []SessionInfo{
+		{Name: "main", Enabled: true},
+		{Name: "review", Enabled: true},
+		{Name: "ops", Enabled: true},
+		{Name: "build", Enabled: true},
+	}
+	m.sessionSnapshots["main"] = status.SessionStatus{SessionName: "main", Nodes: []status.NodeStatus{{Name: "a", VisibleState: "ready"}}}
+	m.sessionSnapshots["review"] = status.SessionStatus{SessionName: "review", Nodes: []status.NodeStatus{{Name: "b", VisibleState: "ready"}, {Name: "c", VisibleState: "stale"}}}
+	m.sessionSnapshots["ops"] = status.SessionStatus{SessionName: "ops", Nodes: []status.NodeStatus{{Name: "d", VisibleState: "ready"}}}
+	m.sessionSnapshots["build"] = status.SessionStatus{SessionName: "build", Nodes: []status.NodeStatus{{Name: "e", VisibleState: "stale"}}}
+	m.selectedSession = 0
+	now := time.Now()
+	m.events = []EventEntry{
+		{Message: "old", SessionName: "review", Timestamp: now.Add(-time.Minute), Severity: SeverityWarning},
+		{Message: "new", SessionName: "build", Timestamp: now, Severity: SeverityCritical},
+	}
+
+	press := func(m Model) Model {
+		t.Helper()
+		newModel, cmd := m.Update(tea.KeyPressMsg{Text: "!", Code: '!'})
+		if cmd != nil {
+			t.Fatalf("Update(!) returned cmd %v, want nil", cmd)
+		}
+		return newModel.(Model)
+	}
+
+	// Both review and build hold a stale node; build alerted most recently.
+	m = press(m)
+	if got := m.getSelectedSessionName(); got != "build" {
+		t.Fatalf("selected session after ! = %q, want build", got)
+	}
+
+	// Recomputed on each press: once build recovers, review is the worst.
+	m.sessionSnapshots["build"] = status.SessionStatus{SessionName: "build", Nodes: []status.NodeStatus{{Name: "e", VisibleState: "ready"}}}
+	m = press(m)
+	if got := m.getSelectedSessionName(); got != "review" {
+		t.Fatalf("selected session after second ! = %q, want review", got)
+	}
+
+	// All ready: the selection stays put.
+	m.sessionSnapshots["review"] = status.SessionStatus{SessionName: "review", Nodes: []status.NodeStatus{{Name: "c", VisibleState: "ready"}}}
+	m.selectedSession = 2
+	m = press(m)
+	if m.selectedSession != 2 {
+		t.Fatalf("selectedSession with all sessions ready = %d, want unchanged 2", m.selectedSession)
+	}
+}
+
 func TestTUI_Update_DefaultSurfacePingDispatchesCommand(t *testing.T) {
 	ch := make(chan DaemonEvent, 10)
 	defer close(ch)
@@ -663,3 +718,371 @@ func TestTUI_Update_PaneDisappearedRecordsDroppedStatusForSession(t *testing.T)
 		t.Fatalf("events[0].Severity = %q, want %q", got, SeverityDropped)
 	}
 }
+
+func TestTUI_Update_MeshSummaryRendersRollup(t *testing.T) {
+	ch := make(chan DaemonEvent, 10)
+	defer close(ch)
+
+	m := InitialModel(ch, nil, config.DefaultConfig(), "")
+	m.width = 120
+	m.height = 40
+	event := DaemonEventMsg{
+		Type: "mesh_summary",
+		Details: map[string]interface{}{
+			"summary": status.MeshSummary{ActiveNodes: 2, IdleNodes: 1, StaleNodes: 1, PendingInbox: 3, DroppedBalls: 1, RecentDeadLetters: 4},
+		},
+	}
+	newModel, _ := m.Update(event)
+	m = newModel.(Model)
+
+	content := m.View().Content
+	want := "nodes 2 active / 1 idle / 1 stale  inbox 3  dropped 1  dead-letters 4"
+	if !strings.Contains(content, want) {
+		t.Fatalf("View() missing mesh rollup %q:\n%s", want, content)
+	}
+}
+
+func TestTUI_Update_SessionToggleDisableRequiresConfirmation(t *testing.T) {
+	ch := make(chan DaemonEvent, 10)
+	defer close(ch)
+	commands := make(chan TUICommand, 2)
+
+	m := InitialModel(ch, commands, config.DefaultConfig(), "")
+	m.width, m.height = 80, 24
+	m.sessions = []SessionInfo{{Name: "main", Enabled: true}}
+	m.selectedSession = 0
+
+	newModel, _ := m.Update(tea.KeyPressMsg{Code: ' ', Text: " "})
+	m = newModel.(Model)
+	if m.pendingDisable != "main" {
+		t.Fatalf("pendingDisable = %q, want %q", m.pendingDisable, "main")
+	}
+	if len(commands) != 0 {
+		t.Fatalf("first disable press emitted %d command(s), want 0", len(commands))
+	}
+	if view := m.View().Content; !strings.Contains(view, "disable main? [space/y:confirm] [n:cancel]") {
+		t.Fatalf("view missing pending confirmation: %q", view)
+	}
+
+	newModel, _ = m.Update(tea.KeyPressMsg{Code: ' ', Text: " "})
+	m = newModel.(Model)
+	if m.pendingDisable != "" {
+		t.Fatalf("pendingDisable = %q after confirm, want empty", m.pendingDisable)
+	}
+	sent := <-commands
+	if sent.Type != "session_toggle" || sent.Target != "main" || sent.Value != "off" {
+		t.Fatalf("sent = %#v, want session_toggle main off", sent)
+	}
+	if m.sessions[0].Enabled {
+		t.Fatal("sessions[0].Enabled = true after confirmed disable")
+	}
+
+	// Enabling is a single press.
+	newModel, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	m = newModel.(Model)
+	sent = <-commands
+	if sent.Type != "session_toggle" || sent.Value != "on" {
+		t.Fatalf("sent = %#v, want session_toggle on", sent)
+	}
+	if m.pendingDisable != "" {
+		t.Fatalf("pendingDisable = %q after enable, want empty", m.pendingDisable)
+	}
+}
+
+func TestTUI_Update_SessionToggleCancelClearsPendingDisable(t *testing.T) {
+	ch := make(chan DaemonEvent, 10)
+	defer close(ch)
+	commands := make(chan TUICommand, 1)
+
+	m := InitialModel(ch, commands, config.DefaultConfig(), "")
+	m.sessions = []SessionInfo{{Name: "main", Enabled: true}}
+	m.selectedSession = 0
+
+	newModel, _ := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	m = newModel.(Model)
+	newModel, _ = m.Update(tea.KeyPressMsg{Text: "n", Code: 'n'})
+	m = newModel.(Model)
+
+	if m.pendingDisable != "" {
+		t.Fatalf("pendingDisable = %q after cancel, want empty", m.pendingDisable)
+	}
+	if len(commands) != 0 || !m.sessions[0].Enabled {
+		t.Fatalf("cancel changed state: commands=%d enabled=%v", len(commands), m.sessions[0].Enabled)
+	}
+}
+
+func TestTUI_View_ShowsHeldDurationForBallHolder(t *testing.T) {
+	ch := make(chan DaemonEvent, 10)
+	defer close(ch)
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m := InitialModel(ch, nil, config.DefaultConfig(), "")
+	m.nowFunc = func() time.Time { return now }
+	m.sessions = []SessionInfo{{Name: "main", Enabled: true}}
+	m.sessionSnapshots["main"] = status.SessionStatus{
+		SessionName:  "main",
+		VisibleState: "pending",
+		Nodes: []status.NodeStatus{
+			{Name: "boss", VisibleState: "active"},
+			{Name: "worker", VisibleState: "pending"},
+		},
+	}
+
+	newModel, _ := m.Update(DaemonEventMsg{
+		Type: "node_activity_update",
+		Details: map[string]interface{}{
+			"node_states": map[string]idle.NodeActivity{
+				"main:boss":   {LastReceived: now.Add(-5 * time.Minute), LivenessConfirmed: true},
+				"main:worker": {LastReceived: now.Add(-3 * time.Minute), LastSent: now.Add(-10 * time.Minute), LivenessConfirmed: true},
+			},
+		},
+	})
+	m = newModel.(Model)
+
+	view := m.View().Content
+	if !strings.Contains(view, "worker(3m)") {
+		t.Fatalf("view missing held duration for worker: %q", view)
+	}
+	if strings.Contains(view, "boss(") {
+		t.Fatalf("view shows held duration for active node: %q", view)
+	}
+}
+
+func TestTUI_InboxUnreadCountUpdate_ShowsPerNodeCounts(t *testing.T) {
+	ch := make(chan DaemonEvent, 10)
+	defer close(ch)
+
+	m := InitialModel(ch, nil, config.DefaultConfig(), "")
+	m.sessions = []SessionInfo{{Name: "main", Enabled: true}}
+	m.sessionSnapshots["main"] = status.SessionStatus{
+		SessionName:  "main",
+		VisibleState: "pending",
+		Nodes: []status.NodeStatus{
+			{Name: "boss", VisibleState: "ready"},
+			{Name: "worker", VisibleState: "pending"},
+		},
+	}
+
+	newModel, _ := m.Update(DaemonEventMsg{
+		Type: "inbox_unread_count_update",
+		Details: map[string]interface{}{
+			"unread_counts": map[string]int{"main:worker": 4, "main:boss": 0},
+		},
+	})
+	m = newModel.(Model)
+
+	if got := m.unreadInboxCounts["main:worker"]; got != 4 {
+		t.Fatalf("unreadInboxCounts[main:worker] = %d, want 4", got)
+	}
+	view := m.View().Content
+	if !strings.Contains(view, "inbox:4") {
+		t.Fatalf("view missing worker inbox count: %q", view)
+	}
+	if strings.Count(view, "inbox:") != 1 {
+		t.Fatalf("view shows inbox count for a node with an empty inbox: %q", view)
+	}
+}
+
+func TestTUI_NodeFilter_NarrowsRenderedNodes(t *testing.T) {
+	ch := make(chan DaemonEvent, 10)
+	defer close(ch)
+
+	m := InitialModel(ch, nil, config.DefaultConfig(), "")
+	m.sessions = []SessionInfo{{Name: "main", Enabled: true}}
+	m.sessionSnapshots["main"] = status.SessionStatus{
+		SessionName:  "main",
+		VisibleState: "ready",
+		Nodes: []status.NodeStatus{
+			{Name: "orchestrator", VisibleState: "ready"},
+			{Name: "worker-a", VisibleState: "ready"},
+			{Name: "worker-b", VisibleState: "ready"},
+		},
+	}
+
+	press := func(key tea.KeyPressMsg) {
+		t.Helper()
+		newModel, _ := m.Update(key)
+		m = newModel.(Model)
+	}
+	press(tea.KeyPressMsg{Text: "/", Code: '/'})
+	for _, r := range "work" {
+		press(tea.KeyPressMsg{Text: string(r), Code: r})
+	}
+	if !m.filtering || m.nodeFilter != "work" {
+		t.Fatalf("filtering=%v nodeFilter=%q, want typing mode with \"work\"", m.filtering, m.nodeFilter)
+	}
+	// Typed keys must not trigger their normal bindings.
+	if m.quitting {
+		t.Fatal("typing in filter mode triggered a key binding")
+	}
+
+	press(tea.KeyPressMsg{Code: tea.KeyEnter})
+	view := m.View().Content
+	if !strings.Contains(view, "worker-a") || !strings.Contains(view, "worker-b") {
+		t.Fatalf("filtered view missing matching nodes: %q", view)
+	}
+	if strings.Contains(view, "orchestrator") {
+		t.Fatalf("filtered view still shows non-matching node: %q", view)
+	}
+
+	press(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if m.nodeFilter != "" {
+		t.Fatalf("nodeFilter = %q after esc, want cleared", m.nodeFilter)
+	}
+	if view := m.View().Content; !strings.Contains(view, "orchestrator") {
+		t.Fatalf("esc did not restore the full node list: %q", view)
+	}
+}
+
+func TestTUI_NodeMuteTogglesSelectedNode(t *testing.T) {
+	ch := make(chan DaemonEvent, 10)
+	defer close(ch)
+	commands := make(chan TUICommand, 2)
+
+	m := InitialModel(ch, commands, config.DefaultConfig(), "")
+	m.sessions = []SessionInfo{{Name: "main", Enabled: true}}
+	m.sessionSnapshots["main"] = status.SessionStatus{
+		SessionName:  "main",
+		VisibleState: "ready",
+		Nodes: []status.NodeStatus{
+			{Name: "orchestrator", VisibleState: "ready"},
+			{Name: "worker", VisibleState: "ready"},
+		},
+	}
+
+	press := func(key tea.KeyPressMsg) {
+		t.Helper()
+		newModel, _ := m.Update(key)
+		m = newModel.(Model)
+	}
+	press(tea.KeyPressMsg{Text: "J", Code: 'J'})
+	press(tea.KeyPressMsg{Text: "J", Code: 'J'})
+	if m.selectedNode != "worker" {
+		t.Fatalf("selectedNode = %q, want worker", m.selectedNode)
+	}
+
+	press(tea.KeyPressMsg{Text: "m", Code: 'm'})
+	select {
+	case sent := <-commands:
+		want := TUICommand{Type: "node_mute", Target: "main:worker", Value: "on"}
+		if sent != want {
+			t.Fatalf("sent command = %#v, want %#v", sent, want)
+		}
+	default:
+		t.Fatal("expected node_mute command after pressing m")
+	}
+	if view := m.View().Content; !strings.Contains(view, "muted") {
+		t.Fatalf("view missing muted marker: %q", view)
+	}
+
+	press(tea.KeyPressMsg{Text: "m", Code: 'm'})
+	if sent := <-commands; sent.Value != "off" {
+		t.Fatalf("second toggle Value = %q, want off", sent.Value)
+	}
+	if m.mutedNodes["main:worker"] {
+		t.Fatal("worker still marked muted after second toggle")
+	}
+}
+
+func TestTUI_ConfiguredIndicatorSymbolsAndColors(t *testing.T) {
+	ch := make(chan DaemonEvent, 10)
+	defer close(ch)
+
+	cfg := config.DefaultConfig()
+	cfg.TUISymbols = map[string]string{"ready": "[ok]", "stale": "[!!]"}
+	cfg.TUIColors = map[string]string{"stale": "#ff0000"}
+	m := InitialModel(ch, nil, cfg, "")
+	m.sessions = []SessionInfo{{Name: "main", Enabled: true}}
+	m.sessionSnapshots["main"] = status.SessionStatus{
+		SessionName:  "main",
+		VisibleState: "ready",
+		Nodes: []status.NodeStatus{
+			{Name: "boss", VisibleState: "ready"},
+			{Name: "worker", VisibleState: "stale"},
+			{Name: "critic", VisibleState: "waiting"},
+		},
+	}
+
+	view := m.View().Content
+	if strings.Contains(view, "🟢") || strings.Contains(view, "🔴") {
+		t.Fatalf("view still shows default symbols for overridden states: %q", view)
+	}
+	if !strings.Contains(view, "[ok]") {
+		t.Fatalf("view missing configured ready symbol: %q", view)
+	}
+	colored := lipgloss.NewStyle().Foreground(lipgloss.Color("#ff0000")).Render("[!!]")
+	if colored == "[!!]" || !strings.Contains(view, colored) {
+		t.Fatalf("view missing colored stale symbol %q: %q", colored, view)
+	}
+	if !strings.Contains(view, "🟡") {
+		t.Fatalf("view lost default symbol for a state left unset: %q", view)
+	}
+}
+
+func TestTUI_CollapsedGroupHidesSessionsBehindAggregateRow(t *testing.T) {
+	ch := make(chan DaemonEvent, 10)
+	defer close(ch)
+
+	cfg := config.DefaultConfig()
+	cfg.TUIGroups = map[string][]string{"backend": {"api", "jobs"}}
+	m := InitialModel(ch, nil, cfg, "")
+	m.knownSessions = []SessionInfo{
+		{Name: "main", Enabled: true},
+		{Name: "api", Enabled: true},
+		{Name: "docs", Enabled: true},
+		{Name: "jobs", Enabled: true},
+	}
+	m.sessionSnapshots["api"] = status.SessionStatus{SessionName: "api", Nodes: []status.NodeStatus{{Name: "a", VisibleState: "ready"}}}
+	m.sessionSnapshots["jobs"] = status.SessionStatus{SessionName: "jobs", Nodes: []status.NodeStatus{{Name: "b", VisibleState: "stale"}}}
+	m.refreshVisibleSessions()
+
+	names := func(rows []SessionInfo) []string {
+		var out []string
+		for _, row := range rows {
+			out = append(out, row.Name)
+		}
+		return out
+	}
+	// The group's sessions are listed together where its first one sits.
+	if got, want := strings.Join(names(m.sessions), ","), "main,api,jobs,docs"; got != want {
+		t.Fatalf("expanded rows = %q, want %q", got, want)
+	}
+	if view := m.View().Content; !strings.Contains(view, "- backend [c:collapse]") {
+		t.Fatalf("view missing group header: %q", view)
+	}
+
+	press := func(m Model) Model {
+		t.Helper()
+		newModel, _ := m.Update(tea.KeyPressMsg{Text: "c", Code: 'c'})
+		return newModel.(Model)
+	}
+	m.selectedSession = 2 // jobs
+	m = press(m)
+	if got, want := strings.Join(names(m.sessions), ","), "main,,docs"; got != want {
+		t.Fatalf("collapsed rows = %q, want %q", got, want)
+	}
+	if m.selectedSession != 1 || m.sessions[1].Group != "backend" {
+		t.Fatalf("selection after collapse = %d (%+v), want the backend row", m.selectedSession, m.sessions[m.selectedSession])
+	}
+	view := m.View().Content
+	if !strings.Contains(view, "+ backend (2 sessions, stale) [c:expand]") {
+		t.Fatalf("view missing aggregate group line: %q", view)
+	}
+	if strings.Contains(view, "] api") || strings.Contains(view, "] jobs") {
+		t.Fatalf("collapsed group still shows its sessions: %q", view)
+	}
+
+	// "!" still finds the stale session behind the collapsed row.
+	newModel, _ := m.Update(tea.KeyPressMsg{Text: "!", Code: '!'})
+	if got := newModel.(Model).selectedSession; got != 1 {
+		t.Fatalf("! selected row %d, want the collapsed backend row 1", got)
+	}
+
+	m = press(m)
+	if got, want := strings.Join(names(m.sessions), ","), "main,api,jobs,docs"; got != want {
+		t.Fatalf("re-expanded rows = %q, want %q", got, want)
+	}
+	if got := m.getSelectedSessionName(); got != "api" {
+		t.Fatalf("selection after expand = %q, want api", got)
+	}
+}
@@ -64,19 +64,40 @@ func main() {
 			LogFilePath: "",
 		},
 		cli.Handlers{
-			Start:                   cli.RunStartWithFlags,
+			Start:                   cli.RunStart,
 			CaptureProfile:          cli.RunCaptureProfile,
 			Pop:                     cli.RunPop,
+			Ack:                     cli.RunAck,
+			Recall:                  cli.RunRecall,
+			Register:                cli.RunRegister,
+			InitDirs:                cli.RunInitDirs,
+			DeliverOnce:             cli.RunDeliverOnce,
+			Focus:                   cli.RunFocus,
+			Ping:                    cli.RunPing,
+			TestDelivery:            cli.RunTestDelivery,
+			PruneContexts:           cli.RunPruneContexts,
+			Contexts:                cli.RunContexts,
+			Stats:                   cli.RunStats,
+			RebuildState:            cli.RunRebuildState,
+			CheckEdges:              cli.RunCheckEdges,
+			TalksTo:                 cli.RunTalksTo,
 			GetSessionStatus:        cli.RunGetSessionStatus,
 			GetSessionStatusOneline: func(args []string) error { return cli.RunGetSessionStatusOneline(os.Stdout, args) },
 			InspectInput:            cli.RunInspectInput,
 			InspectMessage:          cli.RunInspectMessage,
+			Search:                  cli.RunSearch,
 			InspectCommandApprovals: cli.RunInspectCommandApprovals,
 			InspectDaemonSubmit:     cli.RunInspectDaemonSubmit,
 			BackfillVerdictEvents:   cli.RunBackfillVerdictEvents,
 			ExecuteBash:             cli.RunExecuteBash,
 			SendMessage:             cli.RunSendMessage,
 			SendHeredoc:             cli.RunSendHeredoc,
+			Config: func(args []string) error {
+				return cli.RunConfig(os.Stdout, args)
+			},
+			Reindex: func(args []string) error {
+				return cli.RunReindex(os.Stdout, args)
+			},
 			Stop: func(args []string) error {
 				return cli.RunStop(os.Stdout, args)
 			},